@@ -84,6 +84,8 @@ const (
 	NoFFParam        = "no-ff"
 	SquashParam      = "squash"
 	AbortParam       = "abort"
+	SetUpstreamFlag  = "set-upstream"
+	DepthParam       = "depth"
 )
 
 var mergeAbortDetails = `Abort the current conflict resolution process, and try to reconstruct the pre-merge state.
@@ -112,6 +114,53 @@ func CreateMergeArgParser() *argparser.ArgParser {
 	return ap
 }
 
+func CreateCherryPickArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"commit", "The commit whose changes should be applied to the current branch."})
+	return ap
+}
+
+func CreateRevertArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"revision", "The commit revisions whose changes should be reverted, in the order they should be reverted."})
+	return ap
+}
+
+func CreateFetchArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.SupportsFlag(ForceFlag, "f", "Update refs to remote branches with the current state of the remote, overwriting any conflicting history.")
+	ap.SupportsInt(DepthParam, "", "depth", "Limit fetching to the specified number of commits from the tip of each remote branch history. The repository's history becomes shallow at the oldest commits fetched. Fetching a shallow repository with a greater depth deepens its history.")
+	return ap
+}
+
+// ParseDepth returns the value of the depth option, which must be a positive number of commits, or zero if it wasn't
+// given.
+func ParseDepth(apr *argparser.ArgParseResults) (int, error) {
+	if !apr.Contains(DepthParam) {
+		return 0, nil
+	}
+
+	depth, ok := apr.GetInt(DepthParam)
+	if !ok || depth < 1 {
+		return 0, errors.New("depth must be a positive number of commits")
+	}
+
+	return depth, nil
+}
+
+func CreatePushArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.SupportsFlag(SetUpstreamFlag, "u", "For every branch that is up to date or successfully pushed, add upstream (tracking) reference, used by argument-less {{.EmphasisLeft}}dolt pull{{.EmphasisRight}} and other commands.")
+	ap.SupportsFlag(ForceFlag, "f", "Update the remote with local history, overwriting any conflicting history in the remote.")
+	return ap
+}
+
+func CreatePullArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.SupportsFlag(SquashParam, "", "Merges changes to the working set without updating the commit history")
+	return ap
+}
+
 func CreateAddArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParser()
 	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"table", "Working table(s) to add to the list tables staged to be committed. The abbreviation '.' can be used to add all tables."})
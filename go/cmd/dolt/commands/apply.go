@@ -0,0 +1,320 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	eventsapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlfmt"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/libraries/utils/tracing"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+var applyDocs = cli.CommandDocumentationContent{
+	ShortDesc: `Apply a patch to the working set.`,
+	LongDesc: `Applies the changes in a patch written by {{.EmphasisLeft}}dolt diff -r patch{{.EmphasisRight}} to the tables in the working set. The patch is read from {{.LessThan}}patch_file{{.GreaterThan}}, or from standard input if no file is given.
+
+Before anything is changed, every row that the patch removes or modifies is checked against the working set. If any of them is missing or has a different value, the patch does not apply and the working set is left unchanged. Otherwise the schema changes of each table are made, followed by its row changes.`,
+	Synopsis: []string{
+		`[{{.LessThan}}patch_file{{.GreaterThan}}]`,
+	},
+}
+
+type ApplyCmd struct{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd ApplyCmd) Name() string {
+	return "apply"
+}
+
+// Description returns a description of the command
+func (cmd ApplyCmd) Description() string {
+	return applyDocs.ShortDesc
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd ApplyCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, applyDocs, ap))
+}
+
+func (cmd ApplyCmd) createArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"patch_file", "A patch written by dolt diff -r patch. Defaults to standard input."})
+	return ap
+}
+
+// EventType returns the type of the event to log
+func (cmd ApplyCmd) EventType() eventsapi.ClientEventType {
+	return eventsapi.ClientEventType_TYPE_UNSPECIFIED
+}
+
+// Exec executes the command
+func (cmd ApplyCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, applyDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	if apr.NArg() > 1 {
+		verr := errhand.BuildDError("%s takes at most 1 arg", cmd.Name()).SetPrintUsage().Build()
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	var rd io.ReadCloser = os.Stdin
+	if apr.NArg() == 1 {
+		var err error
+		rd, err = dEnv.FS.OpenForRead(apr.Arg(0))
+		if err != nil {
+			verr := errhand.BuildDError("error: failed to open %s", apr.Arg(0)).AddCause(err).Build()
+			return HandleVErrAndExitCode(verr, usage)
+		}
+		defer rd.Close()
+	}
+
+	patches, err := diff.ReadPatch(rd)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	root, verr := GetWorkingWithVErr(dEnv)
+	if verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	verr = checkPatchApplies(ctx, root, patches)
+	if verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	root, verr = applyPatches(ctx, dEnv, patches)
+	if verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	return HandleVErrAndExitCode(UpdateWorkingWithVErr(dEnv, root), usage)
+}
+
+// checkPatchApplies returns an error if any row removed by |patches| doesn't exist in |root| as it is in the patch.
+func checkPatchApplies(ctx context.Context, root *doltdb.RootValue, patches []diff.TablePatch) errhand.VerboseError {
+	for _, tp := range patches {
+		if tp.FromName == "" {
+			continue
+		}
+
+		tbl, ok, err := root.GetTable(ctx, tp.FromName)
+		if err != nil {
+			return errhand.BuildDError("error: failed to read table %s", tp.FromName).AddCause(err).Build()
+		} else if !ok {
+			return errhand.BuildDError("error: patch does not apply: table %s does not exist", tp.FromName).Build()
+		}
+
+		sch, err := tbl.GetSchema(ctx)
+		if err != nil {
+			return errhand.BuildDError("error: failed to read the schema of table %s", tp.FromName).AddCause(err).Build()
+		}
+
+		rowData, err := tbl.GetRowData(ctx)
+		if err != nil {
+			return errhand.BuildDError("error: failed to read the rows of table %s", tp.FromName).AddCause(err).Build()
+		}
+
+		for _, pr := range tp.Rows {
+			if pr.Op != diff.PatchRowRemoved {
+				continue
+			}
+
+			r, err := pr.ToRow(ctx, root.VRW(), sch)
+			if err != nil {
+				return errhand.BuildDError("error: patch does not apply to table %s", tp.FromName).AddCause(err).Build()
+			}
+
+			key, err := r.NomsMapKey(sch).Value(ctx)
+			if err != nil {
+				return errhand.VerboseErrorFromError(err)
+			}
+
+			val, ok, err := rowData.MaybeGet(ctx, key)
+			if err != nil {
+				return errhand.BuildDError("error: failed to read the rows of table %s", tp.FromName).AddCause(err).Build()
+			}
+
+			matches := false
+			if ok {
+				curr, err := row.FromNoms(sch, key.(types.Tuple), val.(types.Tuple))
+				if err != nil {
+					return errhand.VerboseErrorFromError(err)
+				}
+
+				matches, err = pr.Matches(curr, sch)
+				if err != nil {
+					return errhand.VerboseErrorFromError(err)
+				}
+			}
+
+			if !matches {
+				return errhand.BuildDError("error: patch does not apply: a row changed by the patch is not in table %s", tp.FromName).Build()
+			}
+		}
+	}
+
+	return nil
+}
+
+// applyPatches makes the changes in |patches| to the working set of |dEnv| in a single SQL session, and returns the
+// resulting root.
+func applyPatches(ctx context.Context, dEnv *env.DoltEnv, patches []diff.TablePatch) (*doltdb.RootValue, errhand.VerboseError) {
+	mrEnv, err := env.DoltEnvAsMultiEnv(dEnv)
+	if err != nil {
+		return nil, errhand.VerboseErrorFromError(err)
+	}
+
+	roots, err := mrEnv.GetWorkingRoots(ctx)
+	if err != nil {
+		return nil, errhand.VerboseErrorFromError(err)
+	}
+
+	sess := dsess.DefaultSession()
+	sess.Username = *dEnv.Config.GetStringOrDefault(env.UserNameKey, "")
+	sess.Email = *dEnv.Config.GetStringOrDefault(env.UserEmailKey, "")
+
+	sqlCtx := sql.NewContext(ctx,
+		sql.WithSession(sess),
+		sql.WithIndexRegistry(sql.NewIndexRegistry()),
+		sql.WithViewRegistry(sql.NewViewRegistry()),
+		sql.WithTracer(tracing.Tracer(ctx)))
+
+	// the working set is written once all of the changes are made
+	err = sqlCtx.SetSessionVariable(sqlCtx, sql.AutoCommitSessionVar, false)
+	if err != nil {
+		return nil, errhand.VerboseErrorFromError(err)
+	}
+
+	var dbName string
+	for dbName = range roots {
+		sqlCtx.SetCurrentDatabase(dbName)
+	}
+
+	se, err := newSqlEngine(sqlCtx, false, mrEnv, roots, FormatTabular, CollectDBs(mrEnv)...)
+	if err != nil {
+		return nil, errhand.VerboseErrorFromError(err)
+	}
+
+	for _, tp := range patches {
+		// removed rows are deleted from the table as it is before the schema changes, which is the schema they were
+		// written with. This also removes a modified row before it's added back.
+		if verr := applyPatchRows(sqlCtx, se, dbName, tp.FromName, tp.Rows, diff.PatchRowRemoved); verr != nil {
+			return nil, verr
+		}
+
+		for _, stmt := range tp.SchemaStmts {
+			if err = execApplyQuery(sqlCtx, se, stmt); err != nil {
+				return nil, errhand.BuildDError("error: failed to apply the schema changes to table %s", tp.ToName).AddCause(err).Build()
+			}
+		}
+
+		// added rows are inserted into the table as it is after the schema changes
+		if verr := applyPatchRows(sqlCtx, se, dbName, tp.ToName, tp.Rows, diff.PatchRowAdded); verr != nil {
+			return nil, verr
+		}
+	}
+
+	roots, err = se.getRoots(sqlCtx)
+	if err != nil {
+		return nil, errhand.VerboseErrorFromError(err)
+	}
+
+	return roots[dbName], nil
+}
+
+// applyPatchRows deletes or inserts the rows of |rows| with the op |op| from the table |tblName| as it currently is in
+// the database |dbName| of |se|.
+func applyPatchRows(sqlCtx *sql.Context, se *sqlEngine, dbName, tblName string, rows []diff.PatchRow, op diff.PatchRowOp) errhand.VerboseError {
+	hasOp := false
+	for _, pr := range rows {
+		hasOp = hasOp || pr.Op == op
+	}
+
+	if !hasOp {
+		return nil
+	}
+
+	roots, err := se.getRoots(sqlCtx)
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+
+	tbl, ok, err := roots[dbName].GetTable(sqlCtx, tblName)
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	} else if !ok {
+		return errhand.BuildDError("error: patch does not apply: table %s does not exist", tblName).Build()
+	}
+
+	sch, err := tbl.GetSchema(sqlCtx)
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+
+	for _, pr := range rows {
+		if pr.Op != op {
+			continue
+		}
+
+		r, err := pr.ToRow(sqlCtx, roots[dbName].VRW(), sch)
+		if err != nil {
+			return errhand.BuildDError("error: patch does not apply to table %s", tblName).AddCause(err).Build()
+		}
+
+		var stmt string
+		if op == diff.PatchRowRemoved {
+			stmt, err = sqlfmt.RowAsDeleteStmt(r, tblName, sch)
+		} else {
+			stmt, err = sqlfmt.RowAsInsertStmt(r, tblName, sch)
+		}
+		if err != nil {
+			return errhand.VerboseErrorFromError(err)
+		}
+
+		if err = execApplyQuery(sqlCtx, se, stmt); err != nil {
+			return errhand.BuildDError("error: failed to apply the row changes to table %s", tblName).AddCause(err).Build()
+		}
+	}
+
+	return nil
+}
+
+func execApplyQuery(sqlCtx *sql.Context, se *sqlEngine, query string) error {
+	_, rowIter, err := se.query(sqlCtx, query)
+	if err != nil {
+		return err
+	}
+
+	_, err = sql.RowIterToRows(sqlCtx, rowIter)
+	return err
+}
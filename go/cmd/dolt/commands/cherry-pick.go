@@ -0,0 +1,201 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	eventsapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+var cherryPickDocs = cli.CommandDocumentationContent{
+	ShortDesc: `Apply the changes introduced by an existing commit.`,
+	LongDesc: `Applies the changes introduced by the named commit, relative to its parent, to the current branch and records a new commit with the same commit message.
+
+The changes are applied with a three-way merge of the commit's parent, the commit, and the current HEAD. If any changes can't be applied cleanly, no commit is made. The conflicts are recorded in the working set exactly as they are for {{.EmphasisLeft}}dolt merge{{.EmphasisRight}}, and can be resolved with {{.EmphasisLeft}}dolt conflicts{{.EmphasisRight}} or the {{.EmphasisLeft}}dolt_conflicts{{.EmphasisRight}} system tables before committing the result with {{.EmphasisLeft}}dolt commit{{.EmphasisRight}}.
+
+The working set must be clean before a commit can be cherry-picked. Merge commits can't be cherry-picked.`,
+	Synopsis: []string{
+		`{{.LessThan}}commit{{.GreaterThan}}`,
+	},
+}
+
+type CherryPickCmd struct{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd CherryPickCmd) Name() string {
+	return "cherry-pick"
+}
+
+// Description returns a description of the command
+func (cmd CherryPickCmd) Description() string {
+	return cherryPickDocs.ShortDesc
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd CherryPickCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cli.CreateCherryPickArgParser()
+	return CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, cherryPickDocs, ap))
+}
+
+// EventType returns the type of the event to log
+func (cmd CherryPickCmd) EventType() eventsapi.ClientEventType {
+	return eventsapi.ClientEventType_TYPE_UNSPECIFIED
+}
+
+// Exec executes the command
+func (cmd CherryPickCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cli.CreateCherryPickArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, cherryPickDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	if apr.NArg() != 1 {
+		verr := errhand.BuildDError("%s takes exactly 1 arg", cmd.Name()).SetPrintUsage().Build()
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	verr := checkCleanWorkingSet(ctx, dEnv, "cherry-pick")
+	if verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	cm, verr := ResolveCommitWithVErr(dEnv, apr.Arg(0))
+	if verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	meta, err := cm.GetCommitMeta()
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	headRoot, err := dEnv.HeadRoot(ctx)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	mergedRoot, tblToStats, err := merge.CherryPick(ctx, dEnv.DoltDB, headRoot, cm)
+	if err == merge.ErrMergeCommitNotSupported {
+		verr = errhand.BuildDError("error: cherry-picking a merge commit is not supported.").Build()
+		return HandleVErrAndExitCode(verr, usage)
+	} else if err != nil {
+		verr = errhand.BuildDError("error: failed to cherry-pick %s", apr.Arg(0)).AddCause(err).Build()
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	return commitMergedRoot(ctx, dEnv, mergedRoot, tblToStats, meta.Description, "cherry-pick", usage)
+}
+
+// checkCleanWorkingSet returns an error if the working set has any changes relative to HEAD, or an active merge.
+// Commands that apply the changes from other commits require a clean working set so that those changes can be
+// committed on their own.
+func checkCleanWorkingSet(ctx context.Context, dEnv *env.DoltEnv, operation string) errhand.VerboseError {
+	mergeActive, err := dEnv.IsMergeActive(ctx)
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+	if mergeActive {
+		return errhand.BuildDError("error: cannot %s with an active merge.", operation).
+			AddDetails("hint: commit or abort the active merge first.").Build()
+	}
+
+	roots, err := dEnv.Roots(ctx)
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+
+	headHash, err := roots.Head.HashOf()
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+	stagedHash, err := roots.Staged.HashOf()
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+	workingHash, err := roots.Working.HashOf()
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+
+	if headHash != stagedHash || headHash != workingHash {
+		return errhand.BuildDError("error: your local changes would be overwritten by %s.", operation).
+			AddDetails("hint: commit your changes before you %s.", operation).Build()
+	}
+
+	return nil
+}
+
+// commitMergedRoot writes |mergedRoot| to the working set and, if it has no conflicts or constraint violations,
+// stages it and commits it with the message given. Otherwise the conflicts are left in the working set to be
+// resolved and committed by the user.
+func commitMergedRoot(
+	ctx context.Context,
+	dEnv *env.DoltEnv,
+	mergedRoot *doltdb.RootValue,
+	tblToStats map[string]*merge.MergeStats,
+	msg string,
+	operation string,
+	usage cli.UsagePrinter,
+) int {
+	verr := UpdateWorkingWithVErr(dEnv, mergedRoot)
+	if verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	hasConflicts, hasConstraintViolations := printSuccessStats(tblToStats)
+	if hasConflicts || hasConstraintViolations {
+		cli.Printf("error: could not apply changes; fix conflicts and constraint violations, then commit the result with 'dolt commit'.\n")
+		return 1
+	}
+
+	verr = UpdateStagedWithVErr(dEnv, mergedRoot)
+	if verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	name, email, err := actions.GetNameAndEmail(dEnv.Config)
+	if err != nil {
+		return handleCommitErr(ctx, dEnv, err, usage)
+	}
+
+	roots, err := dEnv.Roots(ctx)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	_, err = actions.CommitStaged(ctx, roots, dEnv.DbData(), actions.CommitStagedProps{
+		Message:          msg,
+		Date:             doltdb.CommitNowFunc(),
+		AllowEmpty:       false,
+		CheckForeignKeys: true,
+		Name:             name,
+		Email:            email,
+	})
+	if actions.IsNothingStaged(err) {
+		cli.Printf("No changes were made by %s; the changes are already present on the current branch.\n", operation)
+		return 0
+	} else if err != nil {
+		return handleCommitErr(ctx, dEnv, err, usage)
+	}
+
+	return LogCmd{}.Exec(ctx, "log", []string{"-n=1"}, dEnv)
+}
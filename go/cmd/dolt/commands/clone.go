@@ -42,8 +42,9 @@ import (
 )
 
 const (
-	remoteParam = "remote"
-	branchParam = "branch"
+	remoteParam       = "remote"
+	branchParam       = "branch"
+	singleBranchParam = "single-branch"
 )
 
 var cloneDocs = cli.CommandDocumentationContent{
@@ -53,9 +54,13 @@ var cloneDocs = cli.CommandDocumentationContent{
 After the clone, a plain {{.EmphasisLeft}}dolt fetch{{.EmphasisRight}} without arguments will update all the remote-tracking branches, and a {{.EmphasisLeft}}dolt pull{{.EmphasisRight}} without arguments will in addition merge the remote branch into the current branch.
 
 This default configuration is achieved by creating references to the remote branch heads under {{.LessThan}}refs/remotes/origin{{.GreaterThan}}  and by creating a remote named 'origin'.
+
+With {{.EmphasisLeft}}--depth{{.EmphasisRight}}, only the given number of commits from the tip of each cloned branch are downloaded, and the history of the clone is shallow: {{.EmphasisLeft}}dolt log{{.EmphasisRight}} and merge bases stop at the oldest commits downloaded. A shallow clone can be committed to and pushed as usual, and {{.EmphasisLeft}}dolt fetch --depth{{.EmphasisRight}} with a greater depth deepens its history.
+
+With {{.EmphasisLeft}}--single-branch{{.EmphasisRight}}, only the branch given by {{.EmphasisLeft}}--branch{{.EmphasisRight}}, or the remote's master branch, is cloned, and the remote is configured to fetch only that branch.
 `,
 	Synopsis: []string{
-		"[-remote {{.LessThan}}remote{{.GreaterThan}}] [-branch {{.LessThan}}branch{{.GreaterThan}}]  [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] {{.LessThan}}remote-url{{.GreaterThan}} {{.LessThan}}new-dir{{.GreaterThan}}",
+		"[-remote {{.LessThan}}remote{{.GreaterThan}}] [-branch {{.LessThan}}branch{{.GreaterThan}}] [--depth {{.LessThan}}depth{{.GreaterThan}}] [--single-branch] [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] [--s3-endpoint {{.LessThan}}url{{.GreaterThan}}] {{.LessThan}}remote-url{{.GreaterThan}} {{.LessThan}}new-dir{{.GreaterThan}}",
 	},
 }
 
@@ -87,10 +92,13 @@ func (cmd CloneCmd) createArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParser()
 	ap.SupportsString(remoteParam, "", "name", "Name of the remote to be added. Default will be 'origin'.")
 	ap.SupportsString(branchParam, "b", "branch", "The branch to be cloned.  If not specified all branches will be cloned.")
+	ap.SupportsInt(cli.DepthParam, "", "depth", "Create a shallow clone with a history truncated to the specified number of commits from the tip of each branch.")
+	ap.SupportsFlag(singleBranchParam, "", "Clone only the history leading to the tip of a single branch, either specified by the --branch option or the remote's master branch.")
 	ap.SupportsString(dbfactory.AWSRegionParam, "", "region", "")
 	ap.SupportsValidatedString(dbfactory.AWSCredsTypeParam, "", "creds-type", "", argparser.ValidatorFromStrList(dbfactory.AWSCredsTypeParam, credTypes))
 	ap.SupportsString(dbfactory.AWSCredsFileParam, "", "file", "AWS credentials file.")
 	ap.SupportsString(dbfactory.AWSCredsProfile, "", "profile", "AWS profile to use.")
+	ap.SupportsString(dbfactory.S3EndpointParam, "", "url", "URL of an S3-compatible server to use for s3 remotes, instead of AWS.")
 	return ap
 }
 
@@ -107,9 +115,15 @@ func (cmd CloneCmd) Exec(ctx context.Context, commandStr string, args []string,
 
 	remoteName := apr.GetValueOrDefault(remoteParam, "origin")
 	branch := apr.GetValueOrDefault(branchParam, "")
+	singleBranch := apr.Contains(singleBranchParam)
 	dir, urlStr, verr := parseArgs(apr)
 
-	scheme, remoteUrl, err := getAbsRemoteUrl(dEnv.FS, dEnv.Config, urlStr)
+	depth, err := cli.ParseDepth(apr)
+	if err != nil && verr == nil {
+		verr = errhand.BuildDError("error: %s", err.Error()).SetPrintUsage().Build()
+	}
+
+	scheme, remoteUrl, err := env.GetAbsRemoteUrl(dEnv.FS, dEnv.Config, urlStr)
 
 	if err != nil {
 		verr = errhand.BuildDError("error: '%s' is not valid.", urlStr).Build()
@@ -124,11 +138,19 @@ func (cmd CloneCmd) Exec(ctx context.Context, commandStr string, args []string,
 			var srcDB *doltdb.DoltDB
 			r, srcDB, verr = createRemote(ctx, remoteName, remoteUrl, params)
 
+			if verr == nil && singleBranch {
+				branch, verr = singleBranchToClone(ctx, srcDB, branch)
+
+				if verr == nil && branch != "" {
+					r.FetchSpecs = []string{fmt.Sprintf("refs/heads/%[1]s:refs/remotes/%[2]s/%[1]s", branch, remoteName)}
+				}
+			}
+
 			if verr == nil {
 				dEnv, verr = envForClone(ctx, srcDB.ValueReadWriter().Format(), r, dir, dEnv.FS, dEnv.Version)
 
 				if verr == nil {
-					verr = cloneRemote(ctx, srcDB, remoteName, branch, dEnv)
+					verr = cloneRemote(ctx, srcDB, remoteName, branch, singleBranch, depth, dEnv)
 
 					if verr == nil {
 						evt := events.GetEventFromContext(ctx)
@@ -275,20 +297,53 @@ func cloneProg(eventCh <-chan datas.TableFileEvent) {
 	cli.Println()
 }
 
-func cloneRemote(ctx context.Context, srcDB *doltdb.DoltDB, remoteName, branch string, dEnv *env.DoltEnv) errhand.VerboseError {
-	eventCh := make(chan datas.TableFileEvent, 128)
+// singleBranchToClone returns the branch of |srcDB| to clone with --single-branch, which is |branch| if it's given, or
+// else master, or any branch if there is no master. It returns an empty string if |srcDB| has no branches.
+func singleBranchToClone(ctx context.Context, srcDB *doltdb.DoltDB, branch string) (string, errhand.VerboseError) {
+	branches, err := srcDB.GetBranches(ctx)
+	if err != nil {
+		return "", errhand.BuildDError("error: failed to list remote branches").AddCause(err).Build()
+	}
+
+	if branch != "" {
+		for _, brnch := range branches {
+			if brnch.GetPath() == branch {
+				return branch, nil
+			}
+		}
 
-	wg := &sync.WaitGroup{}
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		cloneProg(eventCh)
-	}()
+		return "", errhand.BuildDError("error: remote branch '%s' not found", branch).Build()
+	}
 
-	err := actions.Clone(ctx, srcDB, dEnv.DoltDB, eventCh)
-	close(eventCh)
+	for _, brnch := range branches {
+		branch = brnch.GetPath()
+		if branch == doltdb.MasterBranch {
+			break
+		}
+	}
 
-	wg.Wait()
+	return branch, nil
+}
+
+func cloneRemote(ctx context.Context, srcDB *doltdb.DoltDB, remoteName, branch string, singleBranch bool, depth int, dEnv *env.DoltEnv) errhand.VerboseError {
+	var err error
+	if singleBranch || depth > 0 {
+		err = cloneBranches(ctx, srcDB, branch, singleBranch, depth, dEnv)
+	} else {
+		eventCh := make(chan datas.TableFileEvent, 128)
+
+		wg := &sync.WaitGroup{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cloneProg(eventCh)
+		}()
+
+		err = actions.Clone(ctx, srcDB, dEnv.DoltDB, eventCh)
+		close(eventCh)
+
+		wg.Wait()
+	}
 
 	if err != nil {
 		if err == datas.ErrNoData {
@@ -355,6 +410,16 @@ func cloneRemote(ctx context.Context, srcDB *doltdb.DoltDB, remoteName, branch s
 			return errhand.BuildDError("error: could not create remote ref at " + remoteRef.String()).AddCause(err).Build()
 		}
 
+		// A sql-server's replication remote has the working sets of its branches, which a clone doesn't take
+		wsRef, err := ref.WorkingSetRefForHead(brnch)
+		if err != nil {
+			return errhand.VerboseErrorFromError(err)
+		}
+		err = dEnv.DoltDB.DeleteWorkingSet(ctx, wsRef)
+		if err != nil {
+			return errhand.BuildDError("error: could not delete working set " + wsRef.String() + " after clone.").AddCause(err).Build()
+		}
+
 		if brnch.GetPath() != branch {
 			err := dEnv.DoltDB.DeleteBranch(ctx, brnch)
 			if err != nil {
@@ -390,6 +455,25 @@ func cloneRemote(ctx context.Context, srcDB *doltdb.DoltDB, remoteName, branch s
 	return nil
 }
 
+// cloneBranches pulls the branches of |srcDB| into the new repository, rather than copying all of its table files. Only
+// |branch| is pulled if |singleBranch| is set, and only the commits within |depth| commits of each branch if |depth|
+// is positive.
+func cloneBranches(ctx context.Context, srcDB *doltdb.DoltDB, branch string, singleBranch bool, depth int, dEnv *env.DoltEnv) error {
+	branches, err := srcDB.GetBranches(ctx)
+	if err != nil {
+		return err
+	}
+
+	var branchRefs []ref.BranchRef
+	for _, brnch := range branches {
+		if !singleBranch || brnch.GetPath() == branch {
+			branchRefs = append(branchRefs, brnch.(ref.BranchRef))
+		}
+	}
+
+	return actions.CloneBranches(ctx, dEnv.TempTableFilesDir(), srcDB, dEnv.DoltDB, branchRefs, depth, runProgFuncs, stopProgFuncs)
+}
+
 // Inits an empty, newly cloned repo. This would be unnecessary if we properly initialized the storage for a repository
 // when we created it on dolthub. If we do that, this code can be removed.
 func initEmptyClonedRepo(ctx context.Context, dEnv *env.DoltEnv) error {
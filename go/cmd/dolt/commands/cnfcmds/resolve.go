@@ -41,16 +41,20 @@ When a merge operation finds conflicting changes, the rows with the conflicts ar
 In its first form {{.EmphasisLeft}}dolt conflicts resolve <table> <key>...{{.EmphasisRight}}, resolve runs in manual merge mode resolving the conflicts whose keys are provided.
 
 In its second form {{.EmphasisLeft}}dolt conflicts resolve --ours|--theirs <table>...{{.EmphasisRight}}, resolve runs in auto resolve mode. Where conflicts are resolved using a rule to determine which version of a row should be used.
+
+In its third form {{.EmphasisLeft}}dolt conflicts resolve --schema --ours|--theirs <table>...{{.EmphasisRight}}, resolve resolves the schema conflicts of tables whose schemas could not be merged. With {{.EmphasisLeft}}--ours{{.EmphasisRight}} the table is kept as it is in the working set, and with {{.EmphasisLeft}}--theirs{{.EmphasisRight}} it is replaced with the version of the table from the branch being merged.
 `,
 	Synopsis: []string{
 		`{{.LessThan}}table{{.GreaterThan}} [{{.LessThan}}key_definition{{.GreaterThan}}] {{.LessThan}}key{{.GreaterThan}}...`,
 		`--ours|--theirs {{.LessThan}}table{{.GreaterThan}}...`,
+		`--schema --ours|--theirs {{.LessThan}}table{{.GreaterThan}}...`,
 	},
 }
 
 const (
 	oursFlag   = "ours"
 	theirsFlag = "theirs"
+	schemaFlag = "schema"
 )
 
 var autoResolvers = map[string]merge.AutoResolver{
@@ -96,6 +100,7 @@ func (cmd ResolveCmd) createArgParser() *argparser.ArgParser {
 	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"key", "key(s) of rows within a table whose conflicts have been resolved"})
 	ap.SupportsFlag("ours", "", "For all conflicts, take the version from our branch and resolve the conflict")
 	ap.SupportsFlag("theirs", "", "For all conflicts, take the version from their branch and resolve the conflict")
+	ap.SupportsFlag(schemaFlag, "", "Resolve the schema conflicts of the given tables instead of their row conflicts")
 
 	return ap
 }
@@ -107,7 +112,9 @@ func (cmd ResolveCmd) Exec(ctx context.Context, commandStr string, args []string
 	apr := cli.ParseArgsOrDie(ap, args, help)
 
 	var verr errhand.VerboseError
-	if apr.ContainsAny(autoResolverParams...) {
+	if apr.Contains(schemaFlag) {
+		verr = schemaResolve(ctx, apr, dEnv)
+	} else if apr.ContainsAny(autoResolverParams...) {
 		verr = autoResolve(ctx, apr, dEnv)
 	} else {
 		verr = manualResolve(ctx, apr, dEnv)
@@ -149,6 +156,44 @@ func autoResolve(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env.
 	return saveDocsOnResolve(ctx, dEnv)
 }
 
+func schemaResolve(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env.DoltEnv) errhand.VerboseError {
+	funcFlags := apr.FlagsEqualTo(autoResolverParams, true)
+
+	if funcFlags.Size() != 1 {
+		ff := strings.Join(autoResolverParams, ", ")
+		return errhand.BuildDError("specify a resolver func from [ %s ]", ff).SetPrintUsage().Build()
+	} else if apr.NArg() == 0 {
+		return errhand.BuildDError("specify at least one table to resolve schema conflicts").SetPrintUsage().Build()
+	}
+
+	ws, err := dEnv.WorkingSet(ctx)
+	if err != nil {
+		return errhand.BuildDError("error: failed to read the working set").AddCause(err).Build()
+	}
+
+	tbls := apr.Args()
+	if len(tbls) == 1 && tbls[0] == "." && ws.MergeActive() {
+		tbls = ws.MergeState().SchemaConflicts()
+	}
+
+	ws, err = merge.ResolveSchemaConflicts(ctx, ws, tbls, funcFlags.Contains(theirsFlag))
+	if err != nil {
+		if err == doltdb.ErrNoConflicts {
+			cli.Println("no schema conflicts to resolve.")
+			return nil
+		}
+
+		return errhand.BuildDError("error: failed to resolve").AddCause(err).Build()
+	}
+
+	err = dEnv.UpdateWorkingSet(ctx, ws)
+	if err != nil {
+		return errhand.BuildDError("error: failed to update the working set").AddCause(err).Build()
+	}
+
+	return nil
+}
+
 func manualResolve(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env.DoltEnv) errhand.VerboseError {
 	args := apr.Args()
 
@@ -18,12 +18,14 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 
 	textdiff "github.com/andreyvit/diff"
 	humanize "github.com/dustin/go-humanize"
 	"github.com/fatih/color"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
@@ -33,6 +35,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdocs"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
 	"github.com/dolthub/dolt/go/libraries/doltcore/row"
 	"github.com/dolthub/dolt/go/libraries/doltcore/rowconv"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
@@ -55,22 +58,30 @@ type diffOutput int
 type diffPart int
 
 const (
-	SchemaOnlyDiff diffPart = 1 // 0b0001
-	DataOnlyDiff   diffPart = 2 // 0b0010
-	Summary        diffPart = 4 // 0b0100
+	SchemaOnlyDiff diffPart = 1  // 0b0001
+	DataOnlyDiff   diffPart = 2  // 0b0010
+	Summary        diffPart = 4  // 0b0100
+	NameOnly       diffPart = 8  // 0b1000
+	Stat           diffPart = 16 // 0b10000
 
 	SchemaAndDataDiff = SchemaOnlyDiff | DataOnlyDiff
 
 	TabularDiffOutput diffOutput = 1
 	SQLDiffOutput     diffOutput = 2
-
-	DataFlag    = "data"
-	SchemaFlag  = "schema"
-	SummaryFlag = "summary"
-	whereParam  = "where"
-	limitParam  = "limit"
-	SQLFlag     = "sql"
-	CachedFlag  = "cached"
+	JSONDiffOutput    diffOutput = 3
+	PatchDiffOutput   diffOutput = 4
+
+	DataFlag     = "data"
+	SchemaFlag   = "schema"
+	SummaryFlag  = "summary"
+	NameOnlyFlag = "name-only"
+	StatFlag     = "stat"
+	whereParam   = "where"
+	limitParam   = "limit"
+	SQLFlag      = "sql"
+	CachedFlag   = "cached"
+
+	threeDotSeparator = "..."
 )
 
 type DiffSink interface {
@@ -93,6 +104,13 @@ Show changes between the working and staged tables, changes between the working
 {{.EmphasisLeft}}dolt diff [--options] <commit> <commit> [<tables>...]{{.EmphasisRight}}
    This is to view the changes between two arbitrary {{.EmphasisLeft}}commit{{.EmphasisRight}}.
 
+{{.EmphasisLeft}}dolt diff [--options] <commit>...<commit> [<tables>...]{{.EmphasisRight}}
+   This is to view the changes on the second {{.EmphasisLeft}}commit{{.EmphasisRight}} since it diverged from the first, starting at their merge base. Either commit may be omitted, in which case it defaults to HEAD.
+
+The names of the changed tables can be listed without their changes with {{.EmphasisLeft}}--name-only{{.EmphasisRight}}, and the number of rows added, modified and deleted in each of them can be shown with {{.EmphasisLeft}}--stat{{.EmphasisRight}}.
+
+The diff can be written in a different format with {{.EmphasisLeft}}--result-format{{.EmphasisRight}}. {{.EmphasisLeft}}sql{{.EmphasisRight}} writes the SQL statements which make the changes, {{.EmphasisLeft}}json{{.EmphasisRight}} writes a JSON document with the schema and row changes of each table, and {{.EmphasisLeft}}patch{{.EmphasisRight}} writes a patch which can be applied to another working set with {{.EmphasisLeft}}dolt apply{{.EmphasisRight}}.
+
 The diffs displayed can be limited to show the first N by providing the parameter {{.EmphasisLeft}}--limit N{{.EmphasisRight}} where {{.EmphasisLeft}}N{{.EmphasisRight}} is the number of diffs to display.
 
 In order to filter which diffs are displayed {{.EmphasisLeft}}--where key=value{{.EmphasisRight}} can be used.  The key in this case would be either {{.EmphasisLeft}}to_COLUMN_NAME{{.EmphasisRight}} or {{.EmphasisLeft}}from_COLUMN_NAME{{.EmphasisRight}}. where {{.EmphasisLeft}}from_COLUMN_NAME=value{{.EmphasisRight}} would filter based on the original value and {{.EmphasisLeft}}to_COLUMN_NAME{{.EmphasisRight}} would select based on its updated value.
@@ -100,6 +118,7 @@ In order to filter which diffs are displayed {{.EmphasisLeft}}--where key=value{
 	Synopsis: []string{
 		`[options] [{{.LessThan}}commit{{.GreaterThan}}] [{{.LessThan}}tables{{.GreaterThan}}...]`,
 		`[options] {{.LessThan}}commit{{.GreaterThan}} {{.LessThan}}commit{{.GreaterThan}} [{{.LessThan}}tables{{.GreaterThan}}...]`,
+		`[options] {{.LessThan}}commit{{.GreaterThan}}...{{.LessThan}}commit{{.GreaterThan}} [{{.LessThan}}tables{{.GreaterThan}}...]`,
 	},
 }
 
@@ -141,7 +160,9 @@ func (cmd DiffCmd) createArgParser() *argparser.ArgParser {
 	ap.SupportsFlag(DataFlag, "d", "Show only the data changes, do not show the schema changes (Both shown by default).")
 	ap.SupportsFlag(SchemaFlag, "s", "Show only the schema changes, do not show the data changes (Both shown by default).")
 	ap.SupportsFlag(SummaryFlag, "", "Show summary of data changes")
-	ap.SupportsString(FormatFlag, "r", "result output format", "How to format diff output. Valid values are tabular & sql. Defaults to tabular. ")
+	ap.SupportsFlag(NameOnlyFlag, "", "Show only the names of the changed tables.")
+	ap.SupportsFlag(StatFlag, "", "Show only the number of rows added, modified and deleted in each changed table.")
+	ap.SupportsString(FormatFlag, "r", "result output format", "How to format diff output. Valid values are tabular, sql, json & patch. Defaults to tabular. ")
 	ap.SupportsString(whereParam, "", "column", "filters columns based on values in the diff.  See {{.EmphasisLeft}}dolt diff --help{{.EmphasisRight}} for details.")
 	ap.SupportsInt(limitParam, "", "record_count", "limits to the first N diffs.")
 	ap.SupportsFlag(CachedFlag, "c", "Show only the unstaged data changes.")
@@ -166,6 +187,11 @@ func (cmd DiffCmd) Exec(ctx context.Context, commandStr string, args []string, d
 		return HandleVErrAndExitCode(verr, usage)
 	}
 
+	// docs are only shown in the tabular and sql formats, and not in the lists of changed tables
+	if dArgs.diffOutput == JSONDiffOutput || dArgs.diffOutput == PatchDiffOutput || dArgs.diffParts == NameOnly || dArgs.diffParts == Stat {
+		return 0
+	}
+
 	err = diffDoltDocs(ctx, dEnv, fromRoot, toRoot, dArgs)
 
 	if err != nil {
@@ -192,6 +218,10 @@ func parseDiffArgs(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgPar
 			return nil, nil, nil, fmt.Errorf("arg %s cannot be combined with arg %s", QueryFlag, SchemaFlag)
 		case apr.Contains(SummaryFlag):
 			return nil, nil, nil, fmt.Errorf("arg %s cannot be combined with arg %s", QueryFlag, SummaryFlag)
+		case apr.Contains(NameOnlyFlag):
+			return nil, nil, nil, fmt.Errorf("arg %s cannot be combined with arg %s", QueryFlag, NameOnlyFlag)
+		case apr.Contains(StatFlag):
+			return nil, nil, nil, fmt.Errorf("arg %s cannot be combined with arg %s", QueryFlag, StatFlag)
 		case apr.Contains(SQLFlag):
 			return nil, nil, nil, fmt.Errorf("arg %s cannot be combined with arg %s", QueryFlag, SQLFlag)
 		case apr.Contains(CachedFlag):
@@ -213,6 +243,10 @@ func parseDiffArgs(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgPar
 		dArgs.diffOutput = TabularDiffOutput
 	case "sql":
 		dArgs.diffOutput = SQLDiffOutput
+	case "json":
+		dArgs.diffOutput = JSONDiffOutput
+	case "patch":
+		dArgs.diffOutput = PatchDiffOutput
 	case "":
 		dArgs.diffOutput = TabularDiffOutput
 	default:
@@ -223,9 +257,36 @@ func parseDiffArgs(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgPar
 		if apr.Contains(SchemaFlag) || apr.Contains(DataFlag) {
 			return nil, nil, nil, fmt.Errorf("invalid Arguments: --summary cannot be combined with --schema or --data")
 		}
+		if dArgs.diffOutput == JSONDiffOutput || dArgs.diffOutput == PatchDiffOutput {
+			return nil, nil, nil, fmt.Errorf("invalid Arguments: --summary cannot be combined with --%s %s", FormatFlag, f)
+		}
 		dArgs.diffParts = Summary
 	}
 
+	for _, flag := range []string{NameOnlyFlag, StatFlag} {
+		if !apr.Contains(flag) {
+			continue
+		}
+
+		if apr.ContainsAny(SummaryFlag, SchemaFlag, DataFlag) {
+			return nil, nil, nil, fmt.Errorf("invalid Arguments: --%s cannot be combined with --summary, --schema or --data", flag)
+		}
+		if dArgs.diffOutput != TabularDiffOutput {
+			return nil, nil, nil, fmt.Errorf("invalid Arguments: --%s cannot be combined with --%s %s", flag, FormatFlag, f)
+		}
+		if apr.Contains(whereParam) || apr.Contains(limitParam) {
+			return nil, nil, nil, fmt.Errorf("invalid Arguments: --%s cannot be combined with --%s or --%s", flag, whereParam, limitParam)
+		}
+	}
+
+	if apr.Contains(NameOnlyFlag) && apr.Contains(StatFlag) {
+		return nil, nil, nil, fmt.Errorf("invalid Arguments: --%s cannot be combined with --%s", NameOnlyFlag, StatFlag)
+	} else if apr.Contains(NameOnlyFlag) {
+		dArgs.diffParts = NameOnly
+	} else if apr.Contains(StatFlag) {
+		dArgs.diffParts = Stat
+	}
+
 	dArgs.limit, _ = apr.GetInt(limitParam)
 	dArgs.where = apr.GetValueOrDefault(whereParam, "")
 
@@ -306,6 +367,19 @@ func getDiffRoots(ctx context.Context, dEnv *env.DoltEnv, args []string, isCache
 		return nil, nil, nil, err
 	}
 
+	if len(args) > 0 && strings.Contains(args[0], threeDotSeparator) {
+		// `dolt diff from_commit...to_commit ...tables`
+		if isCached {
+			return nil, nil, nil, fmt.Errorf("invalid Arguments: --%s cannot be combined with %s", CachedFlag, args[0])
+		}
+
+		from, to, err = getThreeDotDiffRoots(ctx, dEnv, args[0])
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return from, to, args[1:], nil
+	}
+
 	if len(args) == 0 {
 		// `dolt diff`
 		from = stagedRoot
@@ -357,6 +431,56 @@ func getDiffRoots(ctx context.Context, dEnv *env.DoltEnv, args []string, isCache
 	return from, to, leftover, nil
 }
 
+// getThreeDotDiffRoots returns the roots to diff for |spec| of the form from_commit...to_commit: the root of the merge
+// base of the two commits, and the root of to_commit. A commit which is omitted defaults to HEAD.
+func getThreeDotDiffRoots(ctx context.Context, dEnv *env.DoltEnv, spec string) (from, to *doltdb.RootValue, err error) {
+	specs := strings.SplitN(spec, threeDotSeparator, 2)
+
+	commits := make([]*doltdb.Commit, len(specs))
+	for i, cSpecStr := range specs {
+		if cSpecStr == "" {
+			cSpecStr = "HEAD"
+		}
+
+		cs, err := doltdb.NewCommitSpec(cSpecStr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid commit %s", cSpecStr)
+		}
+
+		commits[i], err = dEnv.DoltDB.Resolve(ctx, cs, dEnv.RepoStateReader().CWBHeadRef())
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to resolve commit %s: %w", cSpecStr, err)
+		}
+	}
+
+	mergeBase, err := merge.MergeBase(ctx, commits[0], commits[1])
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not find the merge base of %s: %w", spec, err)
+	}
+
+	cs, err := doltdb.NewCommitSpec(mergeBase.String())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	baseCommit, err := dEnv.DoltDB.Resolve(ctx, cs, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	from, err = baseCommit.GetRootValue()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	to, err = commits[1].GetRootValue()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return from, to, nil
+}
+
 // todo: distinguish between non-existent CommitSpec and other errors, don't assume non-existent
 func maybeResolve(ctx context.Context, dEnv *env.DoltEnv, spec string) (*doltdb.RootValue, bool) {
 	cs, err := doltdb.NewCommitSpec(spec)
@@ -385,23 +509,71 @@ func diffUserTables(ctx context.Context, fromRoot, toRoot *doltdb.RootValue, dAr
 		return errhand.BuildDError("error: unable to diff tables").AddCause(err).Build()
 	}
 
+	if dArgs.diffParts == NameOnly || dArgs.diffParts == Stat {
+		var changed []diff.TableDelta
+		for _, td := range tableDeltas {
+			if td.CurName() == doltdb.DocTableName {
+				continue
+			}
+			if dArgs.tableSet.Contains(td.FromName) || dArgs.tableSet.Contains(td.ToName) {
+				changed = append(changed, td)
+			}
+		}
+
+		sort.Slice(changed, func(i, j int) bool {
+			return changed[i].CurName() < changed[j].CurName()
+		})
+
+		if dArgs.diffParts == NameOnly {
+			for _, td := range changed {
+				cli.Println(td.CurName())
+			}
+			return nil
+		}
+		return diffStat(ctx, changed)
+	}
+
+	var jsonWr *diff.JSONDiffWriter
+	var patchWr *diff.PatchWriter
+	switch dArgs.diffOutput {
+	case JSONDiffOutput:
+		jsonWr, err = diff.NewJSONDiffWriter(iohelp.NopWrCloser(cli.CliOut))
+		if err != nil {
+			return errhand.VerboseErrorFromError(err)
+		}
+		defer func() {
+			if err := jsonWr.Close(); err != nil && verr == nil {
+				verr = errhand.VerboseErrorFromError(err)
+			}
+		}()
+	case PatchDiffOutput:
+		patchWr = diff.NewPatchWriter(iohelp.NopWrCloser(cli.CliOut))
+		defer func() {
+			if err := patchWr.Close(); err != nil && verr == nil {
+				verr = errhand.VerboseErrorFromError(err)
+			}
+		}()
+	}
+
 	for _, td := range tableDeltas {
 
-		if dArgs.diffOutput == SQLDiffOutput {
+		if !dArgs.tableSet.Contains(td.FromName) && !dArgs.tableSet.Contains(td.ToName) {
+			continue
+		}
+
+		if dArgs.diffOutput == SQLDiffOutput || dArgs.diffOutput == PatchDiffOutput {
 			ok, err := td.IsKeyless(ctx)
 			if err != nil {
 				return errhand.VerboseErrorFromError(err)
 			}
-			if ok {
+			if ok && dArgs.diffOutput == PatchDiffOutput {
+				return errhand.BuildDError("error: patches of keyless table %s are not supported", td.CurName()).Build()
+			} else if ok {
 				// todo: implement keyless SQL diff
 				continue
 			}
 		}
 
-		if !dArgs.tableSet.Contains(td.FromName) && !dArgs.tableSet.Contains(td.ToName) {
-			continue
-		}
-
 		tblName := td.ToName
 		fromTable := td.FromTable
 		toTable := td.ToTable
@@ -434,6 +606,18 @@ func diffUserTables(ctx context.Context, fromRoot, toRoot *doltdb.RootValue, dAr
 			verr = diffSummary(ctx, td, numCols)
 		}
 
+		if jsonWr != nil {
+			if verr = jsonTableDiff(ctx, td, jsonWr, dArgs); verr != nil {
+				return verr
+			}
+			continue
+		} else if patchWr != nil {
+			if verr = patchTableDiff(ctx, toRoot, td, patchWr, dArgs); verr != nil {
+				return verr
+			}
+			continue
+		}
+
 		if dArgs.diffParts&SchemaOnlyDiff != 0 {
 			verr = diffSchemas(ctx, fromRoot, toRoot, td, dArgs)
 		}
@@ -444,14 +628,72 @@ func diffUserTables(ctx context.Context, fromRoot, toRoot *doltdb.RootValue, dAr
 			} else if td.IsAdd() {
 				fromSch = toSch
 			}
-			verr = diffRows(ctx, td, dArgs)
+			verr = diffRows(ctx, td, dArgs, nil)
+		}
+
+		if verr != nil {
+			return verr
+		}
+	}
+
+	return nil
+}
+
+// jsonTableDiff writes the diff of the table in |td| to |jw|.
+func jsonTableDiff(ctx context.Context, td diff.TableDelta, jw *diff.JSONDiffWriter, dArgs *diffArgs) errhand.VerboseError {
+	// the rows of a dropped table aren't listed, as with its data in the tabular format
+	withData := dArgs.diffParts&DataOnlyDiff != 0 && !td.IsDrop()
+
+	err := jw.BeginTable(ctx, td, dArgs.diffParts&SchemaOnlyDiff != 0, withData)
+	if err != nil {
+		return errhand.BuildDError("error: failed to write the diff of table %s", td.CurName()).AddCause(err).Build()
+	}
+
+	if withData {
+		verr := diffRows(ctx, td, dArgs, func(joiner *rowconv.Joiner) DiffSink {
+			return jw.RowSink(joiner)
+		})
+		if verr != nil {
+			return verr
 		}
+	}
 
+	err = jw.EndTable()
+	if err != nil {
+		return errhand.BuildDError("error: failed to write the diff of table %s", td.CurName()).AddCause(err).Build()
+	}
+
+	return nil
+}
+
+// patchTableDiff writes the diff of the table in |td| to |pw|.
+func patchTableDiff(ctx context.Context, toRoot *doltdb.RootValue, td diff.TableDelta, pw *diff.PatchWriter, dArgs *diffArgs) errhand.VerboseError {
+	var stmts []string
+	if dArgs.diffParts&SchemaOnlyDiff != 0 {
+		toSchemas, err := toRoot.GetAllSchemas(ctx)
+		if err != nil {
+			return errhand.BuildDError("could not read schemas from toRoot").AddCause(err).Build()
+		}
+
+		var verr errhand.VerboseError
+		stmts, verr = sqlSchemaDiffStmts(ctx, td, toSchemas)
 		if verr != nil {
 			return verr
 		}
 	}
 
+	err := pw.BeginTable(td, stmts)
+	if err != nil {
+		return errhand.BuildDError("error: failed to write the diff of table %s", td.CurName()).AddCause(err).Build()
+	}
+
+	// a dropped table's rows go along with it
+	if dArgs.diffParts&DataOnlyDiff != 0 && !td.IsDrop() {
+		return diffRows(ctx, td, dArgs, func(joiner *rowconv.Joiner) DiffSink {
+			return pw.RowSink(joiner)
+		})
+	}
+
 	return nil
 }
 
@@ -570,29 +812,45 @@ func tabularSchemaDiff(ctx context.Context, td diff.TableDelta, fromSchemas, toS
 }
 
 func sqlSchemaDiff(ctx context.Context, td diff.TableDelta, toSchemas map[string]schema.Schema) errhand.VerboseError {
+	stmts, verr := sqlSchemaDiffStmts(ctx, td, toSchemas)
+	if verr != nil {
+		return verr
+	}
+
+	for _, stmt := range stmts {
+		cli.Println(stmt)
+	}
+	return nil
+}
+
+// sqlSchemaDiffStmts returns the SQL statements which change the schema of the table in |td| from its from schema to
+// its to schema.
+func sqlSchemaDiffStmts(ctx context.Context, td diff.TableDelta, toSchemas map[string]schema.Schema) ([]string, errhand.VerboseError) {
 	fromSch, toSch, err := td.GetSchemas(ctx)
 	if err != nil {
-		return errhand.BuildDError("cannot retrieve schema for table %s", td.ToName).AddCause(err).Build()
+		return nil, errhand.BuildDError("cannot retrieve schema for table %s", td.ToName).AddCause(err).Build()
 	}
 
+	var stmts []string
+
 	if td.IsDrop() {
-		cli.Println(sqlfmt.DropTableStmt(td.FromName))
+		stmts = append(stmts, sqlfmt.DropTableStmt(td.FromName))
 	} else if td.IsAdd() {
 		sqlDb := sqle.NewSingleTableDatabase(td.ToName, toSch, td.ToFks, td.ToFksParentSch)
 		sqlCtx, engine, _ := sqle.PrepareCreateTableStmt(ctx, sqlDb)
 		stmt, err := sqle.GetCreateTableStmt(sqlCtx, engine, td.ToName)
 		if err != nil {
-			return errhand.VerboseErrorFromError(err)
+			return nil, errhand.VerboseErrorFromError(err)
 		}
-		cli.Println(stmt)
+		stmts = append(stmts, stmt)
 	} else {
 		if td.FromName != td.ToName {
-			cli.Println(sqlfmt.RenameTableStmt(td.FromName, td.ToName))
+			stmts = append(stmts, sqlfmt.RenameTableStmt(td.FromName, td.ToName))
 		}
 
 		eq := schema.SchemasAreEqual(fromSch, toSch)
 		if eq && !td.HasFKChanges() {
-			return nil
+			return stmts, nil
 		}
 
 		colDiffs, unionTags := diff.DiffSchColumns(fromSch, toSch)
@@ -601,11 +859,11 @@ func sqlSchemaDiff(ctx context.Context, td diff.TableDelta, toSchemas map[string
 			switch cd.DiffType {
 			case diff.SchDiffNone:
 			case diff.SchDiffAdded:
-				cli.Println(sqlfmt.AlterTableAddColStmt(td.ToName, sqlfmt.FmtCol(0, 0, 0, *cd.New)))
+				stmts = append(stmts, sqlfmt.AlterTableAddColStmt(td.ToName, sqlfmt.FmtCol(0, 0, 0, *cd.New)))
 			case diff.SchDiffRemoved:
-				cli.Print(sqlfmt.AlterTableDropColStmt(td.ToName, cd.Old.Name))
+				stmts = append(stmts, sqlfmt.AlterTableDropColStmt(td.ToName, cd.Old.Name))
 			case diff.SchDiffModified:
-				cli.Print(sqlfmt.AlterTableRenameColStmt(td.ToName, cd.Old.Name, cd.New.Name))
+				stmts = append(stmts, sqlfmt.AlterTableRenameColStmt(td.ToName, cd.Old.Name, cd.New.Name))
 			}
 		}
 
@@ -613,12 +871,12 @@ func sqlSchemaDiff(ctx context.Context, td diff.TableDelta, toSchemas map[string
 			switch idxDiff.DiffType {
 			case diff.SchDiffNone:
 			case diff.SchDiffAdded:
-				cli.Println(sqlfmt.AlterTableAddIndexStmt(td.ToName, idxDiff.To))
+				stmts = append(stmts, sqlfmt.AlterTableAddIndexStmt(td.ToName, idxDiff.To))
 			case diff.SchDiffRemoved:
-				cli.Println(sqlfmt.AlterTableDropIndexStmt(td.FromName, idxDiff.From))
+				stmts = append(stmts, sqlfmt.AlterTableDropIndexStmt(td.FromName, idxDiff.From))
 			case diff.SchDiffModified:
-				cli.Println(sqlfmt.AlterTableDropIndexStmt(td.FromName, idxDiff.From))
-				cli.Println(sqlfmt.AlterTableAddIndexStmt(td.ToName, idxDiff.To))
+				stmts = append(stmts, sqlfmt.AlterTableDropIndexStmt(td.FromName, idxDiff.From))
+				stmts = append(stmts, sqlfmt.AlterTableAddIndexStmt(td.ToName, idxDiff.To))
 			}
 		}
 
@@ -627,17 +885,17 @@ func sqlSchemaDiff(ctx context.Context, td diff.TableDelta, toSchemas map[string
 			case diff.SchDiffNone:
 			case diff.SchDiffAdded:
 				parentSch := toSchemas[fkDiff.To.ReferencedTableName]
-				cli.Println(sqlfmt.AlterTableAddForeignKeyStmt(fkDiff.To, toSch, parentSch))
+				stmts = append(stmts, sqlfmt.AlterTableAddForeignKeyStmt(fkDiff.To, toSch, parentSch))
 			case diff.SchDiffRemoved:
-				cli.Println(sqlfmt.AlterTableDropForeignKeyStmt(fkDiff.From))
+				stmts = append(stmts, sqlfmt.AlterTableDropForeignKeyStmt(fkDiff.From))
 			case diff.SchDiffModified:
-				cli.Println(sqlfmt.AlterTableDropForeignKeyStmt(fkDiff.From))
+				stmts = append(stmts, sqlfmt.AlterTableDropForeignKeyStmt(fkDiff.From))
 				parentSch := toSchemas[fkDiff.To.ReferencedTableName]
-				cli.Println(sqlfmt.AlterTableAddForeignKeyStmt(fkDiff.To, toSch, parentSch))
+				stmts = append(stmts, sqlfmt.AlterTableAddForeignKeyStmt(fkDiff.To, toSch, parentSch))
 			}
 		}
 	}
-	return nil
+	return stmts, nil
 }
 
 func dumbDownSchema(in schema.Schema) (schema.Schema, error) {
@@ -670,7 +928,9 @@ func fromNamer(name string) string {
 	return diff.From + "_" + name
 }
 
-func diffRows(ctx context.Context, td diff.TableDelta, dArgs *diffArgs) errhand.VerboseError {
+// diffRows writes the row diffs of the table in |td|. If |joinedSink| is non-nil, the sink it returns is given the
+// joined rows of the diff source rather than split rows in the output format.
+func diffRows(ctx context.Context, td diff.TableDelta, dArgs *diffArgs, joinedSink func(*rowconv.Joiner) DiffSink) errhand.VerboseError {
 	fromSch, toSch, err := td.GetSchemas(ctx)
 	if err != nil {
 		return errhand.BuildDError("cannot retrieve schema for table %s", td.ToName).AddCause(err).Build()
@@ -696,10 +956,15 @@ func diffRows(ctx context.Context, td diff.TableDelta, dArgs *diffArgs) errhand.
 		return errhand.BuildDError("").AddCause(err).Build()
 	}
 
-	vrw := types.NewMemoryValueStore() // We don't want to persist anything, so we use an internal store
-	unionSch, ds, verr := createSplitter(ctx, vrw, fromSch, toSch, joiner, dArgs)
-	if verr != nil {
-		return verr
+	var unionSch schema.Schema
+	var ds *diff.DiffSplitter
+	if joinedSink == nil {
+		var verr errhand.VerboseError
+		vrw := types.NewMemoryValueStore() // We don't want to persist anything, so we use an internal store
+		unionSch, ds, verr = createSplitter(ctx, vrw, fromSch, toSch, joiner, dArgs)
+		if verr != nil {
+			return verr
+		}
 	}
 
 	rd := diff.NewRowDiffer(ctx, fromSch, toSch, 1024) // assumes no pk changes
@@ -710,26 +975,31 @@ func diffRows(ctx context.Context, td diff.TableDelta, dArgs *diffArgs) errhand.
 	src := diff.NewRowDiffSource(rd, joiner)
 	defer src.Close()
 
-	oldColNames, verr := mapTagToColName(fromSch, unionSch)
+	var oldColNames, newColNames map[uint64]string
+	schemasEqual := true
+	var sink DiffSink
+	if joinedSink != nil {
+		sink = joinedSink(joiner)
+	} else if dArgs.diffOutput == TabularDiffOutput {
+		var verr errhand.VerboseError
+		oldColNames, verr = mapTagToColName(fromSch, unionSch)
 
-	if verr != nil {
-		return verr
-	}
+		if verr != nil {
+			return verr
+		}
 
-	newColNames, verr := mapTagToColName(toSch, unionSch)
+		newColNames, verr = mapTagToColName(toSch, unionSch)
 
-	if verr != nil {
-		return verr
-	}
+		if verr != nil {
+			return verr
+		}
 
-	schemasEqual := reflect.DeepEqual(oldColNames, newColNames)
-	numHeaderRows := 1
-	if !schemasEqual {
-		numHeaderRows = 2
-	}
+		schemasEqual = reflect.DeepEqual(oldColNames, newColNames)
+		numHeaderRows := 1
+		if !schemasEqual {
+			numHeaderRows = 2
+		}
 
-	var sink DiffSink
-	if dArgs.diffOutput == TabularDiffOutput {
 		sink, err = diff.NewColorDiffSink(iohelp.NopWrCloser(cli.CliOut), unionSch, numHeaderRows)
 	} else {
 		sink, err = diff.NewSQLDiffSink(iohelp.NopWrCloser(cli.CliOut), unionSch, td.CurName())
@@ -752,7 +1022,7 @@ func diffRows(ctx context.Context, td diff.TableDelta, dArgs *diffArgs) errhand.
 		return verr
 	}
 
-	if dArgs.diffOutput != SQLDiffOutput {
+	if dArgs.diffOutput == TabularDiffOutput {
 		if schemasEqual {
 			schRow, err := untyped.NewRowFromTaggedStrings(toRows.Format(), unionSch, newColNames)
 
@@ -813,9 +1083,12 @@ func buildPipeline(dArgs *diffArgs, joiner *rowconv.Joiner, ds *diff.DiffSplitte
 		transforms.AppendTransforms(pipeline.NewNamedTransform("select", selTrans.LimitAndFilter))
 	}
 
-	transforms.AppendTransforms(
-		pipeline.NewNamedTransform("split_diffs", ds.SplitDiffIntoOldAndNew),
-	)
+	// sinks of joined rows split them on their own
+	if ds != nil {
+		transforms.AppendTransforms(
+			pipeline.NewNamedTransform("split_diffs", ds.SplitDiffIntoOldAndNew),
+		)
+	}
 
 	if dArgs.diffOutput == TabularDiffOutput {
 		nullPrinter := nullprinter.NewNullPrinter(untypedUnionSch)
@@ -1069,6 +1342,66 @@ func diffSummary(ctx context.Context, td diff.TableDelta, colLen int) errhand.Ve
 	return nil
 }
 
+// diffStat prints the number of rows added, modified and deleted in each of the tables in |tds|, followed by the totals
+// for all of them. The tables are diffed concurrently.
+func diffStat(ctx context.Context, tds []diff.TableDelta) errhand.VerboseError {
+	stats := make([]diff.DiffSummaryProgress, len(tds))
+	eg, egCtx := errgroup.WithContext(ctx)
+	for i, td := range tds {
+		i, td := i, td
+		ch := make(chan diff.DiffSummaryProgress)
+		eg.Go(func() error {
+			defer close(ch)
+			err := diff.SummaryForTableDelta(egCtx, ch, td)
+			if err != nil {
+				return fmt.Errorf("failed to diff table %s: %w", td.CurName(), err)
+			}
+			return nil
+		})
+		eg.Go(func() error {
+			for p := range ch {
+				stats[i].Adds += p.Adds
+				stats[i].Removes += p.Removes
+				stats[i].Changes += p.Changes
+			}
+			return nil
+		})
+	}
+
+	if err := eg.Wait(); err != nil {
+		return errhand.BuildDError("error: unable to diff tables").AddCause(err).Build()
+	}
+
+	nameWidth := 0
+	for _, td := range tds {
+		if len(td.CurName()) > nameWidth {
+			nameWidth = len(td.CurName())
+		}
+	}
+
+	var total diff.DiffSummaryProgress
+	for i, td := range tds {
+		cli.Printf(" %-*s | %s\n", nameWidth, td.CurName(), formatRowCounts(stats[i]))
+		total.Adds += stats[i].Adds
+		total.Removes += stats[i].Removes
+		total.Changes += stats[i].Changes
+	}
+
+	if len(tds) > 0 {
+		tables := pluralize("table changed", "tables changed", uint64(len(tds)))
+		cli.Printf("%s, %s\n", tables, formatRowCounts(total))
+	}
+
+	return nil
+}
+
+func formatRowCounts(acc diff.DiffSummaryProgress) string {
+	insertions := pluralize("row added", "rows added", acc.Adds)
+	changes := pluralize("row modified", "rows modified", acc.Changes)
+	deletions := pluralize("row deleted", "rows deleted", acc.Removes)
+	return fmt.Sprintf("%s, %s, %s", insertions, changes, deletions)
+}
+
 func printSummary(acc diff.DiffSummaryProgress, colLen int) {
 	rowsUnmodified := uint64(acc.OldSize - acc.Changes - acc.Removes)
 	unmodified := pluralize("Row Unmodified", "Rows Unmodified", rowsUnmodified)
@@ -0,0 +1,210 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+)
+
+// setupDiffTestEnv creates a repo in which master and other have diverged since the commit that created tables a and
+// b. Master adds a row to a, and other deletes a row from a and modifies a row in b.
+func setupDiffTestEnv(t *testing.T) *env.DoltEnv {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+
+	run := func(cmd cli.Command, args ...string) {
+		require.Equal(t, 0, cmd.Exec(ctx, "dolt "+cmd.Name(), args, dEnv), "dolt %s %v", cmd.Name(), args)
+	}
+
+	run(SqlCmd{}, "-q", "CREATE TABLE a (pk int PRIMARY KEY, c int);")
+	run(SqlCmd{}, "-q", "CREATE TABLE b (pk int PRIMARY KEY, c int);")
+	run(SqlCmd{}, "-q", "INSERT INTO a VALUES (1,1),(2,2);")
+	run(SqlCmd{}, "-q", "INSERT INTO b VALUES (1,1);")
+	run(AddCmd{}, ".")
+	run(CommitCmd{}, "-m", "created tables")
+	run(BranchCmd{}, "other")
+	run(SqlCmd{}, "-q", "INSERT INTO a VALUES (3,3);")
+	run(CommitCmd{}, "-am", "added a row to a on master")
+	run(CheckoutCmd{}, "other")
+	run(SqlCmd{}, "-q", "DELETE FROM a WHERE pk = 2;")
+	run(SqlCmd{}, "-q", "UPDATE b SET c = 10 WHERE pk = 1;")
+	run(CommitCmd{}, "-am", "changed a and b on other")
+	run(CheckoutCmd{}, "master")
+
+	return dEnv
+}
+
+func resolveRootHash(t *testing.T, dEnv *env.DoltEnv, spec string) string {
+	ctx := context.Background()
+	cs, err := doltdb.NewCommitSpec(spec)
+	require.NoError(t, err)
+	cm, err := dEnv.DoltDB.Resolve(ctx, cs, dEnv.RepoStateReader().CWBHeadRef())
+	require.NoError(t, err)
+	root, err := cm.GetRootValue()
+	require.NoError(t, err)
+	h, err := root.HashOf()
+	require.NoError(t, err)
+	return h.String()
+}
+
+func TestGetThreeDotDiffRoots(t *testing.T) {
+	ctx := context.Background()
+	dEnv := setupDiffTestEnv(t)
+
+	mergeBase := resolveRootHash(t, dEnv, "master~1")
+	master := resolveRootHash(t, dEnv, "master")
+	other := resolveRootHash(t, dEnv, "other")
+
+	tests := []struct {
+		spec     string
+		from, to string
+	}{
+		{"master...other", mergeBase, other},
+		{"other...master", mergeBase, master},
+		{"...other", mergeBase, other},
+		{"other...", mergeBase, master},
+		{"master...master", master, master},
+	}
+
+	for _, test := range tests {
+		t.Run(test.spec, func(t *testing.T) {
+			from, to, err := getThreeDotDiffRoots(ctx, dEnv, test.spec)
+			require.NoError(t, err)
+
+			fromHash, err := from.HashOf()
+			require.NoError(t, err)
+			toHash, err := to.HashOf()
+			require.NoError(t, err)
+			assert.Equal(t, test.from, fromHash.String())
+			assert.Equal(t, test.to, toHash.String())
+		})
+	}
+
+	for _, spec := range []string{"master...missing", "missing...other", "master...other..."} {
+		t.Run(spec, func(t *testing.T) {
+			_, _, err := getThreeDotDiffRoots(ctx, dEnv, spec)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestDiffNameOnlyAndStat(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{
+			[]string{"--name-only", "master...other"},
+			"a\nb\n",
+		},
+		{
+			[]string{"--name-only", "master...other", "b"},
+			"b\n",
+		},
+		{
+			[]string{"--name-only", "other"},
+			"a\nb\n",
+		},
+		{
+			[]string{"--stat", "master...other"},
+			" a | 0 rows added, 0 rows modified, 1 row deleted\n" +
+				" b | 0 rows added, 1 row modified, 0 rows deleted\n" +
+				"2 tables changed, 0 rows added, 1 row modified, 1 row deleted\n",
+		},
+		{
+			[]string{"--stat", "other", "master"},
+			" a | 2 rows added, 0 rows modified, 0 rows deleted\n" +
+				" b | 0 rows added, 1 row modified, 0 rows deleted\n" +
+				"2 tables changed, 2 rows added, 1 row modified, 0 rows deleted\n",
+		},
+		{
+			[]string{"--stat", "master", "master"},
+			"",
+		},
+	}
+
+	dEnv := setupDiffTestEnv(t)
+
+	for _, test := range tests {
+		t.Run(test.args[0]+" "+test.args[1], func(t *testing.T) {
+			out := &bytes.Buffer{}
+			cliOut := cli.CliOut
+			cli.CliOut = out
+			defer func() {
+				cli.CliOut = cliOut
+			}()
+
+			res := DiffCmd{}.Exec(context.Background(), "dolt diff", test.args, dEnv)
+			require.Equal(t, 0, res)
+			assert.Equal(t, test.expected, out.String())
+		})
+	}
+}
+
+func TestParseDiffArgsFlagConflicts(t *testing.T) {
+	ctx := context.Background()
+	dEnv := setupDiffTestEnv(t)
+
+	tests := []struct {
+		args        []string
+		expectedErr string
+	}{
+		{[]string{"--name-only", "--stat"}, "--name-only cannot be combined with --stat"},
+		{[]string{"--name-only", "--summary"}, "--name-only cannot be combined with --summary, --schema or --data"},
+		{[]string{"--stat", "--schema"}, "--stat cannot be combined with --summary, --schema or --data"},
+		{[]string{"--stat", "--data"}, "--stat cannot be combined with --summary, --schema or --data"},
+		{[]string{"--name-only", "-r", "sql"}, "--name-only cannot be combined with --result-format sql"},
+		{[]string{"--stat", "-r", "json"}, "--stat cannot be combined with --result-format json"},
+		{[]string{"--name-only", "--where", "to_pk = 1"}, "--name-only cannot be combined with --where or --limit"},
+		{[]string{"--stat", "--limit", "1"}, "--stat cannot be combined with --where or --limit"},
+		{[]string{"--summary", "--schema"}, "--summary cannot be combined with --schema or --data"},
+		{[]string{"--summary", "-r", "patch"}, "--summary cannot be combined with --result-format patch"},
+		{[]string{"-r", "xml"}, "invalid output format: xml"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expectedErr, func(t *testing.T) {
+			apr, err := DiffCmd{}.createArgParser().Parse(test.args)
+			require.NoError(t, err)
+
+			_, _, _, err = parseDiffArgs(ctx, dEnv, apr)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.expectedErr)
+		})
+	}
+
+	for _, args := range [][]string{{"--name-only"}, {"--stat"}} {
+		apr, err := DiffCmd{}.createArgParser().Parse(args)
+		require.NoError(t, err)
+
+		_, _, dArgs, err := parseDiffArgs(ctx, dEnv, apr)
+		require.NoError(t, err)
+		if args[0] == "--name-only" {
+			assert.Equal(t, NameOnly, dArgs.diffParts)
+		} else {
+			assert.Equal(t, Stat, dArgs.diffParts)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	eventsapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/nbs"
+)
+
+var fsckDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Verifies the integrity of the repository.",
+	LongDesc: `Checks that the data of the repository is intact, and reports any problems found.
+
+The manifest must be readable, and each table file it lists must exist and have a valid footer and index. Every chunk of each table file must pass its checksum and hash to its address.
+
+Then every chunk reachable from the root of the repository, through each branch, tag, remote ref and working set, must be present in the table files and hash to its address. Each missing or corrupt chunk is listed along with the refs that are affected by it.
+
+Exits with a non-zero status if any problems are found.`,
+	Synopsis: []string{
+		"",
+	},
+}
+
+type FsckCmd struct{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd FsckCmd) Name() string {
+	return "fsck"
+}
+
+// Description returns a description of the command
+func (cmd FsckCmd) Description() string {
+	return fsckDocs.ShortDesc
+}
+
+// RequiresRepo should return false if this interface is implemented, and the command does not have the requirement
+// that it be run from within a data repository directory. The table files of a repository whose database can't be
+// loaded are still checked.
+func (cmd FsckCmd) RequiresRepo() bool {
+	return false
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd FsckCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, fsckDocs, ap))
+}
+
+func (cmd FsckCmd) createArgParser() *argparser.ArgParser {
+	return argparser.NewArgParser()
+}
+
+// EventType returns the type of the event to log
+func (cmd FsckCmd) EventType() eventsapi.ClientEventType {
+	return eventsapi.ClientEventType_TYPE_UNSPECIFIED
+}
+
+// Exec executes the command
+func (cmd FsckCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, fsckDocs, ap))
+	cli.ParseArgsOrDie(ap, args, help)
+
+	if !dEnv.HasDoltDir() {
+		cli.PrintErrln(color.RedString("The current directory is not a valid dolt repository."))
+		cli.PrintErrln("run: dolt init before trying to run this command")
+		return 2
+	}
+
+	dir, err := dEnv.FS.Abs(filepath.Join(dEnv.GetDoltDir(), dbfactory.DataDir))
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("could not find the table files of the repository").AddCause(err).Build(), usage)
+	}
+
+	cli.Println("Checking the manifest and table files")
+	storeProblems, err := nbs.VerifyFileStore(ctx, dir)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("could not check the table files").AddCause(err).Build(), usage)
+	}
+
+	for _, p := range storeProblems {
+		cli.PrintErrln(color.RedString(p.String()))
+	}
+
+	if dEnv.DBLoadError != nil {
+		cli.PrintErrln(color.RedString("Failed to load the database, so the chunks reachable from its root can't be checked."))
+		cli.PrintErrln(dEnv.DBLoadError.Error())
+		return 1
+	}
+
+	db, ok := dEnv.DoltDB.ValueReadWriter().(datas.Database)
+	if !ok {
+		return HandleVErrAndExitCode(errhand.BuildDError("this database does not support fsck").Build(), usage)
+	}
+
+	cli.Println("Checking the chunks reachable from the root")
+	results, err := datas.Fsck(ctx, db)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("could not check the chunks of the database").AddCause(err).Build(), usage)
+	}
+
+	for _, p := range results.Problems {
+		cli.PrintErrln(color.RedString("chunk %s: %v", p.Hash.String(), p.Err))
+
+		if len(p.Datasets) == 0 {
+			cli.PrintErrln("\taffects all refs")
+		} else {
+			cli.PrintErrln("\taffects " + strings.Join(p.Datasets, ", "))
+		}
+	}
+
+	cli.Printf("Checked %d chunks reachable from root %s\n", results.ChunksChecked, results.Root.String())
+
+	if n := len(storeProblems) + len(results.Problems); n > 0 {
+		cli.PrintErrln(color.RedString("Found %d problems", n))
+		return 1
+	}
+
+	cli.Println("No problems found")
+	return 0
+}
@@ -16,6 +16,7 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"strconv"
@@ -38,13 +39,17 @@ var mergeDocs = cli.CommandDocumentationContent{
 	ShortDesc: "Join two or more development histories together",
 	LongDesc: `Incorporates changes from the named commits (since the time their histories diverged from the current branch) into the current branch.
 
+When more than one commit is named, an octopus merge merges each of them in turn and the merge commit records all of them as parents. An octopus merge fails if any of the merges conflicts, in which case the commits must be merged one at a time. When criss-cross merges have left more than one best common ancestor, the ancestors are first merged together into a virtual ancestor to merge against.
+
 The second syntax ({{.LessThan}}dolt merge --abort{{.GreaterThan}}) can only be run after the merge has resulted in conflicts. dolt merge {{.EmphasisLeft}}--abort{{.EmphasisRight}} will abort the merge process and try to reconstruct the pre-merge state. However, if there were uncommitted changes when the merge started (and especially if those changes were further modified after the merge was started), dolt merge {{.EmphasisLeft}}--abort{{.EmphasisRight}} will in some cases be unable to reconstruct the original (pre-merge) changes. Therefore: 
 
 {{.LessThan}}Warning{{.GreaterThan}}: Running dolt merge with non-trivial uncommitted changes is discouraged: while possible, it may leave you in a state that is hard to back out of in the case of a conflict.
+
+Conflicts can be resolved automatically by strategies set in the {{.EmphasisLeft}}dolt_merge_strategies{{.EmphasisRight}} table, whose rows give a {{.EmphasisLeft}}table_name{{.EmphasisRight}}, a {{.EmphasisLeft}}column_name{{.EmphasisRight}} (or an empty string for every column of the table) and a {{.EmphasisLeft}}strategy{{.EmphasisRight}}. The strategies are {{.EmphasisLeft}}ours{{.EmphasisRight}}, {{.EmphasisLeft}}theirs{{.EmphasisRight}}, {{.EmphasisLeft}}last_writer_wins(column){{.EmphasisRight}}, which takes the values of the side whose row has the greater value in the column given, {{.EmphasisLeft}}max{{.EmphasisRight}}, {{.EmphasisLeft}}min{{.EmphasisRight}} and {{.EmphasisLeft}}union{{.EmphasisRight}}, which merges the members of SET columns. Rows that one side deleted and the other modified are only resolved by {{.EmphasisLeft}}ours{{.EmphasisRight}} and {{.EmphasisLeft}}theirs{{.EmphasisRight}} set on a whole table.
 `,
 
 	Synopsis: []string{
-		"[--squash] {{.LessThan}}branch{{.GreaterThan}}",
+		"[--squash] {{.LessThan}}branch{{.GreaterThan}}...",
 		"--no-ff [-m message] {{.LessThan}}branch{{.GreaterThan}}",
 		"--abort",
 	},
@@ -99,13 +104,11 @@ func (cmd MergeCmd) Exec(ctx context.Context, commandStr string, args []string,
 
 		verr = abortMerge(ctx, dEnv)
 	} else {
-		if apr.NArg() != 1 {
+		if apr.NArg() == 0 {
 			usage()
 			return 1
 		}
 
-		commitSpecStr := apr.Arg(0)
-
 		var root *doltdb.RootValue
 		root, verr = GetWorkingWithVErr(dEnv)
 
@@ -144,8 +147,10 @@ func (cmd MergeCmd) Exec(ctx context.Context, commandStr string, args []string,
 				return 1
 			}
 
-			if verr == nil {
-				verr = mergeCommitSpec(ctx, apr, dEnv, commitSpecStr)
+			if verr == nil && apr.NArg() == 1 {
+				verr = mergeCommitSpec(ctx, apr, dEnv, apr.Arg(0))
+			} else if verr == nil {
+				verr = mergeCommitSpecs(ctx, apr, dEnv, apr.Args())
 			}
 		}
 	}
@@ -255,6 +260,91 @@ func mergeCommitSpec(ctx context.Context, apr *argparser.ArgParseResults, dEnv *
 	}
 }
 
+// mergeCommitSpecs performs an octopus merge of the commits named by |commitSpecStrs| into HEAD
+func mergeCommitSpecs(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env.DoltEnv, commitSpecStrs []string) errhand.VerboseError {
+	head, verr := ResolveCommitWithVErr(dEnv, "HEAD")
+	if verr != nil {
+		return verr
+	}
+
+	var mergeSpecStrs []string
+	var mergeCommits []*doltdb.Commit
+	seen := make(map[hash.Hash]bool)
+	for _, commitSpecStr := range commitSpecStrs {
+		cm, verr := ResolveCommitWithVErr(dEnv, commitSpecStr)
+		if verr != nil {
+			return verr
+		}
+
+		h, err := cm.HashOf()
+		if err != nil {
+			return errhand.BuildDError("error: failed to get hash of commit").AddCause(err).Build()
+		}
+
+		// there is nothing to merge from commits that HEAD already contains
+		_, err = head.CanFastForwardTo(ctx, cm)
+		if err == doltdb.ErrUpToDate || err == doltdb.ErrIsAhead || seen[h] {
+			continue
+		} else if err != nil {
+			return errhand.BuildDError("error: failed to determine mergability.").AddCause(err).Build()
+		}
+
+		seen[h] = true
+		mergeSpecStrs = append(mergeSpecStrs, commitSpecStr)
+		mergeCommits = append(mergeCommits, cm)
+	}
+
+	if len(mergeCommits) == 0 {
+		cli.Println("Already up to date.")
+		return nil
+	} else if len(mergeCommits) == 1 {
+		return mergeCommitSpec(ctx, apr, dEnv, mergeSpecStrs[0])
+	}
+
+	squash := apr.Contains(cli.SquashParam)
+	if squash {
+		cli.Println("Squash commit -- not updating HEAD")
+	}
+
+	roots, err := dEnv.Roots(ctx)
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+
+	var workingDiffs map[string]hash.Hash
+	for _, cm := range mergeCommits {
+		var tblNames []string
+		tblNames, workingDiffs, err = env.MergeWouldStompChanges(ctx, roots.Working, cm, dEnv.DbData())
+		if err != nil {
+			return errhand.BuildDError("error: failed to determine mergability.").AddCause(err).Build()
+		}
+
+		if len(tblNames) != 0 {
+			bldr := errhand.BuildDError("error: Your local changes to the following tables would be overwritten by merge:")
+			for _, tName := range tblNames {
+				bldr.AddDetails(tName)
+			}
+			bldr.AddDetails("Please commit your changes before you merge.")
+			return bldr.Build()
+		}
+	}
+
+	for _, commitSpecStr := range mergeSpecStrs {
+		cli.Println("Trying simple merge with", commitSpecStr)
+	}
+
+	mergedRoot, tblToStats, err := merge.OctopusMergeCommits(ctx, head, mergeCommits...)
+	if errors.Is(err, merge.ErrOctopusMergeConflict) {
+		return errhand.BuildDError("error: Merge with strategy octopus failed.").
+			AddDetails("Merge the commits one at a time to resolve the conflicts.").
+			AddCause(err).Build()
+	} else if err != nil {
+		return errhand.BuildDError("Bad merge").AddCause(err).Build()
+	}
+
+	return mergedRootToWorking(ctx, squash, dEnv, mergedRoot, workingDiffs, mergeCommits, tblToStats)
+}
+
 func execNoFFMerge(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env.DoltEnv, roots doltdb.Roots, cm2 *doltdb.Commit, verr errhand.VerboseError, workingDiffs map[string]hash.Hash) errhand.VerboseError {
 	mergedRoot, err := cm2.GetRootValue()
 
@@ -262,7 +352,7 @@ func execNoFFMerge(ctx context.Context, apr *argparser.ArgParseResults, dEnv *en
 		return errhand.BuildDError("error: reading from database").AddCause(err).Build()
 	}
 
-	verr = mergedRootToWorking(ctx, false, dEnv, mergedRoot, workingDiffs, cm2, map[string]*merge.MergeStats{})
+	verr = mergedRootToWorking(ctx, false, dEnv, mergedRoot, workingDiffs, []*doltdb.Commit{cm2}, map[string]*merge.MergeStats{})
 
 	if verr != nil {
 		return verr
@@ -399,7 +489,7 @@ func executeMerge(ctx context.Context, squash bool, dEnv *env.DoltEnv, cm1, cm2
 		}
 	}
 
-	return mergedRootToWorking(ctx, squash, dEnv, mergedRoot, workingDiffs, cm2, tblToStats)
+	return mergedRootToWorking(ctx, squash, dEnv, mergedRoot, workingDiffs, []*doltdb.Commit{cm2}, tblToStats)
 }
 
 // TODO: change this to be functional and not write to repo state
@@ -409,11 +499,17 @@ func mergedRootToWorking(
 	dEnv *env.DoltEnv,
 	mergedRoot *doltdb.RootValue,
 	workingDiffs map[string]hash.Hash,
-	cm2 *doltdb.Commit,
+	mergeCommits []*doltdb.Commit,
 	tblToStats map[string]*merge.MergeStats,
 ) errhand.VerboseError {
 	var err error
 
+	if squash {
+		if err = merge.SquashSchemaConflictsErr(tblToStats); err != nil {
+			return errhand.BuildDError("error: %s", err.Error()).AddDetails("Merge without --squash to resolve them.").Build()
+		}
+	}
+
 	workingRoot := mergedRoot
 	if len(workingDiffs) > 0 {
 		workingRoot, err = applyChanges(ctx, mergedRoot, workingDiffs)
@@ -424,11 +520,21 @@ func mergedRootToWorking(
 	}
 
 	if !squash {
-		err = dEnv.StartMerge(ctx, cm2)
+		err = dEnv.StartMerge(ctx, mergeCommits[0], mergeCommits[1:]...)
 
 		if err != nil {
 			return errhand.BuildDError("Unable to update the repo state").AddCause(err).Build()
 		}
+
+		if schConflicts := merge.SchemaConflictTables(tblToStats); len(schConflicts) > 0 {
+			ws, err := dEnv.WorkingSet(ctx)
+			if err == nil {
+				err = dEnv.UpdateWorkingSet(ctx, ws.WithMergeState(ws.MergeState().WithSchemaConflicts(schConflicts)))
+			}
+			if err != nil {
+				return errhand.BuildDError("Unable to update the repo state").AddCause(err).Build()
+			}
+		}
 	}
 
 	unstagedDocs, err := actions.GetUnstagedDocs(ctx, dEnv)
@@ -493,8 +599,12 @@ func printConflictsAndViolations(tblToStats map[string]*merge.MergeStats) (confl
 	hasConflicts := false
 	hasConstraintViolations := false
 	for tblName, stats := range tblToStats {
-		if stats.Operation == merge.TableModified && (stats.Conflicts > 0 || stats.ConstraintViolations > 0) {
+		if stats.Operation == merge.TableModified && (stats.Conflicts > 0 || stats.ConstraintViolations > 0 || stats.SchemaConflicts > 0) {
 			cli.Println("Auto-merging", tblName)
+			if stats.SchemaConflicts > 0 {
+				cli.Println("CONFLICT (schema): Merge conflict in", tblName)
+				hasConflicts = true
+			}
 			if stats.Conflicts > 0 {
 				cli.Println("CONFLICT (content): Merge conflict in", tblName)
 				hasConflicts = true
@@ -28,9 +28,11 @@ import (
 
 var mergeBaseDocs = cli.CommandDocumentationContent{
 	ShortDesc: `Find the common ancestor of two commits.`,
-	LongDesc:  `Find the common ancestor of two commits, and return the ancestor's commit hash.'`,
+	LongDesc: `Find the common ancestor of two commits, and return the ancestor's commit hash.'
+
+When criss-cross merges have left the commits with more than one best common ancestor, {{.EmphasisLeft}}--all{{.EmphasisRight}} returns the hashes of all of them, one per line.`,
 	Synopsis: []string{
-		`{{.LessThan}}commit spec{{.GreaterThan}} {{.LessThan}}commit spec{{.GreaterThan}}`,
+		`[--all] {{.LessThan}}commit spec{{.GreaterThan}} {{.LessThan}}commit spec{{.GreaterThan}}`,
 	},
 }
 
@@ -55,6 +57,7 @@ func (cmd MergeBaseCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr stri
 func (cmd MergeBaseCmd) createArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParser()
 	//ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"start-point", "A commit that a new branch should point at."})
+	ap.SupportsFlag(allFlag, "", "Output all the best common ancestors of the commits, rather than one.")
 	return ap
 }
 
@@ -85,6 +88,19 @@ func (cmd MergeBaseCmd) Exec(ctx context.Context, commandStr string, args []stri
 		return HandleVErrAndExitCode(verr, usage)
 	}
 
+	if apr.Contains(allFlag) {
+		mergeBases, err := merge.MergeBases(ctx, left, right)
+		if err != nil {
+			verr = errhand.BuildDError("could not find merge-base for args %s", apr.Args()).AddCause(err).Build()
+			return HandleVErrAndExitCode(verr, usage)
+		}
+
+		for _, mergeBase := range mergeBases {
+			cli.Println(mergeBase.String())
+		}
+		return 0
+	}
+
 	mergeBase, err := merge.MergeBase(ctx, left, right)
 	if err != nil {
 		verr = errhand.BuildDError("could not find merge-base for args %s", apr.Args()).AddCause(err).Build()
@@ -228,11 +228,10 @@ func fetchMigratedRemoteBranches(ctx context.Context, dEnv *env.DoltEnv, apr *ar
 	}
 
 	// force fetch all branches
-	remotes, _ := dEnv.GetRemotes()
-	r, refSpecs, err := getRefSpecs(apr.Args(), dEnv, remotes)
+	r, refSpecs, err := getRefSpecs(apr.Args(), dEnv)
 
 	if err == nil {
-		err = fetchRefSpecs(ctx, ref.UpdateMode{Force: true}, dEnv, r, refSpecs)
+		err = fetchRefSpecs(ctx, ref.UpdateMode{Force: true}, dEnv, r, refSpecs, 0)
 	}
 
 	return err
@@ -21,6 +21,7 @@ import (
 	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
 	eventsapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
@@ -56,9 +57,7 @@ func (cmd PullCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) e
 }
 
 func (cmd PullCmd) createArgParser() *argparser.ArgParser {
-	ap := argparser.NewArgParser()
-	ap.SupportsFlag(cli.SquashParam, "", "Merges changes to the working set without updating the commit history")
-	return ap
+	return cli.CreatePullArgParser()
 }
 
 // EventType returns the type of the event to log
@@ -118,10 +117,10 @@ func pullFromRemote(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgPa
 		return errhand.BuildDError("error: failed to get remote db").AddCause(err).Build()
 	}
 
-	verr = fetchFollowTags(ctx, dEnv, srcDB, dEnv.DoltDB)
+	err = actions.FetchFollowTags(ctx, dEnv.TempTableFilesDir(), srcDB, dEnv.DoltDB, runProgFuncs, stopProgFuncs)
 
-	if verr != nil {
-		return verr
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
 	}
 
 	return nil
@@ -134,10 +133,10 @@ func pullRemoteBranch(ctx context.Context, apr *argparser.ArgParseResults, dEnv
 		return errhand.BuildDError("error: failed to get remote db").AddCause(err).Build()
 	}
 
-	srcDBCommit, verr := fetchRemoteBranch(ctx, dEnv, r, srcDB, dEnv.DoltDB, srcRef, destRef)
+	srcDBCommit, err := actions.FetchRemoteBranch(ctx, dEnv.TempTableFilesDir(), r, srcDB, dEnv.DoltDB, srcRef, runProgFuncs, stopProgFuncs)
 
-	if verr != nil {
-		return verr
+	if err != nil {
+		return errhand.BuildDError("error: fetch failed").AddCause(err).Build()
 	}
 
 	err = dEnv.DoltDB.FastForward(ctx, destRef, srcDBCommit)
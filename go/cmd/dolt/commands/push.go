@@ -16,6 +16,7 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -32,18 +33,12 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/doltcore/remotestorage"
-	"github.com/dolthub/dolt/go/libraries/events"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/libraries/utils/earl"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/store/datas"
 )
 
-const (
-	SetUpstreamFlag = "set-upstream"
-	ForcePushFlag   = "force"
-)
-
 type pushOpts struct {
 	srcRef      ref.DoltRef
 	destRef     ref.DoltRef
@@ -88,10 +83,7 @@ func (cmd PushCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) e
 }
 
 func (cmd PushCmd) createArgParser() *argparser.ArgParser {
-	ap := argparser.NewArgParser()
-	ap.SupportsFlag(SetUpstreamFlag, "u", "For every branch that is up to date or successfully pushed, add upstream (tracking) reference, used by argument-less {{.EmphasisLeft}}dolt pull{{.EmphasisRight}} and other commands.")
-	ap.SupportsFlag(ForcePushFlag, "f", "Update the remote with local history, overwriting any conflicting history in the remote.")
-	return ap
+	return cli.CreatePushArgParser()
 }
 
 // EventType returns the type of the event to log
@@ -142,7 +134,7 @@ func parsePushArgs(ctx context.Context, apr *argparser.ArgParseResults, dEnv *en
 	if remoteOK && len(args) == 1 {
 		refSpecStr := args[0]
 
-		refSpecStr, err = disambiguateRefSpecStr(ctx, dEnv.DoltDB, refSpecStr)
+		refSpecStr, err = actions.DisambiguateRefSpecStr(ctx, dEnv.DoltDB, refSpecStr)
 		if err != nil {
 			verr = errhand.VerboseErrorFromError(err)
 		}
@@ -156,7 +148,7 @@ func parsePushArgs(ctx context.Context, apr *argparser.ArgParseResults, dEnv *en
 		remoteName = args[0]
 		refSpecStr := args[1]
 
-		refSpecStr, err = disambiguateRefSpecStr(ctx, dEnv.DoltDB, refSpecStr)
+		refSpecStr, err = actions.DisambiguateRefSpecStr(ctx, dEnv.DoltDB, refSpecStr)
 		if err != nil {
 			verr = errhand.VerboseErrorFromError(err)
 		}
@@ -165,7 +157,7 @@ func parsePushArgs(ctx context.Context, apr *argparser.ArgParseResults, dEnv *en
 		if err != nil {
 			verr = errhand.BuildDError("error: invalid refspec '%s'", refSpecStr).AddCause(err).Build()
 		}
-	} else if apr.Contains(SetUpstreamFlag) {
+	} else if apr.Contains(cli.SetUpstreamFlag) {
 		verr = errhand.BuildDError("error: --set-upstream requires <remote> and <refspec> params.").SetPrintUsage().Build()
 	} else if hasUpstream {
 		if len(args) > 0 {
@@ -222,7 +214,7 @@ func parsePushArgs(ctx context.Context, apr *argparser.ArgParseResults, dEnv *en
 	case ref.BranchRefType:
 		remoteRef, verr = getTrackingRef(dest, remote)
 	case ref.TagRefType:
-		if apr.Contains(SetUpstreamFlag) {
+		if apr.Contains(cli.SetUpstreamFlag) {
 			verr = errhand.BuildDError("cannot set upstream for tag").Build()
 		}
 	default:
@@ -239,44 +231,14 @@ func parsePushArgs(ctx context.Context, apr *argparser.ArgParseResults, dEnv *en
 		remoteRef: remoteRef,
 		remote:    remote,
 		mode: ref.UpdateMode{
-			Force: apr.Contains(ForcePushFlag),
+			Force: apr.Contains(cli.ForceFlag),
 		},
-		setUpstream: apr.Contains(SetUpstreamFlag),
+		setUpstream: apr.Contains(cli.SetUpstreamFlag),
 	}
 
 	return opts, nil
 }
 
-// if possible, convert refs to full spec names. prefer branches over tags.
-// eg "master" -> "refs/heads/master", "v1" -> "refs/tags/v1"
-func disambiguateRefSpecStr(ctx context.Context, ddb *doltdb.DoltDB, refSpecStr string) (string, error) {
-	brachRefs, err := ddb.GetBranches(ctx)
-
-	if err != nil {
-		return "", err
-	}
-
-	for _, br := range brachRefs {
-		if br.GetPath() == refSpecStr {
-			return br.String(), nil
-		}
-	}
-
-	tagRefs, err := ddb.GetTags(ctx)
-
-	if err != nil {
-		return "", err
-	}
-
-	for _, tr := range tagRefs {
-		if tr.GetPath() == refSpecStr {
-			return tr.String(), nil
-		}
-	}
-
-	return refSpecStr, nil
-}
-
 func doPush(ctx context.Context, dEnv *env.DoltEnv, opts *pushOpts) (verr errhand.VerboseError) {
 	destDB, err := opts.remote.GetRemoteDB(ctx, dEnv.DoltDB.ValueReadWriter().Format())
 
@@ -334,21 +296,13 @@ func doPush(ctx context.Context, dEnv *env.DoltEnv, opts *pushOpts) (verr errhan
 }
 
 func getTrackingRef(branchRef ref.DoltRef, remote env.Remote) (ref.DoltRef, errhand.VerboseError) {
-	for _, fsStr := range remote.FetchSpecs {
-		fs, err := ref.ParseRefSpecForRemote(remote.Name, fsStr)
-
-		if err != nil {
-			return nil, errhand.BuildDError("error: invalid fetch spec '%s' for remote '%s'", fsStr, remote.Name).Build()
-		}
-
-		remoteRef := fs.DestRef(branchRef)
+	remoteRef, err := actions.GetTrackingRef(branchRef, remote)
 
-		if remoteRef != nil {
-			return remoteRef, nil
-		}
+	if err != nil {
+		return nil, errhand.BuildDError("error: %s", err.Error()).Build()
 	}
 
-	return nil, nil
+	return remoteRef, nil
 }
 
 func deleteRemoteBranch(ctx context.Context, toDelete, remoteRef ref.DoltRef, localDB, remoteDB *doltdb.DoltDB, remote env.Remote) errhand.VerboseError {
@@ -362,48 +316,31 @@ func deleteRemoteBranch(ctx context.Context, toDelete, remoteRef ref.DoltRef, lo
 }
 
 func pushToRemoteBranch(ctx context.Context, dEnv *env.DoltEnv, mode ref.UpdateMode, srcRef, destRef, remoteRef ref.DoltRef, localDB, remoteDB *doltdb.DoltDB, remote env.Remote) errhand.VerboseError {
-	evt := events.GetEventFromContext(ctx)
-
-	u, err := earl.Parse(remote.Url)
-
-	if err == nil {
-		if u.Scheme != "" {
-			evt.SetAttribute(eventsapi.AttributeID_REMOTE_URL_SCHEME, u.Scheme)
-		}
-	}
-
-	cs, _ := doltdb.NewCommitSpec(srcRef.GetPath())
-	cm, err := localDB.Resolve(ctx, cs, dEnv.RepoStateReader().CWBHeadRef())
+	err := actions.PushToRemoteBranch(ctx, dEnv.RepoStateReader(), mode, srcRef, destRef, remoteRef, localDB, remoteDB, remote, runProgFuncs, stopProgFuncs)
 
 	if err != nil {
-		return errhand.BuildDError("error: refspec '%v' not found.", srcRef.GetPath()).Build()
-	} else {
-		wg, progChan, pullerEventCh := runProgFuncs()
-		err = actions.Push(ctx, dEnv, mode, destRef.(ref.BranchRef), remoteRef.(ref.RemoteRef), localDB, remoteDB, cm, progChan, pullerEventCh)
-		stopProgFuncs(wg, progChan, pullerEventCh)
-
-		if err != nil {
-			if err == doltdb.ErrUpToDate {
-				cli.Println("Everything up-to-date")
-			} else if err == doltdb.ErrIsAhead || err == actions.ErrCantFF || err == datas.ErrMergeNeeded {
-				cli.Printf("To %s\n", remote.Url)
-				cli.Printf("! [rejected]          %s -> %s (non-fast-forward)\n", destRef.String(), remoteRef.String())
-				cli.Printf("error: failed to push some refs to '%s'\n", remote.Url)
-				cli.Println("hint: Updates were rejected because the tip of your current branch is behind")
-				cli.Println("hint: its remote counterpart. Integrate the remote changes (e.g.")
-				cli.Println("hint: 'dolt pull ...') before pushing again.")
-				return errhand.BuildDError("").Build()
+		if err == doltdb.ErrUpToDate {
+			cli.Println("Everything up-to-date")
+		} else if errors.Is(err, actions.ErrRefSpecNotFound) {
+			return errhand.BuildDError("error: refspec '%v' not found.", srcRef.GetPath()).Build()
+		} else if err == doltdb.ErrIsAhead || err == actions.ErrCantFF || err == datas.ErrMergeNeeded {
+			cli.Printf("To %s\n", remote.Url)
+			cli.Printf("! [rejected]          %s -> %s (non-fast-forward)\n", destRef.String(), remoteRef.String())
+			cli.Printf("error: failed to push some refs to '%s'\n", remote.Url)
+			cli.Println("hint: Updates were rejected because the tip of your current branch is behind")
+			cli.Println("hint: its remote counterpart. Integrate the remote changes (e.g.")
+			cli.Println("hint: 'dolt pull ...') before pushing again.")
+			return errhand.BuildDError("").Build()
+		} else {
+			status, ok := status.FromError(err)
+			if ok && status.Code() == codes.PermissionDenied {
+				cli.Println("hint: have you logged into DoltHub using 'dolt login'?")
+				cli.Println("hint: check that user.email in 'dolt config --list' has write perms to DoltHub repo")
+			}
+			if rpcErr, ok := err.(*remotestorage.RpcError); ok {
+				return errhand.BuildDError("error: push failed").AddCause(err).AddDetails(rpcErr.FullDetails()).Build()
 			} else {
-				status, ok := status.FromError(err)
-				if ok && status.Code() == codes.PermissionDenied {
-					cli.Println("hint: have you logged into DoltHub using 'dolt login'?")
-					cli.Println("hint: check that user.email in 'dolt config --list' has write perms to DoltHub repo")
-				}
-				if rpcErr, ok := err.(*remotestorage.RpcError); ok {
-					return errhand.BuildDError("error: push failed").AddCause(err).AddDetails(rpcErr.FullDetails()).Build()
-				} else {
-					return errhand.BuildDError("error: push failed").AddCause(err).Build()
-				}
+				return errhand.BuildDError("error: push failed").AddCause(err).Build()
 			}
 		}
 	}
@@ -412,17 +349,7 @@ func pushToRemoteBranch(ctx context.Context, dEnv *env.DoltEnv, mode ref.UpdateM
 }
 
 func pushTagToRemote(ctx context.Context, dEnv *env.DoltEnv, srcRef, destRef ref.DoltRef, localDB, remoteDB *doltdb.DoltDB) errhand.VerboseError {
-	tg, err := localDB.ResolveTag(ctx, srcRef.(ref.TagRef))
-
-	if err != nil {
-		return errhand.VerboseErrorFromError(err)
-	}
-
-	wg, progChan, pullerEventCh := runProgFuncs()
-
-	err = actions.PushTag(ctx, dEnv, destRef.(ref.TagRef), localDB, remoteDB, tg, progChan, pullerEventCh)
-
-	stopProgFuncs(wg, progChan, pullerEventCh)
+	err := actions.PushTagToRemote(ctx, dEnv.TempTableFilesDir(), srcRef, destRef, localDB, remoteDB, runProgFuncs, stopProgFuncs)
 
 	if err != nil {
 		if err == doltdb.ErrUpToDate {
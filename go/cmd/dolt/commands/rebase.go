@@ -0,0 +1,266 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	eventsapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/rebase"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/libraries/utils/editor"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+const (
+	rebaseContinueFlag    = "continue"
+	rebaseAbortFlag       = "abort"
+	rebaseInteractiveFlag = "interactive"
+	rebaseTodoParam       = "todo"
+)
+
+var rebaseDocs = cli.CommandDocumentationContent{
+	ShortDesc: `Reapply commits on top of another base commit.`,
+	LongDesc: `Replays the commits of the current branch that aren't in {{.LessThan}}upstream{{.GreaterThan}} on top of {{.LessThan}}upstream{{.GreaterThan}}, one at a time, and moves the current branch to the last replayed commit. Merge commits are replayed as a single commit containing all the changes they merged in.
+
+Each commit is replayed with a three-way merge of the commit's parent, the commit, and the branch's new HEAD. If a commit can't be replayed cleanly, the rebase stops with the conflicts recorded in the working set exactly as they are for {{.EmphasisLeft}}dolt merge{{.EmphasisRight}}. After resolving the conflicts and staging the result with {{.EmphasisLeft}}dolt add{{.EmphasisRight}}, run {{.EmphasisLeft}}dolt rebase --continue{{.EmphasisRight}} to commit it and replay the remaining commits, or {{.EmphasisLeft}}dolt rebase --abort{{.EmphasisRight}} to return the branch to where it was before the rebase started.
+
+The steps of the rebase can be edited with {{.EmphasisLeft}}--interactive{{.EmphasisRight}}, which opens the list of steps in an editor, or given up front in a file with {{.EmphasisLeft}}--todo{{.EmphasisRight}}. Each line of the list is of the form {{.EmphasisLeft}}<action> <commit> [<message>]{{.EmphasisRight}}, and the steps are executed from top to bottom. The actions are:
+
+{{.EmphasisLeft}}pick{{.EmphasisRight}}: replay the commit with its original commit message.
+
+{{.EmphasisLeft}}reword{{.EmphasisRight}}: replay the commit with {{.LessThan}}message{{.GreaterThan}} as its commit message.
+
+{{.EmphasisLeft}}squash{{.EmphasisRight}}: replay the commit and meld it into the previous commit, combining their commit messages.
+
+{{.EmphasisLeft}}drop{{.EmphasisRight}}: skip the commit.
+
+The working set must be clean before a rebase can start.`,
+	Synopsis: []string{
+		`[-i | --todo {{.LessThan}}file{{.GreaterThan}}] {{.LessThan}}upstream{{.GreaterThan}}`,
+		`--continue`,
+		`--abort`,
+	},
+}
+
+type RebaseCmd struct{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd RebaseCmd) Name() string {
+	return "rebase"
+}
+
+// Description returns a description of the command
+func (cmd RebaseCmd) Description() string {
+	return rebaseDocs.ShortDesc
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd RebaseCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, rebaseDocs, ap))
+}
+
+func (cmd RebaseCmd) createArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"upstream", "The branch or commit to replay the current branch's commits on top of."})
+	ap.SupportsFlag(rebaseInteractiveFlag, "i", "Edit the list of steps of the rebase in an editor before starting.")
+	ap.SupportsString(rebaseTodoParam, "", "file", "Read the list of steps of the rebase from {{.LessThan}}file{{.GreaterThan}}.")
+	ap.SupportsFlag(rebaseContinueFlag, "", "Commit the staged changes for the step the rebase stopped on and replay the remaining commits.")
+	ap.SupportsFlag(rebaseAbortFlag, "", "Abort the rebase in progress and reset the branch to its state before the rebase started.")
+	return ap
+}
+
+// EventType returns the type of the event to log
+func (cmd RebaseCmd) EventType() eventsapi.ClientEventType {
+	return eventsapi.ClientEventType_TYPE_UNSPECIFIED
+}
+
+// Exec executes the command
+func (cmd RebaseCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, rebaseDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	if apr.Contains(rebaseContinueFlag) || apr.Contains(rebaseAbortFlag) {
+		if apr.NArg() != 0 || (apr.Contains(rebaseContinueFlag) && apr.Contains(rebaseAbortFlag)) {
+			verr := errhand.BuildDError("--%s and --%s take no other arguments", rebaseContinueFlag, rebaseAbortFlag).SetPrintUsage().Build()
+			return HandleVErrAndExitCode(verr, usage)
+		}
+
+		if apr.Contains(rebaseAbortFlag) {
+			err := rebase.Abort(ctx, dEnv)
+			if err != nil {
+				verr := errhand.BuildDError("error: failed to abort rebase").AddCause(err).Build()
+				return HandleVErrAndExitCode(verr, usage)
+			}
+			return 0
+		}
+
+		state, err := rebase.Continue(ctx, dEnv)
+		if err != nil {
+			verr := errhand.BuildDError("error: failed to continue rebase").AddCause(err).Build()
+			return HandleVErrAndExitCode(verr, usage)
+		}
+
+		return handleRebaseResult(ctx, dEnv, state)
+	}
+
+	if apr.NArg() != 1 {
+		verr := errhand.BuildDError("%s takes exactly 1 arg", cmd.Name()).SetPrintUsage().Build()
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	if rebase.IsRebaseActive(dEnv) {
+		verr := errhand.BuildDError("error: %s", rebase.ErrRebaseInProgress.Error()).
+			AddDetails("hint: use 'dolt rebase --continue' or 'dolt rebase --abort'.").Build()
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	verr := checkCleanWorkingSet(ctx, dEnv, "rebase")
+	if verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	upstream, verr := ResolveCommitWithVErr(dEnv, apr.Arg(0))
+	if verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	head, err := dEnv.DoltDB.ResolveCommitRef(ctx, dEnv.RepoStateReader().CWBHeadRef())
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	// the branch already contains |upstream| if |upstream| can be fast-forwarded to it, in which case there's
+	// nothing to do unless the steps of the rebase were given
+	upToDate, err := upstream.CanFastForwardTo(ctx, head)
+	if err != nil && err != doltdb.ErrUpToDate && err != doltdb.ErrIsAhead {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	if upToDate && !apr.Contains(rebaseInteractiveFlag) && !apr.Contains(rebaseTodoParam) {
+		cli.Printf("Current branch %s is up to date.\n", dEnv.RepoStateReader().CWBHeadRef().GetPath())
+		return 0
+	}
+
+	commits, err := rebase.CommitsToRebase(ctx, dEnv.DoltDB, head, upstream)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	todo, verr := getRebaseTodoList(dEnv, apr, commits)
+	if verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	state, err := rebase.Start(ctx, dEnv, upstream, todo)
+	if err != nil {
+		bdr := errhand.BuildDError("error: failed to rebase onto %s", apr.Arg(0)).AddCause(err)
+		if rebase.IsRebaseActive(dEnv) {
+			bdr.AddDetails("hint: To get back to the state before 'dolt rebase', run 'dolt rebase --abort'.")
+		}
+		return HandleVErrAndExitCode(bdr.Build(), usage)
+	}
+
+	return handleRebaseResult(ctx, dEnv, state)
+}
+
+// getRebaseTodoList returns the steps of the rebase, which pick each of |commits| unless they were read from a file
+// or edited interactively.
+func getRebaseTodoList(dEnv *env.DoltEnv, apr *argparser.ArgParseResults, commits []*doltdb.Commit) ([]rebase.TodoItem, errhand.VerboseError) {
+	if todoFile, ok := apr.GetValue(rebaseTodoParam); ok {
+		f, err := dEnv.FS.OpenForRead(todoFile)
+		if err != nil {
+			return nil, errhand.BuildDError("error: failed to read todo list %s", todoFile).AddCause(err).Build()
+		}
+		defer f.Close()
+
+		todo, err := rebase.ParseTodoList(f)
+		if err != nil {
+			return nil, errhand.BuildDError("error: invalid todo list %s", todoFile).AddCause(err).Build()
+		}
+		if len(todo) == 0 {
+			return nil, errhand.BuildDError("error: nothing to do").Build()
+		}
+
+		return todo, nil
+	}
+
+	todo, err := rebase.NewTodoList(commits)
+	if err != nil {
+		return nil, errhand.VerboseErrorFromError(err)
+	}
+
+	if !apr.Contains(rebaseInteractiveFlag) || len(todo) == 0 {
+		return todo, nil
+	}
+
+	initial, err := rebase.FormatTodoList(todo, commits)
+	if err != nil {
+		return nil, errhand.VerboseErrorFromError(err)
+	}
+
+	backupEd := "vim"
+	if ed, edSet := os.LookupEnv("EDITOR"); edSet {
+		backupEd = ed
+	}
+	editorStr := dEnv.Config.GetStringOrDefault(env.DoltEditor, backupEd)
+
+	var edited string
+	cli.ExecuteWithStdioRestored(func() {
+		edited, err = editor.OpenCommitEditor(*editorStr, initial)
+	})
+	if err != nil {
+		return nil, errhand.BuildDError("error: failed to edit todo list").AddCause(err).Build()
+	}
+
+	todo, err = rebase.ParseTodoList(strings.NewReader(edited))
+	if err != nil {
+		return nil, errhand.BuildDError("error: invalid todo list").AddCause(err).Build()
+	}
+	if len(todo) == 0 {
+		return nil, errhand.BuildDError("error: nothing to do").Build()
+	}
+
+	return todo, nil
+}
+
+// handleRebaseResult reports the outcome of starting or continuing a rebase. A non-nil |state| means the rebase
+// stopped with conflicts.
+func handleRebaseResult(ctx context.Context, dEnv *env.DoltEnv, state *rebase.State) int {
+	if state == nil {
+		cli.Printf("Successfully rebased and updated refs/heads/%s.\n", dEnv.RepoStateReader().CWBHeadRef().GetPath())
+		return 0
+	}
+
+	tbls, err := dEnv.GetTablesWithConflicts(ctx)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), nil)
+	}
+	for _, tbl := range tbls {
+		cli.Printf("CONFLICT (content): Merge conflict in %s\n", tbl)
+	}
+
+	cli.Printf("error: could not apply %s\n", state.Todo[0].Commit)
+	cli.Println("hint: Resolve all conflicts manually and stage the result with 'dolt add', then run 'dolt rebase --continue'.")
+	cli.Println("hint: To abort and get back to the state before 'dolt rebase', run 'dolt rebase --abort'.")
+	return 1
+}
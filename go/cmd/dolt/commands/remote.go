@@ -18,8 +18,6 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"path"
-	"path/filepath"
 	"strings"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
@@ -29,8 +27,6 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
-	"github.com/dolthub/dolt/go/libraries/utils/config"
-	"github.com/dolthub/dolt/go/libraries/utils/earl"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 )
 
@@ -43,7 +39,7 @@ var remoteDocs = cli.CommandDocumentationContent{
 {{.EmphasisLeft}}add{{.EmphasisRight}}
 Adds a remote named {{.LessThan}}name{{.GreaterThan}} for the repository at {{.LessThan}}url{{.GreaterThan}}. The command dolt fetch {{.LessThan}}name{{.GreaterThan}} can then be used to create and update remote-tracking branches {{.EmphasisLeft}}<name>/<branch>{{.EmphasisRight}}.
 
-The {{.LessThan}}url{{.GreaterThan}} parameter supports url schemes of http, https, aws, gs, and file.  If a url scheme does not prefix the url then https is assumed.  If the {{.LessThan}}url{{.GreaterThan}} paramenter is in the format {{.EmphasisLeft}}<organization>/<repository>{{.EmphasisRight}} then dolt will use the {{.EmphasisLeft}}remotes.default_host{{.EmphasisRight}} from your configuration file (Which will be dolthub.com unless changed).
+The {{.LessThan}}url{{.GreaterThan}} parameter supports url schemes of http, https, aws, s3, gs, and file.  If a url scheme does not prefix the url then https is assumed.  If the {{.LessThan}}url{{.GreaterThan}} paramenter is in the format {{.EmphasisLeft}}<organization>/<repository>{{.EmphasisRight}} then dolt will use the {{.EmphasisLeft}}remotes.default_host{{.EmphasisRight}} from your configuration file (Which will be dolthub.com unless changed).
 
 AWS cloud remote urls should be of the form {{.EmphasisLeft}}aws://[dynamo-table:s3-bucket]/database{{.EmphasisRight}}.  You may configure your aws cloud remote using the optional parameters {{.EmphasisLeft}}aws-region{{.EmphasisRight}}, {{.EmphasisLeft}}aws-creds-type{{.EmphasisRight}}, {{.EmphasisLeft}}aws-creds-file{{.EmphasisRight}}.
 
@@ -52,6 +48,8 @@ aws-creds-type specifies the means by which credentials should be retrieved in o
 	\trole: Use the credentials installed for the current user
 	\tenv: Looks for environment variables AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY
 	\tfile: Uses the credentials file specified by the parameter aws-creds-file
+
+S3 remote urls should be of the form {{.EmphasisLeft}}s3://s3-bucket/path{{.EmphasisRight}}, and need no dynamo table. They're configured using the same parameters as aws cloud remotes, and the optional parameter {{.EmphasisLeft}}s3-endpoint{{.EmphasisRight}}, which is the url of an S3-compatible server, such as MinIO, to use instead of AWS. The server must support conditional writes.
 	
 GCP remote urls should be of the form gs://gcs-bucket/database and will use the credentials setup using the gcloud command line available from Google +
 
@@ -61,7 +59,7 @@ Remove the remote named {{.LessThan}}name{{.GreaterThan}}. All remote-tracking b
 
 	Synopsis: []string{
 		"[-v | --verbose]",
-		"add [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] {{.LessThan}}name{{.GreaterThan}} {{.LessThan}}url{{.GreaterThan}}",
+		"add [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] [--s3-endpoint {{.LessThan}}url{{.GreaterThan}}] {{.LessThan}}name{{.GreaterThan}} {{.LessThan}}url{{.GreaterThan}}",
 		"remove {{.LessThan}}name{{.GreaterThan}}",
 	},
 }
@@ -73,6 +71,7 @@ const (
 )
 
 var awsParams = []string{dbfactory.AWSRegionParam, dbfactory.AWSCredsTypeParam, dbfactory.AWSCredsFileParam, dbfactory.AWSCredsProfile}
+var s3Params = []string{dbfactory.S3EndpointParam}
 var credTypes = []string{dbfactory.RoleCS.String(), dbfactory.EnvCS.String(), dbfactory.FileCS.String()}
 
 type RemoteCmd struct{}
@@ -103,6 +102,7 @@ func (cmd RemoteCmd) createArgParser() *argparser.ArgParser {
 	ap.SupportsValidatedString(dbfactory.AWSCredsTypeParam, "", "creds-type", "", argparser.ValidatorFromStrList(dbfactory.AWSCredsTypeParam, credTypes))
 	ap.SupportsString(dbfactory.AWSCredsFileParam, "", "file", "AWS credentials file")
 	ap.SupportsString(dbfactory.AWSCredsProfile, "", "profile", "AWS profile to use")
+	ap.SupportsString(dbfactory.S3EndpointParam, "", "url", "URL of an S3-compatible server to use for s3 remotes, instead of AWS")
 	return ap
 }
 
@@ -180,68 +180,6 @@ func removeRemote(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgPars
 	return nil
 }
 
-func getAbsRemoteUrl(fs filesys.Filesys, cfg config.ReadableConfig, urlArg string) (string, string, error) {
-	u, err := earl.Parse(urlArg)
-
-	if err != nil {
-		return "", "", err
-	}
-
-	if u.Scheme != "" {
-		if u.Scheme == dbfactory.FileScheme || u.Scheme == dbfactory.LocalBSScheme {
-			absUrl, err := getAbsFileRemoteUrl(u.Host+u.Path, fs)
-
-			if err != nil {
-				return "", "", err
-			}
-
-			return u.Scheme, absUrl, err
-		}
-
-		return u.Scheme, urlArg, nil
-	} else if u.Host != "" {
-		return dbfactory.HTTPSScheme, "https://" + urlArg, nil
-	}
-
-	hostName, err := cfg.GetString(env.RemotesApiHostKey)
-
-	if err != nil {
-		if err != config.ErrConfigParamNotFound {
-			return "", "", err
-		}
-
-		hostName = env.DefaultRemotesApiHost
-	}
-
-	hostName = strings.TrimSpace(hostName)
-
-	return dbfactory.HTTPSScheme, "https://" + path.Join(hostName, u.Path), nil
-}
-
-func getAbsFileRemoteUrl(urlStr string, fs filesys.Filesys) (string, error) {
-	var err error
-	urlStr = filepath.Clean(urlStr)
-	urlStr, err = fs.Abs(urlStr)
-
-	if err != nil {
-		return "", err
-	}
-
-	exists, isDir := fs.Exists(urlStr)
-
-	if !exists {
-		return "", filesys.ErrDirNotExist
-	} else if !isDir {
-		return "", filesys.ErrIsFile
-	}
-
-	urlStr = strings.ReplaceAll(urlStr, `\`, "/")
-	if !strings.HasPrefix(urlStr, "/") {
-		urlStr = "/" + urlStr
-	}
-	return dbfactory.FileScheme + "://" + urlStr, nil
-}
-
 func addRemote(dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.VerboseError {
 	if apr.NArg() != 3 {
 		return errhand.BuildDError("").SetPrintUsage().Build()
@@ -258,7 +196,7 @@ func addRemote(dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.Verbos
 	}
 
 	remoteUrl := apr.Arg(2)
-	scheme, absRemoteUrl, err := getAbsRemoteUrl(dEnv.FS, dEnv.Config, remoteUrl)
+	scheme, absRemoteUrl, err := env.GetAbsRemoteUrl(dEnv.FS, dEnv.Config, remoteUrl)
 
 	if err != nil {
 		return errhand.BuildDError("error: '%s' is not valid.", remoteUrl).AddCause(err).Build()
@@ -285,17 +223,21 @@ func parseRemoteArgs(apr *argparser.ArgParseResults, scheme, remoteUrl string) (
 	params := map[string]string{}
 
 	var verr errhand.VerboseError
-	if scheme == dbfactory.AWSScheme {
+	if scheme == dbfactory.AWSScheme || scheme == dbfactory.S3Scheme {
 		verr = addAWSParams(remoteUrl, apr, params)
 	} else {
 		verr = verifyNoAwsParams(apr)
 	}
 
+	if verr == nil {
+		verr = addS3Params(scheme, apr, params)
+	}
+
 	return params, verr
 }
 
 func addAWSParams(remoteUrl string, apr *argparser.ArgParseResults, params map[string]string) errhand.VerboseError {
-	isAWS := strings.HasPrefix(remoteUrl, "aws")
+	isAWS := strings.HasPrefix(remoteUrl, "aws") || strings.HasPrefix(remoteUrl, "s3")
 
 	if !isAWS {
 		for _, p := range awsParams {
@@ -314,6 +256,20 @@ func addAWSParams(remoteUrl string, apr *argparser.ArgParseResults, params map[s
 	return nil
 }
 
+func addS3Params(scheme string, apr *argparser.ArgParseResults, params map[string]string) errhand.VerboseError {
+	for _, p := range s3Params {
+		if val, ok := apr.GetValue(p); ok {
+			if scheme != dbfactory.S3Scheme {
+				return errhand.BuildDError(p + " param is only valid for s3 remotes in the format s3://s3-bucket/path").Build()
+			}
+
+			params[p] = val
+		}
+	}
+
+	return nil
+}
+
 func verifyNoAwsParams(apr *argparser.ArgParseResults) errhand.VerboseError {
 	if awsParams := apr.GetValues(awsParams...); len(awsParams) > 0 {
 		awsParamKeys := make([]string, 0, len(awsParams))
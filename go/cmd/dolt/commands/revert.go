@@ -0,0 +1,103 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	eventsapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+var revertDocs = cli.CommandDocumentationContent{
+	ShortDesc: `Undo the changes introduced by existing commits.`,
+	LongDesc: `Removes the changes introduced by each of the named commits from the current branch and records a new commit with a generated commit message that names the reverted commits.
+
+Each commit is reverted with a three-way merge of the current HEAD and the commit's parent, using the commit itself as the merge base. The commits are reverted in the order given. If any changes can't be reverted cleanly, no commit is made. The conflicts are recorded in the working set exactly as they are for {{.EmphasisLeft}}dolt merge{{.EmphasisRight}}, and can be resolved with {{.EmphasisLeft}}dolt conflicts{{.EmphasisRight}} or the {{.EmphasisLeft}}dolt_conflicts{{.EmphasisRight}} system tables before committing the result with {{.EmphasisLeft}}dolt commit{{.EmphasisRight}}.
+
+The working set must be clean before commits can be reverted. Merge commits can't be reverted.`,
+	Synopsis: []string{
+		`{{.LessThan}}revision{{.GreaterThan}}...`,
+	},
+}
+
+type RevertCmd struct{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd RevertCmd) Name() string {
+	return "revert"
+}
+
+// Description returns a description of the command
+func (cmd RevertCmd) Description() string {
+	return revertDocs.ShortDesc
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd RevertCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cli.CreateRevertArgParser()
+	return CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, revertDocs, ap))
+}
+
+// EventType returns the type of the event to log
+func (cmd RevertCmd) EventType() eventsapi.ClientEventType {
+	return eventsapi.ClientEventType_TYPE_UNSPECIFIED
+}
+
+// Exec executes the command
+func (cmd RevertCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cli.CreateRevertArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, revertDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	if apr.NArg() == 0 {
+		verr := errhand.BuildDError("%s requires at least 1 arg", cmd.Name()).SetPrintUsage().Build()
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	verr := checkCleanWorkingSet(ctx, dEnv, "revert")
+	if verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	commits := make([]*doltdb.Commit, apr.NArg())
+	for i, revision := range apr.Args() {
+		commits[i], verr = ResolveCommitWithVErr(dEnv, revision)
+		if verr != nil {
+			return HandleVErrAndExitCode(verr, usage)
+		}
+	}
+
+	headRoot, err := dEnv.HeadRoot(ctx)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	revertedRoot, tblToStats, msg, err := merge.Revert(ctx, dEnv.DoltDB, headRoot, commits)
+	if err == merge.ErrMergeCommitNotSupported {
+		verr = errhand.BuildDError("error: reverting a merge commit is not supported.").Build()
+		return HandleVErrAndExitCode(verr, usage)
+	} else if err != nil {
+		verr = errhand.BuildDError("error: failed to revert").AddCause(err).Build()
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	return commitMergedRoot(ctx, dEnv, revertedRoot, tblToStats, msg, "revert", usage)
+}
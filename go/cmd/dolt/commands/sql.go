@@ -51,7 +51,6 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dfunctions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
-	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/globalstate"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
@@ -1441,7 +1440,7 @@ func getDbState(ctx context.Context, db dsqle.Database, mrEnv env.MultiRepoEnv)
 		HeadCommit:  headCommit,
 		WorkingSet:  ws,
 		DbData:      dEnv.DbData(),
-		GlobalState: globalstate.NewGlobalStateStore(),
+		GlobalState: db.GetGlobalState(),
 	}, nil
 }
 
@@ -16,6 +16,7 @@ package sqlserver
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
 	"strconv"
@@ -37,7 +38,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dfunctions"
 	_ "github.com/dolthub/dolt/go/libraries/doltcore/sqle/dfunctions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
-	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/globalstate"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/privileges"
 	"github.com/dolthub/dolt/go/libraries/utils/tracing"
 )
 
@@ -79,12 +80,19 @@ func Serve(ctx context.Context, version string, serverConfig ServerConfig, serve
 		logrus.SetLevel(level)
 	}
 
-	permissions := auth.AllPermissions
-	if serverConfig.ReadOnly() {
-		permissions = auth.ReadPerm
+	var privStore *privileges.Store
+	privStore, startError = newPrivilegeStore(serverConfig)
+	if startError != nil {
+		return startError, nil
+	}
+
+	var tlsConfig *tls.Config
+	tlsConfig, startError = LoadTLSConfig(serverConfig)
+	if startError != nil {
+		return startError, nil
 	}
 
-	userAuth := auth.NewAudit(auth.NewNativeSingle(serverConfig.User(), serverConfig.Password(), permissions), auth.NewAuditLog(logrus.StandardLogger()))
+	userAuth := auth.NewAudit(privStore, auth.NewAuditLog(logrus.StandardLogger()))
 
 	var username string
 	var email string
@@ -108,11 +116,29 @@ func Serve(ctx context.Context, version string, serverConfig ServerConfig, serve
 		}
 	}
 
+	replication := dsess.ReplicationConfig{Remote: serverConfig.ReplicationRemote(), ReadReplica: serverConfig.ReadReplica()}
+	if startError = validateReplicationRemotes(replication, mrEnv); startError != nil {
+		return startError, nil
+	}
+
+	gc := dsess.NewGarbageCollector()
+	if gcInterval := serverConfig.GCIntervalSecs(); gcInterval > 0 {
+		gcCtx, stopGC := context.WithCancel(ctx)
+		defer stopGC()
+		go runBackgroundGC(gcCtx, gc, mrEnv, time.Duration(gcInterval)*time.Second)
+	}
+
 	dbs := commands.CollectDBs(mrEnv)
-	cat := sql.NewCatalogWithDbProvider(dsqle.NewDoltDatabaseProvider(dbs...))
+	pro := dsqle.NewDoltDatabaseProvider(dbs...)
+	cat := sql.NewCatalogWithDbProvider(pro)
 	cat.AddDatabase(information_schema.NewInformationSchemaDatabase(cat))
 
-	a := analyzer.NewBuilder(cat).WithParallelism(serverConfig.QueryParallelism()).Build()
+	writeVarSuffixes := []string{dsess.HeadKeySuffix, dsess.HeadRefKeySuffix, dsess.WorkingKeySuffix, dsess.StagedKeySuffix}
+	a := analyzer.NewBuilder(cat).
+		WithParallelism(serverConfig.QueryParallelism()).
+		AddPreAnalyzeRule(privileges.AuthorizationRuleName, privileges.NewAuthorizationRule(privStore, dfunctions.DoltWriteFunctionNames, writeVarSuffixes)).
+		AddPostValidationRule(dsess.GCHoldRuleName, dsess.NewGCHoldRule(dfunctions.DoltWriteFunctionNames)).
+		Build()
 	sqlEngine := sqle.New(cat, a, nil)
 
 	if err := sqlEngine.Catalog.Register(dfunctions.DoltFunctions...); err != nil {
@@ -141,7 +167,7 @@ func Serve(ctx context.Context, version string, serverConfig ServerConfig, serve
 			// to the value of mysql that we support.
 		},
 		sqlEngine,
-		newSessionBuilder(sqlEngine, username, email, mrEnv, serverConfig.AutoCommit()),
+		newSessionBuilder(sqlEngine, pro, username, email, mrEnv, serverConfig.AutoCommit(), replication, gc),
 	)
 
 	if startError != nil {
@@ -149,6 +175,10 @@ func Serve(ctx context.Context, version string, serverConfig ServerConfig, serve
 		return
 	}
 
+	// with a TLS config the server advertises CLIENT_SSL, and clients may upgrade their connections during the handshake
+	mySQLServer.Listener.TLSConfig = tlsConfig
+	mySQLServer.Listener.RequireSecureTransport = serverConfig.RequireSecureTransport()
+
 	serverController.registerCloseFunction(startError, mySQLServer.Close)
 	closeError = mySQLServer.Start()
 	if closeError != nil {
@@ -168,7 +198,7 @@ func portInUse(hostPort string) bool {
 	return false
 }
 
-func newSessionBuilder(sqlEngine *sqle.Engine, username, email string, mrEnv env.MultiRepoEnv, autocommit bool) server.SessionBuilder {
+func newSessionBuilder(sqlEngine *sqle.Engine, pro dsess.RevisionDatabaseProvider, username, email string, mrEnv env.MultiRepoEnv, autocommit bool, replication dsess.ReplicationConfig, gc *dsess.GarbageCollector) server.SessionBuilder {
 	return func(ctx context.Context, conn *mysql.Conn, host string) (sql.Session, *sql.IndexRegistry, *sql.ViewRegistry, error) {
 		tmpSqlCtx := sql.NewEmptyContext()
 		mysqlSess := sql.NewSession(host, conn.RemoteAddr().String(), conn.User, conn.ConnectionID)
@@ -185,6 +215,11 @@ func newSessionBuilder(sqlEngine *sqle.Engine, username, email string, mrEnv env
 			return nil, nil, nil, err
 		}
 
+		doltSess.SetRevisionDatabaseProvider(pro)
+		doltSess.SetReplicationConfig(replication)
+		doltSess.SetGarbageCollector(gc)
+		gc.AddSession(doltSess, conn.IsClosed)
+
 		err = doltSess.SetSessionVariable(tmpSqlCtx, sql.AutoCommitSessionVar, autocommit)
 
 		if err != nil {
@@ -219,6 +254,73 @@ func newSessionBuilder(sqlEngine *sqle.Engine, username, email string, mrEnv env
 	}
 }
 
+// runBackgroundGC garbage collects every database of |mrEnv| with |gc| each |interval|, until |ctx| is done. A
+// collection fails if a database is written to by something other than the server's sessions while it runs, such as
+// another dolt process, and is retried at the next interval.
+func runBackgroundGC(ctx context.Context, gc *dsess.GarbageCollector, mrEnv env.MultiRepoEnv, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		_ = mrEnv.Iter(func(name string, dEnv *env.DoltEnv) (stop bool, err error) {
+			start := time.Now()
+			err = gc.GC(ctx, dEnv.DoltDB)
+			if err != nil {
+				logrus.Errorf("background garbage collection of database %s failed: %v", name, err)
+			} else {
+				logrus.Infof("garbage collected database %s in %v", name, time.Since(start))
+			}
+
+			return ctx.Err() != nil, nil
+		})
+	}
+}
+
+// newPrivilegeStore returns the store of the users that clients can connect as. The user of |serverConfig| is granted
+// every privilege, and its other users the privileges it grants them. Users can only be defined in the config until
+// CREATE USER and GRANT are supported.
+func newPrivilegeStore(serverConfig ServerConfig) (*privileges.Store, error) {
+	store := privileges.NewStore()
+	store.SetReadOnly(serverConfig.ReadOnly())
+
+	superuser := privileges.User{
+		Name:         serverConfig.User(),
+		PasswordHash: privileges.HashPassword(serverConfig.Password()),
+		Grants: []privileges.Grant{
+			{Database: privileges.Wildcard, Table: privileges.Wildcard, Privileges: privileges.All | privileges.GrantOption},
+		},
+	}
+
+	err := store.AddUsers(append([]privileges.User{superuser}, serverConfig.Users()...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+// validateReplicationRemotes returns an error if any of the databases in |mrEnv| doesn't have the remote that
+// |replication| replicates through.
+func validateReplicationRemotes(replication dsess.ReplicationConfig, mrEnv env.MultiRepoEnv) error {
+	if !replication.Enabled() {
+		return nil
+	}
+
+	return mrEnv.Iter(func(name string, dEnv *env.DoltEnv) (stop bool, err error) {
+		_, err = dsess.GetReplicationRemote(replication, dEnv.RepoStateReader())
+		if err != nil {
+			return true, fmt.Errorf("cannot replicate database %s: %w", name, err)
+		}
+		return false, nil
+	})
+}
+
 func dbsAsDSQLDBs(dbs []sql.Database) []dsqle.Database {
 	dsqlDBs := make([]dsqle.Database, 0, len(dbs))
 
@@ -267,7 +369,7 @@ func getDbStates(ctx context.Context, mrEnv env.MultiRepoEnv, dbs []dsqle.Databa
 			WorkingSet: ws,
 			DbData:     dEnv.DbData(),
 			// TODO: The placement of this may change when multiple Dolt Databases can be represented in one commit log.
-			GlobalState: globalstate.NewGlobalStateStore(),
+			GlobalState: db.GetGlobalState(),
 		})
 	}
 
@@ -15,9 +15,20 @@
 package sqlserver
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/gocraft/dbr/v2"
@@ -111,6 +122,10 @@ func TestServerBadArgs(t *testing.T) {
 		{"-P", "90000"},
 		{"-u", ""},
 		{"-l", "everything"},
+		{"--tls-key", "key.pem"},
+		{"--tls-cert", "cert.pem"},
+		{"--require-secure-transport"},
+		{"--tls-key", "missing-key.pem", "--tls-cert", "missing-cert.pem"},
 	}
 
 	for _, test := range tests {
@@ -218,6 +233,136 @@ func TestServerSelect(t *testing.T) {
 	}
 }
 
+func TestServerTLS(t *testing.T) {
+	env := dtestutils.CreateEnvWithSeedData(t)
+	keyPath, certPath := writeSelfSignedCert(t)
+
+	tests := []struct {
+		name          string
+		config        ServerConfig
+		insecureAllow bool
+	}{
+		{
+			name:          "tls",
+			config:        DefaultServerConfig().withTLS(keyPath, certPath).withPort(15500),
+			insecureAllow: true,
+		},
+		{
+			name:          "require secure transport",
+			config:        DefaultServerConfig().withTLS(keyPath, certPath).withRequireSecureTransport(true).withPort(15501),
+			insecureAllow: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sc := CreateServerController()
+			go func(config ServerConfig, sc *ServerController) {
+				_, _ = Serve(context.Background(), "", config, sc, env)
+			}(test.config, sc)
+			err := sc.WaitForStart()
+			require.NoError(t, err)
+
+			conn, err := dbr.Open("mysql", ConnectionString(test.config)+"?tls=skip-verify", nil)
+			require.NoError(t, err)
+			assert.NoError(t, conn.Ping())
+			require.NoError(t, conn.Close())
+
+			conn, err = dbr.Open("mysql", ConnectionString(test.config), nil)
+			require.NoError(t, err)
+			err = conn.Ping()
+			if test.insecureAllow {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+			require.NoError(t, conn.Close())
+
+			sc.StopServer()
+			err = sc.WaitForClose()
+			assert.NoError(t, err)
+		})
+	}
+}
+
+// writeSelfSignedCert writes a self signed certificate for localhost and its key to a temporary directory, and returns
+// the paths of the key and the certificate.
+func TestServerGC(t *testing.T) {
+	env := dtestutils.CreateEnvWithSeedData(t)
+	serverConfig := DefaultServerConfig().withLogLevel(LogLevel_Fatal).withPort(15600).withMaxConnections(2).withGCIntervalSecs(1)
+
+	sc := CreateServerController()
+	defer sc.StopServer()
+	go func() {
+		_, _ = Serve(context.Background(), "", serverConfig, sc, env)
+	}()
+	err := sc.WaitForStart()
+	require.NoError(t, err)
+
+	const dbName = "dolt"
+	conn, err := dbr.Open("mysql", ConnectionString(serverConfig)+dbName, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	conn.SetMaxOpenConns(2)
+
+	// rows are written while other connections and the server's background collection garbage collect the database
+	const numRows = 100
+	done := make(chan struct{})
+	var gcErr error
+	go func() {
+		defer close(done)
+		for i := 0; i < 10 && gcErr == nil; i++ {
+			_, gcErr = conn.NewSession(nil).SelectBySql("select dolt_gc()").ReturnInt64()
+		}
+	}()
+
+	sess := conn.NewSession(nil)
+	_, err = sess.Exec("create table gc_test (pk int primary key, c1 varchar(20))")
+	require.NoError(t, err)
+	for i := 0; i < numRows; i++ {
+		_, err = sess.InsertInto("gc_test").Pair("pk", i).Pair("c1", fmt.Sprintf("row %d", i)).Exec()
+		require.NoError(t, err)
+	}
+
+	<-done
+	require.NoError(t, gcErr)
+	time.Sleep(1500 * time.Millisecond)
+
+	var count int
+	err = sess.Select("count(*)").From("gc_test").LoadOne(&count)
+	require.NoError(t, err)
+	assert.Equal(t, numRows, count)
+}
+
+func writeSelfSignedCert(t *testing.T) (keyPath, certPath string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	keyPath = filepath.Join(dir, "key.pem")
+	certPath = filepath.Join(dir, "cert.pem")
+	err = os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600)
+	require.NoError(t, err)
+	err = os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}), 0644)
+	require.NoError(t, err)
+
+	return keyPath, certPath
+}
+
 // If a port is already in use, throw error "Port XXXX already in use."
 func TestServerFailsIfPortInUse(t *testing.T) {
 	serverController := CreateServerController()
@@ -15,10 +15,12 @@
 package sqlserver
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/privileges"
 )
 
 // LogLevel defines the available levels of logging for the server.
@@ -34,16 +36,23 @@ const (
 )
 
 const (
-	defaultHost             = "localhost"
-	defaultPort             = 3306
-	defaultUser             = "root"
-	defaultPass             = ""
-	defaultTimeout          = 8 * 60 * 60 * 1000 // 8 hours, same as MySQL
-	defaultReadOnly         = false
-	defaultLogLevel         = LogLevel_Info
-	defaultAutoCommit       = true
-	defaultMaxConnections   = 1
-	defaultQueryParallelism = 2
+	defaultHost              = "localhost"
+	defaultPort              = 3306
+	defaultUser              = "root"
+	defaultPass              = ""
+	defaultTimeout           = 8 * 60 * 60 * 1000 // 8 hours, same as MySQL
+	defaultReadOnly          = false
+	defaultLogLevel          = LogLevel_Info
+	defaultAutoCommit        = true
+	defaultMaxConnections    = 1
+	defaultQueryParallelism  = 2
+	defaultReplicationRemote = ""
+	defaultReadReplica       = false
+	defaultGCIntervalSecs    = 0
+	defaultTLSKey            = ""
+	defaultTLSCert           = ""
+
+	defaultRequireSecureTransport = false
 )
 
 // String returns the string representation of the log level.
@@ -76,10 +85,21 @@ type ServerConfig interface {
 	User() string
 	// Password returns the password that connecting clients must use.
 	Password() string
+	// Users returns the accounts that clients may connect as in addition to User(), and the privileges granted to
+	// them. User() is granted every privilege.
+	Users() []privileges.User
 	// ReadTimeout returns the read timeout in milliseconds
 	ReadTimeout() uint64
 	// WriteTimeout returns the write timeout in milliseconds
 	WriteTimeout() uint64
+	// TLSKey returns the path of the private TLS key, in PEM format, that the server serves connections with. The
+	// server doesn't support TLS when it's empty.
+	TLSKey() string
+	// TLSCert returns the path of the TLS certificate chain, in PEM format, that the server serves connections with.
+	// The server doesn't support TLS when it's empty.
+	TLSCert() string
+	// RequireSecureTransport returns whether the server turns away clients that don't connect with TLS.
+	RequireSecureTransport() bool
 	// ReadOnly returns whether the server will only accept read statements or all statements.
 	ReadOnly() bool
 	// LogLevel returns the level of logging that the server will use.
@@ -94,20 +114,35 @@ type ServerConfig interface {
 	MaxConnections() uint64
 	// QueryParallelism returns the parallelism that should be used by the go-mysql-server analyzer
 	QueryParallelism() int
+	// ReplicationRemote returns the name of the remote that the server's databases are replicated through. Databases
+	// aren't replicated when it's empty.
+	ReplicationRemote() string
+	// ReadReplica returns whether the server pulls its databases from the replication remote before every
+	// transaction, rather than pushing them to it on every commit.
+	ReadReplica() bool
+	// GCIntervalSecs returns the number of seconds between the garbage collections the server runs on its databases
+	// in the background. Databases aren't collected in the background when it's 0.
+	GCIntervalSecs() uint64
 }
 
 type commandLineServerConfig struct {
-	host             string
-	port             int
-	user             string
-	password         string
-	timeout          uint64
-	readOnly         bool
-	logLevel         LogLevel
-	dbNamesAndPaths  []env.EnvNameAndPath
-	autoCommit       bool
-	maxConnections   uint64
-	queryParallelism int
+	host                   string
+	port                   int
+	user                   string
+	password               string
+	timeout                uint64
+	readOnly               bool
+	logLevel               LogLevel
+	dbNamesAndPaths        []env.EnvNameAndPath
+	autoCommit             bool
+	maxConnections         uint64
+	queryParallelism       int
+	replicationRemote      string
+	readReplica            bool
+	gcIntervalSecs         uint64
+	tlsKey                 string
+	tlsCert                string
+	requireSecureTransport bool
 }
 
 // Host returns the domain that the server will run on. Accepts an IPv4 or IPv6 address, in addition to localhost.
@@ -130,6 +165,12 @@ func (cfg *commandLineServerConfig) Password() string {
 	return cfg.password
 }
 
+// Users returns the accounts that clients may connect as in addition to User(). The command line only configures
+// User().
+func (cfg *commandLineServerConfig) Users() []privileges.User {
+	return nil
+}
+
 // ReadTimeout returns the read and write timeouts.
 func (cfg *commandLineServerConfig) ReadTimeout() uint64 {
 	return cfg.timeout
@@ -140,6 +181,23 @@ func (cfg *commandLineServerConfig) WriteTimeout() uint64 {
 	return cfg.timeout
 }
 
+// TLSKey returns the path of the private TLS key, in PEM format, that the server serves connections with. The server
+// doesn't support TLS when it's empty.
+func (cfg *commandLineServerConfig) TLSKey() string {
+	return cfg.tlsKey
+}
+
+// TLSCert returns the path of the TLS certificate chain, in PEM format, that the server serves connections with. The
+// server doesn't support TLS when it's empty.
+func (cfg *commandLineServerConfig) TLSCert() string {
+	return cfg.tlsCert
+}
+
+// RequireSecureTransport returns whether the server turns away clients that don't connect with TLS.
+func (cfg *commandLineServerConfig) RequireSecureTransport() bool {
+	return cfg.requireSecureTransport
+}
+
 // ReadOnly returns whether the server will only accept read statements or all statements.
 func (cfg *commandLineServerConfig) ReadOnly() bool {
 	return cfg.readOnly
@@ -165,6 +223,24 @@ func (cfg *commandLineServerConfig) QueryParallelism() int {
 	return cfg.queryParallelism
 }
 
+// ReplicationRemote returns the name of the remote that the server's databases are replicated through. Databases
+// aren't replicated when it's empty.
+func (cfg *commandLineServerConfig) ReplicationRemote() string {
+	return cfg.replicationRemote
+}
+
+// ReadReplica returns whether the server pulls its databases from the replication remote before every transaction,
+// rather than pushing them to it on every commit.
+func (cfg *commandLineServerConfig) ReadReplica() bool {
+	return cfg.readReplica
+}
+
+// GCIntervalSecs returns the number of seconds between the garbage collections the server runs on its databases in
+// the background. Databases aren't collected in the background when it's 0.
+func (cfg *commandLineServerConfig) GCIntervalSecs() uint64 {
+	return cfg.gcIntervalSecs
+}
+
 // DatabaseNamesAndPaths returns an array of env.EnvNameAndPathObjects corresponding to the databases to be loaded in
 // a multiple db configuration. If nil is returned the server will look for a database in the current directory and
 // give it a name automatically.
@@ -227,6 +303,28 @@ func (cfg *commandLineServerConfig) withQueryParallelism(queryParallelism int) *
 	return cfg
 }
 
+// withTLS updates the paths of the TLS key and certificate and returns the called `*commandLineServerConfig`, which is
+// useful for chaining calls.
+func (cfg *commandLineServerConfig) withTLS(tlsKey, tlsCert string) *commandLineServerConfig {
+	cfg.tlsKey = tlsKey
+	cfg.tlsCert = tlsCert
+	return cfg
+}
+
+// withRequireSecureTransport updates whether clients must connect with TLS and returns the called
+// `*commandLineServerConfig`, which is useful for chaining calls.
+func (cfg *commandLineServerConfig) withRequireSecureTransport(requireSecureTransport bool) *commandLineServerConfig {
+	cfg.requireSecureTransport = requireSecureTransport
+	return cfg
+}
+
+// withGCIntervalSecs updates the number of seconds between background garbage collections and returns the called
+// `*commandLineServerConfig`, which is useful for chaining calls.
+func (cfg *commandLineServerConfig) withGCIntervalSecs(gcIntervalSecs uint64) *commandLineServerConfig {
+	cfg.gcIntervalSecs = gcIntervalSecs
+	return cfg
+}
+
 func (cfg *commandLineServerConfig) withDBNamesAndPaths(dbNamesAndPaths []env.EnvNameAndPath) *commandLineServerConfig {
 	cfg.dbNamesAndPaths = dbNamesAndPaths
 	return cfg
@@ -235,16 +333,23 @@ func (cfg *commandLineServerConfig) withDBNamesAndPaths(dbNamesAndPaths []env.En
 // DefaultServerConfig creates a `*ServerConfig` that has all of the options set to their default values.
 func DefaultServerConfig() *commandLineServerConfig {
 	return &commandLineServerConfig{
-		host:             defaultHost,
-		port:             defaultPort,
-		user:             defaultUser,
-		password:         defaultPass,
-		timeout:          defaultTimeout,
-		readOnly:         defaultReadOnly,
-		logLevel:         defaultLogLevel,
-		autoCommit:       defaultAutoCommit,
-		maxConnections:   defaultMaxConnections,
-		queryParallelism: defaultQueryParallelism,
+		host:              defaultHost,
+		port:              defaultPort,
+		user:              defaultUser,
+		password:          defaultPass,
+		timeout:           defaultTimeout,
+		readOnly:          defaultReadOnly,
+		logLevel:          defaultLogLevel,
+		autoCommit:        defaultAutoCommit,
+		maxConnections:    defaultMaxConnections,
+		queryParallelism:  defaultQueryParallelism,
+		replicationRemote: defaultReplicationRemote,
+		readReplica:       defaultReadReplica,
+		gcIntervalSecs:    defaultGCIntervalSecs,
+		tlsKey:            defaultTLSKey,
+		tlsCert:           defaultTLSCert,
+
+		requireSecureTransport: defaultRequireSecureTransport,
 	}
 }
 
@@ -265,9 +370,33 @@ func ValidateConfig(config ServerConfig) error {
 	if config.LogLevel().String() == "unknown" {
 		return fmt.Errorf("loglevel is invalid: %v\n", string(config.LogLevel()))
 	}
+	if config.ReadReplica() && len(config.ReplicationRemote()) == 0 {
+		return fmt.Errorf("a read replica must have a replication remote")
+	}
+	if (config.TLSKey() == "") != (config.TLSCert() == "") {
+		return fmt.Errorf("tls_key and tls_cert must both be set or both be empty")
+	}
+	if config.RequireSecureTransport() && config.TLSKey() == "" {
+		return fmt.Errorf("require_secure_transport can only be enabled when tls_key and tls_cert are set")
+	}
 	return nil
 }
 
+// LoadTLSConfig returns the TLS config that the server serves connections with, or nil if the server doesn't support
+// TLS.
+func LoadTLSConfig(config ServerConfig) (*tls.Config, error) {
+	if config.TLSKey() == "" && config.TLSCert() == "" {
+		return nil, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(config.TLSCert(), config.TLSKey())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tls_cert '%s' and tls_key '%s': %w", config.TLSCert(), config.TLSKey(), err)
+	}
+
+	return &tls.Config{Certificates: []tls.Certificate{cert}}, nil
+}
+
 // ConnectionString returns a Data Source Name (DSN) to be used by go clients for connecting to a running server.
 func ConnectionString(config ServerConfig) string {
 	return fmt.Sprintf("%v:%v@tcp(%v:%v)/", config.User(), config.Password(), config.Host(), config.Port())
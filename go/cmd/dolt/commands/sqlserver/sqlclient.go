@@ -114,7 +114,13 @@ func (cmd SqlClientCmd) Exec(ctx context.Context, commandStr string, args []stri
 		}
 	}
 
-	conn, err := dbr.Open("mysql", ConnectionString(serverConfig), nil)
+	connStr := ConnectionString(serverConfig)
+	if serverConfig.TLSKey() != "" {
+		// the client uses TLS whenever the server supports it, but doesn't verify the server's certificate
+		connStr += "?tls=preferred"
+	}
+
+	conn, err := dbr.Open("mysql", connStr, nil)
 	if err != nil {
 		cli.PrintErrln(err.Error())
 		serverController.StopServer()
@@ -31,18 +31,21 @@ import (
 )
 
 const (
-	hostFlag             = "host"
-	portFlag             = "port"
-	userFlag             = "user"
-	passwordFlag         = "password"
-	timeoutFlag          = "timeout"
-	readonlyFlag         = "readonly"
-	logLevelFlag         = "loglevel"
-	multiDBDirFlag       = "multi-db-dir"
-	noAutoCommitFlag     = "no-auto-commit"
-	configFileFlag       = "config"
-	queryParallelismFlag = "query-parallelism"
-	maxConnectionsFlag   = "max-connections"
+	hostFlag                   = "host"
+	portFlag                   = "port"
+	userFlag                   = "user"
+	passwordFlag               = "password"
+	timeoutFlag                = "timeout"
+	readonlyFlag               = "readonly"
+	logLevelFlag               = "loglevel"
+	multiDBDirFlag             = "multi-db-dir"
+	noAutoCommitFlag           = "no-auto-commit"
+	configFileFlag             = "config"
+	queryParallelismFlag       = "query-parallelism"
+	maxConnectionsFlag         = "max-connections"
+	tlsKeyFlag                 = "tls-key"
+	tlsCertFlag                = "tls-cert"
+	requireSecureTransportFlag = "require-secure-transport"
 )
 
 var sqlServerDocs = cli.CommandDocumentationContent{
@@ -64,10 +67,20 @@ SUPPORTED CONFIG FILE FIELDS:
 
 		{{.EmphasisLeft}}behavior.autocommit{{.EmphasisRight}} - If true write queries will automatically alter the working set. When working with autocommit enabled it is highly recommended that listener.max_connections be set to 1 as concurrency issues will arise otherwise
 
+		{{.EmphasisLeft}}behavior.gc_interval_secs{{.EmphasisRight}} - The number of seconds between garbage collections of the server's databases, which run in the background while clients stay connected. If 0 databases are only garbage collected when {{.EmphasisLeft}}DOLT_GC(){{.EmphasisRight}} is called
+
 		{{.EmphasisLeft}}user.name{{.EmphasisRight}} - The username that connections should use for authentication
 
 		{{.EmphasisLeft}}user.password{{.EmphasisRight}} - The password that connections should use for authentication.
 
+		{{.EmphasisLeft}}users{{.EmphasisRight}} - a list of additional users that connections may authenticate as. Unlike the user above, which is granted every privilege, these users only have the privileges granted to them
+
+		{{.EmphasisLeft}}users[i].name{{.EmphasisRight}} - The username of the user
+
+		{{.EmphasisLeft}}users[i].password{{.EmphasisRight}} - The password of the user. Alternatively {{.EmphasisLeft}}users[i].password_hash{{.EmphasisRight}} may give its hash in the mysql_native_password format
+
+		{{.EmphasisLeft}}users[i].grants{{.EmphasisRight}} - A list of privileges granted to the user. Each has a comma separated list of {{.EmphasisLeft}}privileges{{.EmphasisRight}} out of SELECT, INSERT, UPDATE, DELETE, CREATE, DROP, ALTER, DDL and ALL, and the database and table they're granted {{.EmphasisLeft}}on{{.EmphasisRight}}, such as {{.EmphasisLeft}}mydb.*{{.EmphasisRight}}
+
 		{{.EmphasisLeft}}listener.host{{.EmphasisRight}} - The host address that the server will run on.  This may be {{.EmphasisLeft}}localhost{{.EmphasisRight}} or an IPv4 or IPv6 address
 
 		{{.EmphasisLeft}}listener.port{{.EmphasisRight}} - The port that the server should listen on
@@ -78,6 +91,12 @@ SUPPORTED CONFIG FILE FIELDS:
 
 		{{.EmphasisLeft}}listener.write_timeout_millis{{.EmphasisRight}} - The number of milliseconds that the server will wait for a write operation
 
+		{{.EmphasisLeft}}listener.tls_key{{.EmphasisRight}} - A path to an unencrypted private TLS key in PEM format. When it and {{.EmphasisLeft}}listener.tls_cert{{.EmphasisRight}} are set, clients may connect to the server with TLS
+
+		{{.EmphasisLeft}}listener.tls_cert{{.EmphasisRight}} - A path to a TLS certificate chain in PEM format
+
+		{{.EmphasisLeft}}listener.require_secure_transport{{.EmphasisRight}} - If true the server turns away clients that don't connect with TLS. Requires {{.EmphasisLeft}}listener.tls_key{{.EmphasisRight}} and {{.EmphasisLeft}}listener.tls_cert{{.EmphasisRight}}
+
 		{{.EmphasisLeft}}performance.query_parallelism{{.EmphasisRight}} - Amount of go routines spawned to process each query
 
 		{{.EmphasisLeft}}databases{{.EmphasisRight}} - a list of dolt data repositories to make available as SQL databases. If databases is missing or empty then the working directory must be a valid dolt data repository which will be made available as a SQL database
@@ -129,6 +148,9 @@ func (cmd SqlServerCmd) CreateArgParser() *argparser.ArgParser {
 	ap.SupportsFlag(noAutoCommitFlag, "", "When provided sessions will not automatically commit their changes to the working set. Anything not manually committed will be lost.")
 	ap.SupportsInt(queryParallelismFlag, "", "num-go-routines", fmt.Sprintf("Set the number of go routines spawned to handle each query (default `%d`)", serverConfig.QueryParallelism()))
 	ap.SupportsInt(maxConnectionsFlag, "", "max-connections", fmt.Sprintf("Set the number of connections handled by the server (default `%d`)", serverConfig.MaxConnections()))
+	ap.SupportsString(tlsKeyFlag, "", "key file", "A path to an unencrypted private TLS key in PEM format. Clients may connect with TLS when it and --tls-cert are provided.")
+	ap.SupportsString(tlsCertFlag, "", "cert file", "A path to a TLS certificate chain in PEM format.")
+	ap.SupportsFlag(requireSecureTransportFlag, "", "When provided the server turns away clients that don't connect with TLS. Requires --tls-key and --tls-cert.")
 	return ap
 }
 
@@ -245,6 +267,11 @@ func getCommandLineServerConfig(dEnv *env.DoltEnv, apr *argparser.ArgParseResult
 		serverConfig.withMaxConnections(uint64(maxConnections))
 	}
 
+	tlsKey, _ := apr.GetValue(tlsKeyFlag)
+	tlsCert, _ := apr.GetValue(tlsCertFlag)
+	serverConfig.withTLS(tlsKey, tlsCert)
+	serverConfig.withRequireSecureTransport(apr.Contains(requireSecureTransportFlag))
+
 	serverConfig.autoCommit = !apr.Contains(noAutoCommitFlag)
 	return serverConfig, nil
 }
@@ -15,6 +15,7 @@
 package sqlserver
 
 import (
+	"fmt"
 	"strings"
 	"unicode"
 	"unicode/utf8"
@@ -22,6 +23,7 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/privileges"
 )
 
 func strPtr(s string) *string {
@@ -40,10 +42,29 @@ func intPtr(n int) *int {
 	return &n
 }
 
+// nillableStrPtr returns nil for empty strings, so that options which aren't set are omitted from the yaml
+func nillableStrPtr(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// nillableBoolPtr returns nil for false, so that options which aren't set are omitted from the yaml
+func nillableBoolPtr(b bool) *bool {
+	if !b {
+		return nil
+	}
+	return &b
+}
+
 // BehaviorYAMLConfig contains server configuration regarding how the server should behave
 type BehaviorYAMLConfig struct {
 	ReadOnly   *bool `yaml:"read_only"`
 	AutoCommit *bool
+	// GCIntervalSecs is the number of seconds between background garbage collections of the server's databases, or 0
+	// to only collect them when DOLT_GC() is called.
+	GCIntervalSecs *uint64 `yaml:"gc_interval_secs"`
 }
 
 // UserYAMLConfig contains server configuration regarding the user account clients must use to connect
@@ -52,6 +73,21 @@ type UserYAMLConfig struct {
 	Password *string
 }
 
+// UserAccountYAMLConfig contains configuration for an additional user account that clients can connect as, and the
+// privileges it's granted
+type UserAccountYAMLConfig struct {
+	Name         string
+	Password     *string
+	PasswordHash *string `yaml:"password_hash"`
+	Grants       []GrantYAMLConfig
+}
+
+// GrantYAMLConfig contains a comma separated list of privileges granted on a database and table, such as "mydb.*"
+type GrantYAMLConfig struct {
+	Privileges string
+	On         string
+}
+
 // DatabaseYAMLConfig contains information on a database that this server will provide access to
 type DatabaseYAMLConfig struct {
 	Name string
@@ -65,6 +101,12 @@ type ListenerYAMLConfig struct {
 	MaxConnections     *uint64 `yaml:"max_connections"`
 	ReadTimeoutMillis  *uint64 `yaml:"read_timeout_millis"`
 	WriteTimeoutMillis *uint64 `yaml:"write_timeout_millis"`
+	// TLSKey is a file system path to an unencrypted private TLS key in PEM format.
+	TLSKey *string `yaml:"tls_key"`
+	// TLSCert is a file system path to a TLS certificate chain in PEM format.
+	TLSCert *string `yaml:"tls_cert"`
+	// RequireSecureTransport can enable a mode where non-TLS connections are turned away.
+	RequireSecureTransport *bool `yaml:"require_secure_transport"`
 }
 
 // PerformanceYAMLConfig contains configuration parameters for performance tweaking
@@ -72,26 +114,39 @@ type PerformanceYAMLConfig struct {
 	QueryParallelism *int `yaml:"query_parallelism"`
 }
 
+// ReplicationYAMLConfig contains configuration for replicating the server's databases through a remote
+type ReplicationYAMLConfig struct {
+	Remote      *string `yaml:"remote"`
+	ReadReplica *bool   `yaml:"read_replica"`
+}
+
 // YAMLConfig is a ServerConfig implementation which is read from a yaml file
 type YAMLConfig struct {
-	LogLevelStr       *string               `yaml:"log_level"`
-	BehaviorConfig    BehaviorYAMLConfig    `yaml:"behavior"`
-	UserConfig        UserYAMLConfig        `yaml:"user"`
-	ListenerConfig    ListenerYAMLConfig    `yaml:"listener"`
-	DatabaseConfig    []DatabaseYAMLConfig  `yaml:"databases"`
-	PerformanceConfig PerformanceYAMLConfig `yaml:"performance"`
+	LogLevelStr       *string                 `yaml:"log_level"`
+	BehaviorConfig    BehaviorYAMLConfig      `yaml:"behavior"`
+	UserConfig        UserYAMLConfig          `yaml:"user"`
+	UsersConfig       []UserAccountYAMLConfig `yaml:"users"`
+	ListenerConfig    ListenerYAMLConfig      `yaml:"listener"`
+	DatabaseConfig    []DatabaseYAMLConfig    `yaml:"databases"`
+	PerformanceConfig PerformanceYAMLConfig   `yaml:"performance"`
+	ReplicationConfig ReplicationYAMLConfig   `yaml:"replication"`
 }
 
 func NewYamlConfig(configFileData []byte) (YAMLConfig, error) {
 	var cfg YAMLConfig
 	err := yaml.UnmarshalStrict(configFileData, &cfg)
+	if err != nil {
+		return cfg, err
+	}
+
+	_, err = cfg.users()
 	return cfg, err
 }
 
 func serverConfigAsYAMLConfig(cfg ServerConfig) YAMLConfig {
 	return YAMLConfig{
 		LogLevelStr:    strPtr(string(cfg.LogLevel())),
-		BehaviorConfig: BehaviorYAMLConfig{boolPtr(cfg.ReadOnly()), boolPtr(cfg.AutoCommit())},
+		BehaviorConfig: BehaviorYAMLConfig{boolPtr(cfg.ReadOnly()), boolPtr(cfg.AutoCommit()), uint64Ptr(cfg.GCIntervalSecs())},
 		UserConfig:     UserYAMLConfig{strPtr(cfg.User()), strPtr(cfg.Password())},
 		ListenerConfig: ListenerYAMLConfig{
 			strPtr(cfg.Host()),
@@ -99,8 +154,15 @@ func serverConfigAsYAMLConfig(cfg ServerConfig) YAMLConfig {
 			uint64Ptr(cfg.MaxConnections()),
 			uint64Ptr(cfg.ReadTimeout()),
 			uint64Ptr(cfg.WriteTimeout()),
+			nillableStrPtr(cfg.TLSKey()),
+			nillableStrPtr(cfg.TLSCert()),
+			nillableBoolPtr(cfg.RequireSecureTransport()),
 		},
 		DatabaseConfig: nil,
+		ReplicationConfig: ReplicationYAMLConfig{
+			strPtr(cfg.ReplicationRemote()),
+			boolPtr(cfg.ReadReplica()),
+		},
 	}
 }
 
@@ -172,6 +234,35 @@ func (cfg YAMLConfig) WriteTimeout() uint64 {
 	return *cfg.ListenerConfig.WriteTimeoutMillis
 }
 
+// TLSKey returns the path of the private TLS key, in PEM format, that the server serves connections with. The server
+// doesn't support TLS when it's empty.
+func (cfg YAMLConfig) TLSKey() string {
+	if cfg.ListenerConfig.TLSKey == nil {
+		return defaultTLSKey
+	}
+
+	return *cfg.ListenerConfig.TLSKey
+}
+
+// TLSCert returns the path of the TLS certificate chain, in PEM format, that the server serves connections with. The
+// server doesn't support TLS when it's empty.
+func (cfg YAMLConfig) TLSCert() string {
+	if cfg.ListenerConfig.TLSCert == nil {
+		return defaultTLSCert
+	}
+
+	return *cfg.ListenerConfig.TLSCert
+}
+
+// RequireSecureTransport returns whether the server turns away clients that don't connect with TLS.
+func (cfg YAMLConfig) RequireSecureTransport() bool {
+	if cfg.ListenerConfig.RequireSecureTransport == nil {
+		return defaultRequireSecureTransport
+	}
+
+	return *cfg.ListenerConfig.RequireSecureTransport
+}
+
 // User returns the username that connecting clients must use.
 func (cfg YAMLConfig) User() string {
 	if cfg.UserConfig.Name == nil {
@@ -190,6 +281,46 @@ func (cfg YAMLConfig) Password() string {
 	return *cfg.UserConfig.Password
 }
 
+// Users returns the accounts that clients may connect as in addition to User(), and the privileges granted to them.
+func (cfg YAMLConfig) Users() []privileges.User {
+	// NewYamlConfig has already validated the users
+	users, _ := cfg.users()
+	return users
+}
+
+func (cfg YAMLConfig) users() ([]privileges.User, error) {
+	users := make([]privileges.User, 0, len(cfg.UsersConfig))
+	for _, userCfg := range cfg.UsersConfig {
+		if userCfg.Name == "" {
+			return nil, fmt.Errorf("users must have a name")
+		}
+
+		user := privileges.User{Name: userCfg.Name}
+		if userCfg.Password != nil && userCfg.PasswordHash != nil {
+			return nil, fmt.Errorf("user '%s' cannot have both a password and a password_hash", userCfg.Name)
+		} else if userCfg.Password != nil {
+			user.PasswordHash = privileges.HashPassword(*userCfg.Password)
+		} else if userCfg.PasswordHash != nil {
+			user.PasswordHash = strings.ToUpper(*userCfg.PasswordHash)
+			if err := privileges.ValidatePasswordHash(user.PasswordHash); err != nil {
+				return nil, fmt.Errorf("invalid password_hash for user '%s': %w", userCfg.Name, err)
+			}
+		}
+
+		for _, grantCfg := range userCfg.Grants {
+			grant, err := privileges.ParseGrant(grantCfg.Privileges, grantCfg.On)
+			if err != nil {
+				return nil, fmt.Errorf("invalid grant for user '%s': %w", userCfg.Name, err)
+			}
+			user.Grants = append(user.Grants, grant)
+		}
+
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
 // ReadOnly returns whether the server will only accept read statements or all statements.
 func (cfg YAMLConfig) ReadOnly() bool {
 	if cfg.BehaviorConfig.ReadOnly == nil {
@@ -246,3 +377,33 @@ func (cfg YAMLConfig) QueryParallelism() int {
 
 	return *cfg.PerformanceConfig.QueryParallelism
 }
+
+// ReplicationRemote returns the name of the remote that the server's databases are replicated through. Databases
+// aren't replicated when it's empty.
+func (cfg YAMLConfig) ReplicationRemote() string {
+	if cfg.ReplicationConfig.Remote == nil {
+		return defaultReplicationRemote
+	}
+
+	return *cfg.ReplicationConfig.Remote
+}
+
+// ReadReplica returns whether the server pulls its databases from the replication remote before every transaction,
+// rather than pushing them to it on every commit.
+func (cfg YAMLConfig) ReadReplica() bool {
+	if cfg.ReplicationConfig.ReadReplica == nil {
+		return defaultReadReplica
+	}
+
+	return *cfg.ReplicationConfig.ReadReplica
+}
+
+// GCIntervalSecs returns the number of seconds between the garbage collections the server runs on its databases in
+// the background. Databases aren't collected in the background when it's 0.
+func (cfg YAMLConfig) GCIntervalSecs() uint64 {
+	if cfg.BehaviorConfig.GCIntervalSecs == nil {
+		return defaultGCIntervalSecs
+	}
+
+	return *cfg.BehaviorConfig.GCIntervalSecs
+}
@@ -21,6 +21,8 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v2"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/privileges"
 )
 
 func TestUnmarshall(t *testing.T) {
@@ -30,26 +32,66 @@ log_level: info
 behavior:
     read_only: false
     autocommit: true
+    gc_interval_secs: 3600
 
 user:
     name: root
     password: ""
 
+users:
+    - name: analyst
+      password: pass
+      grants:
+          - privileges: SELECT
+            on: "*.*"
+    - name: etl
+      password_hash: "*196BDEDE2AE4F84CA44C47D54D78478C7E2BD7B7"
+      grants:
+          - privileges: SELECT, INSERT, UPDATE
+            on: irs_soi.*
+
 listener:
     host: localhost
     port: 3306
     max_connections: 1
     read_timeout_millis: 28800000
     write_timeout_millis: 28800000
+    tls_key: key.pem
+    tls_cert: cert.pem
+    require_secure_transport: true
     
 databases:
     - name: irs_soi
       path: ./datasets/irs-soi
     - name: noaa
       path: /Users/brian/datasets/noaa
+
+replication:
+    remote: origin
+    read_replica: true
 `
 
 	expected := serverConfigAsYAMLConfig(DefaultServerConfig())
+	expected.UsersConfig = []UserAccountYAMLConfig{
+		{
+			Name:     "analyst",
+			Password: strPtr("pass"),
+			Grants:   []GrantYAMLConfig{{Privileges: "SELECT", On: "*.*"}},
+		},
+		{
+			Name:         "etl",
+			PasswordHash: strPtr("*196BDEDE2AE4F84CA44C47D54D78478C7E2BD7B7"),
+			Grants:       []GrantYAMLConfig{{Privileges: "SELECT, INSERT, UPDATE", On: "irs_soi.*"}},
+		},
+	}
+	expected.BehaviorConfig.GCIntervalSecs = uint64Ptr(3600)
+	expected.ListenerConfig.TLSKey = strPtr("key.pem")
+	expected.ListenerConfig.TLSCert = strPtr("cert.pem")
+	expected.ListenerConfig.RequireSecureTransport = boolPtr(true)
+	expected.ReplicationConfig = ReplicationYAMLConfig{
+		Remote:      strPtr("origin"),
+		ReadReplica: boolPtr(true),
+	}
 	expected.DatabaseConfig = []DatabaseYAMLConfig{
 		{
 			Name: "irs_soi",
@@ -64,6 +106,75 @@ databases:
 	config, err := NewYamlConfig([]byte(testStr))
 	require.NoError(t, err)
 	assert.Equal(t, expected, config)
+
+	assert.Equal(t, []privileges.User{
+		{
+			Name:         "analyst",
+			PasswordHash: privileges.HashPassword("pass"),
+			Grants:       []privileges.Grant{{Database: "*", Table: "*", Privileges: privileges.Select}},
+		},
+		{
+			Name:         "etl",
+			PasswordHash: "*196BDEDE2AE4F84CA44C47D54D78478C7E2BD7B7",
+			Grants: []privileges.Grant{
+				{Database: "irs_soi", Table: "*", Privileges: privileges.Select | privileges.Insert | privileges.Update},
+			},
+		},
+	}, config.Users())
+}
+
+func TestUnmarshallInvalidUsers(t *testing.T) {
+	tests := []struct {
+		name  string
+		users string
+	}{
+		{
+			name: "missing name",
+			users: `
+users:
+    - password: pass`,
+		},
+		{
+			name: "password and hash",
+			users: `
+users:
+    - name: analyst
+      password: pass
+      password_hash: "*196BDEDE2AE4F84CA44C47D54D78478C7E2BD7B7"`,
+		},
+		{
+			name: "invalid hash",
+			users: `
+users:
+    - name: analyst
+      password_hash: pass`,
+		},
+		{
+			name: "unknown privilege",
+			users: `
+users:
+    - name: analyst
+      grants:
+          - privileges: SELECT, FLY
+            on: "*.*"`,
+		},
+		{
+			name: "invalid object",
+			users: `
+users:
+    - name: analyst
+      grants:
+          - privileges: SELECT
+            on: mydb`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewYamlConfig([]byte(test.users))
+			assert.Error(t, err)
+		})
+	}
 }
 
 // Tests that a common YAML error (incorrect indentation) throws an error
@@ -111,4 +222,11 @@ func TestYAMLConfigDefaults(t *testing.T) {
 	assert.Equal(t, defaultLogLevel, cfg.LogLevel())
 	assert.Equal(t, defaultAutoCommit, cfg.AutoCommit())
 	assert.Equal(t, uint64(defaultMaxConnections), cfg.MaxConnections())
+	assert.Equal(t, defaultReplicationRemote, cfg.ReplicationRemote())
+	assert.Equal(t, defaultReadReplica, cfg.ReadReplica())
+	assert.Equal(t, uint64(defaultGCIntervalSecs), cfg.GCIntervalSecs())
+	assert.Empty(t, cfg.Users())
+	assert.Equal(t, defaultTLSKey, cfg.TLSKey())
+	assert.Equal(t, defaultTLSCert, cfg.TLSCert())
+	assert.Equal(t, defaultRequireSecureTransport, cfg.RequireSecureTransport())
 }
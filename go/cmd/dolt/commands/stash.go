@@ -0,0 +1,253 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	eventsapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+var stashDocs = cli.CommandDocumentationContent{
+	ShortDesc: `Stash the changes in a dirty working set away.`,
+	LongDesc: `Use {{.EmphasisLeft}}dolt stash{{.EmphasisRight}} to record the current state of the working set and staged tables, and go back to a clean working set that matches HEAD. The stashed changes can be listed with {{.EmphasisLeft}}dolt stash list{{.EmphasisRight}} and restored, potentially on top of a different commit, with {{.EmphasisLeft}}dolt stash pop{{.EmphasisRight}} or {{.EmphasisLeft}}dolt stash apply{{.EmphasisRight}}.
+
+Stashes are referred to by their position in the stash list, most recent first, as {{.EmphasisLeft}}stash@{<n>}{{.EmphasisRight}} or just {{.EmphasisLeft}}<n>{{.EmphasisRight}}. When no stash is given, the most recent stash, {{.EmphasisLeft}}stash@{0}{{.EmphasisRight}}, is used.
+
+{{.EmphasisLeft}}push{{.EmphasisRight}}
+Save the local changes to a new stash and reset the working set to HEAD. This is the default when no subcommand is given.
+
+{{.EmphasisLeft}}list{{.EmphasisRight}}
+List the stashes.
+
+{{.EmphasisLeft}}apply{{.EmphasisRight}}
+Apply the changes of a stash to the working set with a three-way merge, using the commit the stash was created on as the merge base. Changes that can't be applied cleanly are recorded as conflicts, which can be resolved with {{.EmphasisLeft}}dolt conflicts{{.EmphasisRight}}.
+
+{{.EmphasisLeft}}pop{{.EmphasisRight}}
+Apply a stash like {{.EmphasisLeft}}apply{{.EmphasisRight}}, and remove it from the stash list if it applied without conflicts.
+
+{{.EmphasisLeft}}drop{{.EmphasisRight}}
+Remove a stash from the stash list.`,
+	Synopsis: []string{
+		`[push [-m {{.LessThan}}message{{.GreaterThan}}]]`,
+		`list`,
+		`(pop | apply | drop) [{{.LessThan}}stash{{.GreaterThan}}]`,
+	},
+}
+
+const (
+	stashPushId  = "push"
+	stashListId  = "list"
+	stashPopId   = "pop"
+	stashApplyId = "apply"
+	stashDropId  = "drop"
+)
+
+type StashCmd struct{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd StashCmd) Name() string {
+	return "stash"
+}
+
+// Description returns a description of the command
+func (cmd StashCmd) Description() string {
+	return stashDocs.ShortDesc
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd StashCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, stashDocs, ap))
+}
+
+func (cmd StashCmd) createArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"stash", "The stash to pop, apply or drop, e.g. stash@{1}."})
+	ap.SupportsString(cli.CommitMessageArg, "m", "message", "Use the given {{.LessThan}}message{{.GreaterThan}} as the description of the new stash.")
+	return ap
+}
+
+// EventType returns the type of the event to log
+func (cmd StashCmd) EventType() eventsapi.ClientEventType {
+	return eventsapi.ClientEventType_TYPE_UNSPECIFIED
+}
+
+// Exec executes the command
+func (cmd StashCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, stashDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	subcommand := stashPushId
+	if apr.NArg() > 0 {
+		subcommand = apr.Arg(0)
+	}
+
+	var verr errhand.VerboseError
+	switch subcommand {
+	case stashPushId:
+		verr = stashPush(ctx, dEnv, apr)
+	case stashListId:
+		verr = stashList(ctx, dEnv, apr)
+	case stashApplyId:
+		verr = stashApply(ctx, dEnv, apr, false)
+	case stashPopId:
+		verr = stashApply(ctx, dEnv, apr, true)
+	case stashDropId:
+		verr = stashDrop(ctx, dEnv, apr)
+	default:
+		verr = errhand.BuildDError("error: unknown subcommand '%s'", subcommand).SetPrintUsage().Build()
+	}
+
+	return HandleVErrAndExitCode(verr, usage)
+}
+
+func stashPush(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.VerboseError {
+	if apr.NArg() > 1 {
+		return errhand.BuildDError("").SetPrintUsage().Build()
+	}
+
+	name, email, err := actions.GetNameAndEmail(dEnv.Config)
+	if err != nil {
+		return errhand.BuildDError("error: failed to stash changes").AddCause(err).Build()
+	}
+
+	msg, _ := apr.GetValue(cli.CommitMessageArg)
+	err = actions.StashPush(ctx, dEnv, msg, name, email)
+	if err == actions.ErrNoLocalChanges {
+		cli.Println("No local changes to save")
+		return nil
+	} else if err == doltdb.ErrMergeActive {
+		return errhand.BuildDError("error: cannot stash changes with an active merge.").
+			AddDetails("hint: commit or abort the active merge first.").Build()
+	} else if err != nil {
+		return errhand.BuildDError("error: failed to stash changes").AddCause(err).Build()
+	}
+
+	stash, err := actions.GetStash(ctx, dEnv.DoltDB, 0)
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+
+	cli.Printf("Saved working directory and index state %s\n", stash.Meta.Description)
+	return nil
+}
+
+func stashList(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.VerboseError {
+	if apr.NArg() > 1 {
+		return errhand.BuildDError("").SetPrintUsage().Build()
+	}
+
+	stashes, err := actions.ListStashes(ctx, dEnv.DoltDB)
+	if err != nil {
+		return errhand.BuildDError("error: failed to read stashes").AddCause(err).Build()
+	}
+
+	for i, stash := range stashes {
+		cli.Printf("%s: %s\n", actions.StashName(i), stash.Meta.Description)
+	}
+
+	return nil
+}
+
+func stashApply(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults, drop bool) errhand.VerboseError {
+	idx, stash, verr := getStashArg(ctx, dEnv, apr)
+	if verr != nil {
+		return verr
+	}
+
+	tblToStats, err := actions.StashApply(ctx, dEnv, stash)
+	if err == doltdb.ErrMergeActive {
+		return errhand.BuildDError("error: cannot apply a stash with an active merge.").
+			AddDetails("hint: commit or abort the active merge first.").Build()
+	} else if err != nil {
+		return errhand.BuildDError("error: failed to apply %s", actions.StashName(idx)).AddCause(err).Build()
+	}
+
+	hasConflicts, hasConstraintViolations := printSuccessStats(tblToStats)
+	if hasConflicts || hasConstraintViolations {
+		if drop {
+			cli.Println("The stash entry is kept in case you need it again.")
+		}
+		return errhand.BuildDError("error: %s could not be applied cleanly; fix conflicts and constraint violations in the working set.", actions.StashName(idx)).Build()
+	}
+
+	if drop {
+		return dropStash(ctx, dEnv, idx, stash)
+	}
+
+	return nil
+}
+
+func stashDrop(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.VerboseError {
+	idx, stash, verr := getStashArg(ctx, dEnv, apr)
+	if verr != nil {
+		return verr
+	}
+
+	return dropStash(ctx, dEnv, idx, stash)
+}
+
+func dropStash(ctx context.Context, dEnv *env.DoltEnv, idx int, stash *actions.Stash) errhand.VerboseError {
+	h, err := stash.Commit.HashOf()
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+
+	err = actions.StashDrop(ctx, dEnv.DoltDB, stash)
+	if err != nil {
+		return errhand.BuildDError("error: failed to drop %s", actions.StashName(idx)).AddCause(err).Build()
+	}
+
+	cli.Printf("Dropped %s (%s)\n", actions.StashName(idx), h.String())
+	return nil
+}
+
+// getStashArg returns the stash named by the optional argument after the subcommand, or the most recent stash.
+func getStashArg(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults) (int, *actions.Stash, errhand.VerboseError) {
+	if apr.NArg() > 2 {
+		return 0, nil, errhand.BuildDError("").SetPrintUsage().Build()
+	}
+
+	idx := 0
+	if apr.NArg() == 2 {
+		var err error
+		idx, err = actions.ParseStashName(apr.Arg(1))
+		if err != nil {
+			return 0, nil, errhand.BuildDError("error: %s", err.Error()).Build()
+		}
+	}
+
+	stashes, err := actions.ListStashes(ctx, dEnv.DoltDB)
+	if err != nil {
+		return 0, nil, errhand.BuildDError("error: failed to read stashes").AddCause(err).Build()
+	}
+
+	if len(stashes) == 0 {
+		return 0, nil, errhand.BuildDError("error: no stash entries found.").Build()
+	} else if idx >= len(stashes) {
+		return 0, nil, errhand.BuildDError("error: %s is not a valid reference", actions.StashName(idx)).Build()
+	}
+
+	return idx, stashes[idx], nil
+}
@@ -160,6 +160,8 @@ const (
 	statusFmt         = "\t%-16s%s"
 	statusRenameFmt   = "\t%-16s%s -> %s"
 	bothModifiedLabel = "both modified:"
+
+	schemaConflictLabel = "schema conflict:"
 )
 
 func printStagedDiffs(wr io.Writer, stagedTbls []diff.TableDelta, stagedDocs *diff.DocDiffs, printHelp bool) int {
@@ -206,12 +208,12 @@ func printDiffsNotStaged(
 	notStagedDocs *diff.DocDiffs,
 	printHelp bool,
 	linesPrinted int,
-	workingTblsInConflict, workingTblsWithViolations []string,
+	workingTblsInConflict, workingTblsWithViolations, schemaConflictTbls []string,
 ) int {
 	inCnfSet := set.NewStrSet(workingTblsInConflict)
 	violationSet := set.NewStrSet(workingTblsWithViolations)
 
-	if len(workingTblsInConflict) > 0 || len(workingTblsWithViolations) > 0 {
+	if len(workingTblsInConflict) > 0 || len(workingTblsWithViolations) > 0 || len(schemaConflictTbls) > 0 {
 		if linesPrinted > 0 {
 			cli.Println()
 		}
@@ -220,6 +222,15 @@ func printDiffsNotStaged(
 			iohelp.WriteLine(wr, mergedTableHelp)
 		}
 
+		if len(schemaConflictTbls) > 0 {
+			lines := make([]string, 0, len(schemaConflictTbls))
+			for _, tblName := range schemaConflictTbls {
+				lines = append(lines, fmt.Sprintf("\t%s %s", schemaConflictLabel, tblName))
+			}
+			iohelp.WriteLine(wr, color.RedString(strings.Join(lines, "\n")))
+			linesPrinted += len(lines)
+		}
+
 		if len(workingTblsInConflict) > 0 {
 			lines := make([]string, 0, len(notStagedTbls))
 			for _, tblName := range workingTblsInConflict {
@@ -361,8 +372,18 @@ func printStatus(ctx context.Context, dEnv *env.DoltEnv, stagedTbls, notStagedTb
 		return err
 	}
 
+	var schemaConflictTbls []string
+	if mergeActive {
+		schemaConflictTbls, err = dEnv.RepoStateReader().GetMergeSchemaConflicts(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
 	if mergeActive {
-		if len(workingTblsInConflict) > 0 && len(workingTblsWithViolations) > 0 {
+		if len(schemaConflictTbls) > 0 {
+			cli.Println(fmt.Sprintf(unmergedTablesHeader, "schema conflicts"))
+		} else if len(workingTblsInConflict) > 0 && len(workingTblsWithViolations) > 0 {
 			cli.Println(fmt.Sprintf(unmergedTablesHeader, "conflicts and constraint violations"))
 		} else if len(workingTblsInConflict) > 0 {
 			cli.Println(fmt.Sprintf(unmergedTablesHeader, "conflicts"))
@@ -374,7 +395,7 @@ func printStatus(ctx context.Context, dEnv *env.DoltEnv, stagedTbls, notStagedTb
 	}
 
 	n := printStagedDiffs(cli.CliOut, stagedTbls, stagedDocs, true)
-	n = printDiffsNotStaged(ctx, dEnv, cli.CliOut, notStagedTbls, notStagedDocs, true, n, workingTblsInConflict, workingTblsWithViolations)
+	n = printDiffsNotStaged(ctx, dEnv, cli.CliOut, notStagedTbls, notStagedDocs, true, n, workingTblsInConflict, workingTblsWithViolations, schemaConflictTbls)
 
 	if !mergeActive && n == 0 {
 		cli.Println("nothing to commit, working tree clean")
@@ -65,8 +65,13 @@ var doltCommand = cli.NewSubCommandHandler("dolt", "it's git for data", []cli.Co
 	sqlserver.SqlClientCmd{},
 	commands.LogCmd{},
 	commands.DiffCmd{},
+	commands.ApplyCmd{},
 	commands.BlameCmd{},
 	commands.MergeCmd{},
+	commands.CherryPickCmd{},
+	commands.RevertCmd{},
+	commands.RebaseCmd{},
+	commands.StashCmd{},
 	commands.BranchCmd{},
 	commands.TagCmd{},
 	commands.CheckoutCmd{},
@@ -89,6 +94,7 @@ var doltCommand = cli.NewSubCommandHandler("dolt", "it's git for data", []cli.Co
 	indexcmds.Commands,
 	commands.ReadTablesCmd{},
 	commands.GarbageCollectionCmd{},
+	commands.FsckCmd{},
 	commands.FilterBranchCmd{},
 	commands.VerifyConstraintsCmd{},
 	commands.MergeBaseCmd{},
@@ -59,3 +59,10 @@ func TestCreateMemDB(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, db)
 }
+
+func TestCreateS3DBWithoutBucket(t *testing.T) {
+	ctx := context.Background()
+	_, err := CreateDB(ctx, types.Format_Default, "s3:///database", nil)
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,84 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbfactory
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	"github.com/dolthub/dolt/go/store/blobstore"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/nbs"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+const (
+	// S3EndpointParam is a creation parameter that can be used to set the url of an S3-compatible server, such as MinIO,
+	// to use instead of AWS S3. Objects are addressed by path, rather than by virtual host, on such servers.
+	S3EndpointParam = "s3-endpoint"
+
+	// defaultS3EndpointRegion is the region used for an S3-compatible server when none is given, since the aws sdk
+	// requires one for signing requests.
+	defaultS3EndpointRegion = "us-east-1"
+)
+
+// S3Factory is a DBFactory implementation for creating databases backed by an S3 bucket alone, without a DynamoDB
+// table, which is supported by any S3-compatible server with conditional writes.
+type S3Factory struct {
+}
+
+// CreateDB creates an S3 backed database
+func (fact S3Factory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]string) (datas.Database, error) {
+	var db datas.Database
+	if urlObj.Host == "" {
+		return nil, errors.New("s3 url has an invalid format")
+	}
+
+	opts, err := awsConfigFromParams(params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if endpoint, ok := params[S3EndpointParam]; ok {
+		opts.Config.MergeIn(aws.NewConfig().WithEndpoint(endpoint).WithS3ForcePathStyle(true))
+
+		if opts.Config.Region == nil {
+			opts.Config.MergeIn(aws.NewConfig().WithRegion(defaultS3EndpointRegion))
+		}
+	}
+
+	sess, err := session.NewSessionWithOptions(opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	bs := blobstore.NewS3Blobstore(s3.New(sess), urlObj.Host, urlObj.Path)
+	s3Store, err := nbs.NewBSStore(ctx, nbf.VersionString(), bs, defaultMemTableSize)
+
+	if err != nil {
+		return nil, err
+	}
+
+	db = datas.NewDatabase(s3Store)
+
+	return db, err
+}
@@ -0,0 +1,382 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/rowconv"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/pipeline"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+const (
+	jsonAdded    = "added"
+	jsonRemoved  = "removed"
+	jsonModified = "modified"
+	jsonRenamed  = "renamed"
+)
+
+var schChangeToJSON = map[SchemaChangeType]string{
+	SchDiffAdded:    jsonAdded,
+	SchDiffRemoved:  jsonRemoved,
+	SchDiffModified: jsonModified,
+}
+
+// JSONColumn is the JSON representation of a column in a schema diff.
+type JSONColumn struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	PrimaryKey bool   `json:"primary_key"`
+	Nullable   bool   `json:"nullable"`
+	Default    string `json:"default,omitempty"`
+	Comment    string `json:"comment,omitempty"`
+}
+
+// JSONIndex is the JSON representation of an index in a schema diff.
+type JSONIndex struct {
+	Name    string   `json:"name"`
+	Columns []string `json:"columns"`
+	Unique  bool     `json:"unique"`
+}
+
+// JSONForeignKey is the JSON representation of a foreign key in a schema diff.
+type JSONForeignKey struct {
+	Name            string   `json:"name"`
+	Columns         []string `json:"columns"`
+	ReferencedTable string   `json:"referenced_table"`
+}
+
+// JSONColumnDiff is a change to a column. From is nil for added columns and To is nil for removed ones.
+type JSONColumnDiff struct {
+	ChangeType string      `json:"change_type"`
+	From       *JSONColumn `json:"from"`
+	To         *JSONColumn `json:"to"`
+}
+
+// JSONIndexDiff is a change to an index. From is nil for added indexes and To is nil for removed ones.
+type JSONIndexDiff struct {
+	ChangeType string     `json:"change_type"`
+	From       *JSONIndex `json:"from"`
+	To         *JSONIndex `json:"to"`
+}
+
+// JSONForeignKeyDiff is a change to a foreign key. From is nil for added keys and To is nil for removed ones.
+type JSONForeignKeyDiff struct {
+	ChangeType string          `json:"change_type"`
+	From       *JSONForeignKey `json:"from"`
+	To         *JSONForeignKey `json:"to"`
+}
+
+// JSONSchemaDiff is the JSON representation of the changes to a table's schema.
+type JSONSchemaDiff struct {
+	Columns     []JSONColumnDiff     `json:"columns"`
+	Indexes     []JSONIndexDiff      `json:"indexes"`
+	ForeignKeys []JSONForeignKeyDiff `json:"foreign_keys"`
+}
+
+// JSONRowDiff is the JSON representation of a changed row. From is nil for added rows and To is nil for removed ones.
+type JSONRowDiff struct {
+	ChangeType string                 `json:"change_type"`
+	From       map[string]interface{} `json:"from"`
+	To         map[string]interface{} `json:"to"`
+}
+
+// JSONDiffWriter writes the diffs of a set of tables as a single JSON document of the form
+// {"tables":[{"name":...,"from_name":...,"to_name":...,"change_type":...,"schema_diff":{...},"data_diff":[...]},...]}.
+// Each table is started with BeginTable, its row diffs are written by the sink returned from RowSink, and it is ended
+// with EndTable.
+type JSONDiffWriter struct {
+	wr        io.WriteCloser
+	numTables int
+	inData    bool
+}
+
+// NewJSONDiffWriter creates a JSONDiffWriter writing to |wr|.
+func NewJSONDiffWriter(wr io.WriteCloser) (*JSONDiffWriter, error) {
+	if _, err := io.WriteString(wr, `{"tables":[`); err != nil {
+		return nil, err
+	}
+	return &JSONDiffWriter{wr: wr}, nil
+}
+
+// BeginTable starts the JSON object for the table in |td|. Its schema diff is included if |withSchema| is true, and
+// its row diffs are expected to follow if |withData| is true.
+func (w *JSONDiffWriter) BeginTable(ctx context.Context, td TableDelta, withSchema, withData bool) error {
+	fromSch, toSch, err := td.GetSchemas(ctx)
+	if err != nil {
+		return err
+	}
+
+	changeType := jsonModified
+	if td.IsAdd() {
+		changeType = jsonAdded
+	} else if td.IsDrop() {
+		changeType = jsonRemoved
+	} else if td.IsRename() {
+		changeType = jsonRenamed
+	}
+
+	header := struct {
+		Name       string          `json:"name"`
+		FromName   string          `json:"from_name"`
+		ToName     string          `json:"to_name"`
+		ChangeType string          `json:"change_type"`
+		SchemaDiff *JSONSchemaDiff `json:"schema_diff,omitempty"`
+	}{
+		Name:       td.CurName(),
+		FromName:   td.FromName,
+		ToName:     td.ToName,
+		ChangeType: changeType,
+	}
+
+	if withSchema {
+		header.SchemaDiff = jsonSchemaDiff(fromSch, toSch, td.FromFks, td.ToFks)
+	}
+
+	bs, err := json.Marshal(header)
+	if err != nil {
+		return err
+	}
+
+	if w.numTables > 0 {
+		if _, err = io.WriteString(w.wr, ","); err != nil {
+			return err
+		}
+	}
+	w.numTables++
+
+	if !withData {
+		_, err = w.wr.Write(bs)
+		return err
+	}
+
+	// reopen the header object to append the row diffs to it
+	if _, err = w.wr.Write(bs[:len(bs)-1]); err != nil {
+		return err
+	}
+	w.inData = true
+	_, err = io.WriteString(w.wr, `,"data_diff":[`)
+	return err
+}
+
+// RowSink returns a DiffSink that writes the row diffs of the current table. The rows it's given are the joined rows
+// of a RowDiffSource using |joiner|.
+func (w *JSONDiffWriter) RowSink(joiner *rowconv.Joiner) *JSONDiffSink {
+	return &JSONDiffSink{wr: w.wr, joiner: joiner}
+}
+
+// EndTable ends the JSON object of the current table.
+func (w *JSONDiffWriter) EndTable() error {
+	if !w.inData {
+		return nil
+	}
+	w.inData = false
+	_, err := io.WriteString(w.wr, "]}")
+	return err
+}
+
+// Close ends the JSON document and closes the underlying writer.
+func (w *JSONDiffWriter) Close() error {
+	if w.wr == nil {
+		return errors.New("Already closed.")
+	}
+
+	_, err := io.WriteString(w.wr, "]}\n")
+	if err != nil {
+		return err
+	}
+
+	err = w.wr.Close()
+	w.wr = nil
+	return err
+}
+
+func jsonSchemaDiff(fromSch, toSch schema.Schema, fromFks, toFks []doltdb.ForeignKey) *JSONSchemaDiff {
+	sd := &JSONSchemaDiff{
+		Columns:     []JSONColumnDiff{},
+		Indexes:     []JSONIndexDiff{},
+		ForeignKeys: []JSONForeignKeyDiff{},
+	}
+
+	colDiffs, tags := DiffSchColumns(fromSch, toSch)
+	for _, tag := range tags {
+		cd := colDiffs[tag]
+		if cd.DiffType == SchDiffNone {
+			continue
+		}
+		sd.Columns = append(sd.Columns, JSONColumnDiff{
+			ChangeType: schChangeToJSON[cd.DiffType],
+			From:       jsonColumn(cd.Old),
+			To:         jsonColumn(cd.New),
+		})
+	}
+
+	for _, id := range DiffSchIndexes(fromSch, toSch) {
+		if id.DiffType == SchDiffNone {
+			continue
+		}
+		sd.Indexes = append(sd.Indexes, JSONIndexDiff{
+			ChangeType: schChangeToJSON[id.DiffType],
+			From:       jsonIndex(id.From),
+			To:         jsonIndex(id.To),
+		})
+	}
+
+	for _, fd := range DiffForeignKeys(fromFks, toFks) {
+		if fd.DiffType == SchDiffNone {
+			continue
+		}
+		fkd := JSONForeignKeyDiff{ChangeType: schChangeToJSON[fd.DiffType]}
+		if fd.DiffType != SchDiffAdded {
+			fkd.From = jsonForeignKey(fd.From, fromSch)
+		}
+		if fd.DiffType != SchDiffRemoved {
+			fkd.To = jsonForeignKey(fd.To, toSch)
+		}
+		sd.ForeignKeys = append(sd.ForeignKeys, fkd)
+	}
+
+	return sd
+}
+
+func jsonColumn(col *schema.Column) *JSONColumn {
+	if col == nil {
+		return nil
+	}
+	return &JSONColumn{
+		Name:       col.Name,
+		Type:       col.TypeInfo.ToSqlType().String(),
+		PrimaryKey: col.IsPartOfPK,
+		Nullable:   col.IsNullable(),
+		Default:    col.Default,
+		Comment:    col.Comment,
+	}
+}
+
+func jsonIndex(idx schema.Index) *JSONIndex {
+	if idx == nil {
+		return nil
+	}
+	return &JSONIndex{
+		Name:    idx.Name(),
+		Columns: idx.ColumnNames(),
+		Unique:  idx.IsUnique(),
+	}
+}
+
+func jsonForeignKey(fk doltdb.ForeignKey, sch schema.Schema) *JSONForeignKey {
+	cols := make([]string, 0, len(fk.TableColumns))
+	for _, tag := range fk.TableColumns {
+		if col, ok := sch.GetAllCols().GetByTag(tag); ok {
+			cols = append(cols, col.Name)
+		}
+	}
+	return &JSONForeignKey{
+		Name:            fk.Name,
+		Columns:         cols,
+		ReferencedTable: fk.ReferencedTableName,
+	}
+}
+
+// JSONDiffSink writes the row diffs of a single table as the elements of a JSON array. It's created by
+// JSONDiffWriter.RowSink.
+type JSONDiffSink struct {
+	wr      io.Writer
+	joiner  *rowconv.Joiner
+	numRows int
+}
+
+// GetSchema gets the schema of the joined rows that the JSONDiffSink accepts.
+func (s *JSONDiffSink) GetSchema() schema.Schema {
+	return s.joiner.GetSchema()
+}
+
+// ProcRowWithProps satisfies pipeline.SinkFunc; it writes a joined diff row as a JSON object.
+func (s *JSONDiffSink) ProcRowWithProps(r row.Row, props pipeline.ReadableMap) error {
+	rows, err := s.joiner.Split(r)
+	if err != nil {
+		return err
+	}
+
+	rd := JSONRowDiff{}
+	if fromRow, ok := rows[From]; ok {
+		if rd.From, err = jsonRowValues(fromRow, s.joiner.SchemaForName(From)); err != nil {
+			return err
+		}
+	}
+	if toRow, ok := rows[To]; ok {
+		if rd.To, err = jsonRowValues(toRow, s.joiner.SchemaForName(To)); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case rd.From == nil:
+		rd.ChangeType = jsonAdded
+	case rd.To == nil:
+		rd.ChangeType = jsonRemoved
+	default:
+		rd.ChangeType = jsonModified
+	}
+
+	bs, err := json.Marshal(rd)
+	if err != nil {
+		return err
+	}
+
+	if s.numRows > 0 {
+		if _, err = io.WriteString(s.wr, ","); err != nil {
+			return err
+		}
+	}
+	s.numRows++
+
+	_, err = s.wr.Write(bs)
+	return err
+}
+
+// Close is a no-op, as the underlying writer belongs to the JSONDiffWriter.
+func (s *JSONDiffSink) Close() error {
+	return nil
+}
+
+// jsonRowValues returns a map from column name to the value of that column in |r|, as a go value which can be
+// marshalled to JSON.
+func jsonRowValues(r row.Row, sch schema.Schema) (map[string]interface{}, error) {
+	vals := make(map[string]interface{})
+	err := sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		val, ok := r.GetColVal(tag)
+		if !ok || types.IsNull(val) {
+			vals[col.Name] = nil
+			return false, nil
+		}
+
+		vals[col.Name], err = col.TypeInfo.ConvertNomsValueToValue(val)
+		return err != nil, err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return vals, nil
+}
@@ -0,0 +1,355 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/rowconv"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/pipeline"
+	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// The patch format describes the changes to each table with a header naming the table on each side of the diff,
+// followed by the SQL statements that change its schema and the rows removed from and added to it:
+//
+//	diff --dolt a/people b/people
+//	--- a/people
+//	+++ b/people
+//	!ALTER TABLE `people` ADD `age` int;
+//	-{"id":"1","name":"bob"}
+//	+{"age":"32","id":"1","name":"bob"}
+//
+// Rows are JSON objects mapping each column name to its formatted value, or null. A modified row is a removed row
+// followed by an added row with the same primary key. A table which doesn't exist on one side of the diff is named
+// /dev/null on that side.
+const (
+	patchHeaderPrefix = "diff --dolt "
+	patchFromPrefix   = "--- "
+	patchToPrefix     = "+++ "
+	patchFromDir      = "a/"
+	patchToDir        = "b/"
+	patchNoTable      = "/dev/null"
+	patchSchemaMarker = '!'
+	patchRemoveMarker = '-'
+	patchAddMarker    = '+'
+
+	maxPatchLineLen = 64 * 1024 * 1024
+)
+
+// PatchRowOp is the change a patch makes to a row.
+type PatchRowOp int
+
+const (
+	// PatchRowRemoved is the PatchRowOp of a row that the patch removes
+	PatchRowRemoved PatchRowOp = iota
+	// PatchRowAdded is the PatchRowOp of a row that the patch adds
+	PatchRowAdded
+)
+
+// PatchRow is a row removed or added by a patch. Values maps each column name to the formatted value of the column,
+// or nil if the value is NULL.
+type PatchRow struct {
+	Op     PatchRowOp
+	Values map[string]*string
+}
+
+// ToRow converts the PatchRow into a row of the schema |sch|, parsing its values with the types of their columns.
+func (pr PatchRow) ToRow(ctx context.Context, vrw types.ValueReadWriter, sch schema.Schema) (row.Row, error) {
+	allCols := sch.GetAllCols()
+	taggedVals := make(row.TaggedValues)
+	for name, str := range pr.Values {
+		col, ok := allCols.GetByName(name)
+		if !ok {
+			return nil, fmt.Errorf("column %s does not exist", name)
+		}
+
+		val, err := col.TypeInfo.ParseValue(ctx, vrw, str)
+		if err != nil {
+			return nil, err
+		}
+
+		if !types.IsNull(val) {
+			taggedVals[col.Tag] = val
+		}
+	}
+
+	return row.New(vrw.Format(), sch, taggedVals)
+}
+
+// Matches returns whether every column of the schema |sch| has the same value in |r| as in the PatchRow.
+func (pr PatchRow) Matches(r row.Row, sch schema.Schema) (bool, error) {
+	if len(pr.Values) != sch.GetAllCols().Size() {
+		return false, nil
+	}
+
+	matches := true
+	err := sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		patchVal, ok := pr.Values[col.Name]
+		if !ok {
+			matches = false
+			return true, nil
+		}
+
+		var rowVal *string
+		if val, ok := r.GetColVal(tag); ok && !types.IsNull(val) {
+			rowVal, err = col.TypeInfo.FormatValue(val)
+			if err != nil {
+				return true, err
+			}
+		}
+
+		if (rowVal == nil) != (patchVal == nil) || (rowVal != nil && *rowVal != *patchVal) {
+			matches = false
+			return true, nil
+		}
+		return false, nil
+	})
+
+	return matches, err
+}
+
+// TablePatch is the part of a patch which changes a single table. FromName is empty if the patch creates the table,
+// and ToName is empty if the patch drops it.
+type TablePatch struct {
+	FromName    string
+	ToName      string
+	SchemaStmts []string
+	Rows        []PatchRow
+}
+
+// PatchWriter writes table diffs in the patch format. Each table is started with BeginTable, followed by its row diffs
+// written by the sink returned from RowSink.
+type PatchWriter struct {
+	wr io.WriteCloser
+}
+
+// NewPatchWriter creates a PatchWriter writing to |wr|.
+func NewPatchWriter(wr io.WriteCloser) *PatchWriter {
+	return &PatchWriter{wr: wr}
+}
+
+// BeginTable writes the header of the table in |td| and the statements in |schemaStmts| which change its schema.
+func (w *PatchWriter) BeginTable(td TableDelta, schemaStmts []string) error {
+	fromPath, toPath := patchNoTable, patchNoTable
+	if td.FromTable != nil {
+		fromPath = patchFromDir + td.FromName
+	}
+	if td.ToTable != nil {
+		toPath = patchToDir + td.ToName
+	}
+
+	lines := []string{
+		patchHeaderPrefix + patchFromDir + td.CurName() + " " + patchToDir + td.CurName(),
+		patchFromPrefix + fromPath,
+		patchToPrefix + toPath,
+	}
+	for _, stmt := range schemaStmts {
+		for _, line := range strings.Split(stmt, "\n") {
+			lines = append(lines, string(patchSchemaMarker)+line)
+		}
+	}
+
+	return iohelp.WriteLines(w.wr, lines...)
+}
+
+// RowSink returns a DiffSink that writes the row diffs of the current table. The rows it's given are the joined rows
+// of a RowDiffSource using |joiner|.
+func (w *PatchWriter) RowSink(joiner *rowconv.Joiner) *PatchDiffSink {
+	return &PatchDiffSink{wr: w.wr, joiner: joiner}
+}
+
+// Close closes the underlying writer.
+func (w *PatchWriter) Close() error {
+	if w.wr == nil {
+		return errors.New("Already closed.")
+	}
+
+	err := w.wr.Close()
+	w.wr = nil
+	return err
+}
+
+// PatchDiffSink writes the row diffs of a single table in the patch format. It's created by PatchWriter.RowSink.
+type PatchDiffSink struct {
+	wr     io.Writer
+	joiner *rowconv.Joiner
+}
+
+// GetSchema gets the schema of the joined rows that the PatchDiffSink accepts.
+func (s *PatchDiffSink) GetSchema() schema.Schema {
+	return s.joiner.GetSchema()
+}
+
+// ProcRowWithProps satisfies pipeline.SinkFunc; it writes a joined diff row as a removed row, an added row, or both.
+func (s *PatchDiffSink) ProcRowWithProps(r row.Row, props pipeline.ReadableMap) error {
+	rows, err := s.joiner.Split(r)
+	if err != nil {
+		return err
+	}
+
+	if fromRow, ok := rows[From]; ok {
+		if err = s.writeRow(patchRemoveMarker, fromRow, s.joiner.SchemaForName(From)); err != nil {
+			return err
+		}
+	}
+	if toRow, ok := rows[To]; ok {
+		if err = s.writeRow(patchAddMarker, toRow, s.joiner.SchemaForName(To)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *PatchDiffSink) writeRow(marker byte, r row.Row, sch schema.Schema) error {
+	vals := make(map[string]*string)
+	err := sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		val, ok := r.GetColVal(tag)
+		if !ok || types.IsNull(val) {
+			vals[col.Name] = nil
+			return false, nil
+		}
+
+		vals[col.Name], err = col.TypeInfo.FormatValue(val)
+		return err != nil, err
+	})
+
+	if err != nil {
+		return err
+	}
+
+	bs, err := json.Marshal(vals)
+	if err != nil {
+		return err
+	}
+
+	return iohelp.WriteLine(s.wr, string(marker)+string(bs))
+}
+
+// Close is a no-op, as the underlying writer belongs to the PatchWriter.
+func (s *PatchDiffSink) Close() error {
+	return nil
+}
+
+// ReadPatch reads a patch written by a PatchWriter.
+func ReadPatch(rd io.Reader) ([]TablePatch, error) {
+	scanner := bufio.NewScanner(rd)
+	scanner.Buffer(nil, maxPatchLineLen)
+
+	var patches []TablePatch
+	var curr *TablePatch
+	var stmt []string
+	lineNum := 0
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		lineNum++
+
+		if strings.HasPrefix(line, patchHeaderPrefix) {
+			if len(stmt) > 0 {
+				return nil, fmt.Errorf("invalid patch: unterminated statement before line %d", lineNum)
+			}
+
+			fromPath, toPath, err := readPatchTableHeader(scanner, &lineNum)
+			if err != nil {
+				return nil, err
+			}
+
+			patches = append(patches, TablePatch{
+				FromName: strings.TrimPrefix(fromPath, patchFromDir),
+				ToName:   strings.TrimPrefix(toPath, patchToDir),
+			})
+			curr = &patches[len(patches)-1]
+			continue
+		}
+
+		if len(line) == 0 {
+			continue
+		} else if curr == nil {
+			return nil, fmt.Errorf("invalid patch: line %d is not part of a table's changes", lineNum)
+		}
+
+		switch line[0] {
+		case patchSchemaMarker:
+			stmt = append(stmt, line[1:])
+			if strings.HasSuffix(strings.TrimSpace(line), ";") {
+				curr.SchemaStmts = append(curr.SchemaStmts, strings.Join(stmt, "\n"))
+				stmt = nil
+			}
+
+		case patchRemoveMarker, patchAddMarker:
+			pr := PatchRow{Op: PatchRowAdded}
+			if line[0] == patchRemoveMarker {
+				pr.Op = PatchRowRemoved
+			}
+			if err := json.Unmarshal([]byte(line[1:]), &pr.Values); err != nil {
+				return nil, fmt.Errorf("invalid patch: could not read the row on line %d: %w", lineNum, err)
+			}
+			curr.Rows = append(curr.Rows, pr)
+
+		default:
+			return nil, fmt.Errorf("invalid patch: unexpected line %d: %s", lineNum, line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(stmt) > 0 {
+		return nil, errors.New("invalid patch: unterminated statement at the end of the patch")
+	}
+
+	return patches, nil
+}
+
+// readPatchTableHeader reads the lines naming a table on each side of the diff, which follow the first line of a
+// table's header. Tables that don't exist are returned as empty strings.
+func readPatchTableHeader(scanner *bufio.Scanner, lineNum *int) (fromPath, toPath string, err error) {
+	for _, prefix := range []string{patchFromPrefix, patchToPrefix} {
+		if !scanner.Scan() {
+			return "", "", fmt.Errorf("invalid patch: incomplete table header after line %d", *lineNum)
+		}
+		*lineNum++
+
+		line := scanner.Text()
+		if !strings.HasPrefix(line, prefix) {
+			return "", "", fmt.Errorf("invalid patch: expected '%s' on line %d", strings.TrimSpace(prefix), *lineNum)
+		}
+
+		path := line[len(prefix):]
+		if path == patchNoTable {
+			path = ""
+		}
+
+		if prefix == patchFromPrefix {
+			fromPath = path
+		} else {
+			toPath = path
+		}
+	}
+
+	return fromPath, toPath, nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+const testPatch = `diff --dolt a/people b/people
+--- a/people
++++ b/people
+!ALTER TABLE ` + "`people`" + ` ADD ` + "`age`" + ` int;
+-{"id":"1","name":"bob"}
++{"age":"32","id":"1","name":"bob"}
++{"age":null,"id":"2","name":"alice"}
+diff --dolt a/pets b/pets
+--- /dev/null
++++ b/pets
+!CREATE TABLE ` + "`pets`" + ` (
+!  ` + "`id`" + ` int NOT NULL,
+!  PRIMARY KEY (` + "`id`" + `)
+!);
++{"id":"7"}
+diff --dolt a/old b/old
+--- a/old
++++ /dev/null
+!DROP TABLE ` + "`old`" + `;
+`
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestReadPatch(t *testing.T) {
+	patches, err := ReadPatch(strings.NewReader(testPatch))
+	require.NoError(t, err)
+
+	expected := []TablePatch{
+		{
+			FromName:    "people",
+			ToName:      "people",
+			SchemaStmts: []string{"ALTER TABLE `people` ADD `age` int;"},
+			Rows: []PatchRow{
+				{PatchRowRemoved, map[string]*string{"id": strPtr("1"), "name": strPtr("bob")}},
+				{PatchRowAdded, map[string]*string{"age": strPtr("32"), "id": strPtr("1"), "name": strPtr("bob")}},
+				{PatchRowAdded, map[string]*string{"age": nil, "id": strPtr("2"), "name": strPtr("alice")}},
+			},
+		},
+		{
+			FromName:    "",
+			ToName:      "pets",
+			SchemaStmts: []string{"CREATE TABLE `pets` (\n  `id` int NOT NULL,\n  PRIMARY KEY (`id`)\n);"},
+			Rows: []PatchRow{
+				{PatchRowAdded, map[string]*string{"id": strPtr("7")}},
+			},
+		},
+		{
+			FromName:    "old",
+			ToName:      "",
+			SchemaStmts: []string{"DROP TABLE `old`;"},
+		},
+	}
+
+	assert.Equal(t, expected, patches)
+}
+
+func TestReadInvalidPatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+	}{
+		{"row without a table", "+{\"id\":\"1\"}\n"},
+		{"incomplete header", "diff --dolt a/t b/t\n--- a/t\n"},
+		{"header out of order", "diff --dolt a/t b/t\n+++ b/t\n--- a/t\n"},
+		{"invalid row", "diff --dolt a/t b/t\n--- a/t\n+++ b/t\n+{\"id\":\n"},
+		{"unterminated statement", "diff --dolt a/t b/t\n--- a/t\n+++ b/t\n!DROP TABLE `t`\n"},
+		{"unexpected line", "diff --dolt a/t b/t\n--- a/t\n+++ b/t\n?\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ReadPatch(strings.NewReader(test.patch))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestPatchRowToRow(t *testing.T) {
+	ctx := context.Background()
+	vrw := types.NewMemoryValueStore()
+
+	sch, err := schema.SchemaFromCols(schema.NewColCollection(
+		schema.NewColumn("id", 0, types.IntKind, true, schema.NotNullConstraint{}),
+		schema.NewColumn("name", 1, types.StringKind, false),
+	))
+	require.NoError(t, err)
+
+	pr := PatchRow{PatchRowAdded, map[string]*string{"id": strPtr("1"), "name": nil}}
+	r, err := pr.ToRow(ctx, vrw, sch)
+	require.NoError(t, err)
+
+	expected, err := row.New(vrw.Format(), sch, row.TaggedValues{0: types.Int(1)})
+	require.NoError(t, err)
+	assert.True(t, row.AreEqual(expected, r, sch))
+
+	matches, err := pr.Matches(r, sch)
+	require.NoError(t, err)
+	assert.True(t, matches)
+
+	changed := PatchRow{PatchRowRemoved, map[string]*string{"id": strPtr("1"), "name": strPtr("bob")}}
+	matches, err = changed.Matches(r, sch)
+	require.NoError(t, err)
+	assert.False(t, matches)
+
+	missingCol := PatchRow{PatchRowRemoved, map[string]*string{"id": strPtr("1")}}
+	matches, err = missingCol.Matches(r, sch)
+	require.NoError(t, err)
+	assert.False(t, matches)
+
+	unknownCol := PatchRow{PatchRowAdded, map[string]*string{"id": strPtr("1"), "age": strPtr("3")}}
+	_, err = unknownCol.ToRow(ctx, vrw, sch)
+	assert.Error(t, err)
+}
@@ -16,8 +16,10 @@ package doltdb
 
 import (
 	"bytes"
+	"container/heap"
 	"context"
 	"errors"
+	"sort"
 
 	"github.com/dolthub/dolt/go/store/datas"
 	"github.com/dolthub/dolt/go/store/hash"
@@ -50,6 +52,18 @@ func NewCommit(vrw types.ValueReadWriter, commitSt types.Struct) *Commit {
 }
 
 func readParents(vrw types.ValueReadWriter, commitSt types.Struct) ([]types.Ref, error) {
+	if datas.HasShallowCommits(vrw) {
+		h, err := commitSt.Hash(vrw.Format())
+		if err != nil {
+			return nil, err
+		}
+
+		if datas.IsShallowCommit(vrw, h) {
+			// the parents of a shallow commit aren't in the database
+			return nil, nil
+		}
+	}
+
 	if l, found, err := commitSt.MaybeGet(parentsListField); err != nil {
 		return nil, err
 	} else if found && l != nil {
@@ -230,6 +244,173 @@ func getCommitAncestorRef(ctx context.Context, ref1, ref2 types.Ref, vrw1, vrw2
 	return ancestorRef, nil
 }
 
+// GetCommitMergeBases returns the best common ancestors of |cm| and any of |others|, which are the common ancestors
+// that aren't ancestors of other common ancestors. Criss-cross merges can leave more than one. They are returned in
+// order of their hashes.
+//
+// Commits are walked from |cm| and |others| together in order of decreasing height, and each is marked with the sides
+// it can be reached from. The first commits reached from both sides are the best common ancestors, and they mark their
+// own ancestors as stale. A commit is always visited after its children, since it is lower than them, so stale commits
+// are never returned. The walk stops once every commit left to visit is stale, so only the history above the merge
+// bases is read.
+func GetCommitMergeBases(ctx context.Context, cm *Commit, others ...*Commit) ([]*Commit, error) {
+	q := newMergeBaseQueue()
+	err := q.mark(cm, mergeBaseFromCommit)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, other := range others {
+		err = q.mark(other, mergeBaseFromOthers)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var bases []*Commit
+	for q.hasUnstale() {
+		c, h := q.pop()
+		marks := q.marks[h]
+		if marks&mergeBaseStale == 0 && marks&(mergeBaseFromCommit|mergeBaseFromOthers) == mergeBaseFromCommit|mergeBaseFromOthers {
+			bases = append(bases, c)
+			marks |= mergeBaseStale
+			q.marks[h] = marks
+		}
+
+		for i, parent := range c.parents {
+			if q.marks[parent.TargetHash()]&marks == marks {
+				continue
+			}
+
+			parentSt, err := c.getParent(ctx, i)
+			if err != nil {
+				return nil, err
+			}
+
+			err = q.mark(NewCommit(c.vrw, *parentSt), marks)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(bases) == 0 {
+		return nil, ErrNoCommonAncestor
+	}
+
+	hashes := make(map[*Commit]hash.Hash, len(bases))
+	for _, base := range bases {
+		hashes[base], err = base.HashOf()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(bases, func(i, j int) bool {
+		return hashes[bases[i]].Less(hashes[bases[j]])
+	})
+
+	return bases, nil
+}
+
+// the marks of the commits walked by GetCommitMergeBases
+const (
+	mergeBaseFromCommit = 1 << iota
+	mergeBaseFromOthers
+	mergeBaseStale
+)
+
+// mergeBaseQueue holds the commits left to visit by GetCommitMergeBases, and the marks of every commit it has reached.
+// It keeps count of the queued commits that aren't stale, so the walk can tell when to stop without scanning them.
+type mergeBaseQueue struct {
+	pending  mergeBaseHeap
+	queued   map[hash.Hash]bool
+	marks    map[hash.Hash]int
+	numFresh int
+}
+
+type mergeBaseQueueItem struct {
+	c      *Commit
+	h      hash.Hash
+	height uint64
+}
+
+func newMergeBaseQueue() *mergeBaseQueue {
+	return &mergeBaseQueue{queued: make(map[hash.Hash]bool), marks: make(map[hash.Hash]int)}
+}
+
+// mark adds |marks| to the marks of |c|, and queues it to be visited if it isn't already.
+func (q *mergeBaseQueue) mark(c *Commit, marks int) error {
+	h, err := c.HashOf()
+	if err != nil {
+		return err
+	}
+
+	wasStale := q.marks[h]&mergeBaseStale != 0
+	q.marks[h] |= marks
+	isStale := q.marks[h]&mergeBaseStale != 0
+
+	if q.queued[h] {
+		if !wasStale && isStale {
+			q.numFresh--
+		}
+		return nil
+	}
+
+	height, err := c.Height()
+	if err != nil {
+		return err
+	}
+
+	q.queued[h] = true
+	heap.Push(&q.pending, mergeBaseQueueItem{c: c, h: h, height: height})
+	if !isStale {
+		q.numFresh++
+	}
+	return nil
+}
+
+// hasUnstale returns whether any of the queued commits isn't stale.
+func (q *mergeBaseQueue) hasUnstale() bool {
+	return q.numFresh > 0
+}
+
+// pop removes and returns the highest of the queued commits.
+func (q *mergeBaseQueue) pop() (*Commit, hash.Hash) {
+	item := heap.Pop(&q.pending).(mergeBaseQueueItem)
+	delete(q.queued, item.h)
+	if q.marks[item.h]&mergeBaseStale == 0 {
+		q.numFresh--
+	}
+	return item.c, item.h
+}
+
+// mergeBaseHeap is a heap.Interface of queued commits, with the highest on top.
+type mergeBaseHeap []mergeBaseQueueItem
+
+func (mbh mergeBaseHeap) Len() int {
+	return len(mbh)
+}
+
+func (mbh mergeBaseHeap) Less(i, j int) bool {
+	return mbh[i].height > mbh[j].height
+}
+
+func (mbh mergeBaseHeap) Swap(i, j int) {
+	mbh[i], mbh[j] = mbh[j], mbh[i]
+}
+
+func (mbh *mergeBaseHeap) Push(x interface{}) {
+	*mbh = append(*mbh, x.(mergeBaseQueueItem))
+}
+
+func (mbh *mergeBaseHeap) Pop() interface{} {
+	old := *mbh
+	item := old[len(old)-1]
+	*mbh = old[:len(old)-1]
+	return item
+}
+
 func (c *Commit) CanFastForwardTo(ctx context.Context, new *Commit) (bool, error) {
 	ancestor, err := GetCommitAncestor(ctx, c, new)
 
@@ -62,13 +62,15 @@ var ErrCannotDeleteLastBranch = errors.New("cannot delete the last branch")
 // errors in many cases.
 type DoltDB struct {
 	db datas.Database
+	// fs is the filesystem of the repository the database is stored in, or nil if it isn't stored in a local repository
+	fs filesys.Filesys
 }
 
 // DoltDBFromCS creates a DoltDB from a noms chunks.ChunkStore
 func DoltDBFromCS(cs chunks.ChunkStore) *DoltDB {
 	db := datas.NewDatabase(cs)
 
-	return &DoltDB{db}
+	return &DoltDB{db: db}
 }
 
 // LoadDoltDB will acquire a reference to the underlying noms db.  If the Location is InMemDoltDB then a reference
@@ -79,7 +81,9 @@ func LoadDoltDB(ctx context.Context, nbf *types.NomsBinFormat, urlStr string, fs
 }
 
 func LoadDoltDBWithParams(ctx context.Context, nbf *types.NomsBinFormat, urlStr string, fs filesys.Filesys, params map[string]string) (*DoltDB, error) {
+	var repoFS filesys.Filesys
 	if urlStr == LocalDirDoltDB {
+		repoFS = fs
 		exists, isDir := fs.Exists(dbfactory.DoltDataDir)
 
 		if !exists {
@@ -102,7 +106,15 @@ func LoadDoltDBWithParams(ctx context.Context, nbf *types.NomsBinFormat, urlStr
 		return nil, err
 	}
 
-	return &DoltDB{db}, nil
+	ddb := &DoltDB{db: db, fs: repoFS}
+	if repoFS != nil {
+		err = loadShallowCommits(ddb, repoFS)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ddb, nil
 }
 
 func (ddb *DoltDB) CSMetricsSummary() string {
@@ -803,6 +815,13 @@ func (ddb *DoltDB) GetWorkspaces(ctx context.Context) ([]ref.DoltRef, error) {
 	return ddb.GetRefsOfType(ctx, workspacesRefFilter)
 }
 
+var stashesRefFilter = map[ref.RefType]struct{}{ref.StashRefType: {}}
+
+// GetStashes returns a list of all stashes in the database.
+func (ddb *DoltDB) GetStashes(ctx context.Context) ([]ref.DoltRef, error) {
+	return ddb.GetRefsOfType(ctx, stashesRefFilter)
+}
+
 // GetHeadRefs returns a list of all refs that point to a Commit
 func (ddb *DoltDB) GetHeadRefs(ctx context.Context) ([]ref.DoltRef, error) {
 	return ddb.GetRefsOfType(ctx, ref.HeadRefTypes)
@@ -1053,21 +1072,62 @@ func (ddb *DoltDB) DeleteWorkspace(ctx context.Context, workRef ref.DoltRef) err
 	return err
 }
 
+// NewStashAtCommit creates a new stash pointing at the commit given.
+func (ddb *DoltDB) NewStashAtCommit(ctx context.Context, stashRef ref.DoltRef, c *Commit) error {
+	ds, err := ddb.db.GetDataset(ctx, stashRef.String())
+	if err != nil {
+		return err
+	}
+
+	r, err := types.NewRef(c.commitSt, ddb.Format())
+	if err != nil {
+		return err
+	}
+
+	_, err = ddb.db.SetHead(ctx, ds, r)
+
+	return err
+}
+
+func (ddb *DoltDB) DeleteStash(ctx context.Context, stashRef ref.DoltRef) error {
+	err := ddb.deleteRef(ctx, stashRef)
+
+	if err == ErrBranchNotFound {
+		return ErrStashNotFound
+	}
+
+	return err
+}
+
 // GC performs garbage collection on this ddb. Values passed in |uncommitedVals| will be temporarily saved during gc.
-func (ddb *DoltDB) GC(ctx context.Context, uncommitedVals ...hash.Hash) error {
+func (ddb *DoltDB) GC(ctx context.Context, uncommitedVals ...hash.Hash) (err error) {
 	collector, ok := ddb.db.(datas.GarbageCollector)
 	if !ok {
 		return fmt.Errorf("this database does not support garbage collection")
 	}
 
-	err := ddb.pruneUnreferencedDatasets(ctx)
+	err = ddb.pruneUnreferencedDatasets(ctx)
 	if err != nil {
 		return err
 	}
 
 	rand.Seed(time.Now().UnixNano())
-	tmpDatasets := make([]datas.Dataset, len(uncommitedVals))
-	for i, h := range uncommitedVals {
+	var tmpDatasets []datas.Dataset
+	defer func() {
+		// the temporary datasets are removed even if the collection fails, so they don't keep garbage forever
+		for _, ds := range tmpDatasets {
+			ds, delErr := ddb.db.Delete(ctx, ds)
+			if delErr == nil && ds.HasHead() {
+				delErr = fmt.Errorf("unsuccessful delete for dataset %s", ds.ID())
+			}
+
+			if err == nil {
+				err = delErr
+			}
+		}
+	}()
+
+	for _, h := range uncommitedVals {
 		v, err := ddb.db.ReadValue(ctx, h)
 		if err != nil {
 			return err
@@ -1094,26 +1154,21 @@ func (ddb *DoltDB) GC(ctx context.Context, uncommitedVals ...hash.Hash) error {
 			return fmt.Errorf("could not save value %s", h.String())
 		}
 
-		tmpDatasets[i] = ds
-	}
-
-	err = collector.GC(ctx)
-	if err != nil {
-		return err
+		tmpDatasets = append(tmpDatasets, ds)
 	}
 
-	for _, ds := range tmpDatasets {
-		ds, err = ddb.db.Delete(ctx, ds)
-		if err != nil {
-			return err
-		}
+	return collector.GC(ctx)
+}
 
-		if ds.HasHead() {
-			return fmt.Errorf("unsuccessful delete for dataset %s", ds.ID())
-		}
+// WriteGCKeeper writes |rv| so that its hash can be passed to GC as a value to keep. Unlike WriteRootValue, it doesn't
+// modify |rv|, which may be in use by a SQL session, and doesn't flush the database.
+func (ddb *DoltDB) WriteGCKeeper(ctx context.Context, rv *RootValue) (hash.Hash, error) {
+	r, err := ddb.db.WriteValue(ctx, rv.valueSt)
+	if err != nil {
+		return hash.Hash{}, err
 	}
 
-	return nil
+	return r.TargetHash(), nil
 }
 
 func (ddb *DoltDB) pruneUnreferencedDatasets(ctx context.Context) error {
@@ -1185,6 +1240,12 @@ func (ddb *DoltDB) PushChunksForRefHash(ctx context.Context, tempDir string, src
 	}
 }
 
+// Rebase brings this DoltDB's view of the refs in its database inline with the underlying storage, which may have been
+// updated by another process.
+func (ddb *DoltDB) Rebase(ctx context.Context) error {
+	return ddb.db.Rebase(ctx)
+}
+
 // PullChunks initiates a pull into a database from the source database given, at the commit given. Progress is
 // communicated over the provided channel.
 func (ddb *DoltDB) PullChunks(ctx context.Context, tempDir string, srcDB *DoltDB, stRef types.Ref, progChan chan datas.PullProgress, pullerEventCh chan datas.PullerEvent) error {
@@ -206,6 +206,29 @@ func TestEmptyInMemoryRepoCreation(t *testing.T) {
 	}
 }
 
+func TestStashesAreNotHeadRefs(t *testing.T) {
+	ctx := context.Background()
+	ddb, err := LoadDoltDB(ctx, types.Format_Default, InMemDoltDB, filesys.LocalFS)
+	require.NoError(t, err)
+	require.NoError(t, ddb.WriteEmptyRepo(ctx, "Bill Billerson", "bigbillieb@fake.horse"))
+
+	cs, _ := NewCommitSpec("master")
+	commit, err := ddb.Resolve(ctx, cs, nil)
+	require.NoError(t, err)
+
+	stashRef := ref.NewStashRef("1626712371000000000")
+	require.NoError(t, ddb.NewStashAtCommit(ctx, stashRef, commit))
+
+	headRefs, err := ddb.GetHeadRefs(ctx)
+	require.NoError(t, err)
+	assert.NotContains(t, headRefs, stashRef)
+	assert.Contains(t, headRefs, ref.NewBranchRef("master"))
+
+	stashes, err := ddb.GetStashes(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []ref.DoltRef{stashRef}, stashes)
+}
+
 func TestLoadNonExistentLocalFSRepo(t *testing.T) {
 	_, err := test.ChangeToTestDir("TestLoadRepo")
 
@@ -35,6 +35,7 @@ var ErrBranchNotFound = errors.New("branch not found")
 var ErrTagNotFound = errors.New("tag not found")
 var ErrWorkingSetNotFound = errors.New("working set not found")
 var ErrWorkspaceNotFound = errors.New("workspace not found")
+var ErrStashNotFound = errors.New("stash not found")
 var ErrTableNotFound = errors.New("table not found")
 var ErrTableExists = errors.New("table already exists")
 var ErrAlreadyOnBranch = errors.New("Already on branch")
@@ -48,6 +49,7 @@ var ErrIsAhead = errors.New("current fast forward from a to b. a is ahead of b a
 var ErrIsBehind = errors.New("cannot reverse from b to a. b is a is behind a already")
 
 var ErrUnresolvedConflicts = errors.New("merge has unresolved conflicts. please use the dolt_conflicts table to resolve")
+var ErrUnresolvedSchemaConflicts = errors.New("merge has unresolved schema conflicts. please use the dolt_schema_conflicts table to resolve")
 var ErrUnresolvedConstraintViolations = errors.New("merge has unresolved constraint violations. please use the dolt_constraint_violations table to resolve")
 var ErrMergeActive = errors.New("merging is not possible because you have not committed an active merge")
 
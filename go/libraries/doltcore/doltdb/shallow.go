@@ -0,0 +1,124 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// ShallowFile is the file in the dolt directory which lists the shallow commits of a repository, one hash per line.
+const ShallowFile = "shallow"
+
+var shallowFilePath = filepath.Join(dbfactory.DoltDir, ShallowFile)
+
+// loadShallowCommits reads the shallow commits of the repository in |fs| into |ddb|.
+func loadShallowCommits(ddb *DoltDB, fs filesys.ReadableFS) error {
+	exists, isDir := fs.Exists(shallowFilePath)
+	if !exists || isDir {
+		return nil
+	}
+
+	data, err := fs.ReadFile(shallowFilePath)
+	if err != nil {
+		return err
+	}
+
+	commits := hash.NewHashSet()
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		h, ok := hash.MaybeParse(line)
+		if !ok {
+			return fmt.Errorf("invalid commit hash '%s' in %s", line, shallowFilePath)
+		}
+
+		commits.Insert(h)
+	}
+
+	datas.SetShallowCommits(ddb.db, commits)
+	return nil
+}
+
+// IsShallow returns whether the history of the database is incomplete, because it was cloned or fetched with a depth.
+func (ddb *DoltDB) IsShallow() bool {
+	return datas.HasShallowCommits(ddb.db)
+}
+
+// ShallowCommits returns the shallow commits of the database, which are the commits at the boundary of a shallow
+// history. They're treated as though they have no parents.
+func (ddb *DoltDB) ShallowCommits() hash.HashSet {
+	return datas.ShallowCommits(ddb.db)
+}
+
+// setShallowCommits sets the shallow commits of the database, and writes them to the repository it's stored in, if
+// there is one.
+func (ddb *DoltDB) setShallowCommits(commits hash.HashSet) error {
+	datas.SetShallowCommits(ddb.db, commits)
+
+	if ddb.fs == nil {
+		return nil
+	}
+
+	if len(commits) == 0 {
+		exists, _ := ddb.fs.Exists(shallowFilePath)
+		if !exists {
+			return nil
+		}
+
+		return ddb.fs.DeleteFile(shallowFilePath)
+	}
+
+	lines := make([]string, 0, len(commits))
+	for h := range commits {
+		lines = append(lines, h.String())
+	}
+
+	sort.Strings(lines)
+	return ddb.fs.WriteFile(shallowFilePath, []byte(strings.Join(lines, "\n")+"\n"))
+}
+
+// PullShallow pulls the commits of |srcDB| within |depth| commits of |heads| into this database, along with the data
+// they reference but not the rest of their history. The commits at the boundary of the pulled history become shallow
+// commits. Pulling more history into a shallow database deepens it.
+func (ddb *DoltDB) PullShallow(ctx context.Context, tempDir string, srcDB *DoltDB, heads []*Commit, depth int, pullerEventCh chan datas.PullerEvent) error {
+	hashes := make([]hash.Hash, len(heads))
+	for i, cm := range heads {
+		h, err := cm.HashOf()
+		if err != nil {
+			return err
+		}
+
+		hashes[i] = h
+	}
+
+	err := datas.PullShallow(ctx, tempDir, defaultChunksPerTF, srcDB.db, ddb.db, hashes, depth, pullerEventCh)
+	if err != nil {
+		return err
+	}
+
+	return ddb.setShallowCommits(datas.ShallowCommits(ddb.db))
+}
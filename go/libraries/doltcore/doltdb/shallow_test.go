@@ -0,0 +1,59 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestShallowCommitsFile(t *testing.T) {
+	ctx := context.Background()
+	fs := filesys.NewInMemFS([]string{dbfactory.DoltDir}, nil, "")
+
+	ddb, err := LoadDoltDB(ctx, types.Format_Default, InMemDoltDB, fs)
+	require.NoError(t, err)
+	ddb.fs = fs
+	assert.False(t, ddb.IsShallow())
+
+	commits := hash.NewHashSet(hash.Of([]byte("one")), hash.Of([]byte("two")))
+	require.NoError(t, ddb.setShallowCommits(commits))
+	assert.True(t, ddb.IsShallow())
+	assert.Equal(t, commits, ddb.ShallowCommits())
+
+	exists, _ := fs.Exists(shallowFilePath)
+	require.True(t, exists)
+
+	loaded, err := LoadDoltDB(ctx, types.Format_Default, InMemDoltDB, fs)
+	require.NoError(t, err)
+	require.NoError(t, loadShallowCommits(loaded, fs))
+	assert.Equal(t, commits, loaded.ShallowCommits())
+
+	require.NoError(t, ddb.setShallowCommits(hash.NewHashSet()))
+	assert.False(t, ddb.IsShallow())
+	exists, _ = fs.Exists(shallowFilePath)
+	assert.False(t, exists)
+
+	require.NoError(t, fs.WriteFile(shallowFilePath, []byte("not a hash\n")))
+	assert.Error(t, loadShallowCommits(loaded, fs))
+}
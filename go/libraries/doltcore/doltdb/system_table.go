@@ -121,6 +121,7 @@ var writeableSystemTables = []string{
 	DoltQueryCatalogTableName,
 	SchemasTableName,
 	ProceduresTableName,
+	MergeStrategiesTableName,
 }
 
 var persistedSystemTables = []string{
@@ -128,6 +129,7 @@ var persistedSystemTables = []string{
 	DoltQueryCatalogTableName,
 	SchemasTableName,
 	ProceduresTableName,
+	MergeStrategiesTableName,
 }
 
 var generatedSystemTables = []string{
@@ -135,9 +137,12 @@ var generatedSystemTables = []string{
 	LogTableName,
 	TableOfTablesInConflictName,
 	TableOfTablesWithViolationsName,
+	SchemaConflictsTableName,
 	CommitsTableName,
 	CommitAncestorsTableName,
 	StatusTableName,
+	TagsTableName,
+	RemotesTableName,
 }
 
 var generatedSystemTablePrefixes = []string{
@@ -146,6 +151,7 @@ var generatedSystemTablePrefixes = []string{
 	DoltHistoryTablePrefix,
 	DoltConfTablePrefix,
 	DoltConstViolTablePrefix,
+	DoltBlameTablePrefix,
 }
 
 const (
@@ -204,6 +210,8 @@ const (
 	DoltConfTablePrefix = "dolt_conflicts_"
 	// DoltConstViolTablePrefix is the prefix assigned to all the generated constraint violation tables
 	DoltConstViolTablePrefix = "dolt_constraint_violations_"
+	// DoltBlameTablePrefix is the prefix assigned to all the generated blame tables
+	DoltBlameTablePrefix = "dolt_blame_"
 )
 
 const (
@@ -216,6 +224,9 @@ const (
 	// TableOfTablesWithViolationsName is the constraint violations system table name
 	TableOfTablesWithViolationsName = "dolt_constraint_violations"
 
+	// SchemaConflictsTableName is the schema conflicts system table name
+	SchemaConflictsTableName = "dolt_schema_conflicts"
+
 	// BranchesTableName is the branches system table name
 	BranchesTableName = "dolt_branches"
 
@@ -227,6 +238,12 @@ const (
 
 	// StatusTableName is the status system table name.
 	StatusTableName = "dolt_status"
+
+	// TagsTableName is the tags system table name
+	TagsTableName = "dolt_tags"
+
+	// RemotesTableName is the remotes system table name
+	RemotesTableName = "dolt_remotes"
 )
 
 const (
@@ -241,3 +258,15 @@ const (
 	// ProceduresTableModifiedAtCol is the time that the stored procedure was last modified, in UTC.
 	ProceduresTableModifiedAtCol = "modified_at"
 )
+
+const (
+	// MergeStrategiesTableName is the name of the table of strategies that automatically resolve merge conflicts.
+	MergeStrategiesTableName = "dolt_merge_strategies"
+	// MergeStrategiesTableNameCol is the name of the table that a strategy resolves conflicts in.
+	MergeStrategiesTableNameCol = "table_name"
+	// MergeStrategiesColumnNameCol is the name of the column that a strategy resolves conflicts in. It's empty for
+	// strategies that apply to every column of the table.
+	MergeStrategiesColumnNameCol = "column_name"
+	// MergeStrategiesStrategyCol is the strategy, such as `theirs` or `last_writer_wins(updated_at)`.
+	MergeStrategiesStrategyCol = "strategy"
+)
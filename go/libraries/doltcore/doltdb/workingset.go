@@ -26,7 +26,9 @@ import (
 
 type MergeState struct {
 	commit          *Commit
+	otherCommits    []*Commit
 	preMergeWorking *RootValue
+	schemaConflicts []string
 }
 
 // WorkingSetMeta contains all the metadata that is associated with a working set
@@ -78,20 +80,70 @@ func newMergeState(ctx context.Context, vrw types.ValueReadWriter, mergeState ty
 		return nil, err
 	}
 
+	var otherCommits []*Commit
+	otherCommitsVal, ok, err := mergeState.MaybeGet(datas.MergeStateOtherCommitsField)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		err = otherCommitsVal.(types.List).IterAll(ctx, func(v types.Value, _ uint64) error {
+			otherCommits = append(otherCommits, NewCommit(vrw, v.(types.Struct)))
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var schemaConflicts []string
+	schemaConflictsVal, ok, err := mergeState.MaybeGet(datas.MergeStateSchemaConflictsField)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		err = schemaConflictsVal.(types.List).IterAll(ctx, func(v types.Value, _ uint64) error {
+			schemaConflicts = append(schemaConflicts, string(v.(types.String)))
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &MergeState{
 		commit:          commit,
+		otherCommits:    otherCommits,
 		preMergeWorking: workingRoot,
+		schemaConflicts: schemaConflicts,
 	}, nil
 }
 
+// Commit returns the commit being merged, or the first of them for an octopus merge
 func (m MergeState) Commit() *Commit {
 	return m.commit
 }
 
+// Commits returns all the commits being merged, which is more than one for an octopus merge
+func (m MergeState) Commits() []*Commit {
+	return append([]*Commit{m.commit}, m.otherCommits...)
+}
+
 func (m MergeState) PreMergeWorkingRoot() *RootValue {
 	return m.preMergeWorking
 }
 
+// SchemaConflicts returns the names of the tables whose schemas could not be merged. Until they are resolved, these
+// tables keep their pre-merge contents in the working set.
+func (m MergeState) SchemaConflicts() []string {
+	return m.schemaConflicts
+}
+
+// WithSchemaConflicts returns a copy of this merge state with |tables| as the tables whose schemas could not be merged.
+func (m MergeState) WithSchemaConflicts(tables []string) *MergeState {
+	m.schemaConflicts = tables
+	return &m
+}
+
 type WorkingSet struct {
 	Name        string
 	meta        WorkingSetMeta
@@ -125,9 +177,12 @@ func (ws WorkingSet) WithMergeState(mergeState *MergeState) *WorkingSet {
 	return &ws
 }
 
-func (ws WorkingSet) StartMerge(commit *Commit) *WorkingSet {
+// StartMerge returns a copy of this working set with a merge of |commit| in progress. An octopus merge of more than
+// one commit also gives |otherCommits|.
+func (ws WorkingSet) StartMerge(commit *Commit, otherCommits ...*Commit) *WorkingSet {
 	ws.mergeState = &MergeState{
 		commit:          commit,
+		otherCommits:    otherCommits,
 		preMergeWorking: ws.workingRoot,
 	}
 
@@ -321,6 +376,15 @@ func (ws *WorkingSet) HashOf() (hash.Hash, error) {
 	return ws.st.Hash(ws.format)
 }
 
+// GetStRef returns a Noms Ref for this WorkingSet's Noms struct. Only working sets that have been read from or
+// written to a database have one.
+func (ws *WorkingSet) GetStRef() (types.Ref, error) {
+	if ws.st == nil {
+		return types.Ref{}, fmt.Errorf("working set %s has not been persisted", ws.Name)
+	}
+	return types.NewRef(*ws.st, ws.format)
+}
+
 // Ref returns a WorkingSetRef for this WorkingSet.
 func (ws *WorkingSet) Ref() ref.WorkingSetRef {
 	return ref.NewWorkingSetRef(ws.Name)
@@ -355,11 +419,27 @@ func (ws *WorkingSet) writeValues(ctx context.Context, db *DoltDB) (
 			return types.Ref{}, types.Ref{}, nil, err
 		}
 
-		mergeStateRefSt, err := datas.NewMergeState(ctx, preMergeWorking, ws.mergeState.commit.commitSt)
+		var mergeStateRefSt types.Struct
+		if len(ws.mergeState.otherCommits) == 0 {
+			mergeStateRefSt, err = datas.NewMergeState(ctx, preMergeWorking, ws.mergeState.commit.commitSt)
+		} else {
+			otherCommitSts := make([]types.Struct, len(ws.mergeState.otherCommits))
+			for i, cm := range ws.mergeState.otherCommits {
+				otherCommitSts[i] = cm.commitSt
+			}
+			mergeStateRefSt, err = datas.NewOctopusMergeState(ctx, db.db, preMergeWorking, ws.mergeState.commit.commitSt, otherCommitSts)
+		}
 		if err != nil {
 			return types.Ref{}, types.Ref{}, nil, err
 		}
 
+		if len(ws.mergeState.schemaConflicts) > 0 {
+			mergeStateRefSt, err = datas.WithMergeStateSchemaConflicts(ctx, db.db, mergeStateRefSt, ws.mergeState.schemaConflicts)
+			if err != nil {
+				return types.Ref{}, types.Ref{}, nil, err
+			}
+		}
+
 		mergeStateRef, err = db.db.WriteValue(ctx, mergeStateRefSt)
 		if err != nil {
 			return types.Ref{}, types.Ref{}, nil, err
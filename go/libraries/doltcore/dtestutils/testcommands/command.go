@@ -277,6 +277,7 @@ func (m Merge) Exec(t *testing.T, dEnv *env.DoltEnv) error {
 		require.NoError(t, err)
 		for _, stats := range tblToStats {
 			require.True(t, stats.Conflicts == 0)
+			require.True(t, stats.SchemaConflicts == 0)
 		}
 
 		err = dEnv.StartMerge(context.Background(), cm2)
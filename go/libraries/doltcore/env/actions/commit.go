@@ -112,13 +112,18 @@ func CommitStaged(ctx context.Context, roots doltdb.Roots, dbData env.DbData, pr
 		if len(violatesConstraints) > 0 {
 			return nil, NewTblHasConstraintViolations(violatesConstraints)
 		}
-
-		commit, err := rsr.GetMergeCommit(ctx)
+		schemaConflicts, err := rsr.GetMergeSchemaConflicts(ctx)
 		if err != nil {
 			return nil, err
 		}
+		if len(schemaConflicts) > 0 {
+			return nil, NewTblSchemaConflictError(schemaConflicts)
+		}
 
-		mergeParentCommits = []*doltdb.Commit{commit}
+		mergeParentCommits, err = rsr.GetMergeCommits(ctx)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	stagedRoot, err := roots.Staged.UpdateSuperSchemasFromOther(ctx, stagedTblNames, roots.Staged)
@@ -27,6 +27,7 @@ const (
 	tblErrTypeNotExist   tblErrorType = "do not exist"
 	tblErrTypeInConflict tblErrorType = "in conflict"
 	tblErrTypeConstViols tblErrorType = "has constraint violations"
+	tblErrTypeSchConfl   tblErrorType = "have schema conflicts"
 )
 
 type TblError struct {
@@ -46,6 +47,10 @@ func NewTblHasConstraintViolations(tbls []string) TblError {
 	return TblError{tbls, tblErrTypeConstViols}
 }
 
+func NewTblSchemaConflictError(tbls []string) TblError {
+	return TblError{tbls, tblErrTypeSchConfl}
+}
+
 func (te TblError) Error() string {
 	return "error: the table(s) " + strings.Join(te.tables, ", ") + " " + string(te.tblErrType)
 }
@@ -76,6 +81,10 @@ func IsTblViolatesConstraints(err error) bool {
 	return getTblErrType(err) == tblErrTypeConstViols
 }
 
+func IsTblSchemaConflict(err error) bool {
+	return getTblErrType(err) == tblErrTypeSchConfl
+}
+
 func GetTablesForError(err error) []string {
 	te, ok := err.(TblError)
 
@@ -17,21 +17,34 @@ package actions
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
 
+	eventsapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/events"
+	"github.com/dolthub/dolt/go/libraries/utils/earl"
 	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
 )
 
 var ErrCantFF = errors.New("can't fast forward merge")
+var ErrRefSpecNotFound = errors.New("refspec not found")
+
+// ProgStarter starts reporting the progress of a push or pull, returning the channels that progress is sent on.
+type ProgStarter func() (*sync.WaitGroup, chan datas.PullProgress, chan datas.PullerEvent)
+
+// ProgStopper closes the channels returned by a ProgStarter and waits for the progress reporting to finish.
+type ProgStopper func(wg *sync.WaitGroup, progChan chan datas.PullProgress, pullerEventCh chan datas.PullerEvent)
 
 // Push will update a destination branch, in a given destination database if it can be done as a fast forward merge.
 // This is accomplished first by verifying that the remote tracking reference for the source database can be updated to
 // the given commit via a fast forward merge.  If this is the case, an attempt will be made to update the branch in the
 // destination db to the given commit via fast forward move.  If that succeeds the tracking branch is updated in the
 // source db.
-func Push(ctx context.Context, dEnv *env.DoltEnv, mode ref.UpdateMode, destRef ref.BranchRef, remoteRef ref.RemoteRef, srcDB, destDB *doltdb.DoltDB, commit *doltdb.Commit, progChan chan datas.PullProgress, pullerEventCh chan datas.PullerEvent) error {
+func Push(ctx context.Context, tempTableDir string, mode ref.UpdateMode, destRef ref.BranchRef, remoteRef ref.RemoteRef, srcDB, destDB *doltdb.DoltDB, commit *doltdb.Commit, progChan chan datas.PullProgress, pullerEventCh chan datas.PullerEvent) error {
 	var err error
 	if mode == ref.FastForwardOnly {
 		canFF, err := srcDB.CanFastForward(ctx, remoteRef, commit)
@@ -49,7 +62,7 @@ func Push(ctx context.Context, dEnv *env.DoltEnv, mode ref.UpdateMode, destRef r
 		return err
 	}
 
-	err = destDB.PushChunks(ctx, dEnv.TempTableFilesDir(), srcDB, rf, progChan, pullerEventCh)
+	err = destDB.PushChunks(ctx, tempTableDir, srcDB, rf, progChan, pullerEventCh)
 
 	if err != nil {
 		return err
@@ -74,7 +87,7 @@ func Push(ctx context.Context, dEnv *env.DoltEnv, mode ref.UpdateMode, destRef r
 }
 
 // PushTag pushes a commit tag and all underlying data from a local source database to a remote destination database.
-func PushTag(ctx context.Context, dEnv *env.DoltEnv, destRef ref.TagRef, srcDB, destDB *doltdb.DoltDB, tag *doltdb.Tag, progChan chan datas.PullProgress, pullerEventCh chan datas.PullerEvent) error {
+func PushTag(ctx context.Context, tempTableDir string, destRef ref.TagRef, srcDB, destDB *doltdb.DoltDB, tag *doltdb.Tag, progChan chan datas.PullProgress, pullerEventCh chan datas.PullerEvent) error {
 	var err error
 
 	rf, err := tag.GetStRef()
@@ -83,7 +96,7 @@ func PushTag(ctx context.Context, dEnv *env.DoltEnv, destRef ref.TagRef, srcDB,
 		return err
 	}
 
-	err = destDB.PushChunks(ctx, dEnv.TempTableFilesDir(), srcDB, rf, progChan, pullerEventCh)
+	err = destDB.PushChunks(ctx, tempTableDir, srcDB, rf, progChan, pullerEventCh)
 
 	if err != nil {
 		return err
@@ -119,28 +132,409 @@ func DeleteRemoteBranch(ctx context.Context, targetRef ref.BranchRef, remoteRef
 }
 
 // FetchCommit takes a fetches a commit and all underlying data from a remote source database to the local destination database.
-func FetchCommit(ctx context.Context, dEnv *env.DoltEnv, srcDB, destDB *doltdb.DoltDB, srcDBCommit *doltdb.Commit, progChan chan datas.PullProgress, pullerEventCh chan datas.PullerEvent) error {
+func FetchCommit(ctx context.Context, tempTableDir string, srcDB, destDB *doltdb.DoltDB, srcDBCommit *doltdb.Commit, progChan chan datas.PullProgress, pullerEventCh chan datas.PullerEvent) error {
 	stRef, err := srcDBCommit.GetStRef()
 
 	if err != nil {
 		return err
 	}
 
-	return destDB.PullChunks(ctx, dEnv.TempTableFilesDir(), srcDB, stRef, progChan, pullerEventCh)
+	return destDB.PullChunks(ctx, tempTableDir, srcDB, stRef, progChan, pullerEventCh)
 }
 
-// FetchCommit takes a fetches a commit tag and all underlying data from a remote source database to the local destination database.
-func FetchTag(ctx context.Context, dEnv *env.DoltEnv, srcDB, destDB *doltdb.DoltDB, srcDBTag *doltdb.Tag, progChan chan datas.PullProgress, pullerEventCh chan datas.PullerEvent) error {
+// FetchTag takes a fetches a commit tag and all underlying data from a remote source database to the local destination database.
+func FetchTag(ctx context.Context, tempTableDir string, srcDB, destDB *doltdb.DoltDB, srcDBTag *doltdb.Tag, progChan chan datas.PullProgress, pullerEventCh chan datas.PullerEvent) error {
 	stRef, err := srcDBTag.GetStRef()
 
 	if err != nil {
 		return err
 	}
 
-	return destDB.PullChunks(ctx, dEnv.TempTableFilesDir(), srcDB, stRef, progChan, pullerEventCh)
+	return destDB.PullChunks(ctx, tempTableDir, srcDB, stRef, progChan, pullerEventCh)
+}
+
+// FetchCommitWithDepth fetches a commit from a remote source database to the local destination database, along with
+// the commits within |depth| commits of it and the data they reference. A depth of zero fetches the entire history.
+func FetchCommitWithDepth(ctx context.Context, tempTableDir string, srcDB, destDB *doltdb.DoltDB, srcDBCommit *doltdb.Commit, depth int, progChan chan datas.PullProgress, pullerEventCh chan datas.PullerEvent) error {
+	if depth <= 0 {
+		return FetchCommit(ctx, tempTableDir, srcDB, destDB, srcDBCommit, progChan, pullerEventCh)
+	}
+
+	return destDB.PullShallow(ctx, tempTableDir, srcDB, []*doltdb.Commit{srcDBCommit}, depth, pullerEventCh)
 }
 
 // Clone pulls all data from a remote source database to a local destination database.
 func Clone(ctx context.Context, srcDB, destDB *doltdb.DoltDB, eventCh chan<- datas.TableFileEvent) error {
 	return srcDB.Clone(ctx, destDB, eventCh)
 }
+
+// CloneBranches pulls the branches |branches| of a remote source database to a local destination database, along with
+// the tags of the commits pulled. If |depth| is positive, only the commits within |depth| commits of each branch head
+// are pulled, and the history of the destination database is shallow.
+func CloneBranches(ctx context.Context, tempTableDir string, srcDB, destDB *doltdb.DoltDB, branches []ref.BranchRef, depth int, progStarter ProgStarter, progStopper ProgStopper) error {
+	for _, branchRef := range branches {
+		cm, err := srcDB.ResolveCommitRef(ctx, branchRef)
+		if err != nil {
+			return fmt.Errorf("unable to find branch '%s': %w", branchRef.GetPath(), err)
+		}
+
+		wg, progChan, pullerEventCh := progStarter()
+		err = FetchCommitWithDepth(ctx, tempTableDir, srcDB, destDB, cm, depth, progChan, pullerEventCh)
+		progStopper(wg, progChan, pullerEventCh)
+
+		if err != nil {
+			return err
+		}
+
+		err = destDB.SetHeadToCommit(ctx, branchRef, cm)
+		if err != nil {
+			return err
+		}
+	}
+
+	return FetchFollowTags(ctx, tempTableDir, srcDB, destDB, progStarter, progStopper)
+}
+
+// GetTrackingRef returns the remote tracking ref of |remote| that |branchRef| maps to, or nil if none of the remote's
+// fetch specs apply to it.
+func GetTrackingRef(branchRef ref.DoltRef, remote env.Remote) (ref.DoltRef, error) {
+	for _, fsStr := range remote.FetchSpecs {
+		fs, err := ref.ParseRefSpecForRemote(remote.Name, fsStr)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid fetch spec '%s' for remote '%s'", fsStr, remote.Name)
+		}
+
+		remoteRef := fs.DestRef(branchRef)
+
+		if remoteRef != nil {
+			return remoteRef, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// DisambiguateRefSpecStr converts refs to full spec names if possible, preferring branches over tags.
+// eg "master" -> "refs/heads/master", "v1" -> "refs/tags/v1"
+func DisambiguateRefSpecStr(ctx context.Context, ddb *doltdb.DoltDB, refSpecStr string) (string, error) {
+	brachRefs, err := ddb.GetBranches(ctx)
+
+	if err != nil {
+		return "", err
+	}
+
+	for _, br := range brachRefs {
+		if br.GetPath() == refSpecStr {
+			return br.String(), nil
+		}
+	}
+
+	tagRefs, err := ddb.GetTags(ctx)
+
+	if err != nil {
+		return "", err
+	}
+
+	for _, tr := range tagRefs {
+		if tr.GetPath() == refSpecStr {
+			return tr.String(), nil
+		}
+	}
+
+	return refSpecStr, nil
+}
+
+// PushToRemoteBranch pushes the commit |srcRef| refers to the branch |destRef| of |remoteDB|, updating the remote
+// tracking ref |remoteRef| in |localDB|.
+func PushToRemoteBranch(ctx context.Context, rsr env.RepoStateReader, mode ref.UpdateMode, srcRef, destRef, remoteRef ref.DoltRef, localDB, remoteDB *doltdb.DoltDB, remote env.Remote, progStarter ProgStarter, progStopper ProgStopper) error {
+	setRemoteUrlEventAttribute(ctx, remote)
+
+	cs, _ := doltdb.NewCommitSpec(srcRef.GetPath())
+	cm, err := localDB.Resolve(ctx, cs, rsr.CWBHeadRef())
+
+	if err != nil {
+		return fmt.Errorf("%w: '%v'", ErrRefSpecNotFound, srcRef.GetPath())
+	}
+
+	wg, progChan, pullerEventCh := progStarter()
+	err = Push(ctx, rsr.TempTableFilesDir(), mode, destRef.(ref.BranchRef), remoteRef.(ref.RemoteRef), localDB, remoteDB, cm, progChan, pullerEventCh)
+	progStopper(wg, progChan, pullerEventCh)
+
+	return err
+}
+
+// PushTagToRemote pushes the tag |srcRef| to the tag |destRef| of |remoteDB|.
+func PushTagToRemote(ctx context.Context, tempTableDir string, srcRef, destRef ref.DoltRef, localDB, remoteDB *doltdb.DoltDB, progStarter ProgStarter, progStopper ProgStopper) error {
+	tg, err := localDB.ResolveTag(ctx, srcRef.(ref.TagRef))
+
+	if err != nil {
+		return err
+	}
+
+	wg, progChan, pullerEventCh := progStarter()
+	err = PushTag(ctx, tempTableDir, destRef.(ref.TagRef), localDB, remoteDB, tg, progChan, pullerEventCh)
+	progStopper(wg, progChan, pullerEventCh)
+
+	return err
+}
+
+// FetchRemoteBranch fetches the commit |srcRef| refers to in |srcDB|, along with all the data it references, into
+// |destDB|, and returns it.
+func FetchRemoteBranch(ctx context.Context, tempTableDir string, rem env.Remote, srcDB, destDB *doltdb.DoltDB, srcRef ref.DoltRef, progStarter ProgStarter, progStopper ProgStopper) (*doltdb.Commit, error) {
+	return fetchRemoteBranch(ctx, tempTableDir, rem, srcDB, destDB, srcRef, 0, progStarter, progStopper)
+}
+
+// fetchRemoteBranch is FetchRemoteBranch, fetching only the commits within |depth| commits of |srcRef| if |depth| is
+// positive.
+func fetchRemoteBranch(ctx context.Context, tempTableDir string, rem env.Remote, srcDB, destDB *doltdb.DoltDB, srcRef ref.DoltRef, depth int, progStarter ProgStarter, progStopper ProgStopper) (*doltdb.Commit, error) {
+	setRemoteUrlEventAttribute(ctx, rem)
+
+	cs, _ := doltdb.NewCommitSpec(srcRef.String())
+	srcDBCommit, err := srcDB.Resolve(ctx, cs, nil)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to find '%s' on '%s'", srcRef.GetPath(), rem.Name)
+	}
+
+	wg, progChan, pullerEventCh := progStarter()
+	err = FetchCommitWithDepth(ctx, tempTableDir, srcDB, destDB, srcDBCommit, depth, progChan, pullerEventCh)
+	progStopper(wg, progChan, pullerEventCh)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return srcDBCommit, nil
+}
+
+// FetchRefSpecs fetches the branches of |srcDB| that |refSpecs| map to remote tracking refs, and updates those remote
+// tracking refs, followed by any tags of |srcDB| whose commits were fetched. If |depth| is positive, only the commits
+// within |depth| commits of each branch are fetched, and a remote tracking ref whose history can't be compared with the
+// fetched history is updated even when only fast-forwards are allowed.
+func FetchRefSpecs(ctx context.Context, dbData env.DbData, srcDB *doltdb.DoltDB, refSpecs []ref.RemoteRefSpec, remote env.Remote, mode ref.UpdateMode, depth int, progStarter ProgStarter, progStopper ProgStopper) error {
+	tempTableDir := dbData.Rsr.TempTableFilesDir()
+
+	for _, rs := range refSpecs {
+		branchRefs, err := srcDB.GetHeadRefs(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		for _, branchRef := range branchRefs {
+			remoteTrackRef := rs.DestRef(branchRef)
+
+			if remoteTrackRef == nil {
+				continue
+			}
+
+			srcDBCommit, err := fetchRemoteBranch(ctx, tempTableDir, remote, srcDB, dbData.Ddb, branchRef, depth, progStarter, progStopper)
+
+			if err != nil {
+				return err
+			}
+
+			switch mode {
+			case ref.ForceUpdate:
+				err = dbData.Ddb.SetHeadToCommit(ctx, remoteTrackRef, srcDBCommit)
+			case ref.FastForwardOnly:
+				var ok bool
+				ok, err = dbData.Ddb.CanFastForward(ctx, remoteTrackRef, srcDBCommit)
+				if depth > 0 && err == doltdb.ErrNoCommonAncestor {
+					// the fetched history doesn't reach back far enough to be compared with the old one
+					ok, err = true, nil
+				}
+
+				if !ok {
+					return ErrCantFF
+				} else if err == doltdb.ErrUpToDate {
+					err = nil
+				} else if err == nil {
+					err = dbData.Ddb.FastForward(ctx, remoteTrackRef, srcDBCommit)
+				}
+			}
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return FetchFollowTags(ctx, tempTableDir, srcDB, dbData.Ddb, progStarter, progStopper)
+}
+
+// FetchFollowTags fetches all tags from the source DB whose commits have already
+// been fetched into the destination DB.
+// todo: potentially too expensive to iterate over all srcDB tags
+func FetchFollowTags(ctx context.Context, tempTableDir string, srcDB, destDB *doltdb.DoltDB, progStarter ProgStarter, progStopper ProgStopper) error {
+	return IterResolvedTags(ctx, srcDB, func(tag *doltdb.Tag) (stop bool, err error) {
+		stRef, err := tag.GetStRef()
+		if err != nil {
+			return true, err
+		}
+
+		tagHash := stRef.TargetHash()
+
+		tv, err := destDB.ValueReadWriter().ReadValue(ctx, tagHash)
+		if err != nil {
+			return true, err
+		}
+		if tv != nil {
+			// tag is already fetched
+			return false, nil
+		}
+
+		cmHash, err := tag.Commit.HashOf()
+		if err != nil {
+			return true, err
+		}
+
+		cv, err := destDB.ValueReadWriter().ReadValue(ctx, cmHash)
+		if err != nil {
+			return true, err
+		}
+		if cv == nil {
+			// neither tag nor commit has been fetched
+			return false, nil
+		}
+
+		wg, progChan, pullerEventCh := progStarter()
+		err = FetchTag(ctx, tempTableDir, srcDB, destDB, tag, progChan, pullerEventCh)
+		progStopper(wg, progChan, pullerEventCh)
+
+		if err != nil {
+			return true, err
+		}
+
+		err = destDB.SetHead(ctx, tag.GetDoltRef(), stRef)
+
+		return false, err
+	})
+}
+
+// ReplicateBranch makes the branch |branchRef| and its working set in |destDB| the same as they are in |srcDB|. The
+// branch is only fast-forwarded, and it's an error if it has diverged in |destDB|, i.e. if |destDB| has commits on it
+// that |srcDB| doesn't. It's how a sql-server replicates its databases to and from a replication remote, so the working
+// set is copied along with the branch head.
+func ReplicateBranch(ctx context.Context, tempTableDir string, srcDB, destDB *doltdb.DoltDB, branchRef ref.BranchRef, progStarter ProgStarter, progStopper ProgStopper) error {
+	srcCommit, err := srcDB.ResolveCommitRef(ctx, branchRef)
+	if err == doltdb.ErrBranchNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	commitRef, err := srcCommit.GetStRef()
+	if err != nil {
+		return err
+	}
+
+	destCommit, err := destDB.ResolveCommitRef(ctx, branchRef)
+	if err != nil && err != doltdb.ErrBranchNotFound {
+		return err
+	}
+
+	if destCommit == nil || !commitsEqual(srcCommit, destCommit) {
+		if destCommit != nil {
+			canFF, err := destCommit.CanFastForwardTo(ctx, srcCommit)
+			if err != nil && err != doltdb.ErrIsAhead {
+				return err
+			} else if !canFF {
+				return fmt.Errorf("%w: branch '%s' has diverged", ErrCantFF, branchRef.GetPath())
+			}
+		}
+
+		wg, progChan, pullerEventCh := progStarter()
+		err = destDB.PushChunks(ctx, tempTableDir, srcDB, commitRef, progChan, pullerEventCh)
+		progStopper(wg, progChan, pullerEventCh)
+
+		if err != nil {
+			return err
+		}
+
+		err = destDB.FastForward(ctx, branchRef, srcCommit)
+		if err != nil {
+			return err
+		}
+	}
+
+	wsRef, err := ref.WorkingSetRefForHead(branchRef)
+	if err != nil {
+		return err
+	}
+
+	srcWS, err := srcDB.ResolveWorkingSet(ctx, wsRef)
+	if err == doltdb.ErrWorkingSetNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	srcWSHash, err := srcWS.HashOf()
+	if err != nil {
+		return err
+	}
+
+	var destWSHash hash.Hash
+	destWS, err := destDB.ResolveWorkingSet(ctx, wsRef)
+	if err == nil {
+		destWSHash, err = destWS.HashOf()
+		if err != nil {
+			return err
+		}
+	} else if err != doltdb.ErrWorkingSetNotFound {
+		return err
+	}
+
+	if srcWSHash == destWSHash {
+		return nil
+	}
+
+	wsStRef, err := srcWS.GetStRef()
+	if err != nil {
+		return err
+	}
+
+	wg, progChan, pullerEventCh := progStarter()
+	err = destDB.PushChunks(ctx, tempTableDir, srcDB, wsStRef, progChan, pullerEventCh)
+	progStopper(wg, progChan, pullerEventCh)
+
+	if err != nil {
+		return err
+	}
+
+	meta := srcWS.Meta()
+	return destDB.UpdateWorkingSet(ctx, wsRef, srcWS, destWSHash, &meta)
+}
+
+func commitsEqual(left, right *doltdb.Commit) bool {
+	leftHash, err := left.HashOf()
+	if err != nil {
+		return false
+	}
+
+	rightHash, err := right.HashOf()
+	if err != nil {
+		return false
+	}
+
+	return leftHash == rightHash
+}
+
+func setRemoteUrlEventAttribute(ctx context.Context, remote env.Remote) {
+	evt := events.GetEventFromContext(ctx)
+
+	// operations run by the sql server don't have an event in their context
+	if evt == nil {
+		return
+	}
+
+	u, err := earl.Parse(remote.Url)
+
+	if err == nil {
+		if u.Scheme != "" {
+			evt.SetAttribute(eventsapi.AttributeID_REMOTE_URL_SCHEME, u.Scheme)
+		}
+	}
+}
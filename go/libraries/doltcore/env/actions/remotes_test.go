@@ -0,0 +1,119 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestReplicateBranch(t *testing.T) {
+	ctx := context.Background()
+	master := ref.NewBranchRef("master")
+
+	srcDB, err := doltdb.LoadDoltDB(ctx, types.Format_Default, doltdb.InMemDoltDB, filesys.LocalFS)
+	require.NoError(t, err)
+	require.NoError(t, srcDB.WriteEmptyRepo(ctx, "Bill Billerson", "bill@billerson.com"))
+	destDB, err := doltdb.LoadDoltDB(ctx, types.Format_Default, doltdb.InMemDoltDB, filesys.LocalFS)
+	require.NoError(t, err)
+
+	replicate := func() error {
+		return ReplicateBranch(ctx, "", srcDB, destDB, master, testProgStarter, testProgStopper)
+	}
+	requireSameHead := func() {
+		srcHead, err := srcDB.ResolveCommitRef(ctx, master)
+		require.NoError(t, err)
+		destHead, err := destDB.ResolveCommitRef(ctx, master)
+		require.NoError(t, err)
+		assert.True(t, commitsEqual(srcHead, destHead))
+	}
+
+	// the branch is created in a database without it
+	require.NoError(t, replicate())
+	requireSameHead()
+
+	// and fast-forwarded once it's there
+	commitOnMaster(t, srcDB, "src 1")
+	require.NoError(t, replicate())
+	requireSameHead()
+
+	// a branch with commits the source doesn't have is never overwritten
+	destOnly := commitOnMaster(t, destDB, "dest 1")
+	err = replicate()
+	assert.True(t, errors.Is(err, ErrCantFF))
+
+	commitOnMaster(t, srcDB, "src 2")
+	err = replicate()
+	assert.True(t, errors.Is(err, ErrCantFF))
+
+	destHead, err := destDB.ResolveCommitRef(ctx, master)
+	require.NoError(t, err)
+	assert.True(t, commitsEqual(destOnly, destHead))
+}
+
+func commitOnMaster(t *testing.T, ddb *doltdb.DoltDB, msg string) *doltdb.Commit {
+	ctx := context.Background()
+	master := ref.NewBranchRef("master")
+
+	head, err := ddb.ResolveCommitRef(ctx, master)
+	require.NoError(t, err)
+	root, err := head.GetRootValue()
+	require.NoError(t, err)
+	rootHash, err := ddb.WriteRootValue(ctx, root)
+	require.NoError(t, err)
+	meta, err := doltdb.NewCommitMeta("Bill Billerson", "bill@billerson.com", msg)
+	require.NoError(t, err)
+
+	cm, err := ddb.CommitWithParentCommits(ctx, rootHash, master, []*doltdb.Commit{head}, meta)
+	require.NoError(t, err)
+	return cm
+}
+
+func testProgStarter() (*sync.WaitGroup, chan datas.PullProgress, chan datas.PullerEvent) {
+	pullerEventCh := make(chan datas.PullerEvent, 128)
+	progChan := make(chan datas.PullProgress, 128)
+	wg := &sync.WaitGroup{}
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for range progChan {
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for range pullerEventCh {
+		}
+	}()
+
+	return wg, progChan, pullerEventCh
+}
+
+func testProgStopper(wg *sync.WaitGroup, progChan chan datas.PullProgress, pullerEventCh chan datas.PullerEvent) {
+	close(progChan)
+	close(pullerEventCh)
+	wg.Wait()
+}
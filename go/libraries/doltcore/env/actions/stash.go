@@ -0,0 +1,295 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+)
+
+var ErrNoLocalChanges = errors.New("no local changes to save")
+var ErrStashConflicts = errors.New("the working set has unresolved conflicts")
+
+// Stash is a set of working set changes that have been put aside. A stash is stored as a commit of the working root,
+// whose first parent is the HEAD commit the stash was created on, and whose second parent is a commit of the staged
+// root with that same HEAD commit as its parent.
+type Stash struct {
+	Ref    ref.DoltRef
+	Commit *doltdb.Commit
+	Meta   *doltdb.CommitMeta
+}
+
+// StashName returns the name used to refer to the stash at |idx| in the list of stashes, e.g. stash@{0}
+func StashName(idx int) string {
+	return fmt.Sprintf("stash@{%d}", idx)
+}
+
+// ParseStashName returns the index of the stash named, which may be either in the form stash@{<idx>} or just the
+// index itself.
+func ParseStashName(name string) (int, error) {
+	idxStr := name
+	if strings.HasPrefix(name, "stash@{") && strings.HasSuffix(name, "}") {
+		idxStr = name[len("stash@{") : len(name)-1]
+	}
+
+	var idx int
+	_, err := fmt.Sscanf(idxStr, "%d", &idx)
+	if err != nil || idx < 0 || fmt.Sprint(idx) != idxStr {
+		return 0, fmt.Errorf("%s is not a valid reference", name)
+	}
+
+	return idx, nil
+}
+
+// ListStashes returns all the stashes in |ddb|, most recent first.
+func ListStashes(ctx context.Context, ddb *doltdb.DoltDB) ([]*Stash, error) {
+	refs, err := ddb.GetStashes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// stash refs are named for the time they were created, so they sort in the order they were created
+	sort.Slice(refs, func(i, j int) bool {
+		return refs[i].GetPath() > refs[j].GetPath()
+	})
+
+	stashes := make([]*Stash, len(refs))
+	for i, r := range refs {
+		cm, err := ddb.ResolveCommitRef(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+
+		meta, err := cm.GetCommitMeta()
+		if err != nil {
+			return nil, err
+		}
+
+		stashes[i] = &Stash{Ref: r, Commit: cm, Meta: meta}
+	}
+
+	return stashes, nil
+}
+
+// GetStash returns the stash at |idx| in the list of stashes returned by ListStashes.
+func GetStash(ctx context.Context, ddb *doltdb.DoltDB, idx int) (*Stash, error) {
+	stashes, err := ListStashes(ctx, ddb)
+	if err != nil {
+		return nil, err
+	}
+
+	if idx >= len(stashes) {
+		return nil, fmt.Errorf("%w: %s", doltdb.ErrStashNotFound, StashName(idx))
+	}
+
+	return stashes[idx], nil
+}
+
+// StashPush saves the working and staged changes of the current branch as a new stash, and resets the working set to
+// HEAD. The stash's description is |msg|, or one generated from the HEAD commit if |msg| is empty.
+func StashPush(ctx context.Context, dEnv *env.DoltEnv, msg, name, email string) error {
+	ws, err := dEnv.WorkingSet(ctx)
+	if err != nil {
+		return err
+	}
+
+	if ws.MergeActive() {
+		return doltdb.ErrMergeActive
+	}
+
+	roots, err := dEnv.Roots(ctx)
+	if err != nil {
+		return err
+	}
+
+	headHash, err := roots.Head.HashOf()
+	if err != nil {
+		return err
+	}
+	stagedHash, err := roots.Staged.HashOf()
+	if err != nil {
+		return err
+	}
+	workingHash, err := roots.Working.HashOf()
+	if err != nil {
+		return err
+	}
+
+	if headHash == stagedHash && headHash == workingHash {
+		return ErrNoLocalChanges
+	}
+
+	inConflict, err := roots.Working.TablesInConflict(ctx)
+	if err != nil {
+		return err
+	}
+	if len(inConflict) > 0 {
+		return ErrStashConflicts
+	}
+
+	ddb := dEnv.DoltDB
+	branch := dEnv.RepoStateReader().CWBHeadRef()
+	head, err := ddb.ResolveCommitRef(ctx, branch)
+	if err != nil {
+		return err
+	}
+
+	if msg == "" {
+		cmHash, err := head.HashOf()
+		if err != nil {
+			return err
+		}
+
+		headMeta, err := head.GetCommitMeta()
+		if err != nil {
+			return err
+		}
+
+		subject := strings.SplitN(headMeta.Description, "\n", 2)[0]
+		msg = fmt.Sprintf("WIP on %s: %s %s", branch.GetPath(), cmHash.String(), subject)
+	} else {
+		msg = fmt.Sprintf("On %s: %s", branch.GetPath(), msg)
+	}
+
+	meta, err := doltdb.NewCommitMeta(name, email, msg)
+	if err != nil {
+		return err
+	}
+
+	stagedValHash, err := ddb.WriteRootValue(ctx, roots.Staged)
+	if err != nil {
+		return err
+	}
+
+	stagedCm, err := ddb.CommitDanglingWithParentCommits(ctx, stagedValHash, []*doltdb.Commit{head}, meta)
+	if err != nil {
+		return err
+	}
+
+	workingValHash, err := ddb.WriteRootValue(ctx, roots.Working)
+	if err != nil {
+		return err
+	}
+
+	stashCm, err := ddb.CommitDanglingWithParentCommits(ctx, workingValHash, []*doltdb.Commit{head, stagedCm}, meta)
+	if err != nil {
+		return err
+	}
+
+	stashRef := ref.NewStashRef(fmt.Sprintf("%d", time.Now().UnixNano()))
+	err = ddb.NewStashAtCommit(ctx, stashRef, stashCm)
+	if err != nil {
+		return err
+	}
+
+	err = dEnv.UpdateWorkingSet(ctx, ws.WithWorkingRoot(roots.Head).WithStagedRoot(roots.Head))
+	if err != nil {
+		return err
+	}
+
+	return SaveTrackedDocsFromWorking(ctx, dEnv)
+}
+
+// StashApply applies the changes of |stash| to the working set with a three-way merge, using the HEAD commit the
+// stash was created on as the merge base. Staged changes in the stash are restored to the staged root if they can be
+// merged with it cleanly. Changes that can't be applied cleanly are recorded as conflicts in the working root, and
+// the stats of the merge into the working root are returned.
+func StashApply(ctx context.Context, dEnv *env.DoltEnv, stash *Stash) (map[string]*merge.MergeStats, error) {
+	ws, err := dEnv.WorkingSet(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if ws.MergeActive() {
+		return nil, doltdb.ErrMergeActive
+	}
+
+	roots, err := dEnv.Roots(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	inConflict, err := roots.Working.TablesInConflict(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(inConflict) > 0 {
+		return nil, ErrStashConflicts
+	}
+
+	parents, err := dEnv.DoltDB.ResolveAllParents(ctx, stash.Commit)
+	if err != nil {
+		return nil, err
+	}
+	if len(parents) != 2 {
+		return nil, fmt.Errorf("%s is not a valid stash commit", stash.Ref.String())
+	}
+
+	baseRoot, err := parents[0].GetRootValue()
+	if err != nil {
+		return nil, err
+	}
+	stashStaged, err := parents[1].GetRootValue()
+	if err != nil {
+		return nil, err
+	}
+	stashWorking, err := stash.Commit.GetRootValue()
+	if err != nil {
+		return nil, err
+	}
+
+	working, tblToStats, err := merge.MergeRoots(ctx, roots.Working, stashWorking, baseRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	staged := roots.Staged
+	if !merge.HasConflictsOrViolations(tblToStats) {
+		mergedStaged, stagedStats, err := merge.MergeRoots(ctx, roots.Staged, stashStaged, baseRoot)
+		if err != nil {
+			return nil, err
+		}
+
+		if !merge.HasConflictsOrViolations(stagedStats) {
+			staged = mergedStaged
+		}
+	}
+
+	err = dEnv.UpdateWorkingSet(ctx, ws.WithWorkingRoot(working).WithStagedRoot(staged))
+	if err != nil {
+		return nil, err
+	}
+
+	err = SaveTrackedDocsFromWorking(ctx, dEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	return tblToStats, nil
+}
+
+// StashDrop removes |stash|.
+func StashDrop(ctx context.Context, ddb *doltdb.DoltDB, stash *Stash) error {
+	return ddb.DeleteStash(ctx, stash.Ref)
+}
@@ -55,6 +55,7 @@ var ErrPreexistingDoltDir = errors.New(".dolt dir already exists")
 var ErrStateUpdate = errors.New("error updating local data repo state")
 var ErrMarshallingSchema = errors.New("error marshalling schema")
 var ErrInvalidCredsFile = errors.New("invalid creds file")
+var ErrRemoteNotFound = errors.New("remote not found")
 
 // DoltEnv holds the state of the current environment used by the cli.
 type DoltEnv struct {
@@ -545,13 +546,34 @@ func (r *repoStateReader) IsMergeActive(ctx context.Context) (bool, error) {
 	return r.dEnv.IsMergeActive(ctx)
 }
 
-func (r *repoStateReader) GetMergeCommit(ctx context.Context) (*doltdb.Commit, error) {
+func (r *repoStateReader) GetMergeCommits(ctx context.Context) ([]*doltdb.Commit, error) {
 	ws, err := r.dEnv.WorkingSet(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return ws.MergeState().Commit(), nil
+	return ws.MergeState().Commits(), nil
+}
+
+func (r *repoStateReader) GetMergeSchemaConflicts(ctx context.Context) ([]string, error) {
+	ws, err := r.dEnv.WorkingSet(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return ws.MergeState().SchemaConflicts(), nil
+}
+
+func (r *repoStateReader) GetRemotes() (map[string]Remote, error) {
+	return r.dEnv.GetRemotes()
+}
+
+func (r *repoStateReader) GetAbsRemoteUrl(urlArg string) (string, string, error) {
+	return GetAbsRemoteUrl(r.dEnv.FS, r.dEnv.Config, urlArg)
+}
+
+func (r *repoStateReader) TempTableFilesDir() string {
+	return r.dEnv.TempTableFilesDir()
 }
 
 func (dEnv *DoltEnv) RepoStateReader() RepoStateReader {
@@ -582,8 +604,22 @@ func (r *repoStateWriter) ClearMerge(ctx context.Context) error {
 	return r.DoltEnv.ClearMerge(ctx)
 }
 
-func (r *repoStateWriter) StartMerge(ctx context.Context, commit *doltdb.Commit) error {
-	return r.DoltEnv.StartMerge(ctx, commit)
+func (r *repoStateWriter) StartMerge(ctx context.Context, commit *doltdb.Commit, otherCommits ...*doltdb.Commit) error {
+	return r.DoltEnv.StartMerge(ctx, commit, otherCommits...)
+}
+
+func (r *repoStateWriter) AddRemote(rem Remote) error {
+	r.RepoState.AddRemote(rem)
+	return r.RepoState.Save(r.FS)
+}
+
+func (r *repoStateWriter) RemoveRemote(ctx context.Context, name string) error {
+	if _, ok := r.RepoState.Remotes[name]; !ok {
+		return ErrRemoteNotFound
+	}
+
+	delete(r.RepoState.Remotes, name)
+	return r.RepoState.Save(r.FS)
 }
 
 func (dEnv *DoltEnv) RepoStateWriter() RepoStateWriter {
@@ -716,7 +752,7 @@ func (dEnv *DoltEnv) ClearMerge(ctx context.Context) error {
 	return dEnv.DoltDB.UpdateWorkingSet(ctx, ws.Ref(), ws.ClearMerge(), h, dEnv.workingSetMeta())
 }
 
-func (dEnv *DoltEnv) StartMerge(ctx context.Context, commit *doltdb.Commit) error {
+func (dEnv *DoltEnv) StartMerge(ctx context.Context, commit *doltdb.Commit, otherCommits ...*doltdb.Commit) error {
 	ws, err := dEnv.WorkingSet(ctx)
 	if err != nil {
 		return err
@@ -727,7 +763,7 @@ func (dEnv *DoltEnv) StartMerge(ctx context.Context, commit *doltdb.Commit) erro
 		return err
 	}
 
-	return dEnv.DoltDB.UpdateWorkingSet(ctx, ws.Ref(), ws.StartMerge(commit), h, dEnv.workingSetMeta())
+	return dEnv.DoltDB.UpdateWorkingSet(ctx, ws.Ref(), ws.StartMerge(commit, otherCommits...), h, dEnv.workingSetMeta())
 }
 
 // todo: move this out of env to actions
@@ -949,36 +985,14 @@ func (dEnv *DoltEnv) FindRef(ctx context.Context, refStr string) (ref.DoltRef, e
 // GetRefSpecs takes an optional remoteName and returns all refspecs associated with that remote.  If "" is passed as
 // the remoteName then the default remote is used.
 func (dEnv *DoltEnv) GetRefSpecs(remoteName string) ([]ref.RemoteRefSpec, errhand.VerboseError) {
-	var remote Remote
-	var verr errhand.VerboseError
+	refSpecs, err := GetRefSpecs(dEnv.RepoStateReader(), remoteName)
 
-	if remoteName == "" {
-		remote, verr = dEnv.GetDefaultRemote()
-	} else if r, ok := dEnv.RepoState.Remotes[remoteName]; ok {
-		remote = r
-	} else {
-		verr = errhand.BuildDError("error: unknown remote '%s'", remoteName).Build()
-	}
-
-	if verr != nil {
-		return nil, verr
-	}
-
-	var refSpecs []ref.RemoteRefSpec
-	for _, fs := range remote.FetchSpecs {
-		rs, err := ref.ParseRefSpecForRemote(remote.Name, fs)
-
-		if err != nil {
-			return nil, errhand.BuildDError("error: for '%s', '%s' is not a valid refspec.", remote.Name, fs).Build()
-		}
-
-		if rrs, ok := rs.(ref.RemoteRefSpec); !ok {
-			return nil, errhand.BuildDError("error: '%s' is not a valid refspec referring to a remote tracking branch", remote.Name).Build()
-		} else if rrs.GetRemote() != remote.Name {
-			return nil, errhand.BuildDError("error: remote '%s' refers to remote '%s'", remote.Name, rrs.GetRemote()).Build()
-		} else {
-			refSpecs = append(refSpecs, rrs)
-		}
+	if err == ErrNoRemotes {
+		return nil, ErrNoRemote
+	} else if err == ErrCantDetermineDefaultRemote {
+		return nil, ErrCantDetermineDefault
+	} else if err != nil {
+		return nil, errhand.BuildDError("error: %s", err.Error()).Build()
 	}
 
 	return refSpecs, nil
@@ -990,21 +1004,15 @@ var ErrCantDetermineDefault = errhand.BuildDError("error: unable to determine th
 // GetDefaultRemote gets the default remote for the environment.  Not fully implemented yet.  Needs to support multiple
 // repos and a configurable default.
 func (dEnv *DoltEnv) GetDefaultRemote() (Remote, errhand.VerboseError) {
-	remotes := dEnv.RepoState.Remotes
+	remote, err := GetDefaultRemote(dEnv.RepoStateReader())
 
-	if len(remotes) == 0 {
+	if err == ErrNoRemotes {
 		return NoRemote, ErrNoRemote
-	} else if len(remotes) == 1 {
-		for _, v := range remotes {
-			return v, nil
-		}
-	}
-
-	if remote, ok := dEnv.RepoState.Remotes["origin"]; ok {
-		return remote, nil
+	} else if err != nil {
+		return NoRemote, ErrCantDetermineDefault
 	}
 
-	return NoRemote, ErrCantDetermineDefault
+	return remote, nil
 }
 
 // GetUserHomeDir returns the user's home dir
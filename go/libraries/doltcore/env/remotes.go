@@ -16,15 +16,27 @@ package env
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/config"
+	"github.com/dolthub/dolt/go/libraries/utils/earl"
 	filesys2 "github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
 var NoRemote = Remote{}
 
+var ErrNoRemotes = errors.New("no remote")
+var ErrCantDetermineDefaultRemote = errors.New("unable to determine the default remote")
+var ErrUnknownRemote = errors.New("unknown remote")
+
 func IsEmptyRemote(r Remote) bool {
 	return len(r.Name) == 0 && len(r.Url) == 0 && r.FetchSpecs == nil && r.Params == nil
 }
@@ -67,3 +79,207 @@ func (r *Remote) GetRemoteDBWithoutCaching(ctx context.Context, nbf *types.NomsB
 	params[dbfactory.NoCachingParameter] = "true"
 	return doltdb.LoadDoltDBWithParams(ctx, nbf, r.Url, filesys2.LocalFS, params)
 }
+
+// GetDefaultRemote returns the default remote of the repo state.  If there is only a single remote it is the default,
+// otherwise the remote named origin is.
+func GetDefaultRemote(rsr RepoStateReader) (Remote, error) {
+	remotes, err := rsr.GetRemotes()
+
+	if err != nil {
+		return NoRemote, err
+	}
+
+	if len(remotes) == 0 {
+		return NoRemote, ErrNoRemotes
+	} else if len(remotes) == 1 {
+		for _, v := range remotes {
+			return v, nil
+		}
+	}
+
+	if remote, ok := remotes["origin"]; ok {
+		return remote, nil
+	}
+
+	return NoRemote, ErrCantDetermineDefaultRemote
+}
+
+// GetRefSpecs takes an optional remoteName and returns all refspecs associated with that remote.  If "" is passed as
+// the remoteName then the default remote is used.
+func GetRefSpecs(rsr RepoStateReader, remoteName string) ([]ref.RemoteRefSpec, error) {
+	var remote Remote
+	var err error
+
+	if remoteName == "" {
+		remote, err = GetDefaultRemote(rsr)
+	} else {
+		var remotes map[string]Remote
+		remotes, err = rsr.GetRemotes()
+
+		if err == nil {
+			var ok bool
+			remote, ok = remotes[remoteName]
+
+			if !ok {
+				err = fmt.Errorf("%w '%s'", ErrUnknownRemote, remoteName)
+			}
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var refSpecs []ref.RemoteRefSpec
+	for _, fs := range remote.FetchSpecs {
+		rs, err := ref.ParseRefSpecForRemote(remote.Name, fs)
+
+		if err != nil {
+			return nil, fmt.Errorf("for '%s', '%s' is not a valid refspec.", remote.Name, fs)
+		}
+
+		if rrs, ok := rs.(ref.RemoteRefSpec); !ok {
+			return nil, fmt.Errorf("'%s' is not a valid refspec referring to a remote tracking branch", remote.Name)
+		} else if rrs.GetRemote() != remote.Name {
+			return nil, fmt.Errorf("remote '%s' refers to remote '%s'", remote.Name, rrs.GetRemote())
+		} else {
+			refSpecs = append(refSpecs, rrs)
+		}
+	}
+
+	return refSpecs, nil
+}
+
+// ParseRefSpecs returns the remote and refspecs described by the arguments to a fetch: an optional remote name, which
+// defaults to origin, followed by optional refspecs, which default to the fetch specs of the remote.
+func ParseRefSpecs(args []string, rsr RepoStateReader) (Remote, []ref.RemoteRefSpec, error) {
+	remotes, err := rsr.GetRemotes()
+
+	if err != nil {
+		return NoRemote, nil, err
+	}
+
+	if len(remotes) == 0 {
+		return NoRemote, nil, ErrNoRemotes
+	}
+
+	remName := "origin"
+	if len(args) != 0 {
+		if _, ok := remotes[args[0]]; ok {
+			remName = args[0]
+			args = args[1:]
+		}
+	}
+
+	remote, ok := remotes[remName]
+
+	if !ok {
+		return NoRemote, nil, fmt.Errorf("%w '%s'", ErrUnknownRemote, remName)
+	}
+
+	var refSpecs []ref.RemoteRefSpec
+	if len(args) != 0 {
+		refSpecs, err = parseRefSpecsForRemote(remName, args)
+	} else {
+		refSpecs, err = GetRefSpecs(rsr, remName)
+	}
+
+	if err != nil {
+		return NoRemote, nil, err
+	}
+
+	return remote, refSpecs, nil
+}
+
+func parseRefSpecsForRemote(remName string, args []string) ([]ref.RemoteRefSpec, error) {
+	var refSpecs []ref.RemoteRefSpec
+	for _, rsStr := range args {
+		rs, err := ref.ParseRefSpec(rsStr)
+
+		if err != nil {
+			return nil, fmt.Errorf("'%s' is not a valid refspec.", rsStr)
+		}
+
+		if _, ok := rs.(ref.BranchToBranchRefSpec); ok {
+			local := "refs/heads/" + rsStr
+			remTracking := "remotes/" + remName + "/" + rsStr
+			rs2, err := ref.ParseRefSpec(local + ":" + remTracking)
+
+			if err == nil {
+				rs = rs2
+			}
+		}
+
+		if rrs, ok := rs.(ref.RemoteRefSpec); !ok {
+			return nil, fmt.Errorf("'%s' is not a valid refspec referring to a remote tracking branch", rsStr)
+		} else {
+			refSpecs = append(refSpecs, rrs)
+		}
+	}
+
+	return refSpecs, nil
+}
+
+// GetAbsRemoteUrl returns the scheme and the absolute url of the remote at |urlArg|, as given to dolt remote add or dolt
+// clone. Relative file urls are made absolute, and urls without a scheme refer to the remotes api host from |cfg|.
+func GetAbsRemoteUrl(fs filesys2.Filesys, cfg config.ReadableConfig, urlArg string) (string, string, error) {
+	u, err := earl.Parse(urlArg)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	if u.Scheme != "" {
+		if u.Scheme == dbfactory.FileScheme || u.Scheme == dbfactory.LocalBSScheme {
+			absUrl, err := getAbsFileRemoteUrl(u.Host+u.Path, fs)
+
+			if err != nil {
+				return "", "", err
+			}
+
+			return u.Scheme, absUrl, err
+		}
+
+		return u.Scheme, urlArg, nil
+	} else if u.Host != "" {
+		return dbfactory.HTTPSScheme, "https://" + urlArg, nil
+	}
+
+	hostName, err := cfg.GetString(RemotesApiHostKey)
+
+	if err != nil {
+		if err != config.ErrConfigParamNotFound {
+			return "", "", err
+		}
+
+		hostName = DefaultRemotesApiHost
+	}
+
+	hostName = strings.TrimSpace(hostName)
+
+	return dbfactory.HTTPSScheme, "https://" + path.Join(hostName, u.Path), nil
+}
+
+func getAbsFileRemoteUrl(urlStr string, fs filesys2.Filesys) (string, error) {
+	var err error
+	urlStr = filepath.Clean(urlStr)
+	urlStr, err = fs.Abs(urlStr)
+
+	if err != nil {
+		return "", err
+	}
+
+	exists, isDir := fs.Exists(urlStr)
+
+	if !exists {
+		return "", filesys2.ErrDirNotExist
+	} else if !isDir {
+		return "", filesys2.ErrIsFile
+	}
+
+	urlStr = strings.ReplaceAll(urlStr, `\`, "/")
+	if !strings.HasPrefix(urlStr, "/") {
+		urlStr = "/" + urlStr
+	}
+	return dbfactory.FileScheme + "://" + urlStr, nil
+}
@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package commands
+package env
 
 import (
 	"fmt"
@@ -21,7 +21,6 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
-	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/utils/config"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/libraries/utils/osutil"
@@ -45,21 +44,21 @@ func TestGetAbsRemoteUrl(t *testing.T) {
 		{
 			"",
 			config.NewMapConfig(map[string]string{}),
-			"https://" + env.DefaultRemotesApiHost,
+			"https://" + DefaultRemotesApiHost,
 			"https",
 			false,
 		},
 		{
 			"ts/emp",
 			config.NewMapConfig(map[string]string{}),
-			"https://" + env.DefaultRemotesApiHost + "/ts/emp",
+			"https://" + DefaultRemotesApiHost + "/ts/emp",
 			"https",
 			false,
 		},
 		{
 			"ts/emp",
 			config.NewMapConfig(map[string]string{
-				env.RemotesApiHostKey: "host.dom",
+				RemotesApiHostKey: "host.dom",
 			}),
 			"https://host.dom/ts/emp",
 			"https",
@@ -75,7 +74,7 @@ func TestGetAbsRemoteUrl(t *testing.T) {
 		{
 			"https://test.org:443/ts/emp",
 			config.NewMapConfig(map[string]string{
-				env.RemotesApiHostKey: "host.dom",
+				RemotesApiHostKey: "host.dom",
 			}),
 			"https://test.org:443/ts/emp",
 			"https",
@@ -84,7 +83,7 @@ func TestGetAbsRemoteUrl(t *testing.T) {
 		{
 			"localhost/ts/emp",
 			config.NewMapConfig(map[string]string{
-				env.RemotesApiHostKey: "host.dom",
+				RemotesApiHostKey: "host.dom",
 			}),
 			"https://localhost/ts/emp",
 			"https",
@@ -130,7 +129,7 @@ func TestGetAbsRemoteUrl(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.str, func(t *testing.T) {
-			actualScheme, actualUrl, err := getAbsRemoteUrl(fs, test.cfg, test.str)
+			actualScheme, actualUrl, err := GetAbsRemoteUrl(fs, test.cfg, test.str)
 
 			if test.expectErr {
 				assert.Error(t, err)
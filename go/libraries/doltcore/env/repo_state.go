@@ -31,7 +31,13 @@ type RepoStateReader interface {
 	// TODO: get rid of this
 	IsMergeActive(ctx context.Context) (bool, error)
 	// TODO: get rid of this
-	GetMergeCommit(ctx context.Context) (*doltdb.Commit, error)
+	GetMergeCommits(ctx context.Context) ([]*doltdb.Commit, error)
+	// TODO: get rid of this
+	GetMergeSchemaConflicts(ctx context.Context) ([]string, error)
+	GetRemotes() (map[string]Remote, error)
+	// GetAbsRemoteUrl returns the scheme and absolute url of the remote at |urlArg|, the same way as GetAbsRemoteUrl
+	GetAbsRemoteUrl(urlArg string) (string, string, error)
+	TempTableFilesDir() string
 }
 
 type RepoStateWriter interface {
@@ -45,7 +51,9 @@ type RepoStateWriter interface {
 	// TODO: get rid of this
 	ClearMerge(ctx context.Context) error
 	// TODO: get rid of this
-	StartMerge(ctx context.Context, commit *doltdb.Commit) error
+	StartMerge(ctx context.Context, commit *doltdb.Commit, otherCommits ...*doltdb.Commit) error
+	AddRemote(r Remote) error
+	RemoveRemote(ctx context.Context, name string) error
 }
 
 type DocsReadWriter interface {
@@ -325,10 +333,13 @@ func GetGCKeepers(ctx context.Context, env *DoltEnv) ([]hash.Hash, error) {
 			return nil, err
 		}
 
-		cm := ws.MergeState().Commit()
-		ch, err := cm.HashOf()
-		if err != nil {
-			return nil, err
+		for _, cm := range ws.MergeState().Commits() {
+			ch, err := cm.HashOf()
+			if err != nil {
+				return nil, err
+			}
+
+			keepers = append(keepers, ch)
 		}
 
 		pmw := ws.MergeState().PreMergeWorkingRoot()
@@ -337,7 +348,7 @@ func GetGCKeepers(ctx context.Context, env *DoltEnv) ([]hash.Hash, error) {
 			return nil, err
 		}
 
-		keepers = append(keepers, ch, pmwh)
+		keepers = append(keepers, pmwh)
 	}
 
 	return keepers, nil
@@ -0,0 +1,61 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+)
+
+var ErrMergeCommitNotSupported = errors.New("commits with more than one parent are not supported")
+
+// CherryPick applies the changes introduced by |cm|, relative to its parent, to |root|. Changes that can't be applied
+// cleanly are recorded as conflicts in the root returned, exactly as they would be for a merge.
+func CherryPick(ctx context.Context, ddb *doltdb.DoltDB, root *doltdb.RootValue, cm *doltdb.Commit) (*doltdb.RootValue, map[string]*MergeStats, error) {
+	cmRoot, err := cm.GetRootValue()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	parentRoot, err := singleParentRoot(ctx, ddb, cm)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return MergeRoots(ctx, root, cmRoot, parentRoot)
+}
+
+// singleParentRoot returns the root value of the only parent of |cm|, or an empty root value if |cm| has no parents.
+func singleParentRoot(ctx context.Context, ddb *doltdb.DoltDB, cm *doltdb.Commit) (*doltdb.RootValue, error) {
+	numParents, err := cm.NumParents()
+	if err != nil {
+		return nil, err
+	}
+
+	switch numParents {
+	case 0:
+		return doltdb.EmptyRootValue(ctx, ddb.ValueReadWriter())
+	case 1:
+		parent, err := ddb.ResolveParent(ctx, cm, 0)
+		if err != nil {
+			return nil, err
+		}
+		return parent.GetRootValue()
+	default:
+		return nil, ErrMergeCommitNotSupported
+	}
+}
@@ -105,6 +105,80 @@ func TestMerge(t *testing.T) {
 				{"z"},
 			},
 		},
+		{
+			name: "octopus merge",
+			setup: []testCommand{
+				{cmd.BranchCmd{}, args{"other"}},
+				{cmd.BranchCmd{}, args{"another"}},
+				{cmd.SqlCmd{}, args{"-q", "INSERT INTO test VALUES (11,11);"}},
+				{cmd.CommitCmd{}, args{"-am", "added rows on master"}},
+				{cmd.CheckoutCmd{}, args{"other"}},
+				{cmd.SqlCmd{}, args{"-q", "INSERT INTO test VALUES (1,1);"}},
+				{cmd.CommitCmd{}, args{"-am", "added rows on other"}},
+				{cmd.CheckoutCmd{}, args{"another"}},
+				{cmd.SqlCmd{}, args{"-q", "INSERT INTO test VALUES (2,2);"}},
+				{cmd.CommitCmd{}, args{"-am", "added rows on another"}},
+				{cmd.CheckoutCmd{}, args{"master"}},
+				{cmd.MergeCmd{}, args{"other", "another"}},
+				{cmd.CommitCmd{}, args{"-m", "octopus merge"}},
+			},
+			query: "SELECT (SELECT count(*) FROM dolt_commit_ancestors WHERE commit_hash = (SELECT commit_hash FROM dolt_log LIMIT 1)), count(*), sum(pk) FROM test",
+			expected: []sql.Row{
+				{int64(3), int64(3), float64(14)},
+			},
+		},
+		{
+			name: "criss-cross merge",
+			setup: []testCommand{
+				{cmd.SqlCmd{}, args{"-q", "INSERT INTO test VALUES (1,0),(2,0);"}},
+				{cmd.CommitCmd{}, args{"-am", "added rows"}},
+				{cmd.BranchCmd{}, args{"other"}},
+				{cmd.SqlCmd{}, args{"-q", "UPDATE test SET c0 = 1 WHERE pk = 1;"}},
+				{cmd.CommitCmd{}, args{"-am", "updated row on master"}},
+				{cmd.CheckoutCmd{}, args{"other"}},
+				{cmd.SqlCmd{}, args{"-q", "UPDATE test SET c0 = 2 WHERE pk = 2;"}},
+				{cmd.CommitCmd{}, args{"-am", "updated row on other"}},
+				{cmd.CheckoutCmd{}, args{"-b", "merged"}},
+				{cmd.MergeCmd{}, args{"master"}},
+				{cmd.CommitCmd{}, args{"-m", "merged master into other"}},
+				{cmd.SqlCmd{}, args{"-q", "UPDATE test SET c0 = 20 WHERE pk = 2;"}},
+				{cmd.CommitCmd{}, args{"-am", "updated row on merged"}},
+				{cmd.CheckoutCmd{}, args{"master"}},
+				{cmd.MergeCmd{}, args{"other"}},
+				{cmd.CommitCmd{}, args{"-m", "merged other into master"}},
+				{cmd.SqlCmd{}, args{"-q", "UPDATE test SET c0 = 10 WHERE pk = 1;"}},
+				{cmd.CommitCmd{}, args{"-am", "updated row on master"}},
+				// either merge base alone would conflict
+				{cmd.MergeCmd{}, args{"merged"}},
+			},
+			query: "SELECT * FROM test",
+			expected: []sql.Row{
+				{int32(1), int32(10)},
+				{int32(2), int32(20)},
+			},
+		},
+		{
+			name: "widen column type and rename column on different branches",
+			setup: []testCommand{
+				{cmd.SqlCmd{}, args{"-q", "INSERT INTO test VALUES (1,1),(2,2);"}},
+				{cmd.CommitCmd{}, args{"-am", "added rows"}},
+				{cmd.BranchCmd{}, args{"other"}},
+				{cmd.SqlCmd{}, args{"-q", "ALTER TABLE test MODIFY COLUMN c0 bigint;"}},
+				{cmd.SqlCmd{}, args{"-q", "UPDATE test SET c0 = 10 WHERE pk = 1;"}},
+				{cmd.CommitCmd{}, args{"-am", "widened column on master"}},
+				{cmd.CheckoutCmd{}, args{"other"}},
+				{cmd.SqlCmd{}, args{"-q", "ALTER TABLE test RENAME COLUMN c0 TO c1;"}},
+				{cmd.SqlCmd{}, args{"-q", "UPDATE test SET c1 = 20 WHERE pk = 2;"}},
+				{cmd.CommitCmd{}, args{"-am", "renamed column on other"}},
+				{cmd.CheckoutCmd{}, args{"master"}},
+				{cmd.MergeCmd{}, args{"other"}},
+			},
+			query: "SELECT pk, c1 FROM test",
+			expected: []sql.Row{
+				{int32(1), int64(10)},
+				{int32(2), int64(20)},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -218,6 +292,82 @@ func TestMergeConflicts(t *testing.T) {
 				{int32(2), int32(2)},
 			},
 		},
+		{
+			name: "conflict on merge, resolved by merge strategy",
+			setup: []testCommand{
+				{cmd.SqlCmd{}, args{"-q", "INSERT INTO dolt_merge_strategies VALUES ('test', 'c0', 'max');"}},
+				{cmd.AddCmd{}, args{"-A"}},
+				{cmd.CommitCmd{}, args{"-m", "added merge strategy"}},
+				{cmd.CheckoutCmd{}, args{"-b", "other"}},
+				{cmd.SqlCmd{}, args{"-q", "INSERT INTO test VALUES (1,1),(2,22);"}},
+				{cmd.CommitCmd{}, args{"-am", "added rows on other"}},
+				{cmd.CheckoutCmd{}, args{"master"}},
+				{cmd.SqlCmd{}, args{"-q", "INSERT INTO test VALUES (1,11),(2,2);"}},
+				{cmd.CommitCmd{}, args{"-am", "added the same rows on master"}},
+				{cmd.MergeCmd{}, args{"other"}},
+			},
+			query: "SELECT * FROM test",
+			expected: []sql.Row{
+				{int32(1), int32(11)},
+				{int32(2), int32(22)},
+			},
+		},
+		{
+			name: "conflict on merge, deleted row resolved by merge strategy",
+			setup: []testCommand{
+				{cmd.SqlCmd{}, args{"-q", "INSERT INTO test VALUES (1,1),(2,2);"}},
+				{cmd.SqlCmd{}, args{"-q", "INSERT INTO dolt_merge_strategies VALUES ('test', '', 'theirs');"}},
+				{cmd.AddCmd{}, args{"-A"}},
+				{cmd.CommitCmd{}, args{"-m", "added rows and merge strategy"}},
+				{cmd.CheckoutCmd{}, args{"-b", "other"}},
+				{cmd.SqlCmd{}, args{"-q", "UPDATE test SET c0 = 10 WHERE pk = 1;"}},
+				{cmd.SqlCmd{}, args{"-q", "DELETE FROM test WHERE pk = 2;"}},
+				{cmd.CommitCmd{}, args{"-am", "changed rows on other"}},
+				{cmd.CheckoutCmd{}, args{"master"}},
+				{cmd.SqlCmd{}, args{"-q", "UPDATE test SET c0 = 11 WHERE pk = 1;"}},
+				{cmd.SqlCmd{}, args{"-q", "UPDATE test SET c0 = 22 WHERE pk = 2;"}},
+				{cmd.CommitCmd{}, args{"-am", "changed rows on master"}},
+				{cmd.MergeCmd{}, args{"other"}},
+			},
+			query: "SELECT * FROM test",
+			expected: []sql.Row{
+				{int32(1), int32(10)},
+			},
+		},
+		{
+			name: "schema conflict on merge",
+			setup: []testCommand{
+				{cmd.CheckoutCmd{}, args{"-b", "other"}},
+				{cmd.SqlCmd{}, args{"-q", "ALTER TABLE test MODIFY c0 int DEFAULT 1;"}},
+				{cmd.CommitCmd{}, args{"-am", "added default on other"}},
+				{cmd.CheckoutCmd{}, args{"master"}},
+				{cmd.SqlCmd{}, args{"-q", "ALTER TABLE test MODIFY c0 int DEFAULT 2;"}},
+				{cmd.CommitCmd{}, args{"-am", "added a different default on master"}},
+				{cmd.MergeCmd{}, args{"other"}},
+			},
+			query: "SELECT table_name, description FROM dolt_schema_conflicts",
+			expected: []sql.Row{
+				{"test", "different column definitions for our column c0 and their column c0"},
+			},
+		},
+		{
+			name: "schema conflict on merge, resolve with theirs",
+			setup: []testCommand{
+				{cmd.CheckoutCmd{}, args{"-b", "other"}},
+				{cmd.SqlCmd{}, args{"-q", "ALTER TABLE test MODIFY c0 int DEFAULT 1;"}},
+				{cmd.CommitCmd{}, args{"-am", "added default on other"}},
+				{cmd.CheckoutCmd{}, args{"master"}},
+				{cmd.SqlCmd{}, args{"-q", "ALTER TABLE test MODIFY c0 int DEFAULT 2;"}},
+				{cmd.CommitCmd{}, args{"-am", "added a different default on master"}},
+				{cmd.MergeCmd{}, args{"other"}},
+				{cnfcmds.ResolveCmd{}, args{"--schema", "--theirs", "test"}},
+				{cmd.SqlCmd{}, args{"-q", "INSERT INTO test (pk) VALUES (1);"}},
+			},
+			query: "SELECT * FROM test",
+			expected: []sql.Row{
+				{int32(1), int32(1)},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -102,6 +102,78 @@ func TestKeylessMerge(t *testing.T) {
 				dtu.MustTuple(cardTag, types.Uint(2), c1Tag, types.Int(5), c2Tag, types.Int(6)),
 			),
 		},
+		{
+			name: "identical parallel changes",
+			setup: []testCommand{
+				{cmd.SqlCmd{}, []string{"-q", "insert into noKey values (1,2),(1,2);"}},
+				{cmd.CommitCmd{}, []string{"-am", "added rows"}},
+				{cmd.CheckoutCmd{}, []string{"-b", "other"}},
+				{cmd.SqlCmd{}, []string{"-q", "insert into noKey values (3,4);"}},
+				{cmd.CommitCmd{}, []string{"-am", "added rows on other"}},
+				{cmd.CheckoutCmd{}, []string{"master"}},
+				{cmd.SqlCmd{}, []string{"-q", "insert into noKey values (3,4);"}},
+				{cmd.CommitCmd{}, []string{"-am", "added rows on master"}},
+				{cmd.MergeCmd{}, []string{"other"}},
+			},
+			expected: mustTupleSet(
+				dtu.MustTuple(cardTag, types.Uint(2), c1Tag, types.Int(1), c2Tag, types.Int(2)),
+				dtu.MustTuple(cardTag, types.Uint(2), c1Tag, types.Int(3), c2Tag, types.Int(4)),
+			),
+		},
+		{
+			name: "asymmetric parallel deletes",
+			setup: []testCommand{
+				{cmd.SqlCmd{}, []string{"-q", "insert into noKey values (1,2),(1,2),(1,2),(1,2);"}},
+				{cmd.CommitCmd{}, []string{"-am", "added rows"}},
+				{cmd.CheckoutCmd{}, []string{"-b", "other"}},
+				{cmd.SqlCmd{}, []string{"-q", "delete from noKey where (c1,c2) = (1,2) limit 1;"}},
+				{cmd.CommitCmd{}, []string{"-am", "deleted 1 row on other"}},
+				{cmd.CheckoutCmd{}, []string{"master"}},
+				{cmd.SqlCmd{}, []string{"-q", "delete from noKey where (c1,c2) = (1,2) limit 2;"}},
+				{cmd.CommitCmd{}, []string{"-am", "deleted 2 rows on master"}},
+				{cmd.MergeCmd{}, []string{"other"}},
+			},
+			expected: mustTupleSet(
+				dtu.MustTuple(cardTag, types.Uint(1), c1Tag, types.Int(1), c2Tag, types.Int(2)),
+			),
+		},
+		{
+			name: "asymmetric parallel updates",
+			setup: []testCommand{
+				{cmd.SqlCmd{}, []string{"-q", "insert into noKey values (1,2),(1,2),(1,2),(1,2);"}},
+				{cmd.CommitCmd{}, []string{"-am", "added rows"}},
+				{cmd.CheckoutCmd{}, []string{"-b", "other"}},
+				{cmd.SqlCmd{}, []string{"-q", "update noKey set c2 = 9 limit 1;"}},
+				{cmd.CommitCmd{}, []string{"-am", "updated 1 row on other"}},
+				{cmd.CheckoutCmd{}, []string{"master"}},
+				{cmd.SqlCmd{}, []string{"-q", "update noKey set c2 = 9 limit 2;"}},
+				{cmd.CommitCmd{}, []string{"-am", "updated 2 rows on master"}},
+				{cmd.MergeCmd{}, []string{"other"}},
+			},
+			expected: mustTupleSet(
+				dtu.MustTuple(cardTag, types.Uint(1), c1Tag, types.Int(1), c2Tag, types.Int(2)),
+				dtu.MustTuple(cardTag, types.Uint(3), c1Tag, types.Int(1), c2Tag, types.Int(9)),
+			),
+		},
+		{
+			name: "parallel deletes of every row",
+			setup: []testCommand{
+				{cmd.SqlCmd{}, []string{"-q", "insert into noKey values (1,2),(1,2),(3,4);"}},
+				{cmd.CommitCmd{}, []string{"-am", "added rows"}},
+				{cmd.CheckoutCmd{}, []string{"-b", "other"}},
+				{cmd.SqlCmd{}, []string{"-q", "delete from noKey where c1 = 1;"}},
+				{cmd.CommitCmd{}, []string{"-am", "deleted rows on other"}},
+				{cmd.CheckoutCmd{}, []string{"master"}},
+				{cmd.SqlCmd{}, []string{"-q", "delete from noKey where c1 = 1;"}},
+				{cmd.SqlCmd{}, []string{"-q", "insert into noKey values (5,6);"}},
+				{cmd.CommitCmd{}, []string{"-am", "deleted rows on master"}},
+				{cmd.MergeCmd{}, []string{"other"}},
+			},
+			expected: mustTupleSet(
+				dtu.MustTuple(cardTag, types.Uint(1), c1Tag, types.Int(3), c2Tag, types.Int(4)),
+				dtu.MustTuple(cardTag, types.Uint(1), c1Tag, types.Int(5), c2Tag, types.Int(6)),
+			),
+		},
 	}
 
 	for _, test := range tests {
@@ -147,93 +219,32 @@ func TestKeylessMergeConflicts(t *testing.T) {
 		theirsExpected tupleSet
 	}{
 		{
-			name: "identical parallel changes",
+			name: "parallel deletes of more rows than the ancestor had",
 			setup: []testCommand{
 				{cmd.SqlCmd{}, []string{"-q", "insert into noKey values (1,2),(1,2);"}},
 				{cmd.CommitCmd{}, []string{"-am", "added rows"}},
 				{cmd.CheckoutCmd{}, []string{"-b", "other"}},
-				{cmd.SqlCmd{}, []string{"-q", "insert into noKey values (3,4);"}},
-				{cmd.CommitCmd{}, []string{"-am", "added rows on other"}},
-				{cmd.CheckoutCmd{}, []string{"master"}},
-				{cmd.SqlCmd{}, []string{"-q", "insert into noKey values (3,4);"}},
-				{cmd.CommitCmd{}, []string{"-am", "added rows on master"}},
-				{cmd.MergeCmd{}, []string{"other"}},
-			},
-			conflicts: mustTupleSet(
-				dtu.MustTuple(
-					types.NullValue,
-					dtu.MustTuple(cardTag, types.Uint(1), c1Tag, types.Int(3), c2Tag, types.Int(4)),
-					dtu.MustTuple(cardTag, types.Uint(1), c1Tag, types.Int(3), c2Tag, types.Int(4)),
-				),
-			),
-			oursExpected: mustTupleSet(
-				dtu.MustTuple(cardTag, types.Uint(2), c1Tag, types.Int(1), c2Tag, types.Int(2)),
-				dtu.MustTuple(cardTag, types.Uint(1), c1Tag, types.Int(3), c2Tag, types.Int(4)),
-			),
-			theirsExpected: mustTupleSet(
-				dtu.MustTuple(cardTag, types.Uint(2), c1Tag, types.Int(1), c2Tag, types.Int(2)),
-				dtu.MustTuple(cardTag, types.Uint(1), c1Tag, types.Int(3), c2Tag, types.Int(4)),
-			),
-		},
-		{
-			name: "asymmetric parallel deletes",
-			setup: []testCommand{
-				{cmd.SqlCmd{}, []string{"-q", "insert into noKey values (1,2),(1,2),(1,2),(1,2);"}},
-				{cmd.CommitCmd{}, []string{"-am", "added rows"}},
-				{cmd.CheckoutCmd{}, []string{"-b", "other"}},
 				{cmd.SqlCmd{}, []string{"-q", "delete from noKey where (c1,c2) = (1,2) limit 1;"}},
 				{cmd.CommitCmd{}, []string{"-am", "deleted 1 row on other"}},
 				{cmd.CheckoutCmd{}, []string{"master"}},
 				{cmd.SqlCmd{}, []string{"-q", "delete from noKey where (c1,c2) = (1,2) limit 2;"}},
+				{cmd.SqlCmd{}, []string{"-q", "insert into noKey values (3,4);"}},
 				{cmd.CommitCmd{}, []string{"-am", "deleted 2 rows on master"}},
 				{cmd.MergeCmd{}, []string{"other"}},
 			},
 			conflicts: mustTupleSet(
 				dtu.MustTuple(
-					dtu.MustTuple(cardTag, types.Uint(4), c1Tag, types.Int(1), c2Tag, types.Int(2)),
-					dtu.MustTuple(cardTag, types.Uint(2), c1Tag, types.Int(1), c2Tag, types.Int(2)),
-					dtu.MustTuple(cardTag, types.Uint(3), c1Tag, types.Int(1), c2Tag, types.Int(2)),
-				),
-			),
-			oursExpected: mustTupleSet(
-				dtu.MustTuple(cardTag, types.Uint(2), c1Tag, types.Int(1), c2Tag, types.Int(2)),
-			),
-			theirsExpected: mustTupleSet(
-				dtu.MustTuple(cardTag, types.Uint(3), c1Tag, types.Int(1), c2Tag, types.Int(2)),
-			),
-		},
-		{
-			name: "asymmetric parallel updates",
-			setup: []testCommand{
-				{cmd.SqlCmd{}, []string{"-q", "insert into noKey values (1,2),(1,2),(1,2),(1,2);"}},
-				{cmd.CommitCmd{}, []string{"-am", "added rows"}},
-				{cmd.CheckoutCmd{}, []string{"-b", "other"}},
-				{cmd.SqlCmd{}, []string{"-q", "update noKey set c2 = 9 limit 1;"}},
-				{cmd.CommitCmd{}, []string{"-am", "deleted 1 row on other"}},
-				{cmd.CheckoutCmd{}, []string{"master"}},
-				{cmd.SqlCmd{}, []string{"-q", "update noKey set c2 = 9 limit 2;"}},
-				{cmd.CommitCmd{}, []string{"-am", "deleted 2 rows on master"}},
-				{cmd.MergeCmd{}, []string{"other"}},
-			},
-			conflicts: mustTupleSet(
-				dtu.MustTuple(
-					dtu.MustTuple(cardTag, types.Uint(4), c1Tag, types.Int(1), c2Tag, types.Int(2)),
 					dtu.MustTuple(cardTag, types.Uint(2), c1Tag, types.Int(1), c2Tag, types.Int(2)),
-					dtu.MustTuple(cardTag, types.Uint(3), c1Tag, types.Int(1), c2Tag, types.Int(2)),
-				),
-				dtu.MustTuple(
 					types.NullValue,
-					dtu.MustTuple(cardTag, types.Uint(2), c1Tag, types.Int(1), c2Tag, types.Int(9)),
-					dtu.MustTuple(cardTag, types.Uint(1), c1Tag, types.Int(1), c2Tag, types.Int(9)),
+					dtu.MustTuple(cardTag, types.Uint(1), c1Tag, types.Int(1), c2Tag, types.Int(2)),
 				),
 			),
 			oursExpected: mustTupleSet(
-				dtu.MustTuple(cardTag, types.Uint(2), c1Tag, types.Int(1), c2Tag, types.Int(2)),
-				dtu.MustTuple(cardTag, types.Uint(2), c1Tag, types.Int(1), c2Tag, types.Int(9)),
+				dtu.MustTuple(cardTag, types.Uint(1), c1Tag, types.Int(3), c2Tag, types.Int(4)),
 			),
 			theirsExpected: mustTupleSet(
-				dtu.MustTuple(cardTag, types.Uint(3), c1Tag, types.Int(1), c2Tag, types.Int(2)),
-				dtu.MustTuple(cardTag, types.Uint(1), c1Tag, types.Int(1), c2Tag, types.Int(9)),
+				dtu.MustTuple(cardTag, types.Uint(1), c1Tag, types.Int(1), c2Tag, types.Int(2)),
+				dtu.MustTuple(cardTag, types.Uint(1), c1Tag, types.Int(3), c2Tag, types.Int(4)),
 			),
 		},
 	}
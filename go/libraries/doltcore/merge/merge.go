@@ -28,6 +28,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/rowconv"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	json2 "github.com/dolthub/dolt/go/libraries/doltcore/sqle/json"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
@@ -39,17 +40,37 @@ import (
 
 var ErrFastForward = errors.New("fast forward")
 var ErrSameTblAddedTwice = errors.New("table with same name added in 2 commits can't be merged")
+var ErrTableDeletedAndModified = errors.New("conflict: table with same name deleted and modified")
+var ErrOctopusMergeConflict = errors.New("octopus merge conflict")
 
 type Merger struct {
-	root      *doltdb.RootValue
-	mergeRoot *doltdb.RootValue
-	ancRoot   *doltdb.RootValue
-	vrw       types.ValueReadWriter
+	root       *doltdb.RootValue
+	mergeRoot  *doltdb.RootValue
+	ancRoot    *doltdb.RootValue
+	vrw        types.ValueReadWriter
+	strategies MergeStrategies
+	// recordSchemaConflicts is true when tables whose schemas can't be merged should be left unmerged and counted in
+	// their MergeStats, rather than failing the merge.
+	recordSchemaConflicts bool
 }
 
 // NewMerger creates a new merger utility object.
 func NewMerger(ctx context.Context, root, mergeRoot, ancRoot *doltdb.RootValue, vrw types.ValueReadWriter) *Merger {
-	return &Merger{root, mergeRoot, ancRoot, vrw}
+	return &Merger{root: root, mergeRoot: mergeRoot, ancRoot: ancRoot, vrw: vrw}
+}
+
+// tableResolver returns the resolver for the merge strategies set on the table named in our root, or nil if there
+// aren't any.
+func (merger *Merger) tableResolver(ctx context.Context, tblName string, sch schema.Schema) (*tableResolver, error) {
+	if merger.strategies == nil {
+		strategies, err := LoadMergeStrategies(ctx, merger.root)
+		if err != nil {
+			return nil, err
+		}
+		merger.strategies = strategies
+	}
+
+	return merger.strategies.newTableResolver(tblName, sch)
 }
 
 // MergeTable merges schema and table data for the table tblName.
@@ -142,6 +163,10 @@ func (merger *Merger) MergeTable(ctx context.Context, tblName string, sess *edit
 			}
 		}
 
+		if (!ok || !mergeOk) && h != anch && mh != anch {
+			return nil, nil, ErrTableDeletedAndModified
+		}
+
 		if h == anch {
 			// fast-forward
 			ms := MergeStats{Operation: TableModified}
@@ -168,7 +193,10 @@ func (merger *Merger) MergeTable(ctx context.Context, tblName string, sess *edit
 		return nil, nil, err
 	}
 	if schConflicts.Count() != 0 {
-		// error on schema conflicts for now
+		if merger.recordSchemaConflicts {
+			// the table keeps our version until the conflicts are resolved
+			return tbl, &MergeStats{Operation: TableModified, SchemaConflicts: schConflicts.Count()}, nil
+		}
 		return nil, nil, schConflicts.AsError()
 	}
 
@@ -182,18 +210,40 @@ func (merger *Merger) MergeTable(ctx context.Context, tblName string, sess *edit
 		return nil, nil, err
 	}
 
+	// Rows from each side are converted to the merged schema so that columns whose types were widened compare equal.
+	rows, rowsConverted, err := convertRowsToSchema(ctx, merger.vrw, rows, tblSchema, postMergeSchema)
+	if err != nil {
+		return nil, nil, err
+	}
+	mergeRows, _, err = convertRowsToSchema(ctx, merger.vrw, mergeRows, mergeTblSchema, postMergeSchema)
+	if err != nil {
+		return nil, nil, err
+	}
+	ancRows, _, err = convertRowsToSchema(ctx, merger.vrw, ancRows, ancTblSchema, postMergeSchema)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	updatedTbl, err := tbl.UpdateSchema(ctx, postMergeSchema)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	// If any indexes were added during the merge, then we need to generate their row data to add to our updated table.
+	// When our rows were converted then every index is rebuilt from the converted rows.
 	addedIndexesSet := make(map[string]string)
 	for _, index := range postMergeSchema.Indexes().AllIndexes() {
 		addedIndexesSet[strings.ToLower(index.Name())] = index.Name()
 	}
-	for _, index := range tblSchema.Indexes().AllIndexes() {
-		delete(addedIndexesSet, strings.ToLower(index.Name()))
+	if rowsConverted {
+		updatedTbl, err = updatedTbl.UpdateRows(ctx, rows)
+		if err != nil {
+			return nil, nil, err
+		}
+	} else {
+		for _, index := range tblSchema.Indexes().AllIndexes() {
+			delete(addedIndexesSet, strings.ToLower(index.Name()))
+		}
 	}
 	for _, addedIndex := range addedIndexesSet {
 		newIndexData, err := editor.RebuildIndex(ctx, updatedTbl, addedIndex)
@@ -218,7 +268,12 @@ func (merger *Merger) MergeTable(ctx context.Context, tblName string, sess *edit
 		return nil, nil, err
 	}
 
-	resultTbl, conflicts, stats, err := mergeTableData(ctx, merger.vrw, tblName, postMergeSchema, rows, mergeRows, ancRows, updatedTblEditor, sess)
+	resolver, err := merger.tableResolver(ctx, tblName, postMergeSchema)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resultTbl, conflicts, stats, err := mergeTableData(ctx, merger.vrw, tblName, postMergeSchema, rows, mergeRows, ancRows, updatedTblEditor, sess, resolver)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -255,6 +310,80 @@ func (merger *Merger) MergeTable(ctx context.Context, tblName string, sess *edit
 	return resultTbl, stats, nil
 }
 
+// convertRowsToSchema converts |rows|, which have the schema |sch|, to the schema |mergedSch| produced by a schema merge.
+// Rows are only converted when the merge changed the type of one of their columns, and the returned bool reports
+// whether they were. The id of a keyless row is the hash of its values, so it changes along with them, but its
+// cardinality is kept.
+func convertRowsToSchema(ctx context.Context, vrw types.ValueReadWriter, rows types.Map, sch, mergedSch schema.Schema) (types.Map, bool, error) {
+	if rows.Len() == 0 {
+		return rows, false, nil
+	}
+
+	typeChanged := false
+	_ = sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		mergedCol, ok := mergedSch.GetAllCols().GetByTag(tag)
+		typeChanged = ok && !col.TypeInfo.Equals(mergedCol.TypeInfo)
+		return typeChanged, nil
+	})
+	if !typeChanged {
+		return rows, false, nil
+	}
+
+	mapping, err := rowconv.TagMapping(sch, mergedSch)
+	if err != nil {
+		return types.EmptyMap, false, err
+	}
+	rc, err := rowconv.NewRowConverter(ctx, vrw, mapping)
+	if err != nil {
+		return types.EmptyMap, false, err
+	}
+
+	converted, err := types.NewMap(ctx, vrw)
+	if err != nil {
+		return types.EmptyMap, false, err
+	}
+	me := converted.Edit()
+	err = rows.Iter(ctx, func(key, value types.Value) (stop bool, err error) {
+		r, err := row.FromNoms(sch, key.(types.Tuple), value.(types.Tuple))
+		if err != nil {
+			return true, err
+		}
+		r, err = rc.Convert(r)
+		if err != nil {
+			return true, err
+		}
+
+		if !schema.IsKeyless(sch) {
+			me.Set(r.NomsMapKey(mergedSch), r.NomsMapValue(mergedSch))
+			return false, nil
+		}
+
+		k, v, err := row.ToNoms(ctx, mergedSch, r)
+		if err != nil {
+			return true, err
+		}
+		card, err := value.(types.Tuple).Get(row.KeylessCardinalityValIdx)
+		if err != nil {
+			return true, err
+		}
+		v, err = v.Set(row.KeylessCardinalityValIdx, card)
+		if err != nil {
+			return true, err
+		}
+		me.Set(k, v)
+		return false, nil
+	})
+	if err != nil {
+		return types.EmptyMap, false, err
+	}
+
+	converted, err = me.Map(ctx)
+	if err != nil {
+		return types.EmptyMap, false, err
+	}
+	return converted, true, nil
+}
+
 func calcTableMergeStats(ctx context.Context, tbl *doltdb.Table, mergeTbl *doltdb.Table) (MergeStats, error) {
 	rows, err := tbl.GetRowData(ctx)
 
@@ -295,11 +424,11 @@ func calcTableMergeStats(ctx context.Context, tbl *doltdb.Table, mergeTbl *doltd
 	return ms, nil
 }
 
-type rowMerger func(ctx context.Context, nbf *types.NomsBinFormat, sch schema.Schema, r, mergeRow, baseRow types.Value) (types.Value, bool, error)
+type rowMerger func(ctx context.Context, nbf *types.NomsBinFormat, sch schema.Schema, r, mergeRow, baseRow types.Value, resolver *tableResolver) (types.Value, bool, error)
 
 type applicator func(ctx context.Context, sch schema.Schema, tableEditor editor.TableEditor, rowData types.Map, stats *MergeStats, change types.ValueChanged) error
 
-func mergeTableData(ctx context.Context, vrw types.ValueReadWriter, tblName string, sch schema.Schema, rows, mergeRows, ancRows types.Map, tblEdit editor.TableEditor, sess *editor.TableEditSession) (*doltdb.Table, types.Map, *MergeStats, error) {
+func mergeTableData(ctx context.Context, vrw types.ValueReadWriter, tblName string, sch schema.Schema, rows, mergeRows, ancRows types.Map, tblEdit editor.TableEditor, sess *editor.TableEditSession, resolver *tableResolver) (*doltdb.Table, types.Map, *MergeStats, error) {
 	var rowMerge rowMerger
 	var applyChange applicator
 	if schema.IsKeyless(sch) {
@@ -401,7 +530,7 @@ func mergeTableData(ctx context.Context, vrw types.ValueReadWriter, tblName stri
 
 			if !processed {
 				r, mergeRow, ancRow := change.NewValue, mergeChange.NewValue, change.OldValue
-				mergedRow, isConflict, err := rowMerge(ctx, vrw.Format(), sch, r, mergeRow, ancRow)
+				mergedRow, isConflict, err := rowMerge(ctx, vrw.Format(), sch, r, mergeRow, ancRow, resolver)
 				if err != nil {
 					return err
 				}
@@ -419,9 +548,21 @@ func mergeTableData(ctx context.Context, vrw types.ValueReadWriter, tblName stri
 					}
 				} else {
 					vc := types.ValueChanged{ChangeType: change.ChangeType, Key: key, OldValue: ancRow, NewValue: mergedRow}
-					err = applyChange(ctx, sch, tblEdit, rows, stats, vc)
-					if err != nil {
-						return err
+					if schema.IsKeyless(sch) {
+						vc = keylessMergedChange(key, r, mergedRow)
+					} else if mergedRow == nil && r != nil {
+						// a row we modified was resolved to their deletion
+						vc = types.ValueChanged{ChangeType: types.DiffChangeRemoved, Key: key, OldValue: r}
+					} else if mergedRow != nil && r == nil {
+						// a row we deleted was resolved to their modification
+						vc = types.ValueChanged{ChangeType: types.DiffChangeAdded, Key: key, NewValue: mergedRow}
+					}
+
+					if vc.Key != nil {
+						err = applyChange(ctx, sch, tblEdit, rows, stats, vc)
+						if err != nil {
+							return err
+						}
 					}
 				}
 
@@ -685,7 +826,7 @@ func convertValueChanged(vc types.ValueChanged) (types.ValueChanged, uint64, err
 	}
 }
 
-func pkRowMerge(ctx context.Context, nbf *types.NomsBinFormat, sch schema.Schema, r, mergeRow, baseRow types.Value) (types.Value, bool, error) {
+func pkRowMerge(ctx context.Context, nbf *types.NomsBinFormat, sch schema.Schema, r, mergeRow, baseRow types.Value, resolver *tableResolver) (types.Value, bool, error) {
 	var baseVals row.TaggedValues
 	if baseRow == nil {
 		if r.Equals(mergeRow) {
@@ -697,6 +838,9 @@ func pkRowMerge(ctx context.Context, nbf *types.NomsBinFormat, sch schema.Schema
 		return nil, false, nil
 	} else if r == nil || mergeRow == nil {
 		// removed from one and modified in another
+		if resolved, ok := resolver.resolveRow(r, mergeRow); ok {
+			return resolved, false, nil
+		}
 		return nil, true, nil
 	} else {
 		var err error
@@ -717,23 +861,34 @@ func pkRowMerge(ctx context.Context, nbf *types.NomsBinFormat, sch schema.Schema
 		return nil, false, err
 	}
 
-	processTagFunc := func(tag uint64) (resultVal types.Value, isConflict bool) {
+	processTagFunc := func(col schema.Column) (resultVal types.Value, isConflict bool, err error) {
+		tag := col.Tag
 		baseVal, _ := baseVals.Get(tag)
 		val, _ := rowVals.Get(tag)
 		mergeVal, _ := mergeVals.Get(tag)
 
 		if valutil.NilSafeEqCheck(val, mergeVal) {
-			return val, false
+			return val, false, nil
 		} else {
 			modified := !valutil.NilSafeEqCheck(val, baseVal)
 			mergeModified := !valutil.NilSafeEqCheck(mergeVal, baseVal)
 			switch {
 			case modified && mergeModified:
-				return nil, true
+				c := CellConflict{
+					Column:   col,
+					Base:     baseVal,
+					Ours:     val,
+					Theirs:   mergeVal,
+					BaseRow:  baseVals,
+					OurRow:   rowVals,
+					TheirRow: mergeVals,
+				}
+				resolved, ok, err := resolver.resolveCell(ctx, nbf, c)
+				return resolved, !ok, err
 			case modified:
-				return val, false
+				return val, false, nil
 			default:
-				return mergeVal, false
+				return mergeVal, false, nil
 			}
 		}
 
@@ -742,12 +897,12 @@ func pkRowMerge(ctx context.Context, nbf *types.NomsBinFormat, sch schema.Schema
 	resultVals := make(row.TaggedValues)
 
 	var isConflict bool
-	err = sch.GetNonPKCols().Iter(func(tag uint64, _ schema.Column) (stop bool, err error) {
+	err = sch.GetNonPKCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
 		var val types.Value
-		val, isConflict = processTagFunc(tag)
+		val, isConflict, err = processTagFunc(col)
 		resultVals[tag] = val
 
-		return isConflict, nil
+		return isConflict, err
 	})
 
 	if err != nil {
@@ -768,10 +923,76 @@ func pkRowMerge(ctx context.Context, nbf *types.NomsBinFormat, sch schema.Schema
 	return v, false, nil
 }
 
-func keylessRowMerge(ctx context.Context, nbf *types.NomsBinFormat, sch schema.Schema, val, mergeVal, ancVal types.Value) (types.Value, bool, error) {
-	// both sides of the merge produced a diff for this key,
-	// so we always throw a conflict
-	return nil, true, nil
+// keylessRowMerge merges the cardinalities of a row that both sides of the merge changed. Each side's change is its
+// cardinality less the ancestor's, so the merged cardinality is the ancestor's plus both changes. It is a conflict if
+// the two sides together removed more copies of the row than the ancestor had.
+func keylessRowMerge(ctx context.Context, nbf *types.NomsBinFormat, sch schema.Schema, val, mergeVal, ancVal types.Value, _ *tableResolver) (types.Value, bool, error) {
+	if val == nil && mergeVal == nil {
+		// both sides deleted every copy of the row
+		return nil, false, nil
+	}
+
+	card, err := keylessCardinality(val)
+	if err != nil {
+		return nil, false, err
+	}
+	mergeCard, err := keylessCardinality(mergeVal)
+	if err != nil {
+		return nil, false, err
+	}
+	ancCard, err := keylessCardinality(ancVal)
+	if err != nil {
+		return nil, false, err
+	}
+
+	merged := int64(card) + int64(mergeCard) - int64(ancCard)
+	if merged < 0 {
+		return nil, true, nil
+	} else if merged == 0 {
+		return nil, false, nil
+	}
+
+	tup := val
+	if tup == nil {
+		tup = mergeVal
+	}
+	mergedVal, err := tup.(types.Tuple).Set(row.KeylessCardinalityValIdx, types.Uint(merged))
+	if err != nil {
+		return nil, false, err
+	}
+
+	return mergedVal, false, nil
+}
+
+// keylessMergedChange returns the change from our keyless row |r| to the merged row |mergedRow|, either of which may
+// be nil. The change is empty if the two are the same.
+func keylessMergedChange(key, r, mergedRow types.Value) types.ValueChanged {
+	switch {
+	case r == nil && mergedRow == nil:
+		return types.ValueChanged{}
+	case r == nil:
+		return types.ValueChanged{ChangeType: types.DiffChangeAdded, Key: key, NewValue: mergedRow}
+	case mergedRow == nil:
+		return types.ValueChanged{ChangeType: types.DiffChangeRemoved, Key: key, OldValue: r}
+	case r.Equals(mergedRow):
+		return types.ValueChanged{}
+	default:
+		return types.ValueChanged{ChangeType: types.DiffChangeModified, Key: key, OldValue: r, NewValue: mergedRow}
+	}
+}
+
+// keylessCardinality returns the cardinality of the keyless row value |val|, which is zero if |val| is nil
+func keylessCardinality(val types.Value) (uint64, error) {
+	if val == nil {
+		return 0, nil
+	}
+
+	v, err := val.(types.Tuple).Get(row.KeylessCardinalityValIdx)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(v.(types.Uint)), nil
 }
 
 func mergeAutoIncrementValues(ctx context.Context, tbl, otherTbl, resultTbl *doltdb.Table) (*doltdb.Table, error) {
@@ -809,8 +1030,12 @@ func mergeAutoIncrementValues(ctx context.Context, tbl, otherTbl, resultTbl *dol
 	return resultTbl.SetAutoIncrementValue(autoVal)
 }
 
+// MergeCommits merges |mergeCommit| into |commit|. When criss-cross merges have left the two commits with more than one
+// best common ancestor, the ancestors are first merged into a virtual ancestor that the commits are merged against.
+// Tables whose schemas can't be merged keep our version and are reported with SchemaConflicts in their MergeStats, to be
+// recorded in the merge state of the working set.
 func MergeCommits(ctx context.Context, commit, mergeCommit *doltdb.Commit) (*doltdb.RootValue, map[string]*MergeStats, error) {
-	ancCommit, err := doltdb.GetCommitAncestor(ctx, commit, mergeCommit)
+	ancRoot, err := mergeBaseRoot(ctx, mergeCommit, commit)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -825,16 +1050,140 @@ func MergeCommits(ctx context.Context, commit, mergeCommit *doltdb.Commit) (*dol
 		return nil, nil, err
 	}
 
-	ancRoot, err := ancCommit.GetRootValue()
+	return mergeRoots(ctx, ourRoot, theirRoot, ancRoot, true)
+}
+
+// OctopusMergeCommits merges each of |mergeCommits| into |commit| in turn, each against its best common ancestors with
+// |commit| and the commits merged before it. Octopus merges don't record conflicts: if any of the merges conflicts,
+// ErrOctopusMergeConflict is returned and the commits must be merged one at a time instead.
+func OctopusMergeCommits(ctx context.Context, commit *doltdb.Commit, mergeCommits ...*doltdb.Commit) (*doltdb.RootValue, map[string]*MergeStats, error) {
+	root, err := commit.GetRootValue()
 	if err != nil {
 		return nil, nil, err
 	}
 
-	return MergeRoots(ctx, ourRoot, theirRoot, ancRoot)
+	tblToStats := make(map[string]*MergeStats)
+	merged := []*doltdb.Commit{commit}
+	for _, mergeCommit := range mergeCommits {
+		ancRoot, err := mergeBaseRoot(ctx, mergeCommit, merged...)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		theirRoot, err := mergeCommit.GetRootValue()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var stats map[string]*MergeStats
+		root, stats, err = MergeRoots(ctx, root, theirRoot, ancRoot)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for tblName, st := range stats {
+			if st.Conflicts > 0 {
+				h, err := mergeCommit.HashOf()
+				if err != nil {
+					return nil, nil, err
+				}
+				return nil, nil, fmt.Errorf("%w: merging %s conflicts in table '%s'", ErrOctopusMergeConflict, h.String(), tblName)
+			}
+			tblToStats[tblName] = addMergeStats(tblToStats[tblName], st)
+		}
+
+		merged = append(merged, mergeCommit)
+	}
+
+	return root, tblToStats, nil
+}
+
+// addMergeStats returns the stats of a table merged by |prev| and then by |next|. |prev| is nil if the table wasn't
+// merged before.
+func addMergeStats(prev, next *MergeStats) *MergeStats {
+	if prev == nil || prev.Operation == TableUnmodified {
+		return next
+	} else if next.Operation == TableUnmodified {
+		return prev
+	}
+
+	return &MergeStats{
+		Operation:            next.Operation,
+		Adds:                 prev.Adds + next.Adds,
+		Deletes:              prev.Deletes + next.Deletes,
+		Modifications:        prev.Modifications + next.Modifications,
+		ConstraintViolations: prev.ConstraintViolations + next.ConstraintViolations,
+	}
 }
 
+// mergeBaseRoot returns the root to merge |commit| into |ours| against, which is the root of their best common
+// ancestor. If criss-cross merges left more than one, it is a virtual ancestor made by merging them together,
+// recursively using their own best common ancestors. Rows that conflict in the virtual ancestor keep the values of
+// the ancestors merged first. If the ancestors can't be merged, because of schema conflicts or a table added to more
+// than one of them, the first of them is used alone.
+func mergeBaseRoot(ctx context.Context, commit *doltdb.Commit, ours ...*doltdb.Commit) (*doltdb.RootValue, error) {
+	bases, err := doltdb.GetCommitMergeBases(ctx, commit, ours...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bases) > 1 {
+		root, ok, err := virtualMergeBaseRoot(ctx, bases)
+		if err != nil {
+			return nil, err
+		} else if ok {
+			return root, nil
+		}
+	}
+
+	return bases[0].GetRootValue()
+}
+
+// virtualMergeBaseRoot merges the roots of |bases| together in order, and returns false if they can't be merged.
+func virtualMergeBaseRoot(ctx context.Context, bases []*doltdb.Commit) (*doltdb.RootValue, bool, error) {
+	root, err := bases[0].GetRootValue()
+	if err != nil {
+		return nil, false, err
+	}
+
+	for i := 1; i < len(bases); i++ {
+		ancRoot, err := mergeBaseRoot(ctx, bases[i], bases[:i]...)
+		if err != nil {
+			return nil, false, err
+		}
+
+		baseRoot, err := bases[i].GetRootValue()
+		if err != nil {
+			return nil, false, err
+		}
+
+		var stats map[string]*MergeStats
+		root, stats, err = mergeRoots(ctx, root, baseRoot, ancRoot, true)
+		if errors.Is(err, ErrSameTblAddedTwice) {
+			return nil, false, nil
+		} else if err != nil {
+			return nil, false, err
+		}
+
+		for _, st := range stats {
+			if st.SchemaConflicts > 0 {
+				return nil, false, nil
+			}
+		}
+	}
+
+	return root, true, nil
+}
+
+// MergeRoots merges |theirRoot| into |ourRoot| against their common ancestor |ancRoot|. Any schema conflicts fail the
+// merge.
 func MergeRoots(ctx context.Context, ourRoot, theirRoot, ancRoot *doltdb.RootValue) (*doltdb.RootValue, map[string]*MergeStats, error) {
+	return mergeRoots(ctx, ourRoot, theirRoot, ancRoot, false)
+}
+
+func mergeRoots(ctx context.Context, ourRoot, theirRoot, ancRoot *doltdb.RootValue, recordSchemaConflicts bool) (*doltdb.RootValue, map[string]*MergeStats, error) {
 	merger := NewMerger(ctx, ourRoot, theirRoot, ancRoot, ourRoot.VRW())
+	merger.recordSchemaConflicts = recordSchemaConflicts
 
 	tblNames, err := doltdb.UnionTableNames(ctx, ourRoot, theirRoot)
 
@@ -30,3 +30,22 @@ func MergeBase(ctx context.Context, left, right *doltdb.Commit) (base hash.Hash,
 
 	return ancestor.HashOf()
 }
+
+// MergeBases returns the hashes of all the best common ancestors of |left| and |right|, which are the common ancestors
+// that aren't ancestors of other common ancestors. There is more than one when history has criss-cross merges.
+func MergeBases(ctx context.Context, left, right *doltdb.Commit) ([]hash.Hash, error) {
+	bases, err := doltdb.GetCommitMergeBases(ctx, left, right)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]hash.Hash, len(bases))
+	for i, base := range bases {
+		hashes[i], err = base.HashOf()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return hashes, nil
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
 )
 
 type conflictKind byte
@@ -75,6 +76,12 @@ type IdxConflict struct {
 }
 
 func (c IdxConflict) String() string {
+	switch c.Kind {
+	case NameCollision:
+		return fmt.Sprintf("two indexes with the name '%s'", c.Ours.Name())
+	case TagCollision:
+		return fmt.Sprintf("different index definitions for our index %s and their index %s", c.Ours.Name(), c.Theirs.Name())
+	}
 	return ""
 }
 
@@ -240,14 +247,24 @@ func columnsInCommon(ourCC, theirCC, ancCC *schema.ColCollection) (common *schem
 			return false, nil
 		}
 
-		if ourCol.Equals(theirCol) {
-			common = common.Append(ourCol)
+		ancCol, ok := ancCC.GetByTag(ourCol.Tag)
+		if !ok {
+			if ourCol.Equals(theirCol) {
+				common = common.Append(ourCol)
+			} else {
+				// col added on our branch and their branch with different def
+				conflicts = append(conflicts, ColConflict{
+					Kind:   TagCollision,
+					Ours:   ourCol,
+					Theirs: theirCol,
+				})
+			}
 			return false, nil
 		}
 
-		ancCol, ok := ancCC.GetByTag(ourCol.Tag)
+		mergedCol, ok := mergeColumn(ourCol, theirCol, ancCol)
 		if !ok {
-			// col added on our branch and their branch with different def
+			// col modified on our branch and their branch with different def
 			conflicts = append(conflicts, ColConflict{
 				Kind:   TagCollision,
 				Ours:   ourCol,
@@ -256,48 +273,95 @@ func columnsInCommon(ourCC, theirCC, ancCC *schema.ColCollection) (common *schem
 			return false, nil
 		}
 
-		if ancCol.Equals(theirCol) {
-			// col modified on our branch
-			col, ok := common.GetByNameCaseInsensitive(ourCol.Name)
+		if !mergedCol.Equals(ancCol) {
+			col, ok := common.GetByNameCaseInsensitive(mergedCol.Name)
 			if ok {
 				conflicts = append(conflicts, ColConflict{
 					Kind:   NameCollision,
-					Ours:   ourCol,
+					Ours:   mergedCol,
 					Theirs: col,
 				})
-			} else {
-				common = common.Append(ourCol)
-			}
-			return false, nil
-		}
-
-		if ancCol.Equals(ourCol) {
-			// col modified on their branch
-			col, ok := common.GetByNameCaseInsensitive(theirCol.Name)
-			if ok {
-				conflicts = append(conflicts, ColConflict{
-					Kind:   NameCollision,
-					Ours:   col,
-					Theirs: theirCol,
-				})
-			} else {
-				common = common.Append(theirCol)
+				return false, nil
 			}
-			return false, nil
 		}
 
-		// col modified on our branch and their branch with different def
-		conflicts = append(conflicts, ColConflict{
-			Kind:   TagCollision,
-			Ours:   ourCol,
-			Theirs: theirCol,
-		})
+		common = common.Append(mergedCol)
 		return false, nil
 	})
 
 	return common, conflicts
 }
 
+// mergeColumn merges the changes made to the column |anc| on each branch. The name, type, default, comment, auto
+// increment flag and constraints of the column are merged independently, so a rename on one branch combines with a
+// type change on the other. A property changed differently on both branches is a conflict, unless it is the type and
+// one branch's type widens the other's, in which case the wider type wins.
+func mergeColumn(ours, theirs, anc schema.Column) (schema.Column, bool) {
+	merged := anc
+	ok := true
+
+	merged.Name, ok = mergeColumnProperty(ours.Name, theirs.Name, anc.Name, ok)
+	merged.Default, ok = mergeColumnProperty(ours.Default, theirs.Default, anc.Default, ok)
+	merged.Comment, ok = mergeColumnProperty(ours.Comment, theirs.Comment, anc.Comment, ok)
+
+	if ours.IsPartOfPK != theirs.IsPartOfPK {
+		return schema.Column{}, false
+	}
+	merged.IsPartOfPK = ours.IsPartOfPK
+
+	switch {
+	case ours.AutoIncrement == theirs.AutoIncrement || theirs.AutoIncrement == anc.AutoIncrement:
+		merged.AutoIncrement = ours.AutoIncrement
+	case ours.AutoIncrement == anc.AutoIncrement:
+		merged.AutoIncrement = theirs.AutoIncrement
+	default:
+		ok = false
+	}
+
+	switch {
+	case schema.ColConstraintsAreEqual(ours.Constraints, theirs.Constraints) || schema.ColConstraintsAreEqual(theirs.Constraints, anc.Constraints):
+		merged.Constraints = ours.Constraints
+	case schema.ColConstraintsAreEqual(ours.Constraints, anc.Constraints):
+		merged.Constraints = theirs.Constraints
+	default:
+		ok = false
+	}
+
+	switch {
+	case ours.TypeInfo.Equals(theirs.TypeInfo) || theirs.TypeInfo.Equals(anc.TypeInfo):
+		merged.TypeInfo = ours.TypeInfo
+	case ours.TypeInfo.Equals(anc.TypeInfo):
+		merged.TypeInfo = theirs.TypeInfo
+	case typeinfo.IsWidening(ours.TypeInfo, theirs.TypeInfo):
+		merged.TypeInfo = theirs.TypeInfo
+	case typeinfo.IsWidening(theirs.TypeInfo, ours.TypeInfo):
+		merged.TypeInfo = ours.TypeInfo
+	default:
+		ok = false
+	}
+	merged.Kind = merged.TypeInfo.NomsKind()
+
+	if !ok {
+		return schema.Column{}, false
+	}
+	return merged, true
+}
+
+// mergeColumnProperty returns the result of merging the changes to a single column property on each branch, and
+// whether the merge succeeded. It fails if |ok| is already false.
+func mergeColumnProperty(ours, theirs, anc string, ok bool) (string, bool) {
+	switch {
+	case !ok:
+		return "", false
+	case ours == theirs || theirs == anc:
+		return ours, true
+	case ours == anc:
+		return theirs, true
+	default:
+		return "", false
+	}
+}
+
 // assumes indexes are unique over their column sets
 func mergeIndexes(mergedCC *schema.ColCollection, ourSch, theirSch, ancSch schema.Schema) (merged schema.IndexCollection, conflicts []IdxConflict) {
 	merged, conflicts = indexesInCommon(mergedCC, ourSch.Indexes(), theirSch.Indexes(), ancSch.Indexes())
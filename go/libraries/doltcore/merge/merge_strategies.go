@@ -0,0 +1,157 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// MergeStrategiesTableSchema returns the schema of the dolt_merge_strategies table. Each of its rows sets the
+// strategy that resolves conflicts in a column of a table, or in every column of the table if the column name is empty.
+func MergeStrategiesTableSchema() schema.Schema {
+	colColl := schema.NewColCollection(
+		schema.NewColumn(doltdb.MergeStrategiesTableNameCol, schema.MergeStrategiesTableNameTag, types.StringKind, true, schema.NotNullConstraint{}),
+		schema.NewColumn(doltdb.MergeStrategiesColumnNameCol, schema.MergeStrategiesColumnNameTag, types.StringKind, true, schema.NotNullConstraint{}),
+		schema.NewColumn(doltdb.MergeStrategiesStrategyCol, schema.MergeStrategiesStrategyTag, types.StringKind, false, schema.NotNullConstraint{}),
+	)
+	return schema.MustSchemaFromCols(colColl)
+}
+
+// MergeStrategies are the strategies set in the dolt_merge_strategies table of a root, keyed by lower case table
+// name and then by lower case column name. The strategy for a whole table has an empty column name.
+type MergeStrategies map[string]map[string]string
+
+// LoadMergeStrategies returns the strategies set in the dolt_merge_strategies table of |root|, which are empty if it
+// doesn't have the table.
+func LoadMergeStrategies(ctx context.Context, root *doltdb.RootValue) (MergeStrategies, error) {
+	strategies := make(MergeStrategies)
+
+	tbl, ok, err := root.GetTable(ctx, doltdb.MergeStrategiesTableName)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return strategies, nil
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rowData, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = rowData.IterAll(ctx, func(key, value types.Value) error {
+		r, err := row.FromNoms(sch, key.(types.Tuple), value.(types.Tuple))
+		if err != nil {
+			return err
+		}
+
+		tblName, _ := r.GetColVal(schema.MergeStrategiesTableNameTag)
+		colName, _ := r.GetColVal(schema.MergeStrategiesColumnNameTag)
+		strategy, _ := r.GetColVal(schema.MergeStrategiesStrategyTag)
+		if types.IsNull(tblName) || types.IsNull(colName) || types.IsNull(strategy) {
+			return fmt.Errorf("`%s` schema in unexpected format", doltdb.MergeStrategiesTableName)
+		}
+
+		strategies.set(string(tblName.(types.String)), string(colName.(types.String)), string(strategy.(types.String)))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return strategies, nil
+}
+
+func (ms MergeStrategies) set(tblName, colName, strategy string) {
+	tblName, colName = strings.ToLower(tblName), strings.ToLower(colName)
+	if ms[tblName] == nil {
+		ms[tblName] = make(map[string]string)
+	}
+	ms[tblName][colName] = strategy
+}
+
+// tableResolver resolves the conflicts in the rows of a table with the strategies set on it
+type tableResolver struct {
+	table   Resolver
+	columns map[uint64]Resolver
+}
+
+// newTableResolver returns the resolver for the strategies set on the table named, whose merged schema is |sch|. It
+// returns nil if no strategies are set on the table. Strategies set on columns that |sch| doesn't have are ignored.
+func (ms MergeStrategies) newTableResolver(tblName string, sch schema.Schema) (*tableResolver, error) {
+	colStrategies, ok := ms[strings.ToLower(tblName)]
+	if !ok {
+		return nil, nil
+	}
+
+	tr := &tableResolver{columns: make(map[uint64]Resolver)}
+	for colName, strategy := range colStrategies {
+		resolver, err := NewResolver(strategy, sch)
+		if err != nil {
+			return nil, fmt.Errorf("invalid merge strategy for table '%s': %w", tblName, err)
+		}
+
+		if colName == "" {
+			tr.table = resolver
+		} else if col, ok := sch.GetAllCols().GetByNameCaseInsensitive(colName); ok {
+			tr.columns[col.Tag] = resolver
+		}
+	}
+
+	return tr, nil
+}
+
+// resolveCell returns the merged value of the cell in |c|, or false if the conflict can't be resolved
+func (tr *tableResolver) resolveCell(ctx context.Context, nbf *types.NomsBinFormat, c CellConflict) (types.Value, bool, error) {
+	if tr == nil {
+		return nil, false, nil
+	}
+
+	resolver, ok := tr.columns[c.Column.Tag]
+	if !ok {
+		resolver = tr.table
+	}
+	if resolver == nil {
+		return nil, false, nil
+	}
+
+	return resolver.Resolve(ctx, nbf, c)
+}
+
+// resolveRow returns the merged value of a row that one side of the merge deleted and the other modified, which is
+// nil if the row is deleted. Only the ours and theirs strategies set on a whole table resolve these conflicts.
+func (tr *tableResolver) resolveRow(r, mergeRow types.Value) (types.Value, bool) {
+	if tr == nil {
+		return nil, false
+	}
+
+	side, ok := tr.table.(sideResolver)
+	if !ok {
+		return nil, false
+	}
+
+	return side.resolveRow(r, mergeRow), true
+}
@@ -25,8 +25,10 @@ import (
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema/encoding"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
 	filesys2 "github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/store/types"
@@ -208,7 +210,7 @@ func TestRowMerge(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			actualResult, isConflict, err := pkRowMerge(context.Background(), types.Format_Default, test.sch, test.row, test.mergeRow, test.ancRow)
+			actualResult, isConflict, err := pkRowMerge(context.Background(), types.Format_Default, test.sch, test.row, test.mergeRow, test.ancRow, nil)
 			assert.NoError(t, err)
 			assert.Equal(t, test.expectedResult, actualResult, "expected "+mustString(types.EncodedValue(context.Background(), test.expectedResult))+"got "+mustString(types.EncodedValue(context.Background(), actualResult)))
 			assert.Equal(t, test.expectConflict, isConflict)
@@ -468,3 +470,81 @@ func TestMergeCommits(t *testing.T) {
 		assert.Fail(t, "%v and %v do not equal", h, eh)
 	}
 }
+
+func TestMergeTableDeletedAndModified(t *testing.T) {
+	vrw, commit, mergeCommit, _, _ := setupMergeTest(t)
+
+	root, err := commit.GetRootValue()
+	require.NoError(t, err)
+	mergeRoot, err := mergeCommit.GetRootValue()
+	require.NoError(t, err)
+	ancCm, err := doltdb.GetCommitAncestor(context.Background(), commit, mergeCommit)
+	require.NoError(t, err)
+	ancRoot, err := ancCm.GetRootValue()
+	require.NoError(t, err)
+
+	deletedRoot, err := root.RemoveTables(context.Background(), tableName)
+	require.NoError(t, err)
+	deletedMergeRoot, err := mergeRoot.RemoveTables(context.Background(), tableName)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name            string
+		root, mergeRoot *doltdb.RootValue
+	}{
+		{"deleted in root, modified in merge root", deletedRoot, mergeRoot},
+		{"modified in root, deleted in merge root", root, deletedMergeRoot},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			merger := NewMerger(context.Background(), test.root, test.mergeRoot, ancRoot, vrw)
+			tableEditSession := editor.CreateTableEditSession(test.root, editor.TableEditSessionProps{})
+			_, _, err := merger.MergeTable(context.Background(), tableName, tableEditSession)
+			assert.Equal(t, ErrTableDeletedAndModified, err)
+		})
+	}
+}
+
+func TestConvertKeylessRowsToSchema(t *testing.T) {
+	ctx := context.Background()
+	vrw := types.NewMemoryValueStore()
+
+	c0, err := schema.NewColumnWithTypeInfo("c0", 1, typeinfo.Uint32Type, false, "", false, "")
+	require.NoError(t, err)
+	c1, err := schema.NewColumnWithTypeInfo("c1", 2, typeinfo.Int64Type, false, "", false, "")
+	require.NoError(t, err)
+	sch := schema.UnkeyedSchemaFromCols(schema.NewColCollection(c0, c1))
+
+	widenedC0, err := schema.NewColumnWithTypeInfo("c0", 1, typeinfo.Int64Type, false, "", false, "")
+	require.NoError(t, err)
+	mergedSch := schema.UnkeyedSchemaFromCols(schema.NewColCollection(widenedC0, c1))
+
+	// keylessRows returns the rows of |sch| with the values |c0Vals| and |c1Vals| and the cardinalities |cards|
+	keylessRows := func(sch schema.Schema, c0Vals []types.Value, c1Vals []types.Value, cards []uint64) types.Map {
+		m, err := types.NewMap(ctx, vrw)
+		require.NoError(t, err)
+		me := m.Edit()
+		for i := range c0Vals {
+			r, err := row.New(vrw.Format(), sch, row.TaggedValues{1: c0Vals[i], 2: c1Vals[i]})
+			require.NoError(t, err)
+			k, v, err := row.ToNoms(ctx, sch, r)
+			require.NoError(t, err)
+			v, err = v.Set(row.KeylessCardinalityValIdx, types.Uint(cards[i]))
+			require.NoError(t, err)
+			me.Set(k, v)
+		}
+		m, err = me.Map(ctx)
+		require.NoError(t, err)
+		return m
+	}
+
+	rows := keylessRows(sch, []types.Value{types.Uint(1), types.Uint(2)}, []types.Value{types.Int(1), types.Int(2)}, []uint64{2, 1})
+	expected := keylessRows(mergedSch, []types.Value{types.Int(1), types.Int(2)}, []types.Value{types.Int(1), types.Int(2)}, []uint64{2, 1})
+
+	converted, ok, err := convertRowsToSchema(ctx, vrw, rows, sch, mergedSch)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.True(t, converted.Equals(expected), "%s\n!=\n%s",
+		mustString(types.EncodedValue(ctx, converted)), mustString(types.EncodedValue(ctx, expected)))
+}
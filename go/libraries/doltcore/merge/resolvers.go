@@ -0,0 +1,277 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// Resolver resolves conflicts between the values that the two sides of a merge give to the same cell. Resolvers are
+// created for the strategies in the dolt_merge_strategies table by the ResolverFactory registered under the name of
+// the strategy.
+type Resolver interface {
+	// Resolve returns the merged value of the cell in conflict, which may be nil for NULL. It returns false if it can't
+	// resolve the conflict, in which case the row is left in conflict.
+	Resolve(ctx context.Context, nbf *types.NomsBinFormat, c CellConflict) (types.Value, bool, error)
+}
+
+// CellConflict is a cell of a row that both sides of a merge changed to different values. Values are nil for NULL.
+type CellConflict struct {
+	// Column is the column of the cell
+	Column schema.Column
+	// Base, Ours and Theirs are the values of the cell in the merge base, our side and their side
+	Base, Ours, Theirs types.Value
+	// BaseRow, OurRow and TheirRow are the values of the non primary key columns of the row in the merge base, our side
+	// and their side. BaseRow is nil when both sides added the row.
+	BaseRow, OurRow, TheirRow row.TaggedValues
+}
+
+// ResolverFactory returns the Resolver for a strategy set on a table with the schema |sch|. |arg| is the argument
+// given to the strategy in parentheses, if any, such as "updated_at" for "last_writer_wins(updated_at)".
+type ResolverFactory func(arg string, sch schema.Schema) (Resolver, error)
+
+const (
+	// OursStrategy resolves conflicts with the values of our side of the merge. Set on a whole table, it also resolves
+	// rows that one side deleted and the other modified.
+	OursStrategy = "ours"
+	// TheirsStrategy resolves conflicts with the values of their side of the merge. Set on a whole table, it also
+	// resolves rows that one side deleted and the other modified.
+	TheirsStrategy = "theirs"
+	// LastWriterWinsStrategy resolves conflicts with the values of the side whose row has the latest value in the
+	// column given as its argument, such as a timestamp. Ties and NULLs are left in conflict.
+	LastWriterWinsStrategy = "last_writer_wins"
+	// MaxStrategy resolves conflicts with the greatest of the two values. NULLs are left in conflict.
+	MaxStrategy = "max"
+	// MinStrategy resolves conflicts with the least of the two values. NULLs are left in conflict.
+	MinStrategy = "min"
+	// UnionStrategy resolves conflicts in SET columns by keeping the members added by either side and removing the
+	// members removed by either side.
+	UnionStrategy = "union"
+)
+
+var resolverFactoriesMu = &sync.RWMutex{}
+var resolverFactories = map[string]ResolverFactory{
+	OursStrategy:           newSideResolver(false),
+	TheirsStrategy:         newSideResolver(true),
+	LastWriterWinsStrategy: newLastWriterWinsResolver,
+	MaxStrategy:            newExtremumResolver(true),
+	MinStrategy:            newExtremumResolver(false),
+	UnionStrategy:          newUnionResolver,
+}
+
+// RegisterResolver registers a custom strategy under |name|, which can then be set in the dolt_merge_strategies table
+// like the built in ones. It returns an error if a strategy with the same name is already registered.
+func RegisterResolver(name string, factory ResolverFactory) error {
+	name = strings.ToLower(name)
+
+	resolverFactoriesMu.Lock()
+	defer resolverFactoriesMu.Unlock()
+
+	if _, ok := resolverFactories[name]; ok {
+		return fmt.Errorf("merge strategy '%s' is already registered", name)
+	}
+
+	resolverFactories[name] = factory
+	return nil
+}
+
+// NewResolver returns the Resolver for |strategy|, which is the name of a registered strategy optionally followed by
+// an argument in parentheses, set on a table with the schema |sch|.
+func NewResolver(strategy string, sch schema.Schema) (Resolver, error) {
+	name, arg, err := parseStrategy(strategy)
+	if err != nil {
+		return nil, err
+	}
+
+	resolverFactoriesMu.RLock()
+	factory, ok := resolverFactories[name]
+	resolverFactoriesMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown merge strategy '%s'", strategy)
+	}
+
+	return factory(arg, sch)
+}
+
+func parseStrategy(strategy string) (name string, arg string, err error) {
+	strategy = strings.TrimSpace(strategy)
+	open := strings.IndexByte(strategy, '(')
+	if open == -1 {
+		return strings.ToLower(strategy), "", nil
+	}
+
+	if !strings.HasSuffix(strategy, ")") {
+		return "", "", fmt.Errorf("invalid merge strategy '%s', expected a name optionally followed by an argument in parentheses", strategy)
+	}
+
+	name = strings.ToLower(strings.TrimSpace(strategy[:open]))
+	arg = strings.TrimSpace(strategy[open+1 : len(strategy)-1])
+	return name, arg, nil
+}
+
+func noArgument(name, arg string) error {
+	if arg != "" {
+		return fmt.Errorf("merge strategy '%s' does not take an argument", name)
+	}
+	return nil
+}
+
+// sideResolver resolves conflicts with the values of one side of the merge
+type sideResolver struct {
+	theirs bool
+}
+
+func newSideResolver(theirs bool) ResolverFactory {
+	return func(arg string, sch schema.Schema) (Resolver, error) {
+		name := OursStrategy
+		if theirs {
+			name = TheirsStrategy
+		}
+		if err := noArgument(name, arg); err != nil {
+			return nil, err
+		}
+
+		return sideResolver{theirs}, nil
+	}
+}
+
+// Resolve implements Resolver
+func (r sideResolver) Resolve(ctx context.Context, nbf *types.NomsBinFormat, c CellConflict) (types.Value, bool, error) {
+	if r.theirs {
+		return c.Theirs, true, nil
+	}
+	return c.Ours, true, nil
+}
+
+// resolveRow returns the row of the side this resolver picks, out of |ours| and |theirs|
+func (r sideResolver) resolveRow(ours, theirs types.Value) types.Value {
+	if r.theirs {
+		return theirs
+	}
+	return ours
+}
+
+// lastWriterWinsResolver resolves conflicts with the values of the side whose row has the greatest value in a column
+type lastWriterWinsResolver struct {
+	tag uint64
+}
+
+func newLastWriterWinsResolver(arg string, sch schema.Schema) (Resolver, error) {
+	if arg == "" {
+		return nil, fmt.Errorf("merge strategy '%s' requires a column argument, such as %s(updated_at)", LastWriterWinsStrategy, LastWriterWinsStrategy)
+	}
+
+	col, ok := sch.GetNonPKCols().GetByNameCaseInsensitive(arg)
+	if !ok {
+		return nil, fmt.Errorf("merge strategy '%s' references unknown non primary key column '%s'", LastWriterWinsStrategy, arg)
+	}
+
+	return lastWriterWinsResolver{col.Tag}, nil
+}
+
+// Resolve implements Resolver
+func (r lastWriterWinsResolver) Resolve(ctx context.Context, nbf *types.NomsBinFormat, c CellConflict) (types.Value, bool, error) {
+	ours, _ := c.OurRow.Get(r.tag)
+	theirs, _ := c.TheirRow.Get(r.tag)
+	if types.IsNull(ours) || types.IsNull(theirs) || ours.Equals(theirs) {
+		return nil, false, nil
+	}
+
+	less, err := ours.Less(nbf, theirs)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if less {
+		return c.Theirs, true, nil
+	}
+	return c.Ours, true, nil
+}
+
+// extremumResolver resolves conflicts with the greatest or least of the two values
+type extremumResolver struct {
+	max bool
+}
+
+func newExtremumResolver(max bool) ResolverFactory {
+	return func(arg string, sch schema.Schema) (Resolver, error) {
+		name := MinStrategy
+		if max {
+			name = MaxStrategy
+		}
+		if err := noArgument(name, arg); err != nil {
+			return nil, err
+		}
+
+		return extremumResolver{max}, nil
+	}
+}
+
+// Resolve implements Resolver
+func (r extremumResolver) Resolve(ctx context.Context, nbf *types.NomsBinFormat, c CellConflict) (types.Value, bool, error) {
+	if types.IsNull(c.Ours) || types.IsNull(c.Theirs) || c.Ours.Kind() != c.Theirs.Kind() {
+		return nil, false, nil
+	}
+
+	less, err := c.Ours.Less(nbf, c.Theirs)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if less == r.max {
+		return c.Theirs, true, nil
+	}
+	return c.Ours, true, nil
+}
+
+// unionResolver merges the members of SET values, which are stored as bit fields
+type unionResolver struct{}
+
+func newUnionResolver(arg string, sch schema.Schema) (Resolver, error) {
+	if err := noArgument(UnionStrategy, arg); err != nil {
+		return nil, err
+	}
+	return unionResolver{}, nil
+}
+
+// Resolve implements Resolver
+func (r unionResolver) Resolve(ctx context.Context, nbf *types.NomsBinFormat, c CellConflict) (types.Value, bool, error) {
+	ours, ok := c.Ours.(types.Uint)
+	if !ok {
+		return nil, false, nil
+	}
+	theirs, ok := c.Theirs.(types.Uint)
+	if !ok {
+		return nil, false, nil
+	}
+
+	var base types.Uint
+	if !types.IsNull(c.Base) {
+		if base, ok = c.Base.(types.Uint); !ok {
+			return nil, false, nil
+		}
+	}
+
+	// keep the members both sides have, and those either side added
+	return (ours & theirs) | (ours &^ base) | (theirs &^ base), true, nil
+}
@@ -0,0 +1,221 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// resolverTestSch has a primary key with tag 0 and columns v, n, ts and tags with tags 1 through 4
+var resolverTestSch = schema.MustSchemaFromCols(schema.NewColCollection(
+	schema.NewColumn("pk", 0, types.IntKind, true),
+	schema.NewColumn("v", 1, types.IntKind, false),
+	schema.NewColumn("n", 2, types.IntKind, false),
+	schema.NewColumn("ts", 3, types.TimestampKind, false),
+	schema.NewColumn("tags", 4, types.UintKind, false),
+))
+
+func ts(day int) types.Value {
+	return types.Timestamp(time.Date(2021, time.January, day, 0, 0, 0, 0, time.UTC))
+}
+
+func TestRowMergeWithStrategies(t *testing.T) {
+	tests := []struct {
+		name           string
+		strategies     map[string]string
+		row            []types.Value
+		mergeRow       []types.Value
+		ancRow         []types.Value
+		expectedResult []types.Value
+		expectConflict bool
+	}{
+		{
+			name:           "no strategies",
+			strategies:     map[string]string{},
+			row:            []types.Value{types.Int(2), types.Int(1), ts(1), types.Uint(1)},
+			mergeRow:       []types.Value{types.Int(3), types.Int(1), ts(1), types.Uint(1)},
+			ancRow:         []types.Value{types.Int(1), types.Int(1), ts(1), types.Uint(1)},
+			expectConflict: true,
+		},
+		{
+			name:           "theirs",
+			strategies:     map[string]string{"": "theirs"},
+			row:            []types.Value{types.Int(2), types.Int(5), ts(1), types.Uint(1)},
+			mergeRow:       []types.Value{types.Int(3), types.Int(1), ts(1), types.Uint(1)},
+			ancRow:         []types.Value{types.Int(1), types.Int(1), ts(1), types.Uint(1)},
+			expectedResult: []types.Value{types.Int(3), types.Int(5), ts(1), types.Uint(1)},
+		},
+		{
+			name:           "ours on a column",
+			strategies:     map[string]string{"V": "ours"},
+			row:            []types.Value{types.Int(2), types.Int(1), ts(1), types.Uint(1)},
+			mergeRow:       []types.Value{types.Int(3), types.Int(1), ts(1), types.Uint(1)},
+			ancRow:         []types.Value{types.Int(1), types.Int(1), ts(1), types.Uint(1)},
+			expectedResult: []types.Value{types.Int(2), types.Int(1), ts(1), types.Uint(1)},
+		},
+		{
+			name:           "strategy on another column",
+			strategies:     map[string]string{"n": "ours"},
+			row:            []types.Value{types.Int(2), types.Int(1), ts(1), types.Uint(1)},
+			mergeRow:       []types.Value{types.Int(3), types.Int(1), ts(1), types.Uint(1)},
+			ancRow:         []types.Value{types.Int(1), types.Int(1), ts(1), types.Uint(1)},
+			expectConflict: true,
+		},
+		{
+			name:           "max and min",
+			strategies:     map[string]string{"v": "max", "n": "min"},
+			row:            []types.Value{types.Int(2), types.Int(2), ts(1), types.Uint(1)},
+			mergeRow:       []types.Value{types.Int(3), types.Int(3), ts(1), types.Uint(1)},
+			ancRow:         []types.Value{types.Int(1), types.Int(1), ts(1), types.Uint(1)},
+			expectedResult: []types.Value{types.Int(3), types.Int(2), ts(1), types.Uint(1)},
+		},
+		{
+			name:           "max with null",
+			strategies:     map[string]string{"v": "max"},
+			row:            []types.Value{types.NullValue, types.Int(1), ts(1), types.Uint(1)},
+			mergeRow:       []types.Value{types.Int(3), types.Int(1), ts(1), types.Uint(1)},
+			ancRow:         []types.Value{types.Int(1), types.Int(1), ts(1), types.Uint(1)},
+			expectConflict: true,
+		},
+		{
+			name:           "last writer wins",
+			strategies:     map[string]string{"": "last_writer_wins(ts)"},
+			row:            []types.Value{types.Int(2), types.Int(2), ts(3), types.Uint(1)},
+			mergeRow:       []types.Value{types.Int(3), types.Int(3), ts(2), types.Uint(1)},
+			ancRow:         []types.Value{types.Int(1), types.Int(1), ts(1), types.Uint(1)},
+			expectedResult: []types.Value{types.Int(2), types.Int(2), ts(3), types.Uint(1)},
+		},
+		{
+			name:           "last writer wins tie",
+			strategies:     map[string]string{"": "last_writer_wins(ts)"},
+			row:            []types.Value{types.Int(2), types.Int(1), ts(2), types.Uint(1)},
+			mergeRow:       []types.Value{types.Int(3), types.Int(1), ts(2), types.Uint(1)},
+			ancRow:         []types.Value{types.Int(1), types.Int(1), ts(1), types.Uint(1)},
+			expectConflict: true,
+		},
+		{
+			name:           "union",
+			strategies:     map[string]string{"tags": "union"},
+			row:            []types.Value{types.Int(1), types.Int(1), ts(1), types.Uint(0b0110)},
+			mergeRow:       []types.Value{types.Int(1), types.Int(1), ts(1), types.Uint(0b1011)},
+			ancRow:         []types.Value{types.Int(1), types.Int(1), ts(1), types.Uint(0b0011)},
+			expectedResult: []types.Value{types.Int(1), types.Int(1), ts(1), types.Uint(0b1110)},
+		},
+		{
+			name:           "one delete one modify with theirs",
+			strategies:     map[string]string{"": "theirs"},
+			row:            nil,
+			mergeRow:       []types.Value{types.Int(2), types.Int(1), ts(1), types.Uint(1)},
+			ancRow:         []types.Value{types.Int(1), types.Int(1), ts(1), types.Uint(1)},
+			expectedResult: []types.Value{types.Int(2), types.Int(1), ts(1), types.Uint(1)},
+		},
+		{
+			name:           "one delete one modify with ours",
+			strategies:     map[string]string{"": "ours"},
+			row:            nil,
+			mergeRow:       []types.Value{types.Int(2), types.Int(1), ts(1), types.Uint(1)},
+			ancRow:         []types.Value{types.Int(1), types.Int(1), ts(1), types.Uint(1)},
+			expectedResult: nil,
+		},
+		{
+			name:           "one delete one modify with a column strategy",
+			strategies:     map[string]string{"v": "max"},
+			row:            nil,
+			mergeRow:       []types.Value{types.Int(2), types.Int(1), ts(1), types.Uint(1)},
+			ancRow:         []types.Value{types.Int(1), types.Int(1), ts(1), types.Uint(1)},
+			expectConflict: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			strategies := make(MergeStrategies)
+			for colName, strategy := range test.strategies {
+				strategies.set("Test", colName, strategy)
+			}
+			resolver, err := strategies.newTableResolver("test", resolverTestSch)
+			require.NoError(t, err)
+
+			row, mergeRow, ancRow := resolverTestTuple(test.row), resolverTestTuple(test.mergeRow), resolverTestTuple(test.ancRow)
+			actualResult, isConflict, err := pkRowMerge(ctx, types.Format_Default, resolverTestSch, row, mergeRow, ancRow, resolver)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectConflict, isConflict)
+			if !test.expectConflict {
+				assert.Equal(t, resolverTestTuple(test.expectedResult), actualResult)
+			}
+		})
+	}
+}
+
+func TestInvalidStrategies(t *testing.T) {
+	for _, strategy := range []string{"unknown", "max(v)", "last_writer_wins", "last_writer_wins(missing)", "theirs(", "union(x)"} {
+		t.Run(strategy, func(t *testing.T) {
+			strategies := make(MergeStrategies)
+			strategies.set("test", "", strategy)
+			_, err := strategies.newTableResolver("test", resolverTestSch)
+			assert.Error(t, err)
+		})
+	}
+}
+
+type constResolver struct {
+	val types.Value
+}
+
+func (r constResolver) Resolve(ctx context.Context, nbf *types.NomsBinFormat, c CellConflict) (types.Value, bool, error) {
+	return r.val, true, nil
+}
+
+func TestRegisterResolver(t *testing.T) {
+	err := RegisterResolver("Const", func(arg string, sch schema.Schema) (Resolver, error) {
+		return constResolver{types.Int(42)}, nil
+	})
+	require.NoError(t, err)
+	defer func() {
+		resolverFactoriesMu.Lock()
+		defer resolverFactoriesMu.Unlock()
+		delete(resolverFactories, "const")
+	}()
+	err = RegisterResolver("const", nil)
+	assert.Error(t, err)
+	err = RegisterResolver(MaxStrategy, nil)
+	assert.Error(t, err)
+
+	strategies := make(MergeStrategies)
+	strategies.set("test", "v", "const")
+	resolver, err := strategies.newTableResolver("test", resolverTestSch)
+	require.NoError(t, err)
+
+	row := resolverTestTuple([]types.Value{types.Int(2), types.Int(1), ts(1), types.Uint(1)})
+	mergeRow := resolverTestTuple([]types.Value{types.Int(3), types.Int(1), ts(1), types.Uint(1)})
+	ancRow := resolverTestTuple([]types.Value{types.Int(1), types.Int(1), ts(1), types.Uint(1)})
+	result, isConflict, err := pkRowMerge(context.Background(), types.Format_Default, resolverTestSch, row, mergeRow, ancRow, resolver)
+	require.NoError(t, err)
+	assert.False(t, isConflict)
+	assert.Equal(t, resolverTestTuple([]types.Value{types.Int(42), types.Int(1), ts(1), types.Uint(1)}), result)
+}
+
+func resolverTestTuple(vals []types.Value) types.Value {
+	return valsToTestTupleWithPks(vals)
+}
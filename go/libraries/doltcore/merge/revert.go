@@ -0,0 +1,86 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+)
+
+// Revert applies the inverse of the changes introduced by each of |commits|, in order, to |root|. Each revert is a
+// three-way merge that treats the reverted commit as the ancestor and its parent as the other side. If reverting a
+// commit results in conflicts or constraint violations, Revert stops and returns the root with the conflicts along
+// with the merge stats for that commit, so that the caller can surface them. Otherwise it returns the reverted root,
+// the merge stats of the last revert, and a generated commit message describing the reverted commits.
+func Revert(ctx context.Context, ddb *doltdb.DoltDB, root *doltdb.RootValue, commits []*doltdb.Commit) (*doltdb.RootValue, map[string]*MergeStats, string, error) {
+	var tblToStats map[string]*MergeStats
+	var titles, hashes []string
+	for _, cm := range commits {
+		cmRoot, err := cm.GetRootValue()
+		if err != nil {
+			return nil, nil, "", err
+		}
+
+		parentRoot, err := singleParentRoot(ctx, ddb, cm)
+		if err != nil {
+			return nil, nil, "", err
+		}
+
+		root, tblToStats, err = MergeRoots(ctx, root, parentRoot, cmRoot)
+		if err != nil {
+			return nil, nil, "", err
+		}
+
+		if HasConflictsOrViolations(tblToStats) {
+			return root, tblToStats, "", nil
+		}
+
+		meta, err := cm.GetCommitMeta()
+		if err != nil {
+			return nil, nil, "", err
+		}
+		h, err := cm.HashOf()
+		if err != nil {
+			return nil, nil, "", err
+		}
+
+		subject := strings.SplitN(meta.Description, "\n", 2)[0]
+		titles = append(titles, fmt.Sprintf(`Revert "%s"`, subject))
+		hashes = append(hashes, h.String())
+	}
+
+	var msg string
+	if len(hashes) == 1 {
+		msg = fmt.Sprintf("%s\n\nThis reverts commit %s.", titles[0], hashes[0])
+	} else {
+		msg = fmt.Sprintf("%s\n\nThis reverts commits %s.", strings.Join(titles, "\n"), strings.Join(hashes, ", "))
+	}
+
+	return root, tblToStats, msg, nil
+}
+
+// HasConflictsOrViolations returns whether any of the tables merged have conflicts or constraint violations.
+func HasConflictsOrViolations(tblToStats map[string]*MergeStats) bool {
+	for _, stats := range tblToStats {
+		if stats.Operation == TableModified && (stats.Conflicts > 0 || stats.ConstraintViolations > 0 || stats.SchemaConflicts > 0) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,178 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+)
+
+// ErrSquashSchemaConflicts is returned for a squash merge with schema conflicts. Schema conflicts are recorded in the
+// merge state, and a squash merge doesn't start one, so the merge has to be done without squashing.
+var ErrSquashSchemaConflicts = errors.New("cannot squash a merge with schema conflicts")
+
+// SquashSchemaConflictsErr returns an error naming the tables in |tblToStats| whose schemas could not be merged, if
+// any, for a squash merge.
+func SquashSchemaConflictsErr(tblToStats map[string]*MergeStats) error {
+	if tbls := SchemaConflictTables(tblToStats); len(tbls) > 0 {
+		return fmt.Errorf("%w in tables: %s", ErrSquashSchemaConflicts, strings.Join(tbls, ", "))
+	}
+	return nil
+}
+
+// TableSchemaConflict is a table whose schema could not be merged, along with its schema in the common ancestor and
+// on each side of the merge. A schema is nil if the table doesn't exist in that root.
+type TableSchemaConflict struct {
+	TableName   string
+	BaseSch     schema.Schema
+	OurSch      schema.Schema
+	TheirSch    schema.Schema
+	Description string
+}
+
+// SchemaConflictTables returns the names of the tables in |tblToStats| whose schemas could not be merged, in sorted
+// order.
+func SchemaConflictTables(tblToStats map[string]*MergeStats) []string {
+	var tblNames []string
+	for tblName, stats := range tblToStats {
+		if stats.SchemaConflicts > 0 {
+			tblNames = append(tblNames, tblName)
+		}
+	}
+	sort.Strings(tblNames)
+	return tblNames
+}
+
+// GetSchemaConflicts returns the schema conflicts of the merge in progress in |ws|, which merges into |head|.
+func GetSchemaConflicts(ctx context.Context, ws *doltdb.WorkingSet, head *doltdb.Commit) ([]TableSchemaConflict, error) {
+	if !ws.MergeActive() || len(ws.MergeState().SchemaConflicts()) == 0 {
+		return nil, nil
+	}
+
+	mergeCommit := ws.MergeState().Commit()
+	ancRoot, err := mergeBaseRoot(ctx, mergeCommit, head)
+	if err != nil {
+		return nil, err
+	}
+	theirRoot, err := mergeCommit.GetRootValue()
+	if err != nil {
+		return nil, err
+	}
+	ourRoot := ws.MergeState().PreMergeWorkingRoot()
+
+	var conflicts []TableSchemaConflict
+	for _, tblName := range ws.MergeState().SchemaConflicts() {
+		c := TableSchemaConflict{TableName: tblName}
+		if c.BaseSch, err = getSchemaOrNil(ctx, ancRoot, tblName); err != nil {
+			return nil, err
+		}
+		if c.OurSch, err = getSchemaOrNil(ctx, ourRoot, tblName); err != nil {
+			return nil, err
+		}
+		if c.TheirSch, err = getSchemaOrNil(ctx, theirRoot, tblName); err != nil {
+			return nil, err
+		}
+
+		if c.BaseSch != nil && c.OurSch != nil && c.TheirSch != nil {
+			_, sc, err := SchemaMerge(c.OurSch, c.TheirSch, c.BaseSch, tblName)
+			if err != nil {
+				return nil, err
+			}
+			c.Description = sc.description()
+		}
+
+		conflicts = append(conflicts, c)
+	}
+
+	return conflicts, nil
+}
+
+// ResolveSchemaConflicts returns a copy of |ws| with the schema conflicts of |tables| resolved. When |theirs| is true
+// each table is replaced with their version of it, schema and rows alike. Otherwise the tables keep their current
+// contents in the working set, which is our version unless they were altered after the merge.
+func ResolveSchemaConflicts(ctx context.Context, ws *doltdb.WorkingSet, tables []string, theirs bool) (*doltdb.WorkingSet, error) {
+	if !ws.MergeActive() || len(ws.MergeState().SchemaConflicts()) == 0 {
+		return nil, doltdb.ErrNoConflicts
+	}
+
+	remaining := make(map[string]bool)
+	for _, tblName := range ws.MergeState().SchemaConflicts() {
+		remaining[tblName] = true
+	}
+
+	working := ws.WorkingRoot()
+	for _, tblName := range tables {
+		if !remaining[tblName] {
+			return nil, fmt.Errorf("table '%s' does not have schema conflicts", tblName)
+		}
+		delete(remaining, tblName)
+
+		if !theirs {
+			continue
+		}
+
+		theirRoot, err := ws.MergeState().Commit().GetRootValue()
+		if err != nil {
+			return nil, err
+		}
+		tbl, ok, err := theirRoot.GetTable(ctx, tblName)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			working, err = working.PutTable(ctx, tblName, tbl)
+		} else {
+			working, err = working.RemoveTables(ctx, tblName)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var unresolved []string
+	for _, tblName := range ws.MergeState().SchemaConflicts() {
+		if remaining[tblName] {
+			unresolved = append(unresolved, tblName)
+		}
+	}
+
+	return ws.WithWorkingRoot(working).WithMergeState(ws.MergeState().WithSchemaConflicts(unresolved)), nil
+}
+
+func getSchemaOrNil(ctx context.Context, root *doltdb.RootValue, tblName string) (schema.Schema, error) {
+	tbl, ok, err := root.GetTable(ctx, tblName)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return tbl.GetSchema(ctx)
+}
+
+// description returns a one line description of the conflicts
+func (sc SchemaConflict) description() string {
+	var descs []string
+	for _, c := range sc.ColConflicts {
+		descs = append(descs, c.String())
+	}
+	for _, c := range sc.IdxConflicts {
+		descs = append(descs, c.String())
+	}
+	return strings.Join(descs, "; ")
+}
@@ -18,6 +18,8 @@ import (
 	"context"
 	"testing"
 
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/sqltypes"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -92,6 +94,8 @@ var setupCommon = []testCommand{
 	{commands.BranchCmd{}, []string{"other"}},
 }
 
+var varchar100Type, _ = typeinfo.FromSqlType(sql.MustCreateStringWithDefaults(sqltypes.VarChar, 100))
+
 var mergeSchemaTests = []mergeSchemaTest{
 	{
 		name:  "no changes",
@@ -252,6 +256,56 @@ var mergeSchemaTests = []mergeSchemaTest{
 			schema.NewIndex("c3_idx", []uint64{4696}, []uint64{4696, 3228}, nil, schema.IndexProperties{IsUserDefined: true}),
 		),
 	},
+	{
+		name: "modify column default and comment, merge",
+		setup: []testCommand{
+			{commands.SqlCmd{}, []string{"-q", "alter table test modify column c3 int default 7;"}},
+			{commands.AddCmd{}, []string{"."}},
+			{commands.CommitCmd{}, []string{"-m", "modified branch master"}},
+			{commands.CheckoutCmd{}, []string{"other"}},
+			{commands.SqlCmd{}, []string{"-q", "alter table test modify column c3 int comment 'their comment';"}},
+			{commands.AddCmd{}, []string{"."}},
+			{commands.CommitCmd{}, []string{"-m", "modified branch other"}},
+			{commands.CheckoutCmd{}, []string{"master"}},
+		},
+		sch: schemaFromColsAndIdxs(
+			colCollection(
+				newColTypeInfo("pk", uint64(3228), typeinfo.Int32Type, true, schema.NotNullConstraint{}),
+				newColTypeInfo("c1", uint64(8201), typeinfo.Int32Type, false, schema.NotNullConstraint{}),
+				newColTypeInfo("c2", uint64(8539), typeinfo.Int32Type, false),
+				newColWithDefaultAndComment("c3", uint64(4696), typeinfo.Int32Type, "7", "their comment")),
+			schema.NewIndex("c1_idx", []uint64{8201}, []uint64{8201, 3228}, nil, schema.IndexProperties{IsUserDefined: true}),
+		),
+	},
+	{
+		name: "widen column on both branches and rename, merge",
+		setup: []testCommand{
+			{commands.SqlCmd{}, []string{"-q", "alter table test add column c4 varchar(10);"}},
+			{commands.AddCmd{}, []string{"."}},
+			{commands.CommitCmd{}, []string{"-m", "added column on master"}},
+			{commands.CheckoutCmd{}, []string{"other"}},
+			{commands.MergeCmd{}, []string{"master"}},
+			{commands.SqlCmd{}, []string{"-q", "alter table test modify column c4 varchar(50);"}},
+			{commands.SqlCmd{}, []string{"-q", "alter table test rename column c4 to c44;"}},
+			{commands.SqlCmd{}, []string{"-q", "alter table test modify column c2 bigint;"}},
+			{commands.AddCmd{}, []string{"."}},
+			{commands.CommitCmd{}, []string{"-m", "modified branch other"}},
+			{commands.CheckoutCmd{}, []string{"master"}},
+			{commands.SqlCmd{}, []string{"-q", "alter table test modify column c4 varchar(100);"}},
+			{commands.SqlCmd{}, []string{"-q", "alter table test modify column c2 smallint;"}},
+			{commands.AddCmd{}, []string{"."}},
+			{commands.CommitCmd{}, []string{"-m", "modified branch master"}},
+		},
+		sch: schemaFromColsAndIdxs(
+			colCollection(
+				newColTypeInfo("pk", uint64(3228), typeinfo.Int32Type, true, schema.NotNullConstraint{}),
+				newColTypeInfo("c1", uint64(8201), typeinfo.Int32Type, false, schema.NotNullConstraint{}),
+				newColTypeInfo("c2", uint64(8539), typeinfo.Int64Type, false),
+				newColTypeInfo("c3", uint64(4696), typeinfo.Int32Type, false),
+				newColTypeInfo("c44", uint64(10190), varchar100Type, false)),
+			schema.NewIndex("c1_idx", []uint64{8201}, []uint64{8201, 3228}, nil, schema.IndexProperties{IsUserDefined: true}),
+		),
+	},
 }
 
 var mergeSchemaConflictTests = []mergeSchemaConflictTest{
@@ -368,6 +422,29 @@ var mergeSchemaConflictTests = []mergeSchemaConflictTest{
 			},
 		},
 	},
+	{
+		name: "column default collision",
+		setup: []testCommand{
+			{commands.SqlCmd{}, []string{"-q", "alter table test modify column c3 bigint default 1;"}},
+			{commands.AddCmd{}, []string{"."}},
+			{commands.CommitCmd{}, []string{"-m", "modified branch master"}},
+			{commands.CheckoutCmd{}, []string{"other"}},
+			{commands.SqlCmd{}, []string{"-q", "alter table test modify column c3 int default 2;"}},
+			{commands.AddCmd{}, []string{"."}},
+			{commands.CommitCmd{}, []string{"-m", "modified branch other"}},
+			{commands.CheckoutCmd{}, []string{"master"}},
+		},
+		expConflict: merge.SchemaConflict{
+			TableName: "test",
+			ColConflicts: []merge.ColConflict{
+				{
+					Kind:   merge.TagCollision,
+					Ours:   newColWithDefaultAndComment("c3", uint64(4696), typeinfo.Int64Type, "1", ""),
+					Theirs: newColWithDefaultAndComment("c3", uint64(4696), typeinfo.Int32Type, "2", ""),
+				},
+			},
+		},
+	},
 }
 
 var setupForeignKeyTests = []testCommand{
@@ -446,6 +523,14 @@ func newColTypeInfo(name string, tag uint64, typeInfo typeinfo.TypeInfo, partOfP
 	return c
 }
 
+func newColWithDefaultAndComment(name string, tag uint64, typeInfo typeinfo.TypeInfo, defaultVal, comment string) schema.Column {
+	c, err := schema.NewColumnWithTypeInfo(name, tag, typeInfo, false, defaultVal, false, comment)
+	if err != nil {
+		panic("could not create column")
+	}
+	return c
+}
+
 func fkCollection(fks ...doltdb.ForeignKey) *doltdb.ForeignKeyCollection {
 	fkc, err := doltdb.NewForeignKeyCollection(fks...)
 	if err != nil {
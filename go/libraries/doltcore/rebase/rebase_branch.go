@@ -0,0 +1,591 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rebase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+const rebaseStateFile = "rebase_state.json"
+
+var ErrRebaseInProgress = errors.New("a rebase is already in progress")
+var ErrNoRebaseInProgress = errors.New("no rebase in progress")
+var ErrUnstagedChanges = errors.New("there are unstaged changes in the working set")
+
+// State is the persisted state of a branch rebase that has stopped to let the user resolve conflicts. It's written
+// to the .dolt directory when a rebase stops and removed when the rebase completes or is aborted.
+type State struct {
+	// Branch is the branch being rebased
+	Branch string `json:"branch"`
+	// OrigHead is the hash of the branch's HEAD before the rebase started
+	OrigHead string `json:"orig_head"`
+	// Onto is the hash of the commit the branch is being rebased onto
+	Onto string `json:"onto"`
+	// Todo is the list of remaining steps of the rebase. The first step is the one the rebase stopped on.
+	Todo []TodoItem `json:"todo"`
+	// Applied is the number of commits the rebase has made so far
+	Applied int `json:"applied"`
+	// SquashTarget is the last pick or reword step, which following squash steps are folded into
+	SquashTarget *SquashTarget `json:"squash_target,omitempty"`
+}
+
+// SquashTarget is a step of a rebase that squash steps are folded into. It isn't committed until it, or a step
+// squashed into it, changes something.
+type SquashTarget struct {
+	// Commit is the hash of the commit the step applied
+	Commit string `json:"commit"`
+	// Message is the commit message of the step, followed by the messages of the steps squashed into it
+	Message string `json:"message"`
+	// Committed is whether the step has been committed as the HEAD of the branch being rebased
+	Committed bool `json:"committed"`
+}
+
+func getRebaseStateFile() string {
+	return filepath.Join(dbfactory.DoltDir, rebaseStateFile)
+}
+
+// IsRebaseActive returns whether a rebase has stopped and is waiting to be continued or aborted.
+func IsRebaseActive(dEnv *env.DoltEnv) bool {
+	exists, _ := dEnv.FS.Exists(getRebaseStateFile())
+	return exists
+}
+
+// LoadState reads the state of the stopped rebase, or returns ErrNoRebaseInProgress if there isn't one.
+func LoadState(dEnv *env.DoltEnv) (*State, error) {
+	if !IsRebaseActive(dEnv) {
+		return nil, ErrNoRebaseInProgress
+	}
+
+	data, err := dEnv.FS.ReadFile(getRebaseStateFile())
+	if err != nil {
+		return nil, err
+	}
+
+	var state State
+	err = json.Unmarshal(data, &state)
+	if err != nil {
+		return nil, err
+	}
+
+	return &state, nil
+}
+
+func (s *State) save(dEnv *env.DoltEnv) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return dEnv.FS.WriteFile(getRebaseStateFile(), data)
+}
+
+func clearState(dEnv *env.DoltEnv) error {
+	return dEnv.FS.DeleteFile(getRebaseStateFile())
+}
+
+// CommitsToRebase returns the commits reachable from |head| but not from |upstream|, following first parents only,
+// ordered from oldest to newest. These are the commits a rebase of |head| onto |upstream| will replay.
+func CommitsToRebase(ctx context.Context, ddb *doltdb.DoltDB, head, upstream *doltdb.Commit) ([]*doltdb.Commit, error) {
+	base, err := doltdb.GetCommitAncestor(ctx, head, upstream)
+	if err != nil {
+		return nil, err
+	}
+
+	baseHash, err := base.HashOf()
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []*doltdb.Commit
+	cm := head
+	for {
+		h, err := cm.HashOf()
+		if err != nil {
+			return nil, err
+		}
+		if h == baseHash {
+			break
+		}
+
+		commits = append(commits, cm)
+
+		n, err := cm.NumParents()
+		if err != nil {
+			return nil, err
+		}
+		if n == 0 {
+			break
+		}
+
+		cm, err = ddb.ResolveParent(ctx, cm, 0)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for i, j := 0, len(commits)-1; i < j; i, j = i+1, j-1 {
+		commits[i], commits[j] = commits[j], commits[i]
+	}
+
+	return commits, nil
+}
+
+// Start rebases the current branch onto |onto| by resetting the branch to |onto| and then applying each step of
+// |todo| in order. If a step can't be applied cleanly, the rebase stops with the conflicts in the working set and
+// returns its state. The rebase can then be resumed with Continue or rolled back with Abort. A nil state is returned
+// if the rebase completed.
+func Start(ctx context.Context, dEnv *env.DoltEnv, onto *doltdb.Commit, todo []TodoItem) (*State, error) {
+	if IsRebaseActive(dEnv) {
+		return nil, ErrRebaseInProgress
+	}
+
+	err := validateTodoList(todo)
+	if err != nil {
+		return nil, err
+	}
+
+	branch := dEnv.RepoStateReader().CWBHeadRef()
+
+	// resolve every step to a commit hash up front, so that the steps still refer to the same commits while the
+	// branch is being rewritten
+	resolved := make([]TodoItem, len(todo))
+	for i, item := range todo {
+		cs, err := doltdb.NewCommitSpec(item.Commit)
+		if err != nil {
+			return nil, err
+		}
+
+		cm, err := dEnv.DoltDB.Resolve(ctx, cs, branch)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := cm.HashOf()
+		if err != nil {
+			return nil, err
+		}
+
+		resolved[i] = item
+		resolved[i].Commit = h.String()
+	}
+
+	origHead, err := dEnv.DoltDB.ResolveCommitRef(ctx, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	origHash, err := origHead.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	ontoHash, err := onto.HashOf()
+	if err != nil {
+		return nil, err
+	}
+
+	state := &State{
+		Branch:   branch.GetPath(),
+		OrigHead: origHash.String(),
+		Onto:     ontoHash.String(),
+		Todo:     resolved,
+	}
+
+	// save the state before the branch is reset, so that the rebase can be aborted if it fails part way through
+	err = state.save(dEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	err = resetBranch(ctx, dEnv, branch, onto)
+	if err != nil {
+		return nil, err
+	}
+
+	return run(ctx, dEnv, state)
+}
+
+// Continue resumes a stopped rebase. The staged root is committed as the result of the step the rebase stopped on,
+// after which the remaining steps are applied.
+func Continue(ctx context.Context, dEnv *env.DoltEnv) (*State, error) {
+	state, err := LoadState(dEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkBranch(dEnv, state)
+	if err != nil {
+		return nil, err
+	}
+
+	roots, err := dEnv.Roots(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	inConflict, err := roots.Working.TablesInConflict(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(inConflict) > 0 {
+		return nil, doltdb.ErrUnresolvedConflicts
+	}
+
+	violations, err := roots.Working.TablesWithConstraintViolations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(violations) > 0 {
+		return nil, doltdb.ErrUnresolvedConstraintViolations
+	}
+
+	stagedHash, err := roots.Staged.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	workingHash, err := roots.Working.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	if stagedHash != workingHash {
+		return nil, ErrUnstagedChanges
+	}
+
+	cm, err := resolveHash(ctx, dEnv.DoltDB, state.Todo[0].Commit)
+	if err != nil {
+		return nil, err
+	}
+
+	err = commitStep(ctx, dEnv, state, state.Todo[0], cm, roots.Staged)
+	if err != nil {
+		return nil, err
+	}
+
+	state.Todo = state.Todo[1:]
+	return run(ctx, dEnv, state)
+}
+
+// Abort rolls back a stopped rebase, restoring the branch, and its working set, to where they were before the rebase
+// started.
+func Abort(ctx context.Context, dEnv *env.DoltEnv) error {
+	state, err := LoadState(dEnv)
+	if err != nil {
+		return err
+	}
+
+	err = checkBranch(dEnv, state)
+	if err != nil {
+		return err
+	}
+
+	origHead, err := resolveHash(ctx, dEnv.DoltDB, state.OrigHead)
+	if err != nil {
+		return err
+	}
+
+	err = resetBranch(ctx, dEnv, ref.NewBranchRef(state.Branch), origHead)
+	if err != nil {
+		return err
+	}
+
+	return clearState(dEnv)
+}
+
+// checkBranch returns an error if the current branch isn't the one being rebased.
+func checkBranch(dEnv *env.DoltEnv, state *State) error {
+	if cwb := dEnv.RepoStateReader().CWBHeadRef().GetPath(); cwb != state.Branch {
+		return fmt.Errorf("the rebase in progress is for branch '%s', but the current branch is '%s'", state.Branch, cwb)
+	}
+	return nil
+}
+
+// run applies the remaining steps of |state|'s todo list, saving the state after each one. If a step stops with
+// conflicts, the state is returned. Otherwise the saved state is removed once every step is applied and nil is
+// returned. If a step fails, the saved state is left for Abort to restore the branch with.
+func run(ctx context.Context, dEnv *env.DoltEnv, state *State) (*State, error) {
+	for len(state.Todo) > 0 {
+		item := state.Todo[0]
+		if item.Action == Drop {
+			state.Todo = state.Todo[1:]
+			err := state.save(dEnv)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		cm, err := resolveHash(ctx, dEnv.DoltDB, item.Commit)
+		if err != nil {
+			return nil, err
+		}
+
+		headRoot, err := dEnv.HeadRoot(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		cmRoot, err := cm.GetRootValue()
+		if err != nil {
+			return nil, err
+		}
+
+		parentRoot, err := firstParentRoot(ctx, dEnv.DoltDB, cm)
+		if err != nil {
+			return nil, err
+		}
+
+		mergedRoot, tblToStats, err := merge.MergeRoots(ctx, headRoot, cmRoot, parentRoot)
+		if err != nil {
+			return nil, err
+		}
+
+		if merge.HasConflictsOrViolations(tblToStats) {
+			ws, err := dEnv.WorkingSet(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			err = dEnv.UpdateWorkingSet(ctx, ws.WithWorkingRoot(mergedRoot).WithStagedRoot(headRoot))
+			if err != nil {
+				return nil, err
+			}
+
+			err = state.save(dEnv)
+			if err != nil {
+				return nil, err
+			}
+
+			return state, nil
+		}
+
+		err = commitStep(ctx, dEnv, state, item, cm, mergedRoot)
+		if err != nil {
+			return nil, err
+		}
+
+		state.Todo = state.Todo[1:]
+		err = state.save(dEnv)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := clearState(dEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// commitStep records |root| on the branch being rebased as the result of applying |item|. Picks and rewords are
+// committed on top of the branch's HEAD with the original author and date of |cm|, and become the target of the
+// squashes that follow them. Squashes amend the target's commit, adding their messages to it. Steps that don't
+// change anything aren't committed, but a target that isn't committed yet still collects the messages of the
+// squashes that follow it, and is committed once one of them changes something.
+func commitStep(ctx context.Context, dEnv *env.DoltEnv, state *State, item TodoItem, cm *doltdb.Commit, root *doltdb.RootValue) error {
+	ddb := dEnv.DoltDB
+	branch := ref.NewBranchRef(state.Branch)
+
+	head, err := ddb.ResolveCommitRef(ctx, branch)
+	if err != nil {
+		return err
+	}
+
+	headRoot, err := head.GetRootValue()
+	if err != nil {
+		return err
+	}
+
+	rootHash, err := root.HashOf()
+	if err != nil {
+		return err
+	}
+	headRootHash, err := headRoot.HashOf()
+	if err != nil {
+		return err
+	}
+	changed := rootHash != headRootHash
+
+	meta, err := cm.GetCommitMeta()
+	if err != nil {
+		return err
+	}
+
+	target := state.SquashTarget
+	switch {
+	case item.Action == Squash && target != nil:
+		target.Message += "\n\n" + meta.Description
+
+		if target.Committed {
+			err = amendHead(ctx, ddb, branch, head, root, target.Message)
+			if err != nil {
+				return err
+			}
+		} else if changed {
+			targetCm, err := resolveHash(ctx, ddb, target.Commit)
+			if err != nil {
+				return err
+			}
+
+			err = commitOnHead(ctx, ddb, branch, targetCm, root, target.Message)
+			if err != nil {
+				return err
+			}
+
+			target.Committed = true
+			state.Applied++
+		}
+
+	default:
+		desc := meta.Description
+		if item.Action == Reword {
+			desc = item.Message
+		}
+
+		state.SquashTarget = &SquashTarget{Commit: item.Commit, Message: desc}
+		if changed {
+			err = commitOnHead(ctx, ddb, branch, cm, root, desc)
+			if err != nil {
+				return err
+			}
+
+			state.SquashTarget.Committed = true
+			state.Applied++
+		}
+	}
+
+	ws, err := dEnv.WorkingSet(ctx)
+	if err != nil {
+		return err
+	}
+
+	return dEnv.UpdateWorkingSet(ctx, ws.WithWorkingRoot(root).WithStagedRoot(root))
+}
+
+// commitOnHead commits |root| on top of the HEAD of |branch| with the message |desc| and the author and date of |cm|.
+func commitOnHead(ctx context.Context, ddb *doltdb.DoltDB, branch ref.DoltRef, cm *doltdb.Commit, root *doltdb.RootValue, desc string) error {
+	meta, err := cm.GetCommitMeta()
+	if err != nil {
+		return err
+	}
+
+	valHash, err := ddb.WriteRootValue(ctx, root)
+	if err != nil {
+		return err
+	}
+
+	newMeta, err := doltdb.NewCommitMetaWithUserTS(meta.Name, meta.Email, desc, meta.Time())
+	if err != nil {
+		return err
+	}
+
+	_, err = ddb.CommitWithParentCommits(ctx, valHash, branch, nil, newMeta)
+	return err
+}
+
+// amendHead replaces |head|, the HEAD of |branch|, with a commit of |root| that has the same parents, author and date,
+// and the message |desc|.
+func amendHead(ctx context.Context, ddb *doltdb.DoltDB, branch ref.DoltRef, head *doltdb.Commit, root *doltdb.RootValue, desc string) error {
+	headMeta, err := head.GetCommitMeta()
+	if err != nil {
+		return err
+	}
+
+	valHash, err := ddb.WriteRootValue(ctx, root)
+	if err != nil {
+		return err
+	}
+
+	newMeta, err := doltdb.NewCommitMetaWithUserTS(headMeta.Name, headMeta.Email, desc, headMeta.Time())
+	if err != nil {
+		return err
+	}
+
+	parents, err := ddb.ResolveAllParents(ctx, head)
+	if err != nil {
+		return err
+	}
+
+	amended, err := ddb.CommitDanglingWithParentCommits(ctx, valHash, parents, newMeta)
+	if err != nil {
+		return err
+	}
+
+	return ddb.SetHeadToCommit(ctx, branch, amended)
+}
+
+// resetBranch points |branch| at |cm| and resets its working set to the root of |cm|.
+func resetBranch(ctx context.Context, dEnv *env.DoltEnv, branch ref.DoltRef, cm *doltdb.Commit) error {
+	err := dEnv.DoltDB.SetHeadToCommit(ctx, branch, cm)
+	if err != nil {
+		return err
+	}
+
+	root, err := cm.GetRootValue()
+	if err != nil {
+		return err
+	}
+
+	ws, err := dEnv.WorkingSet(ctx)
+	if err != nil {
+		return err
+	}
+
+	return dEnv.UpdateWorkingSet(ctx, ws.WithWorkingRoot(root).WithStagedRoot(root))
+}
+
+// firstParentRoot returns the root value of the first parent of |cm|, or an empty root value if |cm| has no
+// parents. Replaying a merge commit relative to its first parent applies all the changes it merged in.
+func firstParentRoot(ctx context.Context, ddb *doltdb.DoltDB, cm *doltdb.Commit) (*doltdb.RootValue, error) {
+	n, err := cm.NumParents()
+	if err != nil {
+		return nil, err
+	}
+
+	if n == 0 {
+		return doltdb.EmptyRootValue(ctx, ddb.ValueReadWriter())
+	}
+
+	parent, err := ddb.ResolveParent(ctx, cm, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return parent.GetRootValue()
+}
+
+func resolveHash(ctx context.Context, ddb *doltdb.DoltDB, h string) (*doltdb.Commit, error) {
+	if _, ok := hash.MaybeParse(h); !ok {
+		return nil, fmt.Errorf("invalid commit hash '%s'", h)
+	}
+
+	cs, err := doltdb.NewCommitSpec(h)
+	if err != nil {
+		return nil, err
+	}
+
+	return ddb.Resolve(ctx, cs, nil)
+}
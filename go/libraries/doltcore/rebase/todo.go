@@ -0,0 +1,158 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rebase
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+)
+
+// Action is the instruction for a single step of a rebase todo list.
+type Action string
+
+const (
+	// Pick applies the changes of a commit and commits them with the original commit message.
+	Pick Action = "pick"
+	// Squash applies the changes of a commit and folds them into the previous commit, combining the commit messages.
+	Squash Action = "squash"
+	// Drop skips a commit.
+	Drop Action = "drop"
+	// Reword applies the changes of a commit and commits them with a new commit message.
+	Reword Action = "reword"
+)
+
+var actionsByName = map[string]Action{
+	"pick":   Pick,
+	"p":      Pick,
+	"squash": Squash,
+	"s":      Squash,
+	"drop":   Drop,
+	"d":      Drop,
+	"reword": Reword,
+	"r":      Reword,
+}
+
+// TodoItem is a single step of a rebase.
+type TodoItem struct {
+	Action Action `json:"action"`
+	Commit string `json:"commit"`
+	// Message is the new commit message for a reword step
+	Message string `json:"message,omitempty"`
+}
+
+// TodoListHelp is appended to generated todo lists to describe the format.
+const TodoListHelp = `
+# Commands:
+# p, pick <commit> = use commit
+# r, reword <commit> <message> = use commit, but replace its commit message with <message>
+# s, squash <commit> = use commit, but meld into previous commit
+# d, drop <commit> = remove commit
+#
+# These lines can be re-ordered; they are executed from top to bottom.
+# Lines starting with '#' are ignored.
+`
+
+// NewTodoList returns a todo list that picks each of |commits| in order.
+func NewTodoList(commits []*doltdb.Commit) ([]TodoItem, error) {
+	todo := make([]TodoItem, len(commits))
+	for i, cm := range commits {
+		h, err := cm.HashOf()
+		if err != nil {
+			return nil, err
+		}
+
+		todo[i] = TodoItem{Action: Pick, Commit: h.String()}
+	}
+
+	return todo, nil
+}
+
+// FormatTodoList writes |todo| in the format read by ParseTodoList, followed by a summary of each commit's message.
+func FormatTodoList(todo []TodoItem, commits []*doltdb.Commit) (string, error) {
+	sb := strings.Builder{}
+	for i, item := range todo {
+		meta, err := commits[i].GetCommitMeta()
+		if err != nil {
+			return "", err
+		}
+
+		subject := strings.SplitN(meta.Description, "\n", 2)[0]
+		sb.WriteString(fmt.Sprintf("%s %s %s\n", item.Action, item.Commit, subject))
+	}
+
+	sb.WriteString(TodoListHelp)
+	return sb.String(), nil
+}
+
+// ParseTodoList reads a todo list with one step per line in the form "<action> <commit> [<text>]". Blank lines and
+// lines starting with '#' are ignored. The text following the commit is the new commit message for reword steps, and
+// is ignored for all other steps.
+func ParseTodoList(r io.Reader) ([]TodoItem, error) {
+	var todo []TodoItem
+
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		action, ok := actionsByName[strings.ToLower(fields[0])]
+		if !ok {
+			return nil, fmt.Errorf("line %d: unknown action '%s'", lineNum, fields[0])
+		}
+
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("line %d: missing commit for '%s'", lineNum, fields[0])
+		}
+
+		item := TodoItem{Action: action, Commit: fields[1]}
+		if action == Reword {
+			if len(fields) < 3 {
+				return nil, fmt.Errorf("line %d: missing commit message for '%s'", lineNum, fields[0])
+			}
+			msgStart := strings.Index(line, fields[1]) + len(fields[1])
+			item.Message = strings.TrimSpace(line[msgStart:])
+		}
+
+		todo = append(todo, item)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return todo, nil
+}
+
+func validateTodoList(todo []TodoItem) error {
+	for _, item := range todo {
+		switch item.Action {
+		case Drop:
+			continue
+		case Squash:
+			return fmt.Errorf("cannot squash %s without a previous commit", item.Commit)
+		default:
+			return nil
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rebase
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTodoList(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		expected  []TodoItem
+		expectErr bool
+	}{
+		{
+			name: "all actions",
+			input: `pick a1 first commit
+squash b2 second commit
+d c3
+reword  d4   a new   message
+`,
+			expected: []TodoItem{
+				{Action: Pick, Commit: "a1"},
+				{Action: Squash, Commit: "b2"},
+				{Action: Drop, Commit: "c3"},
+				{Action: Reword, Commit: "d4", Message: "a new   message"},
+			},
+		},
+		{
+			name:     "comments and blank lines",
+			input:    "# comment\n\n  p a1\n" + TodoListHelp,
+			expected: []TodoItem{{Action: Pick, Commit: "a1"}},
+		},
+		{
+			name:  "empty",
+			input: TodoListHelp,
+		},
+		{
+			name:      "unknown action",
+			input:     "edit a1",
+			expectErr: true,
+		},
+		{
+			name:      "missing commit",
+			input:     "pick",
+			expectErr: true,
+		},
+		{
+			name:      "reword without message",
+			input:     "reword a1",
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			todo, err := ParseTodoList(strings.NewReader(test.input))
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, todo)
+		})
+	}
+}
+
+func TestValidateTodoList(t *testing.T) {
+	assert.NoError(t, validateTodoList([]TodoItem{{Action: Pick, Commit: "a1"}, {Action: Squash, Commit: "b2"}}))
+	assert.NoError(t, validateTodoList([]TodoItem{{Action: Drop, Commit: "a1"}}))
+	assert.NoError(t, validateTodoList(nil))
+	assert.Error(t, validateTodoList([]TodoItem{{Action: Drop, Commit: "a1"}, {Action: Squash, Commit: "b2"}}))
+}
@@ -51,11 +51,24 @@ const (
 
 	// WorkspaceRefType is a reference to a workspace
 	WorkspaceRefType RefType = "workspaces"
+
+	// StashRefType is a reference to a stash of working set changes
+	StashRefType RefType = "stashes"
 )
 
+// RefTypes are all the ref types that Parse recognizes.
+var RefTypes = map[RefType]struct{}{
+	BranchRefType:    {},
+	RemoteRefType:    {},
+	InternalRefType:  {},
+	TagRefType:       {},
+	WorkspaceRefType: {},
+	StashRefType:     {},
+}
+
 // HeadRefTypes are the ref types that point to a HEAD and contain a Commit struct. These are the types that are
-// returned by GetHeadRefs. Other ref types don't point to Commits necessarily, so aren't in this list and must be
-// asked for explicitly.
+// returned by GetHeadRefs. Other ref types don't point to Commits necessarily, or like stashes aren't heads that users
+// work on, so aren't in this list and must be asked for explicitly.
 var HeadRefTypes = map[RefType]struct{}{
 	BranchRefType:    {},
 	RemoteRefType:    {},
@@ -140,7 +153,7 @@ func Parse(str string) (DoltRef, error) {
 		}
 	}
 
-	for rType := range HeadRefTypes {
+	for rType := range RefTypes {
 		prefix := PrefixForType(rType)
 		if strings.HasPrefix(str, prefix) {
 			str = str[len(prefix):]
@@ -155,6 +168,8 @@ func Parse(str string) (DoltRef, error) {
 				return NewTagRef(str), nil
 			case WorkspaceRefType:
 				return NewWorkspaceRef(str), nil
+			case StashRefType:
+				return NewStashRef(str), nil
 			default:
 				panic("unknown type " + rType)
 			}
@@ -44,6 +44,10 @@ func TestJsonMarshalAndUnmarshal(t *testing.T) {
 			NewWorkspaceRef("newworkspace"),
 			`{"test":"refs/workspaces/newworkspace"}`,
 		},
+		{
+			NewStashRef("1626712371000000000"),
+			`{"test":"refs/stashes/1626712371000000000"}`,
+		},
 	}
 
 	for _, test := range tests {
@@ -334,6 +334,7 @@ func (gr *GetRange) ResourcePath() string {
 
 func (gr *GetRange) Append(other *GetRange) {
 	gr.Url = other.Url
+	gr.Codec = other.Codec
 	gr.Ranges = append(gr.Ranges, other.Ranges...)
 }
 
@@ -367,7 +368,7 @@ func (gr *GetRange) SplitAtGaps(maxGapBytes uint64) []*GetRange {
 			}
 			j++
 		}
-		res = append(res, &GetRange{Url: gr.Url, Ranges: gr.Ranges[i:j]})
+		res = append(res, &GetRange{Url: gr.Url, Ranges: gr.Ranges[i:j], Codec: gr.Codec})
 		i = j
 	}
 	return res
@@ -430,10 +431,14 @@ func (gr *GetRange) GetDownloadFunc(ctx context.Context, stats StatsRecorder, fe
 		if err != nil {
 			return err
 		}
+		codec, ok := ChunkCodecFromProto(gr.Codec)
+		if !ok {
+			return fmt.Errorf("chunks at %s are compressed with unknown codec %v", gr.ResourcePath(), gr.Codec)
+		}
 		// Send the chunk for each range included in GetRange.
 		for i := 0; i < len(gr.Ranges); i++ {
 			s, e := gr.ChunkByteRange(i)
-			cmpChnk, err := nbs.NewCompressedChunk(hash.New(gr.Ranges[i].Hash), comprData[s:e])
+			cmpChnk, err := nbs.NewCompressedChunk(hash.New(gr.Ranges[i].Hash), comprData[s:e], codec)
 			if err != nil {
 				return err
 			}
@@ -553,7 +558,7 @@ func (dcs *DoltChunkStore) getDLLocs(ctx context.Context, hashes []hash.Hash) (d
 		hashesBytes := HashesToSlices(hashes)
 		batchItr(len(hashesBytes), getLocsBatchSize, func(st, end int) (stop bool) {
 			batch := hashesBytes[st:end]
-			req := &remotesapi.GetDownloadLocsRequest{RepoId: dcs.getRepoId(), ChunkHashes: batch}
+			req := &remotesapi.GetDownloadLocsRequest{RepoId: dcs.getRepoId(), ChunkHashes: batch, AcceptedCodecs: ChunkCodecsToProto(nbs.ChunkCodecs)}
 			reqs = append(reqs, req)
 			return false
 		})
@@ -1162,10 +1167,11 @@ func collapseBuffers(bufs [][]byte, length uint64) []byte {
 
 func (dcs *DoltChunkStore) SupportedOperations() nbs.TableFileStoreOps {
 	return nbs.TableFileStoreOps{
-		CanRead:  true,
-		CanWrite: true,
-		CanPrune: false,
-		CanGC:    false,
+		CanRead:     true,
+		CanWrite:    true,
+		CanPrune:    false,
+		CanGC:       false,
+		ChunkCodecs: ChunkCodecsFromProto(dcs.metadata.SupportedCodecs),
 	}
 }
 
@@ -1267,7 +1273,7 @@ func (dcs *DoltChunkStore) PruneTableFiles(ctx context.Context) error {
 // Sources retrieves the current root hash, a list of all the table files (which may include appendix table files)
 // and a list of only appendix table files
 func (dcs *DoltChunkStore) Sources(ctx context.Context) (hash.Hash, []nbs.TableFile, []nbs.TableFile, error) {
-	req := &remotesapi.ListTableFilesRequest{RepoId: dcs.getRepoId()}
+	req := &remotesapi.ListTableFilesRequest{RepoId: dcs.getRepoId(), AcceptedCodecs: ChunkCodecsToProto(nbs.ChunkCodecs)}
 	resp, err := dcs.csClient.ListTableFiles(ctx, req)
 	if err != nil {
 		return hash.Hash{}, nil, nil, NewRpcError(err, "ListTableFiles", dcs.host, req)
@@ -14,7 +14,11 @@
 
 package remotestorage
 
-import "github.com/dolthub/dolt/go/store/hash"
+import (
+	remotesapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/remotesapi/v1alpha1"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/nbs"
+)
 
 // HashesToSlices takes a list of hashes and converts each hash to a byte slice returning a slice of byte slices
 func HashesToSlices(hashes []hash.Hash) [][]byte {
@@ -58,3 +62,53 @@ func ParseByteSlices(byteSlices [][]byte) (hash.HashSet, map[hash.Hash]int) {
 
 	return hs, hashToIndex
 }
+
+// ChunkCodecToProto converts a table file codec to its remotesapi enum value
+func ChunkCodecToProto(codec nbs.ChunkCodec) remotesapi.ChunkCodec {
+	switch codec {
+	case nbs.ZstdCodec:
+		return remotesapi.ChunkCodec_CHUNK_CODEC_ZSTD
+	default:
+		return remotesapi.ChunkCodec_CHUNK_CODEC_SNAPPY
+	}
+}
+
+// ChunkCodecFromProto converts a remotesapi codec enum value to a table file codec. It returns false if the codec is
+// unknown.
+func ChunkCodecFromProto(codec remotesapi.ChunkCodec) (nbs.ChunkCodec, bool) {
+	switch codec {
+	case remotesapi.ChunkCodec_CHUNK_CODEC_SNAPPY:
+		return nbs.SnappyCodec, true
+	case remotesapi.ChunkCodec_CHUNK_CODEC_ZSTD:
+		return nbs.ZstdCodec, true
+	default:
+		return 0, false
+	}
+}
+
+// ChunkCodecsToProto converts a list of table file codecs to their remotesapi enum values
+func ChunkCodecsToProto(codecs []nbs.ChunkCodec) []remotesapi.ChunkCodec {
+	res := make([]remotesapi.ChunkCodec, len(codecs))
+	for i, codec := range codecs {
+		res[i] = ChunkCodecToProto(codec)
+	}
+
+	return res
+}
+
+// ChunkCodecsFromProto converts a list of remotesapi codec enum values to the table file codecs that are known. An
+// empty list is snappy, which is the only codec of peers which don't list their codecs.
+func ChunkCodecsFromProto(codecs []remotesapi.ChunkCodec) []nbs.ChunkCodec {
+	if len(codecs) == 0 {
+		return []nbs.ChunkCodec{nbs.SnappyCodec}
+	}
+
+	var res []nbs.ChunkCodec
+	for _, codec := range codecs {
+		if c, ok := ChunkCodecFromProto(codec); ok {
+			res = append(res, c)
+		}
+	}
+
+	return res
+}
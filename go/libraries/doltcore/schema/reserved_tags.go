@@ -93,6 +93,13 @@ const (
 	DoltProceduresModifiedAtTag
 )
 
+// Tags for the dolt_merge_strategies table
+const (
+	MergeStrategiesTableNameTag = iota + SystemTableReservedMin + uint64(7000)
+	MergeStrategiesColumnNameTag
+	MergeStrategiesStrategyTag
+)
+
 const (
 	DoltConstraintViolationsTypeTag = 0
 	DoltConstraintViolationsInfoTag = math.MaxUint64
@@ -0,0 +1,117 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeinfo
+
+import (
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/dolthub/vitess/go/vt/proto/query"
+)
+
+// numberTypeBits maps each integer and floating point SQL type to the number of bits it occupies.
+var numberTypeBits = map[query.Type]int{
+	sqltypes.Int8:    8,
+	sqltypes.Int16:   16,
+	sqltypes.Int24:   24,
+	sqltypes.Int32:   32,
+	sqltypes.Int64:   64,
+	sqltypes.Uint8:   8,
+	sqltypes.Uint16:  16,
+	sqltypes.Uint24:  24,
+	sqltypes.Uint32:  32,
+	sqltypes.Uint64:  64,
+	sqltypes.Float32: 32,
+	sqltypes.Float64: 64,
+}
+
+// IsWidening returns whether every value of the type |from| is also a value of the type |to|, such that a column may
+// be changed from the former to the latter without losing or altering any of its data. Equal types are widenings of
+// each other.
+func IsWidening(from, to TypeInfo) bool {
+	if from.Equals(to) {
+		return true
+	}
+
+	switch src := from.(type) {
+	case *intType:
+		if dest, ok := to.(*intType); ok {
+			return numberTypeBits[src.sqlIntType.Type()] <= numberTypeBits[dest.sqlIntType.Type()]
+		}
+	case *uintType:
+		switch dest := to.(type) {
+		case *uintType:
+			return numberTypeBits[src.sqlUintType.Type()] <= numberTypeBits[dest.sqlUintType.Type()]
+		case *intType:
+			// the sign bit must fit in addition to the magnitude
+			return numberTypeBits[src.sqlUintType.Type()] < numberTypeBits[dest.sqlIntType.Type()]
+		}
+	case *floatType:
+		if dest, ok := to.(*floatType); ok {
+			return numberTypeBits[src.sqlFloatType.Type()] <= numberTypeBits[dest.sqlFloatType.Type()]
+		}
+	case *decimalType:
+		if dest, ok := to.(*decimalType); ok {
+			srcPrecision, srcScale := int(src.sqlDecimalType.Precision()), int(src.sqlDecimalType.Scale())
+			destPrecision, destScale := int(dest.sqlDecimalType.Precision()), int(dest.sqlDecimalType.Scale())
+			return destScale >= srcScale && destPrecision-destScale >= srcPrecision-srcScale
+		}
+	case *varStringType:
+		if dest, ok := to.(*varStringType); ok {
+			// CHAR values lose their trailing spaces when read, so nothing may widen into a CHAR column
+			if dest.sqlStringType.Type() == sqltypes.Char && src.sqlStringType.Type() != sqltypes.Char {
+				return false
+			}
+			return src.sqlStringType.Collation().Equals(dest.sqlStringType.Collation()) &&
+				src.sqlStringType.MaxCharacterLength() <= dest.sqlStringType.MaxCharacterLength()
+		}
+	case *inlineBlobType:
+		switch dest := to.(type) {
+		case *inlineBlobType:
+			// BINARY values are padded to their length, so only VARBINARY columns may grow
+			return src.sqlBinaryType.Type() == sqltypes.VarBinary && dest.sqlBinaryType.Type() == sqltypes.VarBinary &&
+				src.sqlBinaryType.MaxCharacterLength() <= dest.sqlBinaryType.MaxCharacterLength()
+		case *varBinaryType:
+			return src.sqlBinaryType.MaxCharacterLength() <= dest.sqlBinaryType.MaxCharacterLength()
+		}
+	case *varBinaryType:
+		if dest, ok := to.(*varBinaryType); ok {
+			return src.sqlBinaryType.MaxCharacterLength() <= dest.sqlBinaryType.MaxCharacterLength()
+		}
+	case *enumType:
+		// enums are stored by index, so existing values must keep their positions
+		if dest, ok := to.(*enumType); ok {
+			return isPrefix(src.sqlEnumType.Values(), dest.sqlEnumType.Values())
+		}
+	case *setType:
+		// sets are stored as bit fields, so existing values must keep their positions
+		if dest, ok := to.(*setType); ok {
+			return isPrefix(src.sqlSetType.Values(), dest.sqlSetType.Values())
+		}
+	}
+
+	return false
+}
+
+// isPrefix returns whether |prefix| is a prefix of |vals|.
+func isPrefix(prefix, vals []string) bool {
+	if len(prefix) > len(vals) {
+		return false
+	}
+	for i := range prefix {
+		if prefix[i] != vals[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,64 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeinfo
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsWidening(t *testing.T) {
+	tests := []struct {
+		from     TypeInfo
+		to       TypeInfo
+		widening bool
+	}{
+		{Int32Type, Int32Type, true},
+		{Int32Type, Int64Type, true},
+		{Int64Type, Int32Type, false},
+		{Uint16Type, Uint64Type, true},
+		{Uint16Type, Int32Type, true},
+		{Uint32Type, Int32Type, false},
+		{Int8Type, Uint64Type, false},
+		{Float32Type, Float64Type, true},
+		{Float64Type, Float32Type, false},
+		{Int32Type, Float64Type, false},
+		{generateVarStringType(t, 10, false), generateVarStringType(t, 100, false), true},
+		{generateVarStringType(t, 100, false), generateVarStringType(t, 10, false), false},
+		{generateVarStringType(t, 10, true), generateVarStringType(t, 100, false), true},
+		{generateVarStringType(t, 10, false), generateVarStringType(t, 100, true), false},
+		{generateVarStringType(t, 10, false), &varStringType{sql.CreateLongText(sql.Collation_Default)}, true},
+		{generateVarStringType(t, 10, false), &varStringType{sql.MustCreateString(sqltypes.VarChar, 100, sql.Collation_utf8mb4_bin)}, false},
+		{generateVarBinaryType(t, 10, false), generateVarBinaryType(t, 100, false), true},
+		{generateVarBinaryType(t, 100, false), generateVarBinaryType(t, 10, false), false},
+		{generateDecimalType(t, 10, 2), generateDecimalType(t, 12, 4), true},
+		{generateDecimalType(t, 10, 2), generateDecimalType(t, 10, 4), false},
+		{generateDecimalType(t, 10, 4), generateDecimalType(t, 10, 2), false},
+		{generateEnumType(t, 3), generateEnumType(t, 5), true},
+		{generateEnumType(t, 5), generateEnumType(t, 3), false},
+		{generateSetType(t, 3), generateSetType(t, 5), true},
+		{generateSetType(t, 5), generateSetType(t, 3), false},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%v %v", test.from.String(), test.to.String()), func(t *testing.T) {
+			assert.Equal(t, test.widening, IsWidening(test.from, test.to))
+		})
+	}
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema/alterschema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/globalstate"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlfmt"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
@@ -59,6 +60,7 @@ type Database struct {
 	rsr  env.RepoStateReader
 	rsw  env.RepoStateWriter
 	drw  env.DocsReadWriter
+	gs   globalstate.GlobalState
 }
 
 var _ sql.Database = Database{}
@@ -146,9 +148,15 @@ func NewDatabase(name string, dbData env.DbData) Database {
 		rsr:  dbData.Rsr,
 		rsw:  dbData.Rsw,
 		drw:  dbData.Drw,
+		gs:   globalstate.NewGlobalStateStore(),
 	}
 }
 
+// GetGlobalState returns the state shared by every session using this database, such as its auto increment trackers.
+func (db Database) GetGlobalState() globalstate.GlobalState {
+	return db.gs
+}
+
 // Name returns the name of this database, set at creation time.
 func (db Database) Name() string {
 	return db.name
@@ -244,6 +252,17 @@ func (db Database) GetTableInsensitiveWithRoot(ctx *sql.Context, root *doltdb.Ro
 			return nil, false, err
 		}
 		return dt, true, nil
+	case strings.HasPrefix(lwrName, doltdb.DoltBlameTablePrefix):
+		suffix := tblName[len(doltdb.DoltBlameTablePrefix):]
+		head, err := sess.GetHeadCommit(ctx, db.name)
+		if err != nil {
+			return nil, false, err
+		}
+		dt, err := dtables.NewBlameTable(ctx, suffix, db.ddb, root, head)
+		if err != nil {
+			return nil, false, err
+		}
+		return dt, true, nil
 	case strings.HasPrefix(lwrName, doltdb.DoltConfTablePrefix):
 		suffix := tblName[len(doltdb.DoltConfTablePrefix):]
 		dt, err := dtables.NewConflictsTable(ctx, suffix, root, dtables.RootSetter(db))
@@ -274,6 +293,8 @@ func (db Database) GetTableInsensitiveWithRoot(ctx *sql.Context, root *doltdb.Ro
 		dt, found = dtables.NewTableOfTablesInConflict(ctx, db.ddb, root), true
 	case doltdb.TableOfTablesWithViolationsName:
 		dt, found = dtables.NewTableOfTablesConstraintViolations(ctx, root), true
+	case doltdb.SchemaConflictsTableName:
+		dt, found = dtables.NewSchemaConflictsTable(ctx, db.name), true
 	case doltdb.BranchesTableName:
 		dt, found = dtables.NewBranchesTable(ctx, db.ddb), true
 	case doltdb.CommitsTableName:
@@ -282,16 +303,36 @@ func (db Database) GetTableInsensitiveWithRoot(ctx *sql.Context, root *doltdb.Ro
 		dt, found = dtables.NewCommitAncestorsTable(ctx, db.ddb), true
 	case doltdb.StatusTableName:
 		dt, found = dtables.NewStatusTable(ctx, db.name, db.ddb, dsess.NewSessionStateAdapter(sess, db.name), db.drw), true
+	case doltdb.TagsTableName:
+		dt, found = dtables.NewTagsTable(ctx, db.ddb, dsess.NewSessionStateAdapter(sess, db.name)), true
+	case doltdb.RemotesTableName:
+		adapter := dsess.NewSessionStateAdapter(sess, db.name)
+		dt, found = dtables.NewRemotesTable(ctx, db.ddb, adapter, adapter), true
 	}
 	if found {
 		return dt, found, nil
 	}
 
-	return db.getTable(ctx, root, tblName, false)
+	tbl, found, err := db.getTable(ctx, root, tblName, false)
+	if err != nil || found {
+		return tbl, found, err
+	}
+
+	if lwrName == doltdb.MergeStrategiesTableName {
+		// the table is created the first time rows are inserted into it
+		return &emptyMergeStrategiesTable{db: db}, true, nil
+	}
+
+	return nil, false, nil
 }
 
 // GetTableInsensitiveAsOf implements sql.VersionedDatabase
 func (db Database) GetTableInsensitiveAsOf(ctx *sql.Context, tableName string, asOf interface{}) (sql.Table, bool, error) {
+	lwrName := strings.ToLower(tableName)
+	if strings.HasPrefix(lwrName, doltdb.DoltBlameTablePrefix) {
+		return db.blameTableAsOf(ctx, tableName[len(doltdb.DoltBlameTablePrefix):], asOf)
+	}
+
 	root, err := db.rootAsOf(ctx, asOf)
 
 	if err != nil {
@@ -320,20 +361,53 @@ func (db Database) GetTableInsensitiveAsOf(ctx *sql.Context, tableName string, a
 	}
 }
 
+// blameTableAsOf returns the blame table of the table |tblName| as of the expression given, which blames the rows of
+// the table in that commit.
+func (db Database) blameTableAsOf(ctx *sql.Context, tblName string, asOf interface{}) (sql.Table, bool, error) {
+	cm, err := db.commitAsOf(ctx, asOf)
+	if err != nil {
+		return nil, false, err
+	} else if cm == nil {
+		return nil, false, nil
+	}
+
+	root, err := cm.GetRootValue()
+	if err != nil {
+		return nil, false, err
+	}
+
+	dt, err := dtables.NewBlameTable(ctx, tblName, db.ddb, root, cm)
+	if err != nil {
+		return nil, false, err
+	}
+	return dt, true, nil
+}
+
 // rootAsOf returns the root of the DB as of the expression given, which may be nil in the case that it refers to an
 // expression before the first commit.
 func (db Database) rootAsOf(ctx *sql.Context, asOf interface{}) (*doltdb.RootValue, error) {
+	cm, err := db.commitAsOf(ctx, asOf)
+	if err != nil || cm == nil {
+		return nil, err
+	}
+
+	return cm.GetRootValue()
+}
+
+// commitAsOf returns the commit of the DB as of the expression given, which may be nil in the case that it refers to an
+// expression before the first commit.
+func (db Database) commitAsOf(ctx *sql.Context, asOf interface{}) (*doltdb.Commit, error) {
 	switch x := asOf.(type) {
 	case string:
-		return db.getRootForCommitRef(ctx, x)
+		return db.getCommitForCommitRef(ctx, x)
 	case time.Time:
-		return db.getRootForTime(ctx, x)
+		return db.getCommitForTime(ctx, x)
 	default:
 		panic(fmt.Sprintf("unsupported AS OF type %T", asOf))
 	}
 }
 
-func (db Database) getRootForTime(ctx *sql.Context, asOf time.Time) (*doltdb.RootValue, error) {
+func (db Database) getCommitForTime(ctx *sql.Context, asOf time.Time) (*doltdb.Commit, error) {
 	cs, err := doltdb.NewCommitSpec("HEAD")
 	if err != nil {
 		return nil, err
@@ -368,30 +442,20 @@ func (db Database) getRootForTime(ctx *sql.Context, asOf time.Time) (*doltdb.Roo
 		}
 
 		if meta.Time().Equal(asOf) || meta.Time().Before(asOf) {
-			return curr.GetRootValue()
+			return curr, nil
 		}
 	}
 
 	return nil, nil
 }
 
-func (db Database) getRootForCommitRef(ctx *sql.Context, commitRef string) (*doltdb.RootValue, error) {
+func (db Database) getCommitForCommitRef(ctx *sql.Context, commitRef string) (*doltdb.Commit, error) {
 	cs, err := doltdb.NewCommitSpec(commitRef)
 	if err != nil {
 		return nil, err
 	}
 
-	cm, err := db.ddb.Resolve(ctx, cs, db.rsr.CWBHeadRef())
-	if err != nil {
-		return nil, err
-	}
-
-	root, err := cm.GetRootValue()
-	if err != nil {
-		return nil, err
-	}
-
-	return root, nil
+	return db.ddb.Resolve(ctx, cs, db.rsr.CWBHeadRef())
 }
 
 // GetTableNamesAsOf implements sql.VersionedDatabase
@@ -493,8 +557,10 @@ var hashType = sql.MustCreateString(query.Type_TEXT, 32, sql.Collation_ascii_bin
 // GetRoot returns the root value for this database session
 func (db Database) GetRoot(ctx *sql.Context) (*doltdb.RootValue, error) {
 	sess := dsess.DSessFromSess(ctx.Session)
-	dbState, dbRootOk := sess.DbStates[db.name]
-	if !dbRootOk {
+	dbState, dbRootOk, err := sess.LookupDbState(ctx, db.name)
+	if err != nil {
+		return nil, err
+	} else if !dbRootOk {
 		return nil, fmt.Errorf("no root value found in session")
 	}
 
@@ -746,9 +812,14 @@ func (db Database) RenameTable(ctx *sql.Context, oldName, newName string) error
 // Flush flushes the current batch of outstanding changes and returns any errors.
 func (db Database) Flush(ctx *sql.Context) error {
 	sess := dsess.DSessFromSess(ctx.Session)
-	editSession := sess.DbStates[db.name].EditSession
+	dbState, ok, err := sess.LookupDbState(ctx, db.name)
+	if err != nil {
+		return err
+	} else if !ok {
+		return sql.ErrDatabaseNotFound.New(db.name)
+	}
 
-	newRoot, err := editSession.Flush(ctx)
+	newRoot, err := dbState.EditSession.Flush(ctx)
 	if err != nil {
 		return err
 	}
@@ -760,7 +831,7 @@ func (db Database) Flush(ctx *sql.Context) error {
 
 	// Flush any changes made to temporary tables
 	// TODO: Shouldn't always be updating both roots. Needs to update either both roots or neither of them, atomically
-	tempTableEditSession := sess.DbStates[db.name].TempTableEditSession
+	tempTableEditSession := dbState.TempTableEditSession
 	if tempTableEditSession != nil {
 		newTempTableRoot, err := tempTableEditSession.Flush(ctx)
 		if err != nil {
@@ -964,7 +1035,12 @@ func (db Database) addFragToSchemasTable(ctx *sql.Context, fragType, name, defin
 
 	// If rows exist, then grab the highest id and add 1 to get the new id
 	indexToUse := int64(1)
-	te, err := db.TableEditSession(ctx, tbl.IsTemporary()).GetTableEditor(ctx, doltdb.SchemasTableName, tbl.sch)
+	editSession, err := db.TableEditSession(ctx, tbl.IsTemporary())
+	if err != nil {
+		return err
+	}
+
+	te, err := editSession.GetTableEditor(ctx, doltdb.SchemasTableName, tbl.sch)
 	if err != nil {
 		return err
 	}
@@ -1028,11 +1104,18 @@ func (db Database) dropFragFromSchemasTable(ctx *sql.Context, fragType, name str
 }
 
 // TableEditSession returns the TableEditSession for this database from the given context.
-func (db Database) TableEditSession(ctx *sql.Context, isTemporary bool) *editor.TableEditSession {
+func (db Database) TableEditSession(ctx *sql.Context, isTemporary bool) (*editor.TableEditSession, error) {
+	dbState, ok, err := dsess.DSessFromSess(ctx.Session).LookupDbState(ctx, db.name)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(db.name)
+	}
+
 	if isTemporary {
-		return dsess.DSessFromSess(ctx.Session).DbStates[db.name].TempTableEditSession
+		return dbState.TempTableEditSession, nil
 	}
-	return dsess.DSessFromSess(ctx.Session).DbStates[db.name].EditSession
+	return dbState.EditSession, nil
 }
 
 // GetAllTemporaryTables returns all temporary tables
@@ -1041,8 +1124,8 @@ func (db Database) GetAllTemporaryTables(ctx *sql.Context) ([]sql.Table, error)
 
 	tables := make([]sql.Table, 0)
 
-	root := sess.DbStates[db.name].TempTableRoot
-	if root != nil {
+	root, ok := sess.GetTempTableRootValue(ctx, db.name)
+	if ok {
 		tNames, err := root.GetTableNames(ctx)
 		if err != nil {
 			return nil, err
@@ -15,34 +15,69 @@
 package sqle
 
 import (
+	"context"
+	"strings"
+	"sync"
+
 	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/store/hash"
 )
 
+// DbRevisionDelimiter separates a database name from the revision it names, e.g. "mydb/mybranch"
+const DbRevisionDelimiter = "/"
+
 type DoltDatabaseProvider struct {
 	databases map[string]sql.Database
+	// revisions caches the revision databases resolved so far, keyed by name. They aren't listed by AllDatabases.
+	revisions map[string]sql.Database
+	mu        *sync.RWMutex
 }
 
-var _ sql.DatabaseProvider = DoltDatabaseProvider{}
+var _ sql.MutableDatabaseProvider = DoltDatabaseProvider{}
+var _ dsess.RevisionDatabaseProvider = DoltDatabaseProvider{}
 
-func NewDoltDatabaseProvider(databases ...Database) sql.MutableDatabaseProvider {
+// NewDoltDatabaseProvider returns a provider of |databases| and their revisions.
+func NewDoltDatabaseProvider(databases ...Database) DoltDatabaseProvider {
 	dbs := make(map[string]sql.Database, len(databases))
 	for _, db := range databases {
 		dbs[db.Name()] = db
 	}
 
-	return DoltDatabaseProvider{databases: dbs}
+	return DoltDatabaseProvider{
+		databases: dbs,
+		revisions: make(map[string]sql.Database),
+		mu:        &sync.RWMutex{},
+	}
 }
 
-func (p DoltDatabaseProvider) Database(name string) (db sql.Database, err error) {
-	var ok bool
-	if db, ok = p.databases[name]; ok {
+// Database returns the database named. In addition to the databases this provider was created with, names of the
+// form "mydb/revision" are resolved on demand, where revision is a branch, a tag or a commit hash of mydb. Branch
+// revisions are read-write, and tag and commit revisions are read-only. Resolved revisions are cached, so this
+// normally returns a revision already resolved by RevisionDbState with its caller's context. sql.DatabaseProvider
+// doesn't pass a context to Database, so a revision that isn't cached yet is resolved with a background context.
+func (p DoltDatabaseProvider) Database(name string) (sql.Database, error) {
+	p.mu.RLock()
+	db, ok := p.databases[name]
+	if !ok {
+		db, ok = p.revisions[name]
+	}
+	p.mu.RUnlock()
+	if ok {
 		return db, nil
 	}
 
-	// TODO: dynamically provide databases for refs
-	// eg: "USE mydatabase/mybranch;"
+	db, _, ok, err := p.databaseForRevision(context.Background(), name)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(name)
+	}
 
-	return nil, sql.ErrDatabaseNotFound.New(name)
+	return db, nil
 }
 
 func (p DoltDatabaseProvider) HasDatabase(name string) bool {
@@ -51,6 +86,9 @@ func (p DoltDatabaseProvider) HasDatabase(name string) bool {
 }
 
 func (p DoltDatabaseProvider) AllDatabases() (all []sql.Database) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
 	i := 0
 	all = make([]sql.Database, len(p.databases))
 	for _, db := range p.databases {
@@ -61,9 +99,186 @@ func (p DoltDatabaseProvider) AllDatabases() (all []sql.Database) {
 }
 
 func (p DoltDatabaseProvider) AddDatabase(db sql.Database) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	p.databases[db.Name()] = db
 }
 
 func (p DoltDatabaseProvider) DropDatabase(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	delete(p.databases, name)
+
+	for revDB := range p.revisions {
+		if strings.HasPrefix(revDB, name+DbRevisionDelimiter) {
+			delete(p.revisions, revDB)
+		}
+	}
+}
+
+// RevisionDbState implements dsess.RevisionDatabaseProvider
+func (p DoltDatabaseProvider) RevisionDbState(ctx context.Context, revDB string) (dsess.InitialDbState, bool, error) {
+	_, init, ok, err := p.databaseForRevision(ctx, revDB)
+	return init, ok, err
+}
+
+// databaseForRevision returns a database and its initial session state for a name of the form "mydb/revision", or
+// false if the name doesn't name a revision of a known database. The database is cached for later calls to Database,
+// and dropped from the cache once its revision no longer resolves.
+func (p DoltDatabaseProvider) databaseForRevision(ctx context.Context, revDB string) (sql.Database, dsess.InitialDbState, bool, error) {
+	db, init, ok, err := p.resolveRevision(ctx, revDB)
+	if err != nil {
+		return nil, dsess.InitialDbState{}, false, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !ok {
+		delete(p.revisions, revDB)
+		return nil, dsess.InitialDbState{}, false, nil
+	}
+	p.revisions[revDB] = db
+
+	return db, init, true, nil
+}
+
+// resolveRevision resolves a name of the form "mydb/revision" to a database and its initial session state.
+func (p DoltDatabaseProvider) resolveRevision(ctx context.Context, revDB string) (sql.Database, dsess.InitialDbState, bool, error) {
+	if !strings.Contains(revDB, DbRevisionDelimiter) {
+		return nil, dsess.InitialDbState{}, false, nil
+	}
+
+	parts := strings.SplitN(revDB, DbRevisionDelimiter, 2)
+	dbName, revSpec := parts[0], parts[1]
+
+	p.mu.RLock()
+	candidate, ok := p.databases[dbName]
+	p.mu.RUnlock()
+	if !ok {
+		return nil, dsess.InitialDbState{}, false, nil
+	}
+
+	srcDb, ok := candidate.(Database)
+	if !ok {
+		return nil, dsess.InitialDbState{}, false, nil
+	}
+
+	ddb := srcDb.GetDoltDB()
+
+	isBranch, err := ddb.HasRef(ctx, ref.NewBranchRef(revSpec))
+	if err != nil {
+		return nil, dsess.InitialDbState{}, false, err
+	}
+	if isBranch {
+		db, init, err := dbRevisionForBranch(ctx, srcDb, revDB, revSpec)
+		if err != nil {
+			return nil, dsess.InitialDbState{}, false, err
+		}
+		return db, init, true, nil
+	}
+
+	isTag, err := ddb.HasRef(ctx, ref.NewTagRef(revSpec))
+	if err != nil {
+		return nil, dsess.InitialDbState{}, false, err
+	}
+	if isTag {
+		tag, err := ddb.ResolveTag(ctx, ref.NewTagRef(revSpec))
+		if err != nil {
+			return nil, dsess.InitialDbState{}, false, err
+		}
+		db, init, err := dbRevisionForCommit(srcDb, revDB, revSpec, tag.Commit)
+		if err != nil {
+			return nil, dsess.InitialDbState{}, false, err
+		}
+		return db, init, true, nil
+	}
+
+	if hash.IsValid(revSpec) {
+		cs, err := doltdb.NewCommitSpec(revSpec)
+		if err != nil {
+			return nil, dsess.InitialDbState{}, false, err
+		}
+
+		cm, err := ddb.Resolve(ctx, cs, nil)
+		if err == doltdb.ErrHashNotFound {
+			return nil, dsess.InitialDbState{}, false, nil
+		} else if err != nil {
+			return nil, dsess.InitialDbState{}, false, err
+		}
+
+		db, init, err := dbRevisionForCommit(srcDb, revDB, revSpec, cm)
+		if err != nil {
+			return nil, dsess.InitialDbState{}, false, err
+		}
+		return db, init, true, nil
+	}
+
+	return nil, dsess.InitialDbState{}, false, nil
+}
+
+// revisionDatabase returns a database named |revDB| for a revision of |srcDb|. It shares the global state of |srcDb|,
+// so that sessions using either of them share auto increment values.
+func revisionDatabase(srcDb Database, revDB string) Database {
+	db := NewDatabase(revDB, srcDb.DbData())
+	db.gs = srcDb.gs
+	return db
+}
+
+// dbRevisionForBranch returns a read-write database for the branch named, with its working set as its initial state
+func dbRevisionForBranch(ctx context.Context, srcDb Database, revDB, branch string) (Database, dsess.InitialDbState, error) {
+	branchRef := ref.NewBranchRef(branch)
+	db := revisionDatabase(srcDb, revDB)
+
+	cm, err := srcDb.GetDoltDB().ResolveCommitRef(ctx, branchRef)
+	if err != nil {
+		return Database{}, dsess.InitialDbState{}, err
+	}
+
+	wsRef, err := ref.WorkingSetRefForHead(branchRef)
+	if err != nil {
+		return Database{}, dsess.InitialDbState{}, err
+	}
+
+	ws, err := srcDb.GetDoltDB().ResolveWorkingSet(ctx, wsRef)
+	if err == doltdb.ErrWorkingSetNotFound {
+		root, err := cm.GetRootValue()
+		if err != nil {
+			return Database{}, dsess.InitialDbState{}, err
+		}
+		ws = doltdb.EmptyWorkingSet(wsRef).WithWorkingRoot(root).WithStagedRoot(root)
+	} else if err != nil {
+		return Database{}, dsess.InitialDbState{}, err
+	}
+
+	init := dsess.InitialDbState{
+		Db:          db,
+		HeadCommit:  cm,
+		WorkingSet:  ws,
+		DbData:      srcDb.DbData(),
+		GlobalState: srcDb.GetGlobalState(),
+	}
+
+	return db, init, nil
+}
+
+// dbRevisionForCommit returns a read-only database for the commit given. Its working set is never persisted, so it
+// isn't associated with any branch.
+func dbRevisionForCommit(srcDb Database, revDB, revSpec string, cm *doltdb.Commit) (ReadOnlyDatabase, dsess.InitialDbState, error) {
+	db := ReadOnlyDatabase{Database: revisionDatabase(srcDb, revDB)}
+
+	root, err := cm.GetRootValue()
+	if err != nil {
+		return ReadOnlyDatabase{}, dsess.InitialDbState{}, err
+	}
+
+	init := dsess.InitialDbState{
+		Db:          db,
+		HeadCommit:  cm,
+		WorkingSet:  doltdb.EmptyWorkingSet(ref.NewWorkingSetRef(revSpec)).WithWorkingRoot(root).WithStagedRoot(root),
+		DbData:      srcDb.DbData(),
+		GlobalState: srcDb.GetGlobalState(),
+		ReadOnly:    true,
+	}
+
+	return db, init, nil
 }
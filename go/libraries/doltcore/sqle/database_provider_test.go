@@ -0,0 +1,217 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+func TestRevisionDatabases(t *testing.T) {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+
+	cs, err := doltdb.NewCommitSpec("master")
+	require.NoError(t, err)
+	cm, err := dEnv.DoltDB.Resolve(ctx, cs, nil)
+	require.NoError(t, err)
+	h, err := cm.HashOf()
+	require.NoError(t, err)
+
+	require.NoError(t, dEnv.DoltDB.NewBranchAtCommit(ctx, ref.NewBranchRef("feature"), cm))
+	require.NoError(t, dEnv.DoltDB.NewTagAtCommit(ctx, ref.NewTagRef("v1"), cm, doltdb.NewTagMeta("billy bob", "bigbillieb@fake.horse", "")))
+
+	pro := NewDoltDatabaseProvider(NewDatabase("dolt", dEnv.DbData()))
+
+	tests := []struct {
+		name     string
+		found    bool
+		readOnly bool
+	}{
+		{"dolt", true, false},
+		{"dolt/feature", true, false},
+		{"dolt/v1", true, true},
+		{"dolt/" + h.String(), true, true},
+		{"dolt/missing", false, false},
+		{"missing/feature", false, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, err := pro.Database(test.name)
+			if !test.found {
+				assert.True(t, sql.ErrDatabaseNotFound.Is(err))
+				assert.False(t, pro.HasDatabase(test.name))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.name, db.Name())
+
+			_, isReadOnly := db.(sql.ReadOnlyDatabase)
+			assert.Equal(t, test.readOnly, isReadOnly)
+		})
+	}
+
+	// revision databases are not listed alongside the databases the provider was created with
+	assert.Len(t, pro.AllDatabases(), 1)
+}
+
+func TestRevisionDatabaseSessionState(t *testing.T) {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+
+	cs, err := doltdb.NewCommitSpec("master")
+	require.NoError(t, err)
+	cm, err := dEnv.DoltDB.Resolve(ctx, cs, nil)
+	require.NoError(t, err)
+	require.NoError(t, dEnv.DoltDB.NewBranchAtCommit(ctx, ref.NewBranchRef("feature"), cm))
+
+	parent := NewDatabase("dolt", dEnv.DbData())
+	pro := NewDoltDatabaseProvider(parent)
+	sqlCtx := NewTestSQLCtx(ctx)
+	sess := dsess.DSessFromSess(sqlCtx.Session)
+	sess.SetRevisionDatabaseProvider(pro)
+
+	db, err := pro.Database("dolt/feature")
+	require.NoError(t, err)
+
+	_, err = db.(Database).StartTransaction(sqlCtx)
+	require.NoError(t, err)
+
+	root, err := db.(Database).GetRoot(sqlCtx)
+	require.NoError(t, err)
+	assert.NotNil(t, root)
+
+	headRef, err := sess.CWBHeadRef(sqlCtx, "dolt/feature")
+	require.NoError(t, err)
+	assert.Equal(t, ref.NewBranchRef("feature"), headRef)
+
+	// revisions share the auto increment trackers of the database they're a revision of
+	init, ok, err := pro.RevisionDbState(ctx, "dolt/feature")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Same(t, parent.GetGlobalState(), init.GlobalState)
+	assert.Same(t, parent.GetGlobalState().GetAutoIncrementTracker(init.WorkingSet.Ref()), init.GlobalState.GetAutoIncrementTracker(init.WorkingSet.Ref()))
+}
+
+func TestRevisionDatabaseSessionStateLoadedOnFirstUse(t *testing.T) {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+
+	cs, err := doltdb.NewCommitSpec("master")
+	require.NoError(t, err)
+	cm, err := dEnv.DoltDB.Resolve(ctx, cs, nil)
+	require.NoError(t, err)
+	require.NoError(t, dEnv.DoltDB.NewBranchAtCommit(ctx, ref.NewBranchRef("feature"), cm))
+
+	pro := NewDoltDatabaseProvider(NewDatabase("dolt", dEnv.DbData()))
+
+	// none of these start a transaction first, so each is the first path to reach the revision database's state
+	tests := []struct {
+		name string
+		use  func(sqlCtx *sql.Context, sess *dsess.Session, db Database) error
+	}{
+		{"CWBHeadRef", func(sqlCtx *sql.Context, sess *dsess.Session, db Database) error {
+			_, err := sess.CWBHeadRef(sqlCtx, db.Name())
+			return err
+		}},
+		{"WorkingSet", func(sqlCtx *sql.Context, sess *dsess.Session, db Database) error {
+			_, err := sess.WorkingSet(sqlCtx, db.Name())
+			return err
+		}},
+		{"GetHeadCommit", func(sqlCtx *sql.Context, sess *dsess.Session, db Database) error {
+			_, err := sess.GetHeadCommit(sqlCtx, db.Name())
+			return err
+		}},
+		{"Flush", func(sqlCtx *sql.Context, sess *dsess.Session, db Database) error {
+			return db.Flush(sqlCtx)
+		}},
+		{"TableEditSession", func(sqlCtx *sql.Context, sess *dsess.Session, db Database) error {
+			_, err := db.TableEditSession(sqlCtx, false)
+			return err
+		}},
+		{"GetAllTemporaryTables", func(sqlCtx *sql.Context, sess *dsess.Session, db Database) error {
+			_, err := db.GetAllTemporaryTables(sqlCtx)
+			return err
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sqlCtx := NewTestSQLCtx(ctx)
+			sess := dsess.DSessFromSess(sqlCtx.Session)
+			sess.SetRevisionDatabaseProvider(pro)
+
+			db, err := pro.Database("dolt/feature")
+			require.NoError(t, err)
+
+			require.NoError(t, test.use(sqlCtx, sess, db.(Database)))
+			_, ok := sess.GetRoots(sqlCtx, "dolt/feature")
+			assert.True(t, ok)
+		})
+	}
+
+	sqlCtx := NewTestSQLCtx(ctx)
+	sess := dsess.DSessFromSess(sqlCtx.Session)
+	sess.SetRevisionDatabaseProvider(pro)
+	_, err = sess.CWBHeadRef(sqlCtx, "dolt/missing")
+	assert.True(t, sql.ErrDatabaseNotFound.Is(err))
+}
+
+func TestRevisionDatabaseCache(t *testing.T) {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+
+	cs, err := doltdb.NewCommitSpec("master")
+	require.NoError(t, err)
+	cm, err := dEnv.DoltDB.Resolve(ctx, cs, nil)
+	require.NoError(t, err)
+	require.NoError(t, dEnv.DoltDB.NewBranchAtCommit(ctx, ref.NewBranchRef("feature"), cm))
+
+	pro := NewDoltDatabaseProvider(NewDatabase("dolt", dEnv.DbData()))
+
+	_, ok, err := pro.RevisionDbState(ctx, "dolt/feature")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Contains(t, pro.revisions, "dolt/feature")
+
+	db, err := pro.Database("dolt/feature")
+	require.NoError(t, err)
+	assert.Equal(t, pro.revisions["dolt/feature"], db)
+	assert.Len(t, pro.AllDatabases(), 1)
+
+	// a revision that no longer resolves is dropped from the cache
+	require.NoError(t, dEnv.DoltDB.DeleteBranch(ctx, ref.NewBranchRef("feature")))
+	_, ok, err = pro.RevisionDbState(ctx, "dolt/feature")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.False(t, pro.HasDatabase("dolt/feature"))
+
+	// dropping a database drops its revisions
+	_, err = pro.Database("dolt/master")
+	require.NoError(t, err)
+	assert.Contains(t, pro.revisions, "dolt/master")
+	pro.DropDatabase("dolt")
+	assert.Empty(t, pro.revisions)
+}
@@ -53,7 +53,7 @@ func (d DoltAddFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	allFlag := apr.Contains(cli.AllFlag)
 
 	dSess := dsess.DSessFromSess(ctx.Session)
-	roots, ok := dSess.GetRoots(dbName)
+	roots, ok := dSess.GetRoots(ctx, dbName)
 	if apr.NArg() == 0 && !allFlag {
 		return 1, fmt.Errorf("Nothing specified, nothing added. Maybe you wanted to say 'dolt add .'?")
 	} else if allFlag || apr.NArg() == 1 && apr.Arg(0) == "." {
@@ -104,6 +104,11 @@ func (d DoltAddFunc) String() string {
 	return fmt.Sprintf("DOLT_ADD(%s)", strings.Join(childrenStrings, ","))
 }
 
+// FunctionName implements the sql.FunctionExpression interface.
+func (d DoltAddFunc) FunctionName() string {
+	return DoltAddFuncName
+}
+
 func (d DoltAddFunc) Type() sql.Type {
 	return sql.Int8
 }
@@ -0,0 +1,217 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+const DoltCherryPickFuncName = "dolt_cherry_pick"
+
+type DoltCherryPickFunc struct {
+	expression.NaryExpression
+}
+
+// NewDoltCherryPickFunc creates a new DoltCherryPickFunc expression whose children represents the args passed in
+// DOLT_CHERRY_PICK.
+func NewDoltCherryPickFunc(ctx *sql.Context, args ...sql.Expression) (sql.Expression, error) {
+	return &DoltCherryPickFunc{expression.NaryExpression{ChildExpressions: args}}, nil
+}
+
+// Eval applies the changes of the commit named to the current branch and commits them, as `dolt cherry-pick` does.
+// If the changes conflict with HEAD, the conflicts are written to the session's working set and no commit is made.
+func (d DoltCherryPickFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	dbName := ctx.GetCurrentDatabase()
+
+	if len(dbName) == 0 {
+		return 1, fmt.Errorf("Empty database name.")
+	}
+
+	ap := cli.CreateCherryPickArgParser()
+	args, err := getDoltArgs(ctx, row, d.Children())
+	if err != nil {
+		return nil, err
+	}
+
+	apr, err := ap.Parse(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if apr.NArg() != 1 {
+		return 1, fmt.Errorf("%s takes exactly 1 argument", strings.ToUpper(DoltCherryPickFuncName))
+	}
+
+	dSess := dsess.DSessFromSess(ctx.Session)
+	dbData, ok := dSess.GetDbData(ctx, dbName)
+	if !ok {
+		return 1, fmt.Errorf("Could not load database %s", dbName)
+	}
+
+	ws, roots, err := cleanWorkingSetForCommit(ctx, dSess, dbName)
+	if err != nil {
+		return 1, err
+	}
+
+	cs, err := doltdb.NewCommitSpec(apr.Arg(0))
+	if err != nil {
+		return 1, err
+	}
+
+	cm, err := dbData.Ddb.Resolve(ctx, cs, dbData.Rsr.CWBHeadRef())
+	if err != nil {
+		return 1, err
+	}
+
+	meta, err := cm.GetCommitMeta()
+	if err != nil {
+		return nil, err
+	}
+
+	mergedRoot, tblToStats, err := merge.CherryPick(ctx, dbData.Ddb, roots.Head, cm)
+	if err != nil {
+		return 1, err
+	}
+
+	return commitMergedRoot(ctx, dSess, dbName, ws, roots, mergedRoot, tblToStats, meta.Description)
+}
+
+// cleanWorkingSetForCommit returns the working set and roots of the database named, or an error if the working set
+// has any changes relative to HEAD or an active merge.
+func cleanWorkingSetForCommit(ctx *sql.Context, dSess *dsess.Session, dbName string) (*doltdb.WorkingSet, doltdb.Roots, error) {
+	roots, ok := dSess.GetRoots(ctx, dbName)
+	if !ok {
+		return nil, doltdb.Roots{}, fmt.Errorf("Could not load database %s", dbName)
+	}
+
+	ws, err := dSess.WorkingSet(ctx, dbName)
+	if err != nil {
+		return nil, doltdb.Roots{}, err
+	}
+
+	if ws.MergeActive() {
+		return nil, doltdb.Roots{}, doltdb.ErrMergeActive
+	}
+
+	err = checkForUncommittedChanges(roots.Working, roots.Head)
+	if err != nil {
+		return nil, doltdb.Roots{}, err
+	}
+
+	err = checkForUncommittedChanges(roots.Staged, roots.Head)
+	if err != nil {
+		return nil, doltdb.Roots{}, err
+	}
+
+	return ws, roots, nil
+}
+
+// commitMergedRoot commits |mergedRoot| as a new dolt commit with the message given and returns the new commit's
+// hash. If |mergedRoot| has conflicts or constraint violations, it's written to the session's working set instead and
+// no commit is made.
+func commitMergedRoot(
+	ctx *sql.Context,
+	dSess *dsess.Session,
+	dbName string,
+	ws *doltdb.WorkingSet,
+	roots doltdb.Roots,
+	mergedRoot *doltdb.RootValue,
+	tblToStats map[string]*merge.MergeStats,
+	msg string,
+) (interface{}, error) {
+	if merge.HasConflictsOrViolations(tblToStats) {
+		// this error is recoverable in-session, so we write the new working set and return the error message
+		err := dSess.SetWorkingSet(ctx, dbName, ws.WithWorkingRoot(mergedRoot), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return doltdb.ErrUnresolvedConflicts.Error(), nil
+	}
+
+	roots.Working = mergedRoot
+	roots.Staged = mergedRoot
+
+	// Commit any pending transaction before a dolt commit
+	tx := ctx.Session.GetTransaction()
+	if _, ok := tx.(*dsess.DoltTransaction); !ok {
+		return nil, fmt.Errorf("expected a DoltTransaction, got %T", tx)
+	}
+
+	err := dSess.SetRoots(ctx, dbName, roots)
+	if err != nil {
+		return nil, err
+	}
+
+	err = dSess.CommitTransaction(ctx, dbName, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unsetting the transaction here ensures that it won't be re-committed when this statement concludes
+	ctx.SetTransaction(nil)
+
+	commit, err := dSess.CommitToDolt(ctx, roots, dbName, actions.CommitStagedProps{
+		Message:          msg,
+		Date:             ctx.QueryTime(),
+		AllowEmpty:       false,
+		CheckForeignKeys: true,
+		Name:             dSess.Username,
+		Email:            dSess.Email,
+	})
+	if err != nil {
+		return 1, err
+	}
+
+	cmHash, err := commit.HashOf()
+	if err != nil {
+		return nil, err
+	}
+
+	return cmHash.String(), nil
+}
+
+func (d DoltCherryPickFunc) String() string {
+	childrenStrings := make([]string, len(d.Children()))
+
+	for i, child := range d.Children() {
+		childrenStrings[i] = child.String()
+	}
+
+	return fmt.Sprintf("DOLT_CHERRY_PICK(%s)", strings.Join(childrenStrings, ","))
+}
+
+// FunctionName implements the sql.FunctionExpression interface.
+func (d DoltCherryPickFunc) FunctionName() string {
+	return DoltCherryPickFuncName
+}
+
+func (d DoltCherryPickFunc) Type() sql.Type {
+	return sql.Text
+}
+
+func (d DoltCherryPickFunc) WithChildren(ctx *sql.Context, children ...sql.Expression) (sql.Expression, error) {
+	return NewDoltCherryPickFunc(ctx, children...)
+}
@@ -0,0 +1,117 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+const DoltFetchFuncName = "dolt_fetch"
+
+type DoltFetchFunc struct {
+	expression.NaryExpression
+}
+
+// NewDoltFetchFunc creates a new DoltFetchFunc expression whose children represents the args passed in DOLT_FETCH.
+func NewDoltFetchFunc(ctx *sql.Context, args ...sql.Expression) (sql.Expression, error) {
+	return &DoltFetchFunc{expression.NaryExpression{ChildExpressions: args}}, nil
+}
+
+// Eval fetches the branches of a remote and updates their remote tracking branches, as `dolt fetch` does. The
+// progress of the fetch is reported as warnings.
+func (d DoltFetchFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	dbName := ctx.GetCurrentDatabase()
+
+	if len(dbName) == 0 {
+		return 1, fmt.Errorf("Empty database name.")
+	}
+
+	dSess := dsess.DSessFromSess(ctx.Session)
+	dbData, ok := dSess.GetDbData(ctx, dbName)
+
+	if !ok {
+		return 1, fmt.Errorf("Could not load database %s", dbName)
+	}
+
+	ap := cli.CreateFetchArgParser()
+	args, err := getDoltArgs(ctx, row, d.Children())
+
+	if err != nil {
+		return 1, err
+	}
+
+	apr, err := ap.Parse(args)
+	if err != nil {
+		return 1, err
+	}
+
+	remote, refSpecs, err := env.ParseRefSpecs(apr.Args(), dbData.Rsr)
+	if err != nil {
+		return 1, err
+	}
+
+	srcDB, err := remote.GetRemoteDBWithoutCaching(ctx, dbData.Ddb.ValueReadWriter().Format())
+	if err != nil {
+		return 1, fmt.Errorf("failed to get remote db: %w", err)
+	}
+
+	depth, err := cli.ParseDepth(apr)
+	if err != nil {
+		return 1, err
+	}
+
+	updateMode := ref.UpdateMode{Force: apr.Contains(cli.ForceFlag)}
+	err = actions.FetchRefSpecs(ctx, dbData, srcDB, refSpecs, remote, updateMode, depth, newProgStarter(ctx), stopProgFuncs)
+	if err == actions.ErrCantFF {
+		return 1, fmt.Errorf("fetch failed, can't fast forward remote tracking ref")
+	} else if err != nil {
+		return 1, fmt.Errorf("fetch failed: %w", err)
+	}
+
+	return 0, nil
+}
+
+func (d DoltFetchFunc) String() string {
+	childrenStrings := make([]string, len(d.Children()))
+
+	for i, child := range d.Children() {
+		childrenStrings[i] = child.String()
+	}
+
+	return fmt.Sprintf("DOLT_FETCH(%s)", strings.Join(childrenStrings, ","))
+}
+
+// FunctionName implements the sql.FunctionExpression interface.
+func (d DoltFetchFunc) FunctionName() string {
+	return DoltFetchFuncName
+}
+
+func (d DoltFetchFunc) Type() sql.Type {
+	return sql.Int8
+}
+
+func (d DoltFetchFunc) WithChildren(ctx *sql.Context, children ...sql.Expression) (sql.Expression, error) {
+	return NewDoltFetchFunc(ctx, children...)
+}
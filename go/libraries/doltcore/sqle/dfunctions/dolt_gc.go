@@ -0,0 +1,88 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+const DoltGCFuncName = "dolt_gc"
+
+type DoltGCFunc struct {
+}
+
+// NewDoltGCFunc creates a new DoltGCFunc expression.
+func NewDoltGCFunc(ctx *sql.Context) sql.Expression {
+	return &DoltGCFunc{}
+}
+
+// Eval garbage collects the current database, as `dolt gc` does. The values referenced by every session of the
+// server are kept, and other sessions keep running while it does, waiting only to commit transactions.
+func (d *DoltGCFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	dbName := ctx.GetCurrentDatabase()
+
+	if len(dbName) == 0 {
+		return 1, fmt.Errorf("Empty database name.")
+	}
+
+	dSess := dsess.DSessFromSess(ctx.Session)
+	err := dSess.GC(ctx, dbName)
+	if err != nil {
+		return 1, fmt.Errorf("gc failed: %w", err)
+	}
+
+	return 0, nil
+}
+
+// String implements the Stringer interface.
+func (d *DoltGCFunc) String() string {
+	return fmt.Sprint("DOLT_GC()")
+}
+
+// FunctionName implements the sql.FunctionExpression interface.
+func (d *DoltGCFunc) FunctionName() string {
+	return DoltGCFuncName
+}
+
+// IsNullable implements the Expression interface.
+func (d *DoltGCFunc) IsNullable() bool {
+	return false
+}
+
+// Resolved implements the Expression interface.
+func (*DoltGCFunc) Resolved() bool {
+	return true
+}
+
+func (d *DoltGCFunc) Type() sql.Type {
+	return sql.Int8
+}
+
+// Children implements the Expression interface.
+func (*DoltGCFunc) Children() []sql.Expression {
+	return nil
+}
+
+// WithChildren implements the Expression interface.
+func (d *DoltGCFunc) WithChildren(ctx *sql.Context, children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 0 {
+		return nil, sql.ErrInvalidChildrenNumber.New(d, len(children), 0)
+	}
+	return NewDoltGCFunc(ctx), nil
+}
@@ -45,7 +45,7 @@ func (d DoltMergeFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error)
 	}
 
 	sess := dsess.DSessFromSess(ctx.Session)
-	dbData, ok := sess.GetDbData(dbName)
+	dbData, ok := sess.GetDbData(ctx, dbName)
 
 	if !ok {
 		return 1, fmt.Errorf("Could not load database %s", dbName)
@@ -67,8 +67,12 @@ func (d DoltMergeFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error)
 		return 1, fmt.Errorf("error: Flags '--%s' and '--%s' cannot be used together.\n", cli.SquashParam, cli.NoFFParam)
 	}
 
-	ws := sess.WorkingSet(ctx, dbName)
-	roots, ok := sess.GetRoots(dbName)
+	ws, err := sess.WorkingSet(ctx, dbName)
+	if err != nil {
+		return 1, err
+	}
+
+	roots, ok := sess.GetRoots(ctx, dbName)
 
 	// logrus.Errorf("heads are working: %s\nhead: %s", roots.Working.DebugString(ctx, true), roots.Head.DebugString(ctx, true))
 
@@ -94,11 +98,23 @@ func (d DoltMergeFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error)
 		return "Merge aborted", nil
 	}
 
-	ddb, ok := sess.GetDoltDB(dbName)
+	ddb, ok := sess.GetDoltDB(ctx, dbName)
 	if !ok {
 		return nil, sql.ErrDatabaseNotFound.New(dbName)
 	}
 
+	branchName := apr.Arg(0)
+	mergeCommit, _, err := getBranchCommit(ctx, branchName, ddb)
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeIntoWorkingSet(ctx, sess, dbName, dbData, apr, ws, roots, mergeCommit)
+}
+
+// mergeIntoWorkingSet merges |mergeCommit| into the session's working set for |dbName|, as DOLT_MERGE does. The
+// result is the message for DOLT_MERGE to return.
+func mergeIntoWorkingSet(ctx *sql.Context, sess *dsess.Session, dbName string, dbData env.DbData, apr *argparser.ArgParseResults, ws *doltdb.WorkingSet, roots doltdb.Roots, mergeCommit *doltdb.Commit) (interface{}, error) {
 	if hasConflicts, err := roots.Working.HasConflicts(ctx); err != nil {
 		return 1, err
 	} else if hasConflicts {
@@ -115,13 +131,12 @@ func (d DoltMergeFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error)
 		return 1, doltdb.ErrMergeActive
 	}
 
-	err = checkForUncommittedChanges(roots.Working, roots.Head)
+	err := checkForUncommittedChanges(roots.Working, roots.Head)
 	if err != nil {
 		return nil, err
 	}
 
-	branchName := apr.Arg(0)
-	mergeCommit, cmh, err := getBranchCommit(ctx, branchName, ddb)
+	cmh, err := mergeCommit.HashOf()
 	if err != nil {
 		return nil, err
 	}
@@ -172,7 +187,7 @@ func (d DoltMergeFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error)
 	}
 
 	ws, err = executeMerge(ctx, apr.Contains(cli.SquashParam), headCommit, mergeCommit, ws)
-	if err == doltdb.ErrUnresolvedConflicts {
+	if err == doltdb.ErrUnresolvedConflicts || err == doltdb.ErrUnresolvedSchemaConflicts {
 		// if there are unresolved conflicts, write the resulting working set back to the session and return an
 		// error message
 		wsErr := sess.SetWorkingSet(ctx, dbName, ws, nil)
@@ -316,7 +331,7 @@ func executeNoFFMerge(
 	}
 
 	// The roots need refreshing after the above
-	roots, _ := dSess.GetRoots(dbName)
+	roots, _ := dSess.GetRoots(ctx, dbName)
 
 	// TODO: this does several session state updates, and it really needs to just do one
 	//  We also need to commit any pending transaction before we do this.
@@ -344,12 +359,26 @@ func mergeRootToWorking(
 	mergeStats map[string]*merge.MergeStats,
 ) (*doltdb.WorkingSet, error) {
 
+	if squash {
+		if err := merge.SquashSchemaConflictsErr(mergeStats); err != nil {
+			return nil, err
+		}
+	}
+
 	workingRoot := mergedRoot
+	schConflicts := merge.SchemaConflictTables(mergeStats)
 	if !squash {
 		ws = ws.StartMerge(cm2)
+		if len(schConflicts) > 0 {
+			ws = ws.WithMergeState(ws.MergeState().WithSchemaConflicts(schConflicts))
+		}
 	}
 
 	ws = ws.WithWorkingRoot(workingRoot).WithStagedRoot(workingRoot)
+	if len(schConflicts) > 0 {
+		// this error is recoverable in-session, so we return the new ws along with the error
+		return ws, doltdb.ErrUnresolvedSchemaConflicts
+	}
 	if checkForConflicts(mergeStats) {
 		// this error is recoverable in-session, so we return the new ws along with the error
 		return ws, doltdb.ErrUnresolvedConflicts
@@ -378,6 +407,11 @@ func (d DoltMergeFunc) String() string {
 	return fmt.Sprintf("DOLT_MERGE(%s)", strings.Join(childrenStrings, ","))
 }
 
+// FunctionName implements the sql.FunctionExpression interface.
+func (d DoltMergeFunc) FunctionName() string {
+	return DoltMergeFuncName
+}
+
 func (d DoltMergeFunc) Type() sql.Type {
 	return sql.Text
 }
@@ -0,0 +1,170 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+const DoltPullFuncName = "dolt_pull"
+
+type DoltPullFunc struct {
+	expression.NaryExpression
+}
+
+// NewDoltPullFunc creates a new DoltPullFunc expression whose children represents the args passed in DOLT_PULL.
+func NewDoltPullFunc(ctx *sql.Context, args ...sql.Expression) (sql.Expression, error) {
+	return &DoltPullFunc{expression.NaryExpression{ChildExpressions: args}}, nil
+}
+
+// Eval fetches the current branch from a remote and merges it into the session's working set, as `dolt pull` does.
+// Like DOLT_MERGE, it returns the hash of the merged commit or a message describing the conflicts of the merge.
+func (d DoltPullFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	dbName := ctx.GetCurrentDatabase()
+
+	if len(dbName) == 0 {
+		return 1, fmt.Errorf("Empty database name.")
+	}
+
+	sess := dsess.DSessFromSess(ctx.Session)
+	dbData, ok := sess.GetDbData(ctx, dbName)
+
+	if !ok {
+		return 1, fmt.Errorf("Could not load database %s", dbName)
+	}
+
+	ap := cli.CreatePullArgParser()
+	args, err := getDoltArgs(ctx, row, d.Children())
+
+	if err != nil {
+		return 1, err
+	}
+
+	apr, err := ap.Parse(args)
+	if err != nil {
+		return 1, err
+	}
+
+	if apr.NArg() > 1 {
+		return 1, fmt.Errorf("%s takes at most one arg", strings.ToUpper(DoltPullFuncName))
+	}
+
+	var remoteName string
+	if apr.NArg() == 1 {
+		remoteName = apr.Arg(0)
+	}
+
+	refSpecs, err := env.GetRefSpecs(dbData.Rsr, remoteName)
+	if err != nil {
+		return 1, err
+	}
+
+	if len(refSpecs) == 0 {
+		return 1, fmt.Errorf("no refspec for remote")
+	}
+
+	remotes, err := dbData.Rsr.GetRemotes()
+	if err != nil {
+		return 1, err
+	}
+
+	remote := remotes[refSpecs[0].GetRemote()]
+	srcDB, err := remote.GetRemoteDBWithoutCaching(ctx, dbData.Ddb.ValueReadWriter().Format())
+	if err != nil {
+		return 1, fmt.Errorf("failed to get remote db: %w", err)
+	}
+
+	branch := dbData.Rsr.CWBHeadRef()
+	tempTableDir := dbData.Rsr.TempTableFilesDir()
+
+	var result interface{} = "Already up to date"
+	for _, refSpec := range refSpecs {
+		remoteTrackRef := refSpec.DestRef(branch)
+
+		if remoteTrackRef == nil {
+			continue
+		}
+
+		srcDBCommit, err := actions.FetchRemoteBranch(ctx, tempTableDir, remote, srcDB, dbData.Ddb, branch, newProgStarter(ctx), stopProgFuncs)
+		if err != nil {
+			return 1, fmt.Errorf("fetch failed: %w", err)
+		}
+
+		err = dbData.Ddb.FastForward(ctx, remoteTrackRef, srcDBCommit)
+		if err != nil {
+			return 1, fmt.Errorf("fetch failed: %w", err)
+		}
+
+		roots, ok := sess.GetRoots(ctx, dbName)
+		if !ok {
+			return 1, fmt.Errorf("Could not load database %s", dbName)
+		}
+
+		ws, err := sess.WorkingSet(ctx, dbName)
+		if err != nil {
+			return 1, err
+		}
+
+		merged, err := mergeIntoWorkingSet(ctx, sess, dbName, dbData, apr, ws, roots, srcDBCommit)
+		if err == doltdb.ErrUpToDate || err == doltdb.ErrIsAhead {
+			// the remote branch has no commits that aren't on the current branch already
+			continue
+		} else if err != nil {
+			return merged, err
+		}
+
+		result = merged
+	}
+
+	err = actions.FetchFollowTags(ctx, tempTableDir, srcDB, dbData.Ddb, newProgStarter(ctx), stopProgFuncs)
+	if err != nil {
+		return 1, err
+	}
+
+	return result, nil
+}
+
+func (d DoltPullFunc) String() string {
+	childrenStrings := make([]string, len(d.Children()))
+
+	for i, child := range d.Children() {
+		childrenStrings[i] = child.String()
+	}
+
+	return fmt.Sprintf("DOLT_PULL(%s)", strings.Join(childrenStrings, ","))
+}
+
+// FunctionName implements the sql.FunctionExpression interface.
+func (d DoltPullFunc) FunctionName() string {
+	return DoltPullFuncName
+}
+
+func (d DoltPullFunc) Type() sql.Type {
+	return sql.Text
+}
+
+func (d DoltPullFunc) WithChildren(ctx *sql.Context, children ...sql.Expression) (sql.Expression, error) {
+	return NewDoltPullFunc(ctx, children...)
+}
@@ -0,0 +1,172 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/store/datas"
+)
+
+const DoltPushFuncName = "dolt_push"
+
+type DoltPushFunc struct {
+	expression.NaryExpression
+}
+
+// NewDoltPushFunc creates a new DoltPushFunc expression whose children represents the args passed in DOLT_PUSH.
+func NewDoltPushFunc(ctx *sql.Context, args ...sql.Expression) (sql.Expression, error) {
+	return &DoltPushFunc{expression.NaryExpression{ChildExpressions: args}}, nil
+}
+
+// Eval pushes a branch or tag to a remote, as `dolt push <remote> <refspec>` does. The progress of the push is
+// reported as warnings.
+func (d DoltPushFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	dbName := ctx.GetCurrentDatabase()
+
+	if len(dbName) == 0 {
+		return 1, fmt.Errorf("Empty database name.")
+	}
+
+	dSess := dsess.DSessFromSess(ctx.Session)
+	dbData, ok := dSess.GetDbData(ctx, dbName)
+
+	if !ok {
+		return 1, fmt.Errorf("Could not load database %s", dbName)
+	}
+
+	ap := cli.CreatePushArgParser()
+	args, err := getDoltArgs(ctx, row, d.Children())
+
+	if err != nil {
+		return 1, err
+	}
+
+	apr, err := ap.Parse(args)
+	if err != nil {
+		return 1, err
+	}
+
+	if apr.Contains(cli.SetUpstreamFlag) {
+		return 1, fmt.Errorf("--%s is not supported by %s", cli.SetUpstreamFlag, strings.ToUpper(DoltPushFuncName))
+	}
+
+	if apr.NArg() != 2 {
+		return 1, fmt.Errorf("usage: %s('<remote>', '<refspec>' [, '--force'])", strings.ToUpper(DoltPushFuncName))
+	}
+
+	remotes, err := dbData.Rsr.GetRemotes()
+	if err != nil {
+		return 1, err
+	}
+
+	remote, ok := remotes[apr.Arg(0)]
+	if !ok {
+		return 1, fmt.Errorf("%w '%s'", env.ErrUnknownRemote, apr.Arg(0))
+	}
+
+	refSpecStr, err := actions.DisambiguateRefSpecStr(ctx, dbData.Ddb, apr.Arg(1))
+	if err != nil {
+		return 1, err
+	}
+
+	refSpec, err := ref.ParseRefSpec(refSpecStr)
+	if err != nil {
+		return 1, fmt.Errorf("invalid refspec '%s': %w", refSpecStr, err)
+	}
+
+	src := refSpec.SrcRef(dbData.Rsr.CWBHeadRef())
+	dest := refSpec.DestRef(src)
+
+	destDB, err := remote.GetRemoteDB(ctx, dbData.Ddb.ValueReadWriter().Format())
+	if err != nil {
+		return 1, fmt.Errorf("failed to get remote db: %w", err)
+	}
+
+	mode := ref.UpdateMode{Force: apr.Contains(cli.ForceFlag)}
+
+	switch src.GetType() {
+	case ref.BranchRefType:
+		err = pushBranch(ctx, dbData, mode, src, dest, dbData.Ddb, destDB, remote)
+	case ref.TagRefType:
+		err = actions.PushTagToRemote(ctx, dbData.Rsr.TempTableFilesDir(), src, dest, dbData.Ddb, destDB, newProgStarter(ctx), stopProgFuncs)
+	default:
+		return 1, fmt.Errorf("cannot push ref %s of type %s", src.String(), src.GetType())
+	}
+
+	if err == doltdb.ErrUpToDate {
+		ctx.Warn(remoteWarningCode, "Everything up-to-date")
+	} else if err != nil {
+		return 1, err
+	}
+
+	return 0, nil
+}
+
+func pushBranch(ctx *sql.Context, dbData env.DbData, mode ref.UpdateMode, src, dest ref.DoltRef, localDB, remoteDB *doltdb.DoltDB, remote env.Remote) error {
+	remoteRef, err := actions.GetTrackingRef(dest, remote)
+	if err != nil {
+		return err
+	} else if remoteRef == nil {
+		return fmt.Errorf("remote '%s' has no fetch spec for '%s'", remote.Name, dest.GetPath())
+	}
+
+	if src == ref.EmptyBranchRef {
+		return actions.DeleteRemoteBranch(ctx, dest.(ref.BranchRef), remoteRef.(ref.RemoteRef), localDB, remoteDB)
+	}
+
+	err = actions.PushToRemoteBranch(ctx, dbData.Rsr, mode, src, dest, remoteRef, localDB, remoteDB, remote, newProgStarter(ctx), stopProgFuncs)
+	if err == doltdb.ErrIsAhead || err == actions.ErrCantFF || err == datas.ErrMergeNeeded {
+		return fmt.Errorf("failed to push some refs to '%s': updates were rejected because the tip of %s is behind its remote counterpart", remote.Url, src.GetPath())
+	} else if err != nil && !errors.Is(err, doltdb.ErrUpToDate) {
+		return fmt.Errorf("push failed: %w", err)
+	}
+
+	return err
+}
+
+func (d DoltPushFunc) String() string {
+	childrenStrings := make([]string, len(d.Children()))
+
+	for i, child := range d.Children() {
+		childrenStrings[i] = child.String()
+	}
+
+	return fmt.Sprintf("DOLT_PUSH(%s)", strings.Join(childrenStrings, ","))
+}
+
+// FunctionName implements the sql.FunctionExpression interface.
+func (d DoltPushFunc) FunctionName() string {
+	return DoltPushFuncName
+}
+
+func (d DoltPushFunc) Type() sql.Type {
+	return sql.Int8
+}
+
+func (d DoltPushFunc) WithChildren(ctx *sql.Context, children ...sql.Expression) (sql.Expression, error) {
+	return NewDoltPushFunc(ctx, children...)
+}
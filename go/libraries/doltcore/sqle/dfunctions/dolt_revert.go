@@ -0,0 +1,118 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+const DoltRevertFuncName = "dolt_revert"
+
+type DoltRevertFunc struct {
+	expression.NaryExpression
+}
+
+// NewDoltRevertFunc creates a new DoltRevertFunc expression whose children represents the args passed in DOLT_REVERT.
+func NewDoltRevertFunc(ctx *sql.Context, args ...sql.Expression) (sql.Expression, error) {
+	return &DoltRevertFunc{expression.NaryExpression{ChildExpressions: args}}, nil
+}
+
+// Eval reverts the changes of the commits named on the current branch and commits the result, as `dolt revert` does.
+// If the changes conflict with HEAD, the conflicts are written to the session's working set and no commit is made.
+func (d DoltRevertFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	dbName := ctx.GetCurrentDatabase()
+
+	if len(dbName) == 0 {
+		return 1, fmt.Errorf("Empty database name.")
+	}
+
+	ap := cli.CreateRevertArgParser()
+	args, err := getDoltArgs(ctx, row, d.Children())
+	if err != nil {
+		return nil, err
+	}
+
+	apr, err := ap.Parse(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if apr.NArg() == 0 {
+		return 1, fmt.Errorf("%s requires at least 1 argument", strings.ToUpper(DoltRevertFuncName))
+	}
+
+	dSess := dsess.DSessFromSess(ctx.Session)
+	dbData, ok := dSess.GetDbData(ctx, dbName)
+	if !ok {
+		return 1, fmt.Errorf("Could not load database %s", dbName)
+	}
+
+	ws, roots, err := cleanWorkingSetForCommit(ctx, dSess, dbName)
+	if err != nil {
+		return 1, err
+	}
+
+	commits := make([]*doltdb.Commit, apr.NArg())
+	for i, revision := range apr.Args() {
+		cs, err := doltdb.NewCommitSpec(revision)
+		if err != nil {
+			return 1, err
+		}
+
+		commits[i], err = dbData.Ddb.Resolve(ctx, cs, dbData.Rsr.CWBHeadRef())
+		if err != nil {
+			return 1, err
+		}
+	}
+
+	revertedRoot, tblToStats, msg, err := merge.Revert(ctx, dbData.Ddb, roots.Head, commits)
+	if err != nil {
+		return 1, err
+	}
+
+	return commitMergedRoot(ctx, dSess, dbName, ws, roots, revertedRoot, tblToStats, msg)
+}
+
+func (d DoltRevertFunc) String() string {
+	childrenStrings := make([]string, len(d.Children()))
+
+	for i, child := range d.Children() {
+		childrenStrings[i] = child.String()
+	}
+
+	return fmt.Sprintf("DOLT_REVERT(%s)", strings.Join(childrenStrings, ","))
+}
+
+// FunctionName implements the sql.FunctionExpression interface.
+func (d DoltRevertFunc) FunctionName() string {
+	return DoltRevertFuncName
+}
+
+func (d DoltRevertFunc) Type() sql.Type {
+	return sql.Text
+}
+
+func (d DoltRevertFunc) WithChildren(ctx *sql.Context, children ...sql.Expression) (sql.Expression, error) {
+	return NewDoltRevertFunc(ctx, children...)
+}
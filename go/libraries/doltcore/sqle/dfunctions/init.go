@@ -28,10 +28,36 @@ var DoltFunctions = []sql.Function{
 	sql.FunctionN{Name: DoltResetFuncName, Fn: NewDoltResetFunc},
 	sql.FunctionN{Name: DoltCheckoutFuncName, Fn: NewDoltCheckoutFunc},
 	sql.FunctionN{Name: DoltMergeFuncName, Fn: NewDoltMergeFunc},
+	sql.FunctionN{Name: DoltCherryPickFuncName, Fn: NewDoltCherryPickFunc},
+	sql.FunctionN{Name: DoltRevertFuncName, Fn: NewDoltRevertFunc},
+	sql.FunctionN{Name: DoltFetchFuncName, Fn: NewDoltFetchFunc},
+	sql.FunctionN{Name: DoltPushFuncName, Fn: NewDoltPushFunc},
+	sql.FunctionN{Name: DoltPullFuncName, Fn: NewDoltPullFunc},
+	sql.Function0{Name: DoltGCFuncName, Fn: NewDoltGCFunc},
 	sql.Function0{Name: ActiveBranchFuncName, Fn: NewActiveBranchFunc},
 	sql.Function2{Name: DoltMergeBaseFuncName, Fn: NewMergeBase},
 }
 
+// DoltWriteFunctionNames are the names of the DoltFunctions that change the database they're called against, which
+// users need privileges to write to.
+var DoltWriteFunctionNames = []string{
+	CommitFuncName,
+	MergeFuncName,
+	resetFuncName,
+	SquashFuncName,
+	DoltCommitFuncName,
+	DoltAddFuncName,
+	DoltResetFuncName,
+	DoltCheckoutFuncName,
+	DoltMergeFuncName,
+	DoltCherryPickFuncName,
+	DoltRevertFuncName,
+	DoltFetchFuncName,
+	DoltPushFuncName,
+	DoltPullFuncName,
+	DoltGCFuncName,
+}
+
 // These are the DoltFunctions that get exposed to Dolthub Api.
 var DolthubApiFunctions = []sql.Function{
 	sql.Function1{Name: HashOfFuncName, Fn: NewHashOf},
@@ -71,12 +71,12 @@ func (cf *MergeFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	}
 
 	dbName := sess.GetCurrentDatabase()
-	ddb, ok := sess.GetDoltDB(dbName)
+	ddb, ok := sess.GetDoltDB(ctx, dbName)
 	if !ok {
 		return nil, sql.ErrDatabaseNotFound.New(dbName)
 	}
 
-	root, ok := sess.GetRoot(dbName)
+	root, ok := sess.GetRoot(ctx, dbName)
 	if !ok {
 		return nil, sql.ErrDatabaseNotFound.New(dbName)
 	}
@@ -118,12 +118,16 @@ func (cf *MergeFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		}
 	}
 
-	mergeRoot, _, err := merge.MergeCommits(ctx, head, cm)
+	mergeRoot, mergeStats, err := merge.MergeCommits(ctx, head, cm)
 
 	if err != nil {
 		return nil, err
 	}
 
+	if err = schemaConflictsErr(mergeStats); err != nil {
+		return nil, err
+	}
+
 	h, err := ddb.WriteRootValue(ctx, mergeRoot)
 	if err != nil {
 		return nil, err
@@ -148,6 +152,14 @@ func (cf *MergeFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	return h.String(), nil
 }
 
+// schemaConflictsErr returns an error naming the tables in |tblToStats| whose schemas could not be merged, if any.
+func schemaConflictsErr(tblToStats map[string]*merge.MergeStats) error {
+	if tbls := merge.SchemaConflictTables(tblToStats); len(tbls) > 0 {
+		return fmt.Errorf("schema conflicts for tables: %s", strings.Join(tbls, ", "))
+	}
+	return nil
+}
+
 func checkForUncommittedChanges(root *doltdb.RootValue, headRoot *doltdb.RootValue) error {
 	rh, err := root.HashOf()
 
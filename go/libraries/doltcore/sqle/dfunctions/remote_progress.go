@@ -0,0 +1,108 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/store/datas"
+)
+
+// remoteProgressInterval is the minimum time between the warnings reporting the progress of a push or pull
+const remoteProgressInterval = time.Second
+
+// remoteWarningCode is the code of the warnings that remote operations report their progress and results with
+const remoteWarningCode = 0
+
+// newProgStarter returns an actions.ProgStarter that reports the progress of a push or pull as warnings of the session
+// running it, since a sql client has nowhere else to see it.
+func newProgStarter(ctx *sql.Context) actions.ProgStarter {
+	return func() (*sync.WaitGroup, chan datas.PullProgress, chan datas.PullerEvent) {
+		pullerEventCh := make(chan datas.PullerEvent, 128)
+		progChan := make(chan datas.PullProgress, 128)
+		wg := &sync.WaitGroup{}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			progFunc(ctx, progChan)
+		}()
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pullerProgFunc(ctx, pullerEventCh)
+		}()
+
+		return wg, progChan, pullerEventCh
+	}
+}
+
+// stopProgFuncs is the actions.ProgStopper for an actions.ProgStarter returned by newProgStarter
+func stopProgFuncs(wg *sync.WaitGroup, progChan chan datas.PullProgress, pullerEventCh chan datas.PullerEvent) {
+	close(progChan)
+	close(pullerEventCh)
+	wg.Wait()
+}
+
+func progFunc(ctx *sql.Context, progChan chan datas.PullProgress) {
+	var latest datas.PullProgress
+	var reported datas.PullProgress
+	last := time.Now()
+
+	for progress := range progChan {
+		latest = progress
+
+		if time.Since(last) > remoteProgressInterval && latest.KnownCount > 0 {
+			ctx.Warn(remoteWarningCode, "Counted chunks: %d, Buffered chunks: %d", latest.KnownCount, latest.DoneCount)
+			reported = latest
+			last = time.Now()
+		}
+	}
+
+	if latest.KnownCount > 0 && latest != reported {
+		ctx.Warn(remoteWarningCode, "Counted chunks: %d, Buffered chunks: %d", latest.KnownCount, latest.DoneCount)
+	}
+}
+
+func pullerProgFunc(ctx *sql.Context, pullerEventCh chan datas.PullerEvent) {
+	var tableFilesBuffered int
+	var filesUploaded int
+	last := time.Now()
+
+	for evt := range pullerEventCh {
+		switch evt.EventType {
+		case datas.TableFileClosedEvent:
+			tableFilesBuffered += 1
+		case datas.EndUploadTableFileEvent:
+			filesUploaded += 1
+		default:
+			continue
+		}
+
+		if time.Since(last) > remoteProgressInterval {
+			ctx.Warn(remoteWarningCode, "Files Written: %d, Files Uploaded: %d", tableFilesBuffered, filesUploaded)
+			last = time.Now()
+		}
+	}
+
+	if tableFilesBuffered > 0 || filesUploaded > 0 {
+		ctx.Warn(remoteWarningCode, "Files Written: %d, Files Uploaded: %d", tableFilesBuffered, filesUploaded)
+	}
+}
@@ -48,12 +48,12 @@ func (s SquashFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	}
 
 	dbName := sess.GetCurrentDatabase()
-	ddb, ok := sess.GetDoltDB(dbName)
+	ddb, ok := sess.GetDoltDB(ctx, dbName)
 	if !ok {
 		return nil, sql.ErrDatabaseNotFound.New(dbName)
 	}
 
-	root, ok := sess.GetRoot(dbName)
+	root, ok := sess.GetRoot(ctx, dbName)
 	if !ok {
 		return nil, sql.ErrDatabaseNotFound.New(dbName)
 	}
@@ -73,11 +73,15 @@ func (s SquashFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, err
 	}
 
-	mergeRoot, _, err := merge.MergeCommits(ctx, head, cm)
+	mergeRoot, mergeStats, err := merge.MergeCommits(ctx, head, cm)
 	if err != nil {
 		return nil, err
 	}
 
+	if err = schemaConflictsErr(mergeStats); err != nil {
+		return nil, err
+	}
+
 	h, err := ddb.WriteRootValue(ctx, mergeRoot)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,338 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsess
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/analyzer"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// GarbageCollector runs garbage collections of the databases used by a set of sessions, such as those of a server,
+// while the sessions keep running. Every value referenced by the state of a live session is kept. Sessions hold the
+// collector (see Session.HoldGC) while they write values which aren't referenced by their state yet, which keeps a
+// collection from starting until they're done, and keeps them from writing while it runs. Reads aren't blocked.
+type GarbageCollector struct {
+	// writes is held for reading by sessions while they write, and for writing by a collection
+	writes sync.RWMutex
+
+	mu       sync.Mutex
+	sessions map[*Session]func() bool
+}
+
+// NewGarbageCollector returns a GarbageCollector without any sessions.
+func NewGarbageCollector() *GarbageCollector {
+	return &GarbageCollector{sessions: make(map[*Session]func() bool)}
+}
+
+// AddSession adds |sess| to the sessions whose values are kept by collections. |closed| reports whether the session
+// has ended, after which it's forgotten. A nil |closed| is a session that never ends.
+func (gc *GarbageCollector) AddSession(sess *Session, closed func() bool) {
+	if closed == nil {
+		closed = func() bool { return false }
+	}
+
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+	gc.sessions[sess] = closed
+}
+
+// GC garbage collects |ddb|, keeping the values referenced by its branches and working sets and by every live session.
+// Sessions wait for it before committing transactions.
+func (gc *GarbageCollector) GC(ctx context.Context, ddb *doltdb.DoltDB) error {
+	gc.writes.Lock()
+	defer gc.writes.Unlock()
+
+	var keepers []hash.Hash
+	for _, sess := range gc.liveSessions() {
+		hs, err := sess.gcKeepers(ctx, ddb)
+		if err != nil {
+			return err
+		}
+
+		keepers = append(keepers, hs...)
+	}
+
+	return ddb.GC(ctx, keepers...)
+}
+
+// liveSessions returns the sessions of |gc| which haven't ended, and forgets the others.
+func (gc *GarbageCollector) liveSessions() []*Session {
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+
+	sessions := make([]*Session, 0, len(gc.sessions))
+	for sess, closed := range gc.sessions {
+		if closed() {
+			delete(gc.sessions, sess)
+			continue
+		}
+
+		sessions = append(sessions, sess)
+	}
+
+	return sessions
+}
+
+// SetGarbageCollector sets the collector that garbage collects this session's databases. This is only safe to do
+// during initialization.
+func (sess *Session) SetGarbageCollector(gc *GarbageCollector) {
+	sess.gc = gc
+}
+
+// GarbageCollector returns the collector that garbage collects this session's databases, or nil if it doesn't have
+// one.
+func (sess *Session) GarbageCollector() *GarbageCollector {
+	return sess.gc
+}
+
+// HoldGC keeps the session's GarbageCollector from starting a collection until the returned function is called, and
+// waits for one which is running to finish. Writes of values that aren't yet referenced by the session's state must
+// hold it until the state referencing them is set. Holds may be nested.
+func (sess *Session) HoldGC() (release func()) {
+	if sess.gc == nil {
+		return func() {}
+	}
+
+	sess.gcHoldsMu.Lock()
+	defer sess.gcHoldsMu.Unlock()
+	if sess.gcHolds == 0 {
+		sess.gc.writes.RLock()
+	}
+	sess.gcHolds++
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			sess.gcHoldsMu.Lock()
+			defer sess.gcHoldsMu.Unlock()
+			sess.gcHolds--
+			if sess.gcHolds == 0 {
+				sess.gc.writes.RUnlock()
+			}
+		})
+	}
+}
+
+// GC garbage collects the database named with the session's GarbageCollector, keeping the values referenced by every
+// session it has. A session without a GarbageCollector is the only session whose values are kept. The session's own
+// holds are released while the collection runs, so it can be called by a query which holds the collector.
+func (sess *Session) GC(ctx *sql.Context, dbName string) error {
+	ddb, ok := sess.GetDoltDB(ctx, dbName)
+	if !ok {
+		return sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	gc := sess.gc
+	if gc == nil {
+		gc = NewGarbageCollector()
+		gc.AddSession(sess, nil)
+		return gc.GC(ctx, ddb)
+	}
+
+	sess.gcHoldsMu.Lock()
+	defer sess.gcHoldsMu.Unlock()
+	if sess.gcHolds > 0 {
+		gc.writes.RUnlock()
+		defer gc.writes.RLock()
+	}
+
+	return gc.GC(ctx, ddb)
+}
+
+// gcKeepers returns the hashes of the values referenced by the state of this session for |ddb|, which must be kept by
+// a garbage collection: the working and staged roots, head commit, merge state and temporary tables of each database,
+// and the roots the current transaction started from and its savepoints. The state is read under dbStatesMu, since
+// the session may be running a query on another goroutine.
+func (sess *Session) gcKeepers(ctx context.Context, ddb *doltdb.DoltDB) ([]hash.Hash, error) {
+	roots, commits := sess.gcKeeperState(ddb)
+
+	var keepers []hash.Hash
+	for _, cm := range commits {
+		h, err := cm.HashOf()
+		if err != nil {
+			return nil, err
+		}
+
+		keepers = append(keepers, h)
+	}
+
+	for _, root := range roots {
+		if root == nil {
+			continue
+		}
+
+		h, err := ddb.WriteGCKeeper(ctx, root)
+		if err != nil {
+			return nil, err
+		}
+
+		keepers = append(keepers, h)
+	}
+
+	return keepers, nil
+}
+
+// gcKeeperState returns the roots and commits referenced by the state of this session for |ddb|.
+func (sess *Session) gcKeeperState(ddb *doltdb.DoltDB) ([]*doltdb.RootValue, []*doltdb.Commit) {
+	sess.dbStatesMu.Lock()
+	defer sess.dbStatesMu.Unlock()
+
+	var roots []*doltdb.RootValue
+	var commits []*doltdb.Commit
+	for _, dbState := range sess.DbStates {
+		if dbState.dbData.Ddb != ddb {
+			continue
+		}
+
+		if dbState.headCommit != nil {
+			commits = append(commits, dbState.headCommit)
+		}
+
+		if ws := dbState.WorkingSet; ws != nil {
+			roots = append(roots, ws.WorkingRoot(), ws.StagedRoot())
+
+			if ws.MergeActive() {
+				commits = append(commits, ws.MergeState().Commits()...)
+				roots = append(roots, ws.MergeState().PreMergeWorkingRoot())
+			}
+		}
+
+		roots = append(roots, dbState.TempTableRoot)
+	}
+
+	if tx, ok := sess.Session.GetTransaction().(*DoltTransaction); ok && tx.dbData.Ddb == ddb && tx.startState != nil {
+		roots = append(roots, tx.startState.WorkingRoot(), tx.startState.StagedRoot())
+		roots = append(roots, tx.savepointRoots()...)
+	}
+
+	return roots, commits
+}
+
+// GCHoldRuleName is the name of the analyzer rule returned by NewGCHoldRule.
+const GCHoldRuleName = "gc_hold"
+
+// NewGCHoldRule returns an analyzer rule which makes each query that writes to a database hold the session's
+// GarbageCollector (see Session.HoldGC) from when it starts running until its results are closed. A query writes if it
+// changes rows or schemas, or calls one of the functions named in |writeFuncNames|.
+func NewGCHoldRule(writeFuncNames []string) analyzer.RuleFunc {
+	writeFuncs := make(map[string]bool, len(writeFuncNames))
+	for _, name := range writeFuncNames {
+		writeFuncs[strings.ToLower(name)] = true
+	}
+
+	return func(ctx *sql.Context, a *analyzer.Analyzer, n sql.Node, scope *analyzer.Scope) (sql.Node, error) {
+		if scope != nil || !n.Resolved() {
+			return n, nil
+		}
+
+		if _, ok := n.(*gcHold); ok || !queryWrites(n, writeFuncs) {
+			return n, nil
+		}
+
+		return &gcHold{plan.UnaryNode{Child: n}}, nil
+	}
+}
+
+// queryWrites returns whether the query |n| writes to a database.
+func queryWrites(n sql.Node, writeFuncs map[string]bool) bool {
+	writes := false
+	plan.Inspect(n, func(n sql.Node) bool {
+		switch n.(type) {
+		case *plan.InsertInto, *plan.Update, *plan.DeleteFrom, *plan.Call,
+			*plan.AlterAutoIncrement, *plan.AlterDefaultSet, *plan.AlterDefaultDrop:
+			writes = true
+		default:
+			writes = writes || plan.IsDDLNode(n)
+		}
+		return !writes
+	})
+
+	if writes {
+		return true
+	}
+
+	plan.InspectExpressions(n, func(e sql.Expression) bool {
+		if f, ok := e.(sql.FunctionExpression); ok && writeFuncs[strings.ToLower(f.FunctionName())] {
+			writes = true
+		}
+		return !writes
+	})
+
+	return writes
+}
+
+// gcHold is a node which holds the session's GarbageCollector while its child runs.
+type gcHold struct {
+	plan.UnaryNode
+}
+
+var _ sql.Node = (*gcHold)(nil)
+
+// RowIter implements the sql.Node interface.
+func (h *gcHold) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	release := DSessFromSess(ctx.Session).HoldGC()
+
+	iter, err := h.Child.RowIter(ctx, row)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	return &gcHoldIter{iter: iter, release: release}, nil
+}
+
+// WithChildren implements the sql.Node interface.
+func (h *gcHold) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(h, len(children), 1)
+	}
+
+	return &gcHold{plan.UnaryNode{Child: children[0]}}, nil
+}
+
+// String implements the sql.Node interface. The node isn't shown in query plans.
+func (h *gcHold) String() string {
+	return h.Child.String()
+}
+
+// DebugString implements the sql.DebugStringer interface.
+func (h *gcHold) DebugString() string {
+	return sql.DebugString(h.Child)
+}
+
+// gcHoldIter releases a hold of a GarbageCollector when it's closed.
+type gcHoldIter struct {
+	iter    sql.RowIter
+	release func()
+}
+
+// Next implements the sql.RowIter interface.
+func (itr *gcHoldIter) Next() (sql.Row, error) {
+	return itr.iter.Next()
+}
+
+// Close implements the sql.RowIter interface.
+func (itr *gcHoldIter) Close(ctx *sql.Context) error {
+	defer itr.release()
+	return itr.iter.Close(ctx)
+}
@@ -0,0 +1,180 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsess
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/datas"
+)
+
+// replicationPushTimeout bounds how long a transaction commit waits to push to its replication remote
+const replicationPushTimeout = 5 * time.Minute
+
+// replicationWarningCode is the code of the warning a transaction commit reports a failed push with
+const replicationWarningCode = 0
+
+// ReplicationConfig configures how the databases of a session are replicated through a remote.
+type ReplicationConfig struct {
+	// Remote is the name of the remote that databases are replicated through. Every database replicated must have a
+	// remote with this name. No replication happens when it's empty.
+	Remote string
+	// ReadReplica is true for sessions that pull the current branch and working set of each database from Remote
+	// before every transaction. Otherwise every transaction commit pushes them to Remote.
+	ReadReplica bool
+}
+
+// Enabled returns whether this config replicates anything
+func (cfg ReplicationConfig) Enabled() bool {
+	return cfg.Remote != ""
+}
+
+// SetReplicationConfig sets how the databases of this session are replicated. This is only safe to do during
+// initialization.
+func (sess *Session) SetReplicationConfig(cfg ReplicationConfig) {
+	sess.replication = cfg
+}
+
+// GetReplicationRemote returns the remote that the database described by |rsr| is replicated through according to
+// |cfg|.
+func GetReplicationRemote(cfg ReplicationConfig, rsr env.RepoStateReader) (env.Remote, error) {
+	remotes, err := rsr.GetRemotes()
+	if err != nil {
+		return env.NoRemote, err
+	}
+
+	remote, ok := remotes[cfg.Remote]
+	if !ok {
+		return env.NoRemote, fmt.Errorf("%w '%s'", env.ErrUnknownRemote, cfg.Remote)
+	}
+
+	return remote, nil
+}
+
+// pushToReplicationRemote pushes the current branch and working set of the database named to the replication remote,
+// if this session has one and isn't a read replica.
+func (sess *Session) pushToReplicationRemote(ctx *sql.Context, dbName string) error {
+	if !sess.replication.Enabled() || sess.replication.ReadReplica {
+		return nil
+	}
+
+	return sess.replicate(ctx, dbName, func(localDB, remoteDB *doltdb.DoltDB, branchRef ref.BranchRef, tempTableDir string) error {
+		// The transaction has already committed, and the context of the query that committed it may already be
+		// canceled, but the replica needs the commit regardless. An unreachable remote mustn't hold the session forever.
+		pushCtx, cancel := context.WithTimeout(context.Background(), replicationPushTimeout)
+		defer cancel()
+
+		return actions.ReplicateBranch(pushCtx, tempTableDir, localDB, remoteDB, branchRef, silentProgStarter, silentProgStopper)
+	})
+}
+
+// pullFromReplicationRemote pulls the current branch and working set of the database named from the replication
+// remote, if this session is a read replica.
+func (sess *Session) pullFromReplicationRemote(ctx *sql.Context, dbName string) error {
+	if !sess.replication.Enabled() || !sess.replication.ReadReplica {
+		return nil
+	}
+
+	return sess.replicate(ctx, dbName, func(localDB, remoteDB *doltdb.DoltDB, branchRef ref.BranchRef, tempTableDir string) error {
+		// The remote is written to by another process, so our view of it may be stale
+		err := remoteDB.Rebase(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = actions.ReplicateBranch(ctx, tempTableDir, remoteDB, localDB, branchRef, silentProgStarter, silentProgStopper)
+		if errors.Is(err, datas.ErrOptimisticLockFailed) {
+			// another session of this server replicated the working set first
+			return nil
+		}
+
+		return err
+	})
+}
+
+func (sess *Session) replicate(ctx *sql.Context, dbName string, replicateFunc func(localDB, remoteDB *doltdb.DoltDB, branchRef ref.BranchRef, tempTableDir string) error) error {
+	sessionState, ok, err := sess.LookupDbState(ctx, dbName)
+	if err != nil {
+		return err
+	} else if !ok || sessionState.readOnly || sessionState.remotesRsr == nil {
+		return nil
+	}
+
+	remote, err := GetReplicationRemote(sess.replication, sessionState.remotesRsr)
+	if err != nil {
+		return err
+	}
+
+	localDB := sessionState.dbData.Ddb
+	remoteDB, err := remote.GetRemoteDB(ctx, localDB.Format())
+	if err != nil {
+		return err
+	}
+
+	headRef, err := sessionState.WorkingSet.Ref().ToHeadRef()
+	if err != nil {
+		return err
+	}
+
+	branchRef, ok := headRef.(ref.BranchRef)
+	if !ok {
+		return nil
+	}
+
+	err = replicateFunc(localDB, remoteDB, branchRef, sessionState.remotesRsr.TempTableFilesDir())
+	if err != nil {
+		return fmt.Errorf("failed to replicate database %s through remote '%s': %w", dbName, remote.Name, err)
+	}
+
+	return nil
+}
+
+func silentProgStarter() (*sync.WaitGroup, chan datas.PullProgress, chan datas.PullerEvent) {
+	pullerEventCh := make(chan datas.PullerEvent, 128)
+	progChan := make(chan datas.PullProgress, 128)
+	wg := &sync.WaitGroup{}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for range progChan {
+		}
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for range pullerEventCh {
+		}
+	}()
+
+	return wg, progChan, pullerEventCh
+}
+
+func silentProgStopper(wg *sync.WaitGroup, progChan chan datas.PullProgress, pullerEventCh chan datas.PullerEvent) {
+	close(progChan)
+	close(pullerEventCh)
+	wg.Wait()
+}
@@ -15,9 +15,11 @@
 package dsess
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/sirupsen/logrus"
@@ -128,21 +130,41 @@ type Session struct {
 	Username  string
 	Email     string
 	// TODO: make this private again
-	DbStates map[string]*DatabaseSessionState
+	DbStates    map[string]*DatabaseSessionState
+	provider    RevisionDatabaseProvider
+	replication ReplicationConfig
+	gc          *GarbageCollector
+	gcHolds     int
+	gcHoldsMu   sync.Mutex
+	// dbStatesMu guards DbStates, along with the working sets, head commits and temporary table roots of its states and
+	// the session's transaction, which a GarbageCollector reads from another goroutine. The session's own goroutine
+	// holds it only to change them.
+	dbStatesMu sync.Mutex
+}
+
+// RevisionDatabaseProvider provides the initial session state for databases that aren't known to a session when it's
+// created, such as those that name a specific revision of a database, e.g. "mydb/mybranch".
+type RevisionDatabaseProvider interface {
+	// RevisionDbState returns the InitialDbState for the revision database named, and whether it could be found.
+	RevisionDbState(ctx context.Context, revDB string) (InitialDbState, bool, error)
 }
 
 type DatabaseSessionState struct {
-	dbName               string
-	headCommit           *doltdb.Commit
-	detachedHead         bool
-	headRoot             *doltdb.RootValue
-	WorkingSet           *doltdb.WorkingSet
-	dbData               env.DbData
+	dbName       string
+	headCommit   *doltdb.Commit
+	detachedHead bool
+	headRoot     *doltdb.RootValue
+	WorkingSet   *doltdb.WorkingSet
+	dbData       env.DbData
+	// remotesRsr and remotesRsw read and write the remotes of the repo state, which aren't tracked by the session
+	remotesRsr           env.RepoStateReader
+	remotesRsw           env.RepoStateWriter
 	EditSession          *editor.TableEditSession
 	dirty                bool
 	TempTableRoot        *doltdb.RootValue
 	TempTableEditSession *editor.TableEditSession
 	GlobalState          globalstate.GlobalState
+	readOnly             bool
 }
 
 func (d DatabaseSessionState) GetRoots() doltdb.Roots {
@@ -172,6 +194,9 @@ type InitialDbState struct {
 	WorkingSet  *doltdb.WorkingSet
 	DbData      env.DbData
 	GlobalState globalstate.GlobalState
+	// ReadOnly is true for databases whose HEAD isn't a branch, such as tags and commits. Their working set is never
+	// persisted.
+	ReadOnly bool
 }
 
 // NewSession creates a Session object from a standard sql.Session and 0 or more Database objects.
@@ -201,6 +226,37 @@ func (sess *Session) EnableBatchedMode() {
 	sess.BatchMode = Batched
 }
 
+// SetRevisionDatabaseProvider sets the provider used to load the state of databases this session doesn't yet know
+// about. This is only safe to do during initialization.
+func (sess *Session) SetRevisionDatabaseProvider(provider RevisionDatabaseProvider) {
+	sess.provider = provider
+}
+
+// LookupDbState returns the session state for the database named, loading it from the session's
+// RevisionDatabaseProvider if this is the first time the session has seen it.
+func (sess *Session) LookupDbState(ctx *sql.Context, dbName string) (*DatabaseSessionState, bool, error) {
+	dbState, ok := sess.DbStates[dbName]
+	if ok {
+		return dbState, true, nil
+	}
+
+	if sess.provider == nil {
+		return nil, false, nil
+	}
+
+	init, ok, err := sess.provider.RevisionDbState(ctx, dbName)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	err = sess.AddDB(ctx, init)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return sess.DbStates[dbName], true, nil
+}
+
 // DSessFromSess retrieves a dolt session from a standard sql.Session
 func DSessFromSess(sess sql.Session) *Session {
 	return sess.(*Session)
@@ -210,8 +266,16 @@ func DSessFromSess(sess sql.Session) *Session {
 // happens automatically as part of statement execution, and is only necessary when the session is manually batched (as
 // for bulk SQL import)
 func (sess *Session) Flush(ctx *sql.Context, dbName string) error {
-	editSession := sess.DbStates[dbName].EditSession
-	newRoot, err := editSession.Flush(ctx)
+	defer sess.HoldGC()()
+
+	sessionState, ok, err := sess.LookupDbState(ctx, dbName)
+	if err != nil {
+		return err
+	} else if !ok {
+		return sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	newRoot, err := sessionState.EditSession.Flush(ctx)
 	if err != nil {
 		return err
 	}
@@ -226,13 +290,43 @@ func (d DisabledTransaction) String() string {
 	return "Disabled transaction"
 }
 
+// SetTransaction implements sql.Session. It holds dbStatesMu, since a GarbageCollector reads the transaction.
+func (sess *Session) SetTransaction(tx sql.Transaction) {
+	sess.dbStatesMu.Lock()
+	defer sess.dbStatesMu.Unlock()
+	sess.Session.SetTransaction(tx)
+}
+
 // CommitTransaction commits the in-progress transaction for the database named
 func (sess *Session) StartTransaction(ctx *sql.Context, dbName string) (sql.Transaction, error) {
 	if TransactionsDisabled(ctx) {
 		return DisabledTransaction{}, nil
 	}
 
-	sessionState := sess.DbStates[dbName]
+	sessionState, ok, err := sess.LookupDbState(ctx, dbName)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	// Read-only databases have no working set to resolve, and nothing to commit
+	if sessionState.readOnly {
+		return DisabledTransaction{}, nil
+	}
+
+	// A read replica sees the latest changes of its primary at the start of every transaction. A session with
+	// uncommitted changes, as during DOLT_COMMIT, keeps what it has.
+	if !sessionState.dirty {
+		// the pulled chunks aren't referenced by the session until its working set is set below
+		release := sess.HoldGC()
+		defer release()
+
+		err = sess.pullFromReplicationRemote(ctx, dbName)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	wsRef := sessionState.WorkingSet.Ref()
 	ws, err := sessionState.dbData.Ddb.ResolveWorkingSet(ctx, wsRef)
@@ -257,7 +351,7 @@ func (sess *Session) StartTransaction(ctx *sql.Context, dbName string) (sql.Tran
 }
 
 func (sess *Session) newWorkingSetForHead(ctx *sql.Context, wsRef ref.WorkingSetRef, dbName string) (*doltdb.WorkingSet, error) {
-	dbData, _ := sess.GetDbData(dbName)
+	dbData, _ := sess.GetDbData(ctx, dbName)
 
 	headSpec, _ := doltdb.NewCommitSpec("HEAD")
 	headRef, err := wsRef.ToHeadRef()
@@ -291,17 +385,16 @@ func (sess *Session) CommitTransaction(ctx *sql.Context, dbName string, tx sql.T
 		return nil
 	}
 
-	if !sess.DbStates[dbName].dirty {
-		return nil
-	}
-
 	// This is triggered when certain commands are sent to the server (ex. commit) when a database is not selected.
 	// These commands should not error.
 	if dbName == "" {
 		return nil
 	}
 
-	dbstate, ok := sess.DbStates[dbName]
+	dbstate, ok, err := sess.LookupDbState(ctx, dbName)
+	if err != nil {
+		return err
+	}
 	// It's possible that this returns false if the user has created an in-Memory database. Moreover,
 	// the analyzer will check for us whether a db exists or not.
 	// TODO: fix this
@@ -309,6 +402,12 @@ func (sess *Session) CommitTransaction(ctx *sql.Context, dbName string, tx sql.T
 		return nil
 	}
 
+	if !dbstate.dirty {
+		return nil
+	}
+
+	defer sess.HoldGC()()
+
 	// Newer commit path does a concurrent merge of the current root with the one other clients are editing, then
 	// updates the session with this new root.
 	// TODO: validate that the transaction belongs to the DB named
@@ -338,6 +437,14 @@ func (sess *Session) CommitTransaction(ctx *sql.Context, dbName string, tx sql.T
 	}
 
 	dbstate.dirty = false
+
+	// The commit has succeeded locally whether or not the push does, so a failed push is reported without failing it
+	err = sess.pushToReplicationRemote(ctx, dbName)
+	if err != nil {
+		logrus.Warnf("transaction committed, but not replicated: %s", err.Error())
+		ctx.Warn(replicationWarningCode, "transaction committed, but not replicated: %s", err.Error())
+	}
+
 	return nil
 }
 
@@ -347,8 +454,10 @@ func (sess *Session) CommitToDolt(
 	dbName string,
 	props actions.CommitStagedProps,
 ) (*doltdb.Commit, error) {
-	sessionState := sess.DbStates[dbName]
-	dbData := sessionState.dbData
+	dbData, ok := sess.GetDbData(ctx, dbName)
+	if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(dbName)
+	}
 
 	// TODO: this does several session state updates, and it really needs to just do one
 	//  It's also not atomic with the above commit. We need a way to set both new HEAD and update the working
@@ -368,7 +477,11 @@ func (sess *Session) CommitToDolt(
 	// repo state writer, so we're never persisting the new working set to disk like in a command line context.
 	// TODO: fix this mess
 
-	ws := sess.WorkingSet(ctx, dbName)
+	ws, err := sess.WorkingSet(ctx, dbName)
+	if err != nil {
+		return nil, err
+	}
+
 	// StartTransaction sets the working set for the session, and we want the one we previous had, not the one on disk
 	// Updating the working set like this also updates the head commit and root info for the session
 	tx, err := sess.StartTransaction(ctx, dbName)
@@ -406,8 +519,10 @@ func (sess *Session) CreateDoltCommit(ctx *sql.Context, dbName string) error {
 		return err
 	}
 
-	sessionState := sess.DbStates[dbName]
-	roots := sessionState.GetRoots()
+	roots, ok := sess.GetRoots(ctx, dbName)
+	if !ok {
+		return sql.ErrDatabaseNotFound.New(dbName)
+	}
 
 	roots, err = actions.StageAllTablesNoDocs(ctx, roots)
 	if err != nil {
@@ -435,7 +550,14 @@ func (sess *Session) RollbackTransaction(ctx *sql.Context, dbName string, tx sql
 		return nil
 	}
 
-	if !sess.DbStates[dbName].dirty {
+	dbState, ok, err := sess.LookupDbState(ctx, dbName)
+	if err != nil {
+		return err
+	} else if !ok {
+		return sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	if !dbState.dirty {
 		return nil
 	}
 
@@ -444,12 +566,12 @@ func (sess *Session) RollbackTransaction(ctx *sql.Context, dbName string, tx sql
 		return fmt.Errorf("expected a DoltTransaction")
 	}
 
-	err := sess.SetRoot(ctx, dbName, dtx.startState.WorkingRoot())
+	err = sess.SetRoot(ctx, dbName, dtx.startState.WorkingRoot())
 	if err != nil {
 		return err
 	}
 
-	sess.DbStates[dbName].dirty = false
+	dbState.dirty = false
 	return nil
 }
 
@@ -465,7 +587,12 @@ func (sess *Session) CreateSavepoint(ctx *sql.Context, savepointName, dbName str
 		return fmt.Errorf("expected a DoltTransaction")
 	}
 
-	dtx.CreateSavepoint(savepointName, sess.DbStates[dbName].GetRoots().Working)
+	roots, ok := sess.GetRoots(ctx, dbName)
+	if !ok {
+		return sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	dtx.CreateSavepoint(savepointName, roots.Working)
 	return nil
 }
 
@@ -515,46 +642,45 @@ func (sess *Session) ReleaseSavepoint(ctx *sql.Context, savepointName, dbName st
 }
 
 // GetDoltDB returns the *DoltDB for a given database by name
-func (sess *Session) GetDoltDB(dbName string) (*doltdb.DoltDB, bool) {
-	dbstate, ok := sess.DbStates[dbName]
-	if !ok {
+func (sess *Session) GetDoltDB(ctx *sql.Context, dbName string) (*doltdb.DoltDB, bool) {
+	dbstate, ok, err := sess.LookupDbState(ctx, dbName)
+	if err != nil || !ok {
 		return nil, false
 	}
 
 	return dbstate.dbData.Ddb, true
 }
 
-func (sess *Session) GetDoltDBRepoStateWriter(dbName string) (env.RepoStateWriter, bool) {
-	d, ok := sess.DbStates[dbName]
-	if !ok {
+func (sess *Session) GetDoltDBRepoStateWriter(ctx *sql.Context, dbName string) (env.RepoStateWriter, bool) {
+	d, ok, err := sess.LookupDbState(ctx, dbName)
+	if err != nil || !ok {
 		return nil, false
 	}
 
 	return d.dbData.Rsw, true
 }
 
-func (sess *Session) GetDoltDBRepoStateReader(dbName string) (env.RepoStateReader, bool) {
-	d, ok := sess.DbStates[dbName]
-	if !ok {
+func (sess *Session) GetDoltDBRepoStateReader(ctx *sql.Context, dbName string) (env.RepoStateReader, bool) {
+	d, ok, err := sess.LookupDbState(ctx, dbName)
+	if err != nil || !ok {
 		return nil, false
 	}
 
 	return d.dbData.Rsr, true
 }
 
-func (sess *Session) GetDoltDBDocsReadWriter(dbName string) (env.DocsReadWriter, bool) {
-	d, ok := sess.DbStates[dbName]
-	if !ok {
+func (sess *Session) GetDoltDBDocsReadWriter(ctx *sql.Context, dbName string) (env.DocsReadWriter, bool) {
+	d, ok, err := sess.LookupDbState(ctx, dbName)
+	if err != nil || !ok {
 		return nil, false
 	}
 
 	return d.dbData.Drw, true
 }
 
-func (sess *Session) GetDoltDbAutoIncrementTracker(dbName string) (globalstate.AutoIncrementTracker, bool) {
-	d, ok := sess.DbStates[dbName]
-
-	if !ok {
+func (sess *Session) GetDoltDbAutoIncrementTracker(ctx *sql.Context, dbName string) (globalstate.AutoIncrementTracker, bool) {
+	d, ok, err := sess.LookupDbState(ctx, dbName)
+	if err != nil || !ok {
 		return nil, false
 	}
 
@@ -569,9 +695,9 @@ func (sess *Session) GetDoltDbAutoIncrementTracker(dbName string) (globalstate.A
 	return tracker, true
 }
 
-func (sess *Session) GetDbData(dbName string) (env.DbData, bool) {
-	sessionState, ok := sess.DbStates[dbName]
-	if !ok {
+func (sess *Session) GetDbData(ctx *sql.Context, dbName string) (env.DbData, bool) {
+	sessionState, ok, err := sess.LookupDbState(ctx, dbName)
+	if err != nil || !ok {
 		return env.DbData{}, false
 	}
 
@@ -579,9 +705,9 @@ func (sess *Session) GetDbData(dbName string) (env.DbData, bool) {
 }
 
 // GetRoot returns the current working *RootValue for a given database associated with the session
-func (sess *Session) GetRoot(dbName string) (*doltdb.RootValue, bool) {
-	dbstate, ok := sess.DbStates[dbName]
-	if !ok {
+func (sess *Session) GetRoot(ctx *sql.Context, dbName string) (*doltdb.RootValue, bool) {
+	dbstate, ok, err := sess.LookupDbState(ctx, dbName)
+	if err != nil || !ok {
 		return nil, false
 	}
 
@@ -589,9 +715,9 @@ func (sess *Session) GetRoot(dbName string) (*doltdb.RootValue, bool) {
 }
 
 // GetRoots returns the current roots for a given database associated with the session
-func (sess *Session) GetRoots(dbName string) (doltdb.Roots, bool) {
-	dbstate, ok := sess.DbStates[dbName]
-	if !ok {
+func (sess *Session) GetRoots(ctx *sql.Context, dbName string) (doltdb.Roots, bool) {
+	dbstate, ok, err := sess.LookupDbState(ctx, dbName)
+	if err != nil || !ok {
 		return doltdb.Roots{}, false
 	}
 
@@ -604,7 +730,13 @@ func (sess *Session) GetRoots(dbName string) (doltdb.Roots, bool) {
 // Data changes contained in the |newRoot| aren't persisted until this session is committed.
 // TODO: rename to SetWorkingRoot
 func (sess *Session) SetRoot(ctx *sql.Context, dbName string, newRoot *doltdb.RootValue) error {
-	sessionState := sess.DbStates[dbName]
+	sessionState, ok, err := sess.LookupDbState(ctx, dbName)
+	if err != nil {
+		return err
+	} else if !ok {
+		return sql.ErrDatabaseNotFound.New(dbName)
+	}
+
 	if rootsEqual(sessionState.GetRoots().Working, newRoot) {
 		return nil
 	}
@@ -616,7 +748,12 @@ func (sess *Session) SetRoot(ctx *sql.Context, dbName string, newRoot *doltdb.Ro
 func (sess *Session) setRoot(ctx *sql.Context, dbName string, newRoot *doltdb.RootValue) error {
 	// logrus.Tracef("setting root value %s", newRoot.DebugString(ctx, true))
 
-	sessionState := sess.DbStates[dbName]
+	sessionState, ok, err := sess.LookupDbState(ctx, dbName)
+	if err != nil {
+		return err
+	} else if !ok {
+		return sql.ErrDatabaseNotFound.New(dbName)
+	}
 
 	h, err := newRoot.HashOf()
 	if err != nil {
@@ -629,7 +766,9 @@ func (sess *Session) setRoot(ctx *sql.Context, dbName string, newRoot *doltdb.Ro
 		return err
 	}
 
+	sess.dbStatesMu.Lock()
 	sessionState.WorkingSet = sessionState.WorkingSet.WithWorkingRoot(newRoot)
+	sess.dbStatesMu.Unlock()
 
 	err = sessionState.EditSession.SetRoot(ctx, newRoot)
 	if err != nil {
@@ -645,7 +784,14 @@ func (sess *Session) setRoot(ctx *sql.Context, dbName string, newRoot *doltdb.Ro
 // Unlike setting the only the working root, this method always marks the database state dirty.
 func (sess *Session) SetRoots(ctx *sql.Context, dbName string, roots doltdb.Roots) error {
 	// TODO: handle HEAD here?
-	workingSet := sess.DbStates[dbName].WorkingSet.WithWorkingRoot(roots.Working).WithStagedRoot(roots.Staged)
+	sessionState, ok, err := sess.LookupDbState(ctx, dbName)
+	if err != nil {
+		return err
+	} else if !ok {
+		return sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	workingSet := sessionState.WorkingSet.WithWorkingRoot(roots.Working).WithStagedRoot(roots.Staged)
 	return sess.SetWorkingSet(ctx, dbName, workingSet, nil)
 }
 
@@ -662,8 +808,16 @@ func (sess *Session) SetWorkingSet(
 		panic("attempted to set a nil working set for the session")
 	}
 
-	sessionState := sess.DbStates[dbName]
+	sessionState, ok, err := sess.LookupDbState(ctx, dbName)
+	if err != nil {
+		return err
+	} else if !ok {
+		return sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	sess.dbStatesMu.Lock()
 	sessionState.WorkingSet = ws
+	sess.dbStatesMu.Unlock()
 
 	if headRoot == nil && !sessionState.detachedHead {
 		cs, err := doltdb.NewCommitSpec(ws.Ref().GetPath())
@@ -681,7 +835,9 @@ func (sess *Session) SetWorkingSet(
 			return err
 		}
 
+		sess.dbStatesMu.Lock()
 		sessionState.headCommit = cm
+		sess.dbStatesMu.Unlock()
 
 		headRoot, err = cm.GetRootValue()
 		if err != nil {
@@ -693,7 +849,7 @@ func (sess *Session) SetWorkingSet(
 		sessionState.headRoot = headRoot
 	}
 
-	err := sess.setSessionVarsForDb(ctx, dbName)
+	err = sess.setSessionVarsForDb(ctx, dbName)
 	if err != nil {
 		return err
 	}
@@ -716,7 +872,12 @@ func (sess *Session) SwitchWorkingSet(
 	ctx *sql.Context,
 	dbName string,
 	wsRef ref.WorkingSetRef) error {
-	sessionState := sess.DbStates[dbName]
+	sessionState, ok, err := sess.LookupDbState(ctx, dbName)
+	if err != nil {
+		return err
+	} else if !ok {
+		return sql.ErrDatabaseNotFound.New(dbName)
+	}
 
 	if sessionState.dirty {
 		return fmt.Errorf("Cannot switch working set, session state is dirty. " +
@@ -735,7 +896,9 @@ func (sess *Session) SwitchWorkingSet(
 	}
 
 	// TODO: just call SetWorkingSet?
+	sess.dbStatesMu.Lock()
 	sessionState.WorkingSet = ws
+	sess.dbStatesMu.Unlock()
 
 	cs, err := doltdb.NewCommitSpec(ws.Ref().GetPath())
 	if err != nil {
@@ -752,7 +915,10 @@ func (sess *Session) SwitchWorkingSet(
 		return err
 	}
 
+	sess.dbStatesMu.Lock()
 	sessionState.headCommit = cm
+	sess.dbStatesMu.Unlock()
+
 	sessionState.headRoot, err = cm.GetRootValue()
 	if err != nil {
 		return err
@@ -778,14 +944,20 @@ func (sess *Session) SwitchWorkingSet(
 	return nil
 }
 
-func (sess *Session) WorkingSet(ctx *sql.Context, dbName string) *doltdb.WorkingSet {
-	sessionState := sess.DbStates[dbName]
-	return sessionState.WorkingSet
+func (sess *Session) WorkingSet(ctx *sql.Context, dbName string) (*doltdb.WorkingSet, error) {
+	sessionState, ok, err := sess.LookupDbState(ctx, dbName)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	return sessionState.WorkingSet, nil
 }
 
 func (sess *Session) GetTempTableRootValue(ctx *sql.Context, dbName string) (*doltdb.RootValue, bool) {
-	dbstate, ok := sess.DbStates[dbName]
-	if !ok {
+	dbstate, ok, err := sess.LookupDbState(ctx, dbName)
+	if err != nil || !ok {
 		return nil, false
 	}
 
@@ -797,14 +969,26 @@ func (sess *Session) GetTempTableRootValue(ctx *sql.Context, dbName string) (*do
 }
 
 func (sess *Session) SetTempTableRoot(ctx *sql.Context, dbName string, newRoot *doltdb.RootValue) error {
-	sess.DbStates[dbName].TempTableRoot = newRoot
-	return sess.DbStates[dbName].TempTableEditSession.SetRoot(ctx, newRoot)
+	dbState, ok, err := sess.LookupDbState(ctx, dbName)
+	if err != nil {
+		return err
+	} else if !ok {
+		return sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	sess.dbStatesMu.Lock()
+	dbState.TempTableRoot = newRoot
+	sess.dbStatesMu.Unlock()
+
+	return dbState.TempTableEditSession.SetRoot(ctx, newRoot)
 }
 
 // GetHeadCommit returns the parent commit of the current session.
 func (sess *Session) GetHeadCommit(ctx *sql.Context, dbName string) (*doltdb.Commit, error) {
-	dbState, dbFound := sess.DbStates[dbName]
-	if !dbFound {
+	dbState, ok, err := sess.LookupDbState(ctx, dbName)
+	if err != nil {
+		return nil, err
+	} else if !ok {
 		return nil, sql.ErrDatabaseNotFound.New(dbName)
 	}
 
@@ -817,13 +1001,7 @@ func (sess *Session) SetSessionVariable(ctx *sql.Context, key string, value inte
 	// TODO: working set ref
 
 	if isHead, dbName := IsHeadKey(key); isHead {
-		err := sess.setHeadSessionVar(ctx, value, dbName)
-		if err != nil {
-			return err
-		}
-
-		sess.DbStates[dbName].detachedHead = true
-		return nil
+		return sess.setHeadSessionVar(ctx, value, dbName)
 	}
 
 	if isWorking, dbName := IsWorkingKey(key); isWorking {
@@ -869,8 +1047,10 @@ func (sess *Session) setWorkingSessionVar(ctx *sql.Context, value interface{}, d
 		return doltdb.ErrInvalidHash
 	}
 
-	dbstate, dbFound := sess.DbStates[dbName]
-	if !dbFound {
+	dbstate, ok, err := sess.LookupDbState(ctx, dbName)
+	if err != nil {
+		return err
+	} else if !ok {
 		return sql.ErrDatabaseNotFound.New(dbName)
 	}
 
@@ -885,8 +1065,10 @@ func (sess *Session) setWorkingSessionVar(ctx *sql.Context, value interface{}, d
 }
 
 func (sess *Session) setHeadSessionVar(ctx *sql.Context, value interface{}, dbName string) error {
-	dbstate, dbFound := sess.DbStates[dbName]
-	if !dbFound {
+	dbstate, ok, err := sess.LookupDbState(ctx, dbName)
+	if err != nil {
+		return err
+	} else if !ok {
 		return sql.ErrDatabaseNotFound.New(dbName)
 	}
 
@@ -906,7 +1088,9 @@ func (sess *Session) setHeadSessionVar(ctx *sql.Context, value interface{}, dbNa
 		return err
 	}
 
+	sess.dbStatesMu.Lock()
 	dbstate.headCommit = cm
+	sess.dbStatesMu.Unlock()
 
 	root, err := cm.GetRootValue()
 	if err != nil {
@@ -921,7 +1105,13 @@ func (sess *Session) setHeadSessionVar(ctx *sql.Context, value interface{}, dbNa
 	}
 
 	// TODO: preserve working set changes?
-	return sess.SetRoot(ctx, dbName, root)
+	err = sess.SetRoot(ctx, dbName, root)
+	if err != nil {
+		return err
+	}
+
+	dbstate.detachedHead = true
+	return nil
 }
 
 // SetSessionVarDirectly directly updates sess.Session. This is useful in the context of the sql shell where
@@ -937,15 +1127,21 @@ func (sess *Session) AddDB(ctx *sql.Context, dbState InitialDbState) error {
 	defineSystemVariables(db.Name())
 
 	sessionState := &DatabaseSessionState{}
+	sess.dbStatesMu.Lock()
 	sess.DbStates[db.Name()] = sessionState
+	sess.dbStatesMu.Unlock()
 
 	// TODO: get rid of all repo state reader / writer stuff. Until we do, swap out the reader with one of our own, and
 	//  the writer with one that errors out
 	sessionState.dbData = dbState.DbData
+	sessionState.remotesRsr = dbState.DbData.Rsr
+	sessionState.remotesRsw = dbState.DbData.Rsw
 	adapter := NewSessionStateAdapter(sess, db.Name())
 	sessionState.dbData.Rsr = adapter
 	sessionState.dbData.Rsw = adapter
 	sessionState.GlobalState = dbState.GlobalState
+	sessionState.readOnly = dbState.ReadOnly
+	sessionState.detachedHead = dbState.ReadOnly
 
 	sessionState.EditSession = editor.CreateTableEditSession(nil, editor.TableEditSessionProps{})
 
@@ -954,7 +1150,10 @@ func (sess *Session) AddDB(ctx *sql.Context, dbState InitialDbState) error {
 		return err
 	}
 
+	sess.dbStatesMu.Lock()
 	sessionState.WorkingSet = dbState.WorkingSet
+	sess.dbStatesMu.Unlock()
+
 	workingRoot := dbState.WorkingSet.WorkingRoot()
 	logrus.Tracef("working root intialized to %s", workingRoot.DebugString(ctx, false))
 
@@ -965,7 +1164,14 @@ func (sess *Session) AddDB(ctx *sql.Context, dbState InitialDbState) error {
 
 	// This has to happen after SetRoot above, since it does a stale check before its work
 	// TODO: this needs to be kept up to date as the working set ref changes
+	sess.dbStatesMu.Lock()
 	sessionState.headCommit = dbState.HeadCommit
+	sess.dbStatesMu.Unlock()
+
+	sessionState.headRoot, err = dbState.HeadCommit.GetRootValue()
+	if err != nil {
+		return err
+	}
 
 	headCommitHash, err := dbState.HeadCommit.HashOf()
 	if err != nil {
@@ -986,24 +1192,44 @@ func (sess *Session) AddDB(ctx *sql.Context, dbState InitialDbState) error {
 // temporary tables. This should only be used on demand. That is only when a temporary table is created should we
 // create the root map and edit session map.
 func (sess *Session) CreateTemporaryTablesRoot(ctx *sql.Context, dbName string, ddb *doltdb.DoltDB) error {
+	dbState, ok, err := sess.LookupDbState(ctx, dbName)
+	if err != nil {
+		return err
+	} else if !ok {
+		return sql.ErrDatabaseNotFound.New(dbName)
+	}
+
 	newRoot, err := doltdb.EmptyRootValue(ctx, ddb.ValueReadWriter())
 	if err != nil {
 		return err
 	}
 
-	sess.DbStates[dbName].TempTableEditSession = editor.CreateTableEditSession(newRoot, editor.TableEditSessionProps{})
+	dbState.TempTableEditSession = editor.CreateTableEditSession(newRoot, editor.TableEditSessionProps{})
 
 	return sess.SetTempTableRoot(ctx, dbName, newRoot)
 }
 
 // CWBHeadRef returns the branch ref for this session HEAD for the database named
-func (sess *Session) CWBHeadRef(dbName string) (ref.DoltRef, error) {
-	return sess.DbStates[dbName].WorkingSet.Ref().ToHeadRef()
+func (sess *Session) CWBHeadRef(ctx *sql.Context, dbName string) (ref.DoltRef, error) {
+	dbState, ok, err := sess.LookupDbState(ctx, dbName)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	return dbState.WorkingSet.Ref().ToHeadRef()
 }
 
 // setSessionVarsForDb updates the three session vars that track the value of the session root hashes
 func (sess *Session) setSessionVarsForDb(ctx *sql.Context, dbName string) error {
-	state := sess.DbStates[dbName]
+	state, ok, err := sess.LookupDbState(ctx, dbName)
+	if err != nil {
+		return err
+	} else if !ok {
+		return sql.ErrDatabaseNotFound.New(dbName)
+	}
+
 	roots := state.GetRoots()
 
 	h, err := roots.Working.HashOf()
@@ -17,6 +17,7 @@ package dsess
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/dolthub/go-mysql-server/sql"
 
@@ -32,14 +33,50 @@ type SessionStateAdapter struct {
 	dbName  string
 }
 
+// lookupDbState returns the session state of the adapter's database, loading it through the session if |ctx| is a
+// *sql.Context.
+func (s SessionStateAdapter) lookupDbState(ctx context.Context) (*DatabaseSessionState, error) {
+	sqlCtx, ok := ctx.(*sql.Context)
+	if !ok {
+		return s.knownDbState()
+	}
+
+	dbState, ok, err := s.session.LookupDbState(sqlCtx, s.dbName)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(s.dbName)
+	}
+
+	return dbState, nil
+}
+
+// knownDbState returns the session state of the adapter's database for methods without a context to load it with.
+func (s SessionStateAdapter) knownDbState() (*DatabaseSessionState, error) {
+	dbState, ok := s.session.DbStates[s.dbName]
+	if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(s.dbName)
+	}
+
+	return dbState, nil
+}
+
 func (s SessionStateAdapter) UpdateStagedRoot(ctx context.Context, newRoot *doltdb.RootValue) error {
-	roots, _ := s.session.GetRoots(s.dbName)
+	roots, err := s.GetRoots(ctx)
+	if err != nil {
+		return err
+	}
+
 	roots.Staged = newRoot
 	return s.session.SetRoots(ctx.(*sql.Context), s.dbName, roots)
 }
 
 func (s SessionStateAdapter) UpdateWorkingRoot(ctx context.Context, newRoot *doltdb.RootValue) error {
-	roots, _ := s.session.GetRoots(s.dbName)
+	roots, err := s.GetRoots(ctx)
+	if err != nil {
+		return err
+	}
+
 	roots.Working = newRoot
 	return s.session.SetRoots(ctx.(*sql.Context), s.dbName, roots)
 }
@@ -56,10 +93,70 @@ func (s SessionStateAdapter) ClearMerge(ctx context.Context) error {
 	return nil
 }
 
-func (s SessionStateAdapter) StartMerge(ctx context.Context, commit *doltdb.Commit) error {
+func (s SessionStateAdapter) StartMerge(ctx context.Context, commit *doltdb.Commit, otherCommits ...*doltdb.Commit) error {
 	return fmt.Errorf("Cannot start merge with a SessionStateAdapter")
 }
 
+func (s SessionStateAdapter) GetRemotes() (map[string]env.Remote, error) {
+	dbState, err := s.knownDbState()
+	if err != nil {
+		return nil, err
+	}
+
+	rsr := dbState.remotesRsr
+	if rsr == nil {
+		return nil, nil
+	}
+	return rsr.GetRemotes()
+}
+
+func (s SessionStateAdapter) GetAbsRemoteUrl(urlArg string) (string, string, error) {
+	dbState, err := s.knownDbState()
+	if err != nil {
+		return "", "", err
+	}
+
+	rsr := dbState.remotesRsr
+	if rsr == nil {
+		return "", "", fmt.Errorf("Cannot resolve remote urls for database %s", s.dbName)
+	}
+	return rsr.GetAbsRemoteUrl(urlArg)
+}
+
+func (s SessionStateAdapter) TempTableFilesDir() string {
+	dbState, err := s.knownDbState()
+	if err != nil || dbState.remotesRsr == nil {
+		return os.TempDir()
+	}
+	return dbState.remotesRsr.TempTableFilesDir()
+}
+
+func (s SessionStateAdapter) AddRemote(r env.Remote) error {
+	dbState, err := s.knownDbState()
+	if err != nil {
+		return err
+	}
+
+	rsw := dbState.remotesRsw
+	if rsw == nil {
+		return fmt.Errorf("Cannot add a remote to database %s", s.dbName)
+	}
+	return rsw.AddRemote(r)
+}
+
+func (s SessionStateAdapter) RemoveRemote(ctx context.Context, name string) error {
+	dbState, err := s.lookupDbState(ctx)
+	if err != nil {
+		return err
+	}
+
+	rsw := dbState.remotesRsw
+	if rsw == nil {
+		return fmt.Errorf("Cannot remove a remote from database %s", s.dbName)
+	}
+	return rsw.RemoveRemote(ctx, name)
+}
+
 var _ env.RepoStateReader = SessionStateAdapter{}
 var _ env.RepoStateWriter = SessionStateAdapter{}
 var _ env.RootsProvider = SessionStateAdapter{}
@@ -69,12 +166,22 @@ func NewSessionStateAdapter(session *Session, dbName string) SessionStateAdapter
 }
 
 func (s SessionStateAdapter) GetRoots(ctx context.Context) (doltdb.Roots, error) {
-	return s.session.DbStates[s.dbName].GetRoots(), nil
+	dbState, err := s.lookupDbState(ctx)
+	if err != nil {
+		return doltdb.Roots{}, err
+	}
+
+	return dbState.GetRoots(), nil
 }
 
 func (s SessionStateAdapter) CWBHeadRef() ref.DoltRef {
-	workingSet := s.session.DbStates[s.dbName].WorkingSet
-	headRef, err := workingSet.Ref().ToHeadRef()
+	dbState, err := s.knownDbState()
+	// TODO: fix this interface
+	if err != nil {
+		panic(err)
+	}
+
+	headRef, err := dbState.WorkingSet.Ref().ToHeadRef()
 	// TODO: fix this interface
 	if err != nil {
 		panic(err)
@@ -93,13 +200,37 @@ func (s SessionStateAdapter) CWBHeadSpec() *doltdb.CommitSpec {
 }
 
 func (s SessionStateAdapter) IsMergeActive(ctx context.Context) (bool, error) {
-	return s.session.DbStates[s.dbName].WorkingSet.MergeActive(), nil
+	dbState, err := s.lookupDbState(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return dbState.WorkingSet.MergeActive(), nil
+}
+
+func (s SessionStateAdapter) GetMergeCommits(ctx context.Context) ([]*doltdb.Commit, error) {
+	dbState, err := s.lookupDbState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return dbState.WorkingSet.MergeState().Commits(), nil
 }
 
-func (s SessionStateAdapter) GetMergeCommit(ctx context.Context) (*doltdb.Commit, error) {
-	return s.session.DbStates[s.dbName].WorkingSet.MergeState().Commit(), nil
+func (s SessionStateAdapter) GetMergeSchemaConflicts(ctx context.Context) ([]string, error) {
+	dbState, err := s.lookupDbState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return dbState.WorkingSet.MergeState().SchemaConflicts(), nil
 }
 
 func (s SessionStateAdapter) GetPreMergeWorking(ctx context.Context) (*doltdb.RootValue, error) {
-	return s.session.DbStates[s.dbName].WorkingSet.MergeState().PreMergeWorkingRoot(), nil
+	dbState, err := s.lookupDbState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return dbState.WorkingSet.MergeState().PreMergeWorkingRoot(), nil
 }
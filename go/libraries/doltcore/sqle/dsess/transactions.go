@@ -17,6 +17,7 @@ package dsess
 import (
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -36,7 +37,9 @@ type DoltTransaction struct {
 	startState    *doltdb.WorkingSet
 	workingSetRef ref.WorkingSetRef
 	dbData        env.DbData
-	savepoints    []savepoint
+	// mu guards savepoints, which a GarbageCollector reads from another goroutine
+	mu         *sync.Mutex
+	savepoints []savepoint
 }
 
 type savepoint struct {
@@ -49,6 +52,7 @@ func NewDoltTransaction(startState *doltdb.WorkingSet, workingSet ref.WorkingSet
 		startState:    startState,
 		workingSetRef: workingSet,
 		dbData:        dbData,
+		mu:            &sync.Mutex{},
 	}
 }
 
@@ -140,6 +144,9 @@ func (tx *DoltTransaction) Commit(ctx *sql.Context, workingSet *doltdb.WorkingSe
 // CreateSavepoint creates a new savepoint with the name and root value given. If a savepoint with the name given
 // already exists, it's overwritten.
 func (tx *DoltTransaction) CreateSavepoint(name string, root *doltdb.RootValue) {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+
 	existing := tx.findSavepoint(name)
 	if existing >= 0 {
 		tx.savepoints = append(tx.savepoints[:existing], tx.savepoints[existing+1:]...)
@@ -160,6 +167,9 @@ func (tx *DoltTransaction) findSavepoint(name string) int {
 // RollbackToSavepoint returns the root value associated with the savepoint name given, or nil if no such savepoint can
 // be found. All savepoints created after the one being rolled back to are no longer accessible.
 func (tx *DoltTransaction) RollbackToSavepoint(name string) *doltdb.RootValue {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+
 	existing := tx.findSavepoint(name)
 	if existing >= 0 {
 		// Clear out any savepoints past this one
@@ -172,6 +182,9 @@ func (tx *DoltTransaction) RollbackToSavepoint(name string) *doltdb.RootValue {
 // ClearSavepoint removes the savepoint with the name given and returns the root value recorded there, or nil if no
 // savepoint exists with that name.
 func (tx *DoltTransaction) ClearSavepoint(name string) *doltdb.RootValue {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+
 	existing := tx.findSavepoint(name)
 	var existingRoot *doltdb.RootValue
 	if existing >= 0 {
@@ -181,6 +194,18 @@ func (tx *DoltTransaction) ClearSavepoint(name string) *doltdb.RootValue {
 	return existingRoot
 }
 
+// savepointRoots returns the root values of the savepoints of this transaction
+func (tx *DoltTransaction) savepointRoots() []*doltdb.RootValue {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+
+	roots := make([]*doltdb.RootValue, len(tx.savepoints))
+	for i, s := range tx.savepoints {
+		roots[i] = s.root
+	}
+	return roots
+}
+
 func (tx DoltTransaction) getWorkingSetMeta(ctx *sql.Context) *doltdb.WorkingSetMeta {
 	sess := DSessFromSess(ctx.Session)
 	return &doltdb.WorkingSetMeta{
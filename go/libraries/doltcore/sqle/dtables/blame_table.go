@@ -0,0 +1,457 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"errors"
+	"io"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/commitwalk"
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+const (
+	// EmailCol is the name of the column containing the committer's email in the result set
+	EmailCol = "email"
+
+	// MessageCol is the name of the column containing the commit message in the result set
+	MessageCol = "message"
+
+	blameDiffBatchSize = 1024
+)
+
+// ErrBlameKeylessTable is returned when a blame table is requested for a table without a primary key
+var ErrBlameKeylessTable = errors.New("dolt_blame tables are not supported for tables without a primary key")
+
+var _ sql.Table = (*BlameTable)(nil)
+
+// BlameTable is a system table that shows the commit which last changed each row of a table. Each row contains the
+// primary key of a row of the table, and the hash, committer, email, date and message of that commit. Rows that were
+// changed since the head commit haven't been committed, and have no commit.
+type BlameTable struct {
+	name    string
+	ddb     *doltdb.DoltDB
+	head    *doltdb.Commit
+	tbl     *doltdb.Table
+	sch     schema.Schema
+	rowData types.Map
+	sqlSch  sql.Schema
+}
+
+// NewBlameTable creates a blame table for the table |tblName| as it is in |root|, blaming its rows on |head| and its
+// ancestors.
+func NewBlameTable(ctx *sql.Context, tblName string, ddb *doltdb.DoltDB, root *doltdb.RootValue, head *doltdb.Commit) (sql.Table, error) {
+	blameTblName := doltdb.DoltBlameTablePrefix + tblName
+
+	tbl, tblName, ok, err := root.GetTableInsensitive(ctx, tblName)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, sql.ErrTableNotFound.New(blameTblName)
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if schema.IsKeyless(sch) {
+		return nil, ErrBlameKeylessTable
+	}
+
+	rowData, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pkSqlSch, err := sqlutil.FromDoltSchema(blameTblName, schema.UnkeyedSchemaFromCols(sch.GetPKCols()))
+	if err != nil {
+		return nil, err
+	}
+
+	sqlSch := make(sql.Schema, 0, len(pkSqlSch)+5)
+	for _, col := range pkSqlSch {
+		col.PrimaryKey = true
+		sqlSch = append(sqlSch, col)
+	}
+	sqlSch = append(sqlSch,
+		&sql.Column{Name: CommitHashCol, Type: sql.Text, Source: blameTblName},
+		&sql.Column{Name: CommitterCol, Type: sql.Text, Source: blameTblName},
+		&sql.Column{Name: EmailCol, Type: sql.Text, Source: blameTblName},
+		&sql.Column{Name: CommitDateCol, Type: sql.Datetime, Source: blameTblName},
+		&sql.Column{Name: MessageCol, Type: sql.Text, Source: blameTblName},
+	)
+
+	return &BlameTable{
+		name:    tblName,
+		ddb:     ddb,
+		head:    head,
+		tbl:     tbl,
+		sch:     sch,
+		rowData: rowData,
+		sqlSch:  sqlSch,
+	}, nil
+}
+
+// Name returns the name of the table
+func (bt *BlameTable) Name() string {
+	return doltdb.DoltBlameTablePrefix + bt.name
+}
+
+// String returns the name of the table
+func (bt *BlameTable) String() string {
+	return doltdb.DoltBlameTablePrefix + bt.name
+}
+
+// Schema returns the sql.Schema of the table
+func (bt *BlameTable) Schema() sql.Schema {
+	return bt.sqlSch
+}
+
+// Partitions returns a PartitionIter which contains the single partition of the table
+func (bt *BlameTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return sqlutil.NewSinglePartitionIter(types.Map{}), nil
+}
+
+// PartitionRows returns a RowIter over the rows of the table in primary key order, with the commit which last changed
+// each of them
+func (bt *BlameTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	blame, err := blameRows(ctx, bt.ddb, bt.head, bt.name, bt.tbl, bt.rowData)
+	if err != nil {
+		return nil, err
+	}
+
+	mapItr, err := bt.rowData.Iterator(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &blameRowItr{ctx: ctx, sch: bt.sch, nbf: bt.rowData.Format(), itr: mapItr, blame: blame}, nil
+}
+
+// blameCommit is the commit that a row is blamed on
+type blameCommit struct {
+	hash string
+	meta *doltdb.CommitMeta
+}
+
+// blameRows finds the commit which last changed each row of |rowData|, the rows of the table |tblName| as it is in
+// |tbl|. Rows that |tbl| changed since |head| aren't blamed. The others are passed from |head| down through its
+// ancestors, which are walked in topological order, so every child of a commit is visited before it. Each commit
+// passes a row on to the first of its parents which has the row unchanged, and a row which was changed since every
+// parent is blamed on the commit. A table which was created or had its columns changed since a parent has every row
+// changed since it. The walk stops as soon as every row has been blamed, so only as much history as the rows need is
+// read. The returned map is keyed by the hash of each row's key.
+func blameRows(ctx *sql.Context, ddb *doltdb.DoltDB, head *doltdb.Commit, tblName string, tbl *doltdb.Table, rowData types.Map) (map[hash.Hash]*blameCommit, error) {
+	nbf := rowData.Format()
+	rowKeys := make(map[hash.Hash]struct{}, rowData.Len())
+	err := rowData.IterAll(ctx, func(key, _ types.Value) error {
+		h, err := key.Hash(nbf)
+		if err != nil {
+			return err
+		}
+		rowKeys[h] = struct{}{}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	headTbl, _, err := tableAtCommit(ctx, head, tblName)
+	if err != nil {
+		return nil, err
+	}
+
+	unchanged, err := unchangedRows(ctx, nbf, headTbl, tbl, rowKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	headHash, err := head.HashOf()
+	if err != nil {
+		return nil, err
+	}
+
+	// pending holds the rows which each commit still to be visited must pass on or be blamed for
+	pending := map[hash.Hash]map[hash.Hash]struct{}{headHash: unchanged}
+	numPending := len(unchanged)
+
+	cmItr, err := commitwalk.GetTopologicalOrderIterator(ctx, ddb, headHash)
+	if err != nil {
+		return nil, err
+	}
+
+	blame := make(map[hash.Hash]*blameCommit, len(unchanged))
+	for numPending > 0 {
+		h, cm, err := cmItr.Next(ctx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		keys, ok := pending[h]
+		if !ok {
+			continue
+		}
+		delete(pending, h)
+
+		cmTbl, ok, err := tableAtCommit(ctx, cm, tblName)
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			numPending -= len(keys)
+			continue
+		}
+
+		numParents, err := cm.NumParents()
+		if err != nil {
+			return nil, err
+		}
+
+		for i := 0; i < numParents && len(keys) > 0; i++ {
+			parent, err := ddb.ResolveParent(ctx, cm, i)
+			if err != nil {
+				return nil, err
+			}
+
+			parentTbl, _, err := tableAtCommit(ctx, parent, tblName)
+			if err != nil {
+				return nil, err
+			}
+
+			inherited, err := unchangedRows(ctx, nbf, parentTbl, cmTbl, keys)
+			if err != nil {
+				return nil, err
+			} else if len(inherited) == 0 {
+				continue
+			}
+
+			parentHash, err := parent.HashOf()
+			if err != nil {
+				return nil, err
+			}
+
+			parentKeys, ok := pending[parentHash]
+			if !ok {
+				parentKeys = make(map[hash.Hash]struct{}, len(inherited))
+				pending[parentHash] = parentKeys
+			}
+
+			for key := range inherited {
+				parentKeys[key] = struct{}{}
+				delete(keys, key)
+			}
+		}
+
+		if len(keys) == 0 {
+			continue
+		}
+
+		meta, err := cm.GetCommitMeta()
+		if err != nil {
+			return nil, err
+		}
+
+		bc := &blameCommit{hash: h.String(), meta: meta}
+		for key := range keys {
+			blame[key] = bc
+		}
+		numPending -= len(keys)
+	}
+
+	return blame, nil
+}
+
+// unchangedRows returns the hashes of the keys among |keys| whose rows are the same in |tbl| as in |parentTbl|, which
+// is nil if the table doesn't exist in the parent.
+func unchangedRows(ctx *sql.Context, nbf *types.NomsBinFormat, parentTbl, tbl *doltdb.Table, keys map[hash.Hash]struct{}) (map[hash.Hash]struct{}, error) {
+	unchanged := make(map[hash.Hash]struct{})
+	if parentTbl == nil {
+		return unchanged, nil
+	}
+
+	changedCols, err := blameTableChange(ctx, parentTbl, tbl)
+	if err != nil || changedCols {
+		return unchanged, err
+	}
+
+	for h := range keys {
+		unchanged[h] = struct{}{}
+	}
+
+	err = blameRowChanges(ctx, parentTbl, tbl, func(key types.Value) error {
+		h, err := key.Hash(nbf)
+		if err != nil {
+			return err
+		}
+		delete(unchanged, h)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return unchanged, nil
+}
+
+func tableAtCommit(ctx *sql.Context, cm *doltdb.Commit, tblName string) (*doltdb.Table, bool, error) {
+	root, err := cm.GetRootValue()
+	if err != nil {
+		return nil, false, err
+	}
+
+	return root.GetTable(ctx, tblName)
+}
+
+// blameTableChange returns whether every row of |tbl| was changed since |parentTbl|, because the columns of the table
+// were changed. Changes to its indexes and constraints don't change its rows.
+func blameTableChange(ctx *sql.Context, parentTbl, tbl *doltdb.Table) (bool, error) {
+	parentSch, err := parentTbl.GetSchema(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	parentCols, cols := parentSch.GetAllCols(), sch.GetAllCols()
+	if parentCols.Size() != cols.Size() {
+		return true, nil
+	}
+
+	changed := false
+	_ = cols.Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		parentCol, ok := parentCols.GetByTag(tag)
+		changed = !ok || parentCol.Name != col.Name || parentCol.IsPartOfPK != col.IsPartOfPK || !parentCol.TypeInfo.Equals(col.TypeInfo)
+		return changed, nil
+	})
+
+	return changed, nil
+}
+
+// blameRowChanges calls |cb| with the key of each row which was added to or modified in |tbl| since |parentTbl|.
+func blameRowChanges(ctx *sql.Context, parentTbl, tbl *doltdb.Table, cb func(key types.Value) error) error {
+	parentData, err := parentTbl.GetRowData(ctx)
+	if err != nil {
+		return err
+	}
+
+	data, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return err
+	}
+
+	if parentData.Equals(data) {
+		return nil
+	}
+
+	ad := diff.NewAsyncDiffer(blameDiffBatchSize)
+	ad.Start(ctx, parentData, data)
+	defer ad.Close()
+
+	for {
+		diffs, more, err := ad.GetDiffsWithoutTimeout(blameDiffBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, d := range diffs {
+			if d.ChangeType == types.DiffChangeRemoved {
+				continue
+			}
+
+			if err = cb(d.KeyValue); err != nil {
+				return err
+			}
+		}
+
+		if !more {
+			return nil
+		}
+	}
+}
+
+// blameRowItr is a sql.RowIter over the rows of a blame table
+type blameRowItr struct {
+	ctx   *sql.Context
+	sch   schema.Schema
+	nbf   *types.NomsBinFormat
+	itr   types.MapIterator
+	blame map[hash.Hash]*blameCommit
+}
+
+// Next returns the next row, or io.EOF after the last row
+func (itr *blameRowItr) Next() (sql.Row, error) {
+	key, _, err := itr.itr.Next(itr.ctx)
+	if err != nil {
+		return nil, err
+	} else if key == nil {
+		return nil, io.EOF
+	}
+
+	taggedVals, err := row.ParseTaggedValues(key.(types.Tuple))
+	if err != nil {
+		return nil, err
+	}
+
+	pkCols := itr.sch.GetPKCols()
+	sqlRow := make(sql.Row, 0, pkCols.Size()+5)
+	err = pkCols.Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		var val interface{}
+		if nomsVal, ok := taggedVals[tag]; ok {
+			val, err = col.TypeInfo.ConvertNomsValueToValue(nomsVal)
+			if err != nil {
+				return true, err
+			}
+		}
+
+		sqlRow = append(sqlRow, val)
+		return false, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := key.Hash(itr.nbf)
+	if err != nil {
+		return nil, err
+	}
+
+	if bc, ok := itr.blame[h]; ok {
+		sqlRow = append(sqlRow, bc.hash, bc.meta.Name, bc.meta.Email, bc.meta.Time(), bc.meta.Description)
+	} else {
+		sqlRow = append(sqlRow, nil, nil, nil, nil, nil)
+	}
+
+	return sqlRow, nil
+}
+
+// Close closes the iterator
+func (itr *blameRowItr) Close(*sql.Context) error {
+	return nil
+}
@@ -0,0 +1,400 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+var _ sql.Table = (*RemotesTable)(nil)
+var _ sql.UpdatableTable = (*RemotesTable)(nil)
+var _ sql.DeletableTable = (*RemotesTable)(nil)
+var _ sql.InsertableTable = (*RemotesTable)(nil)
+var _ sql.ReplaceableTable = (*RemotesTable)(nil)
+
+// RemotesTable is a sql.Table implementation that implements a system table which shows the dolt remotes
+type RemotesTable struct {
+	ddb *doltdb.DoltDB
+	rsr env.RepoStateReader
+	rsw env.RepoStateWriter
+}
+
+// NewRemotesTable creates a RemotesTable
+func NewRemotesTable(_ *sql.Context, ddb *doltdb.DoltDB, rsr env.RepoStateReader, rsw env.RepoStateWriter) sql.Table {
+	return &RemotesTable{ddb, rsr, rsw}
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// RemotesTableName
+func (rt *RemotesTable) Name() string {
+	return doltdb.RemotesTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// RemotesTableName
+func (rt *RemotesTable) String() string {
+	return doltdb.RemotesTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the remotes system table
+func (rt *RemotesTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "name", Type: sql.Text, Source: doltdb.RemotesTableName, PrimaryKey: true, Nullable: false},
+		{Name: "url", Type: sql.Text, Source: doltdb.RemotesTableName, PrimaryKey: false, Nullable: false},
+		{Name: "fetch_specs", Type: sql.JSON, Source: doltdb.RemotesTableName, PrimaryKey: false, Nullable: true},
+		{Name: "params", Type: sql.JSON, Source: doltdb.RemotesTableName, PrimaryKey: false, Nullable: true},
+	}
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data.  Currently the data is unpartitioned.
+func (rt *RemotesTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return sqlutil.NewSinglePartitionIter(types.Map{}), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (rt *RemotesTable) PartitionRows(sqlCtx *sql.Context, part sql.Partition) (sql.RowIter, error) {
+	return NewRemoteItr(sqlCtx, rt.rsr)
+}
+
+// RemoteItr is a sql.RowItr implementation which iterates over each remote as if it's a row in the table.
+type RemoteItr struct {
+	remotes []env.Remote
+	idx     int
+}
+
+// NewRemoteItr creates a RemoteItr from the current environment.
+func NewRemoteItr(_ *sql.Context, rsr env.RepoStateReader) (*RemoteItr, error) {
+	remoteMap, err := rsr.GetRemotes()
+
+	if err != nil {
+		return nil, err
+	}
+
+	remotes := make([]env.Remote, 0, len(remoteMap))
+	for _, r := range remoteMap {
+		remotes = append(remotes, r)
+	}
+
+	sort.Slice(remotes, func(i, j int) bool {
+		return remotes[i].Name < remotes[j].Name
+	})
+
+	return &RemoteItr{remotes, 0}, nil
+}
+
+// Next retrieves the next row. It will return io.EOF if it's the last row.
+// After retrieving the last row, Close will be automatically closed.
+func (itr *RemoteItr) Next() (sql.Row, error) {
+	if itr.idx >= len(itr.remotes) {
+		return nil, io.EOF
+	}
+
+	defer func() {
+		itr.idx++
+	}()
+
+	return remoteRow(itr.remotes[itr.idx]), nil
+}
+
+// remoteRow returns the row of the remotes table that describes |r|.
+func remoteRow(r env.Remote) sql.Row {
+	fetchSpecs := make([]interface{}, len(r.FetchSpecs))
+	for i, fs := range r.FetchSpecs {
+		fetchSpecs[i] = fs
+	}
+
+	params := make(map[string]interface{}, len(r.Params))
+	for k, v := range r.Params {
+		params[k] = v
+	}
+
+	return sql.NewRow(r.Name, r.Url, sql.JSONDocument{Val: fetchSpecs}, sql.JSONDocument{Val: params})
+}
+
+// Close closes the iterator.
+func (itr *RemoteItr) Close(*sql.Context) error {
+	return nil
+}
+
+// Replacer returns a RowReplacer for this table. The RowReplacer will have Insert and optionally Delete called once
+// for each row, followed by a call to Close() when all rows have been processed.
+func (rt *RemotesTable) Replacer(ctx *sql.Context) sql.RowReplacer {
+	return remoteReplacer{remoteWriter{rt}}
+}
+
+// Updater returns a RowUpdater for this table. The RowUpdater will have Update called once for each row to be
+// updated, followed by a call to Close() when all rows have been processed.
+func (rt *RemotesTable) Updater(ctx *sql.Context) sql.RowUpdater {
+	return remoteWriter{rt}
+}
+
+// Inserter returns an Inserter for this table. The Inserter will get one call to Insert() for each row to be
+// inserted, and will end with a call to Close() to finalize the insert operation.
+func (rt *RemotesTable) Inserter(*sql.Context) sql.RowInserter {
+	return remoteWriter{rt}
+}
+
+// Deleter returns a RowDeleter for this table. The RowDeleter will get one call to Delete for each row to be deleted,
+// and will end with a call to Close() to finalize the delete operation.
+func (rt *RemotesTable) Deleter(*sql.Context) sql.RowDeleter {
+	return remoteWriter{rt}
+}
+
+var _ sql.RowReplacer = remoteReplacer{}
+var _ sql.RowUpdater = remoteWriter{nil}
+var _ sql.RowInserter = remoteWriter{nil}
+var _ sql.RowDeleter = remoteWriter{nil}
+
+type remoteWriter struct {
+	rt *RemotesTable
+}
+
+func remoteNameFromRow(r sql.Row) (string, error) {
+	remoteName, ok := r[0].(string)
+
+	if !ok {
+		return "", errors.New("invalid value type for remote")
+	} else if len(remoteName) == 0 || strings.IndexAny(remoteName, " \t\n\r./\\!@#$%^&*(){}[],<>'\"?=+|") != -1 {
+		return "", fmt.Errorf("invalid remote name: %s", remoteName)
+	}
+
+	return remoteName, nil
+}
+
+// remoteFromRow returns the remote described by |r|. Its url is made absolute by |rsr| the same way as by dolt remote
+// add. When no fetch specs are given, the remote gets the default fetch spec, which tracks all of the remote's
+// branches.
+func remoteFromRow(r sql.Row, rsr env.RepoStateReader) (env.Remote, error) {
+	remoteName, err := remoteNameFromRow(r)
+
+	if err != nil {
+		return env.NoRemote, err
+	}
+
+	url, ok := r[1].(string)
+
+	if !ok {
+		return env.NoRemote, errors.New("invalid value type for url")
+	}
+
+	_, absUrl, err := rsr.GetAbsRemoteUrl(url)
+
+	if err != nil {
+		return env.NoRemote, fmt.Errorf("'%s' is not a valid url: %w", url, err)
+	}
+
+	url = absUrl
+
+	var params map[string]string
+	err = unmarshalJSONColumn(r[3], "params", &params)
+
+	if err != nil {
+		return env.NoRemote, err
+	}
+
+	remote := env.NewRemote(remoteName, url, params)
+	if remote.Params == nil {
+		remote.Params = map[string]string{}
+	}
+
+	err = unmarshalJSONColumn(r[2], "fetch_specs", &remote.FetchSpecs)
+
+	if err != nil {
+		return env.NoRemote, err
+	}
+
+	if len(remote.FetchSpecs) == 0 {
+		remote.FetchSpecs = env.NewRemote(remoteName, url, nil).FetchSpecs
+	}
+
+	for _, fs := range remote.FetchSpecs {
+		if _, err := ref.ParseRefSpecForRemote(remoteName, fs); err != nil {
+			return env.NoRemote, err
+		}
+	}
+
+	return remote, nil
+}
+
+// unmarshalJSONColumn unmarshals the value of a JSON column into |dest|, leaving |dest| unchanged if the value is nil.
+func unmarshalJSONColumn(val interface{}, colName string, dest interface{}) error {
+	if val == nil {
+		return nil
+	}
+
+	var data []byte
+	switch val := val.(type) {
+	case string:
+		data = []byte(val)
+	case sql.JSONDocument:
+		var err error
+		data, err = json.Marshal(val.Val)
+
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("invalid value type for %s", colName)
+	}
+
+	err := json.Unmarshal(data, dest)
+
+	if err != nil {
+		return fmt.Errorf("invalid value for %s: %w", colName, err)
+	}
+
+	return nil
+}
+
+// Insert inserts the row given, returning an error if it cannot. Insert will be called once for each row to process
+// for the insert operation, which may involve many rows. After all rows in an operation have been processed, Close
+// is called.
+func (rWr remoteWriter) Insert(ctx *sql.Context, r sql.Row) error {
+	remote, err := remoteFromRow(r, rWr.rt.rsr)
+
+	if err != nil {
+		return err
+	}
+
+	remotes, err := rWr.rt.rsr.GetRemotes()
+
+	if err != nil {
+		return err
+	}
+
+	if existing, ok := remotes[remote.Name]; ok {
+		return sql.NewUniqueKeyErr(remote.Name, true, remoteRow(existing))
+	}
+
+	return rWr.rt.rsw.AddRemote(remote)
+}
+
+// Update the given row. Provides both the old and new rows. Renaming a remote removes the old remote and its remote
+// tracking branches.
+func (rWr remoteWriter) Update(ctx *sql.Context, old sql.Row, new sql.Row) error {
+	remote, err := remoteFromRow(new, rWr.rt.rsr)
+
+	if err != nil {
+		return err
+	}
+
+	oldName, err := remoteNameFromRow(old)
+
+	if err != nil {
+		return err
+	}
+
+	if oldName != remote.Name {
+		err = rWr.Delete(ctx, old)
+
+		if err != nil {
+			return err
+		}
+
+		return rWr.Insert(ctx, new)
+	}
+
+	return rWr.rt.rsw.AddRemote(remote)
+}
+
+// Delete deletes the given row. Returns ErrDeleteRowNotFound if the row was not found. Delete will be called once for
+// each row to process for the delete operation, which may involve many rows. After all rows have been processed,
+// Close is called. The remote tracking branches of the remote are deleted along with it.
+func (rWr remoteWriter) Delete(ctx *sql.Context, r sql.Row) error {
+	remoteName, err := remoteNameFromRow(r)
+
+	if err != nil {
+		return err
+	}
+
+	remotes, err := rWr.rt.rsr.GetRemotes()
+
+	if err != nil {
+		return err
+	}
+
+	if _, ok := remotes[remoteName]; !ok {
+		return sql.ErrDeleteRowNotFound.New()
+	}
+
+	ddb := rWr.rt.ddb
+	refs, err := ddb.GetRefsOfType(ctx, map[ref.RefType]struct{}{ref.RemoteRefType: {}})
+
+	if err != nil {
+		return err
+	}
+
+	for _, dref := range refs {
+		rr := dref.(ref.RemoteRef)
+
+		if rr.GetRemote() == remoteName {
+			err = ddb.DeleteBranch(ctx, rr)
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return rWr.rt.rsw.RemoveRemote(ctx, remoteName)
+}
+
+// remoteReplacer is the sql.RowReplacer of the remotes table. Replacing a row with the same name as an existing remote
+// updates the remote in place instead of deleting it, which would also delete its remote tracking branches.
+type remoteReplacer struct {
+	remoteWriter
+}
+
+// Insert inserts the row given, replacing the remote of the same name if there is one.
+func (rRp remoteReplacer) Insert(ctx *sql.Context, r sql.Row) error {
+	remote, err := remoteFromRow(r, rRp.rt.rsr)
+
+	if err != nil {
+		return err
+	}
+
+	return rRp.rt.rsw.AddRemote(remote)
+}
+
+// StatementBegin implements the interface sql.TableEditor. Currently a no-op.
+func (rWr remoteWriter) StatementBegin(ctx *sql.Context) {}
+
+// DiscardChanges implements the interface sql.TableEditor. Currently a no-op.
+func (rWr remoteWriter) DiscardChanges(ctx *sql.Context, errorEncountered error) error {
+	return nil
+}
+
+// StatementComplete implements the interface sql.TableEditor. Currently a no-op.
+func (rWr remoteWriter) StatementComplete(ctx *sql.Context) error {
+	return nil
+}
+
+// Close finalizes the delete operation, persisting the result.
+func (rWr remoteWriter) Close(*sql.Context) error {
+	return nil
+}
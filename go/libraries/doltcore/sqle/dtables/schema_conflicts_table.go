@@ -0,0 +1,162 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlfmt"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+var _ sql.Table = (*SchemaConflictsTable)(nil)
+var _ sql.DeletableTable = (*SchemaConflictsTable)(nil)
+
+// SchemaConflictsTable is a sql.Table implementation that implements a system table which shows the tables whose
+// schemas could not be merged by the merge in progress. Deleting a row resolves the conflict, keeping the table as it
+// currently is in the working set.
+type SchemaConflictsTable struct {
+	dbName string
+}
+
+// NewSchemaConflictsTable creates a SchemaConflictsTable
+func NewSchemaConflictsTable(_ *sql.Context, dbName string) sql.Table {
+	return &SchemaConflictsTable{dbName: dbName}
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// SchemaConflictsTableName
+func (sct *SchemaConflictsTable) Name() string {
+	return doltdb.SchemaConflictsTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// SchemaConflictsTableName
+func (sct *SchemaConflictsTable) String() string {
+	return doltdb.SchemaConflictsTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the schema conflicts system table
+func (sct *SchemaConflictsTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "table_name", Type: sql.Text, Source: doltdb.SchemaConflictsTableName, PrimaryKey: true, Nullable: false},
+		{Name: "base_schema", Type: sql.Text, Source: doltdb.SchemaConflictsTableName, PrimaryKey: false, Nullable: true},
+		{Name: "our_schema", Type: sql.Text, Source: doltdb.SchemaConflictsTableName, PrimaryKey: false, Nullable: true},
+		{Name: "their_schema", Type: sql.Text, Source: doltdb.SchemaConflictsTableName, PrimaryKey: false, Nullable: true},
+		{Name: "description", Type: sql.Text, Source: doltdb.SchemaConflictsTableName, PrimaryKey: false, Nullable: false},
+	}
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data.  Currently the data is unpartitioned.
+func (sct *SchemaConflictsTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return sqlutil.NewSinglePartitionIter(types.Map{}), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (sct *SchemaConflictsTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	sess := dsess.DSessFromSess(ctx.Session)
+	head, err := sess.GetHeadCommit(ctx, sct.dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	ws, err := sess.WorkingSet(ctx, sct.dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts, err := merge.GetSchemaConflicts(ctx, ws, head)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []sql.Row
+	for _, c := range conflicts {
+		rows = append(rows, sql.NewRow(
+			c.TableName,
+			createStmtOrNil(c.TableName, c.BaseSch),
+			createStmtOrNil(c.TableName, c.OurSch),
+			createStmtOrNil(c.TableName, c.TheirSch),
+			c.Description,
+		))
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// createStmtOrNil returns the CREATE TABLE statement for |sch|, or nil if the table doesn't exist.
+func createStmtOrNil(tblName string, sch schema.Schema) interface{} {
+	if sch == nil {
+		return nil
+	}
+	return sqlfmt.CreateTableStmt(tblName, sch)
+}
+
+// Deleter returns a RowDeleter for this table. The RowDeleter will get one call to Delete for each row to be deleted,
+// and will end with a call to Close() to finalize the delete operation.
+func (sct *SchemaConflictsTable) Deleter(*sql.Context) sql.RowDeleter {
+	return &schemaConflictDeleter{sct: sct}
+}
+
+var _ sql.RowDeleter = (*schemaConflictDeleter)(nil)
+
+type schemaConflictDeleter struct {
+	sct    *SchemaConflictsTable
+	tables []string
+}
+
+// Delete implements the interface sql.RowDeleter.
+func (scd *schemaConflictDeleter) Delete(ctx *sql.Context, r sql.Row) error {
+	scd.tables = append(scd.tables, r[0].(string))
+	return nil
+}
+
+// StatementBegin implements the interface sql.TableEditor. Currently a no-op.
+func (scd *schemaConflictDeleter) StatementBegin(ctx *sql.Context) {}
+
+// DiscardChanges implements the interface sql.TableEditor. Currently a no-op.
+func (scd *schemaConflictDeleter) DiscardChanges(ctx *sql.Context, errorEncountered error) error {
+	return nil
+}
+
+// StatementComplete implements the interface sql.TableEditor. Currently a no-op.
+func (scd *schemaConflictDeleter) StatementComplete(ctx *sql.Context) error {
+	return nil
+}
+
+// Close finalizes the delete operation, persisting the result.
+func (scd *schemaConflictDeleter) Close(ctx *sql.Context) error {
+	if len(scd.tables) == 0 {
+		return nil
+	}
+
+	sess := dsess.DSessFromSess(ctx.Session)
+	ws, err := sess.WorkingSet(ctx, scd.sct.dbName)
+	if err != nil {
+		return err
+	}
+
+	ws, err = merge.ResolveSchemaConflicts(ctx, ws, scd.tables, false)
+	if err != nil {
+		return err
+	}
+
+	return sess.SetWorkingSet(ctx, scd.sct.dbName, ws, nil)
+}
@@ -0,0 +1,317 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"errors"
+	"io"
+	"sort"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+var _ sql.Table = (*TagsTable)(nil)
+var _ sql.UpdatableTable = (*TagsTable)(nil)
+var _ sql.DeletableTable = (*TagsTable)(nil)
+var _ sql.InsertableTable = (*TagsTable)(nil)
+var _ sql.ReplaceableTable = (*TagsTable)(nil)
+
+// TagsTable is a sql.Table implementation that implements a system table which shows the dolt tags
+type TagsTable struct {
+	ddb *doltdb.DoltDB
+	rsr env.RepoStateReader
+}
+
+// NewTagsTable creates a TagsTable
+func NewTagsTable(_ *sql.Context, ddb *doltdb.DoltDB, rsr env.RepoStateReader) sql.Table {
+	return &TagsTable{ddb, rsr}
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// TagsTableName
+func (tt *TagsTable) Name() string {
+	return doltdb.TagsTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// TagsTableName
+func (tt *TagsTable) String() string {
+	return doltdb.TagsTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the tags system table
+func (tt *TagsTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "name", Type: sql.Text, Source: doltdb.TagsTableName, PrimaryKey: true, Nullable: false},
+		{Name: "hash", Type: sql.Text, Source: doltdb.TagsTableName, PrimaryKey: false, Nullable: false},
+		{Name: "tagger", Type: sql.Text, Source: doltdb.TagsTableName, PrimaryKey: false, Nullable: true},
+		{Name: "email", Type: sql.Text, Source: doltdb.TagsTableName, PrimaryKey: false, Nullable: true},
+		{Name: "date", Type: sql.Datetime, Source: doltdb.TagsTableName, PrimaryKey: false, Nullable: true},
+		{Name: "message", Type: sql.Text, Source: doltdb.TagsTableName, PrimaryKey: false, Nullable: true},
+	}
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data.  Currently the data is unpartitioned.
+func (tt *TagsTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return sqlutil.NewSinglePartitionIter(types.Map{}), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (tt *TagsTable) PartitionRows(sqlCtx *sql.Context, part sql.Partition) (sql.RowIter, error) {
+	return NewTagItr(sqlCtx, tt.ddb)
+}
+
+// TagItr is a sql.RowItr implementation which iterates over each tag as if it's a row in the table.
+type TagItr struct {
+	tags []*doltdb.Tag
+	idx  int
+}
+
+// NewTagItr creates a TagItr from the current environment.
+func NewTagItr(sqlCtx *sql.Context, ddb *doltdb.DoltDB) (*TagItr, error) {
+	refs, err := ddb.GetTags(sqlCtx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]*doltdb.Tag, 0, len(refs))
+	for _, r := range refs {
+		tr, ok := r.(ref.TagRef)
+
+		if !ok {
+			return nil, errors.New("DoltDB.GetTags() returned non-tag DoltRef")
+		}
+
+		tag, err := ddb.ResolveTag(sqlCtx, tr)
+
+		if err != nil {
+			return nil, err
+		}
+
+		tags = append(tags, tag)
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].Name < tags[j].Name
+	})
+
+	return &TagItr{tags, 0}, nil
+}
+
+// Next retrieves the next row. It will return io.EOF if it's the last row.
+// After retrieving the last row, Close will be automatically closed.
+func (itr *TagItr) Next() (sql.Row, error) {
+	if itr.idx >= len(itr.tags) {
+		return nil, io.EOF
+	}
+
+	defer func() {
+		itr.idx++
+	}()
+
+	return tagRow(itr.tags[itr.idx])
+}
+
+// tagRow returns the row of the tags table that describes |tag|.
+func tagRow(tag *doltdb.Tag) (sql.Row, error) {
+	h, err := tag.Commit.HashOf()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return sql.NewRow(tag.Name, h.String(), tag.Meta.Name, tag.Meta.Email, tag.Meta.Time(), tag.Meta.Description), nil
+}
+
+// Close closes the iterator.
+func (itr *TagItr) Close(*sql.Context) error {
+	return nil
+}
+
+// Replacer returns a RowReplacer for this table. The RowReplacer will have Insert and optionally Delete called once
+// for each row, followed by a call to Close() when all rows have been processed.
+func (tt *TagsTable) Replacer(ctx *sql.Context) sql.RowReplacer {
+	return tagWriter{tt}
+}
+
+// Updater returns a RowUpdater for this table. The RowUpdater will have Update called once for each row to be
+// updated, followed by a call to Close() when all rows have been processed.
+func (tt *TagsTable) Updater(ctx *sql.Context) sql.RowUpdater {
+	return tagWriter{tt}
+}
+
+// Inserter returns an Inserter for this table. The Inserter will get one call to Insert() for each row to be
+// inserted, and will end with a call to Close() to finalize the insert operation.
+func (tt *TagsTable) Inserter(*sql.Context) sql.RowInserter {
+	return tagWriter{tt}
+}
+
+// Deleter returns a RowDeleter for this table. The RowDeleter will get one call to Delete for each row to be deleted,
+// and will end with a call to Close() to finalize the delete operation.
+func (tt *TagsTable) Deleter(*sql.Context) sql.RowDeleter {
+	return tagWriter{tt}
+}
+
+var _ sql.RowReplacer = tagWriter{nil}
+var _ sql.RowUpdater = tagWriter{nil}
+var _ sql.RowInserter = tagWriter{nil}
+var _ sql.RowDeleter = tagWriter{nil}
+
+type tagWriter struct {
+	tt *TagsTable
+}
+
+func tagNameFromRow(r sql.Row) (string, error) {
+	tagName, ok := r[0].(string)
+
+	if !ok {
+		return "", errors.New("invalid value type for tag")
+	} else if !ref.IsValidTagName(tagName) {
+		return "", doltdb.ErrInvTagName
+	}
+
+	return tagName, nil
+}
+
+// Insert inserts the row given, returning an error if it cannot. Insert will be called once for each row to process
+// for the insert operation, which may involve many rows. After all rows in an operation have been processed, Close
+// is called. The hash column may be any commit spec, such as a branch name. The tagger and email default to the
+// user of the session when they are not given, and the date is always the time the tag is created.
+func (tWr tagWriter) Insert(ctx *sql.Context, r sql.Row) error {
+	tagName, err := tagNameFromRow(r)
+
+	if err != nil {
+		return err
+	}
+
+	commitSpec, ok := r[1].(string)
+
+	if !ok {
+		return errors.New("invalid value type for hash")
+	}
+
+	cs, err := doltdb.NewCommitSpec(commitSpec)
+
+	if err != nil {
+		return err
+	}
+
+	ddb := tWr.tt.ddb
+	cm, err := ddb.Resolve(ctx, cs, tWr.tt.rsr.CWBHeadRef())
+
+	if err != nil {
+		return err
+	}
+
+	tagRef := ref.NewTagRef(tagName)
+	exists, err := ddb.HasRef(ctx, tagRef)
+
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		existing, err := ddb.ResolveTag(ctx, tagRef)
+
+		if err != nil {
+			return err
+		}
+
+		row, err := tagRow(existing)
+
+		if err != nil {
+			return err
+		}
+
+		return sql.NewUniqueKeyErr(tagName, true, row)
+	}
+
+	sess := dsess.DSessFromSess(ctx.Session)
+	tagger, email, msg := sess.Username, sess.Email, ""
+
+	if s, ok := r[2].(string); ok {
+		tagger = s
+	}
+	if s, ok := r[3].(string); ok {
+		email = s
+	}
+	if s, ok := r[5].(string); ok {
+		msg = s
+	}
+
+	return ddb.NewTagAtCommit(ctx, tagRef, cm, doltdb.NewTagMeta(tagger, email, msg))
+}
+
+// Update the given row. Provides both the old and new rows. Tags can't be modified in place, so the old tag is deleted
+// and a new one is created from the new row.
+func (tWr tagWriter) Update(ctx *sql.Context, old sql.Row, new sql.Row) error {
+	err := tWr.Delete(ctx, old)
+
+	if err != nil {
+		return err
+	}
+
+	return tWr.Insert(ctx, new)
+}
+
+// Delete deletes the given row. Returns ErrDeleteRowNotFound if the row was not found. Delete will be called once for
+// each row to process for the delete operation, which may involve many rows. After all rows have been processed,
+// Close is called.
+func (tWr tagWriter) Delete(ctx *sql.Context, r sql.Row) error {
+	tagName, err := tagNameFromRow(r)
+
+	if err != nil {
+		return err
+	}
+
+	tagRef := ref.NewTagRef(tagName)
+	exists, err := tWr.tt.ddb.HasRef(ctx, tagRef)
+
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return sql.ErrDeleteRowNotFound.New()
+	}
+
+	return tWr.tt.ddb.DeleteTag(ctx, tagRef)
+}
+
+// StatementBegin implements the interface sql.TableEditor. Currently a no-op.
+func (tWr tagWriter) StatementBegin(ctx *sql.Context) {}
+
+// DiscardChanges implements the interface sql.TableEditor. Currently a no-op.
+func (tWr tagWriter) DiscardChanges(ctx *sql.Context, errorEncountered error) error {
+	return nil
+}
+
+// StatementComplete implements the interface sql.TableEditor. Currently a no-op.
+func (tWr tagWriter) StatementComplete(ctx *sql.Context) error {
+	return nil
+}
+
+// Close finalizes the delete operation, persisting the result.
+func (tWr tagWriter) Close(*sql.Context) error {
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	sqle "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/analyzer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dfunctions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// TestGCWithCommits garbage collects while a session commits and makes savepoints, and checks that none of the
+// session's values are collected. Run with -race to check that the session's state is read safely by the collector.
+func TestGCWithCommits(t *testing.T) {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+	db := NewDatabase("dolt", dEnv.DbData())
+
+	cat := sql.NewCatalogWithDbProvider(NewDoltDatabaseProvider(db))
+	a := analyzer.NewBuilder(cat).
+		AddPostValidationRule(dsess.GCHoldRuleName, dsess.NewGCHoldRule(dfunctions.DoltWriteFunctionNames)).
+		Build()
+	engine := sqle.New(cat, a, nil)
+	require.NoError(t, engine.Catalog.Register(dfunctions.DoltFunctions...))
+
+	sqlCtx := NewTestSQLCtx(ctx)
+	sess := dsess.DSessFromSess(sqlCtx.Session)
+	require.NoError(t, sess.AddDB(sqlCtx, getDbState(t, db, dEnv)))
+
+	gc := dsess.NewGarbageCollector()
+	sess.SetGarbageCollector(gc)
+	gc.AddSession(sess, nil)
+
+	query := func(q string) {
+		_, iter, err := engine.Query(sqlCtx, q)
+		require.NoError(t, err, q)
+		require.NoError(t, drainIter(sqlCtx, iter), q)
+	}
+
+	query("create table gc_test (pk int primary key, c1 varchar(20))")
+
+	const numCommits = 20
+	done := make(chan struct{})
+	var gcErr error
+	go func() {
+		defer close(done)
+		for i := 0; i < numCommits && gcErr == nil; i++ {
+			gcErr = gc.GC(ctx, dEnv.DoltDB)
+		}
+	}()
+
+	for i := 0; i < numCommits; i++ {
+		query("begin")
+		query(fmt.Sprintf("insert into gc_test values (%d, 'row %d')", i, i))
+		query("savepoint sp")
+		query(fmt.Sprintf("insert into gc_test values (%d, 'rolled back')", numCommits+i))
+		query("rollback to savepoint sp")
+		query(fmt.Sprintf("select dolt_commit('-am', 'commit %d', '--author', 'John Doe <john@doe.com>')", i))
+		query("commit")
+	}
+
+	<-done
+	require.NoError(t, gcErr)
+
+	require.NoError(t, gc.GC(ctx, dEnv.DoltDB))
+
+	count := func(q string) int64 {
+		_, iter, err := engine.Query(sqlCtx, q)
+		require.NoError(t, err, q)
+		rows, err := sql.RowIterToRows(sqlCtx, iter)
+		require.NoError(t, err, q)
+		require.Len(t, rows, 1)
+		return rows[0][0].(int64)
+	}
+
+	assert.Equal(t, int64(numCommits), count("select count(*) from gc_test"))
+	assert.True(t, count("select count(*) from dolt_log") > numCommits)
+}
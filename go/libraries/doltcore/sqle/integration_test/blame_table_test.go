@@ -0,0 +1,130 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration_test
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	cmd "github.com/dolthub/dolt/go/cmd/dolt/commands"
+)
+
+func TestBlameTable(t *testing.T) {
+	SkipByDefaultInCI(t)
+	dEnv := setupHistoryTests(t)
+	for _, test := range blameTableTests() {
+		t.Run(test.name, func(t *testing.T) {
+			testHistoryTable(t, test, dEnv)
+		})
+	}
+}
+
+func blameTableTests() []historyTableTest {
+	return []historyTableTest{
+		{
+			name:  "select pk, commit_hash from dolt_blame_test",
+			query: "select pk, commit_hash from dolt_blame_test",
+			rows: []sql.Row{
+				{int32(0), HEAD},
+				{int32(1), HEAD_2},
+				{int32(2), HEAD},
+				{int32(3), HEAD_1},
+			},
+		},
+		{
+			name:  "join with the table",
+			query: "select test.pk, c0, message from test join dolt_blame_test on test.pk = dolt_blame_test.pk where c0 < 10",
+			rows: []sql.Row{
+				{int32(1), int32(1), "second"},
+				{int32(3), int32(3), "third"},
+			},
+		},
+		{
+			name: "index change doesn't blame every row",
+			setup: []testCommand{
+				{cmd.SqlCmd{}, args{"-q", "create index c0_idx on test (c0);"}},
+				{cmd.AddCmd{}, args{"."}},
+				{cmd.CommitCmd{}, args{"-m", "added index"}},
+			},
+			query: "select pk, message from dolt_blame_test",
+			rows: []sql.Row{
+				{int32(0), "fourth"},
+				{int32(1), "second"},
+				{int32(2), "fourth"},
+				{int32(3), "third"},
+			},
+		},
+		{
+			name: "uncommitted changes aren't blamed",
+			setup: []testCommand{
+				{cmd.SqlCmd{}, args{"-q", "update test set c0 = 100 where pk = 1;"}},
+			},
+			query: "select pk, message from dolt_blame_test",
+			rows: []sql.Row{
+				{int32(0), "fourth"},
+				{int32(1), nil},
+				{int32(2), "fourth"},
+				{int32(3), "third"},
+			},
+		},
+		{
+			name: "rows from a merged branch are blamed on the branch's commits",
+			setup: []testCommand{
+				{cmd.CheckoutCmd{}, args{"test"}},
+				{cmd.CheckoutCmd{}, args{"-b", "other"}},
+				{cmd.SqlCmd{}, args{"-q", "update test set c0 = 30 where pk = 3;"}},
+				{cmd.CommitCmd{}, args{"-am", "changed on other"}},
+				{cmd.CheckoutCmd{}, args{"master"}},
+				{cmd.SqlCmd{}, args{"-q", "update test set c0 = 40 where pk = 0;"}},
+				{cmd.CommitCmd{}, args{"-am", "changed on master"}},
+				{cmd.MergeCmd{}, args{"other"}},
+				{cmd.CommitCmd{}, args{"-m", "merged other"}},
+			},
+			query: "select pk, message from dolt_blame_test",
+			rows: []sql.Row{
+				{int32(0), "changed on master"},
+				{int32(1), "second"},
+				{int32(2), "fourth"},
+				{int32(3), "changed on other"},
+			},
+		},
+		{
+			name:  "as of",
+			query: "select pk, message from dolt_blame_test as of 'HEAD~1'",
+			rows: []sql.Row{
+				{int32(0), "changed on master"},
+				{int32(1), "second"},
+				{int32(2), "fourth"},
+				{int32(3), "third"},
+			},
+		},
+		{
+			name: "schema change blames every row",
+			setup: []testCommand{
+				{cmd.SqlCmd{}, args{"-q", "alter table test add column c1 int;"}},
+				{cmd.AddCmd{}, args{"."}},
+				{cmd.CommitCmd{}, args{"-m", "fifth"}},
+			},
+			query: "select pk, message from dolt_blame_test",
+			rows: []sql.Row{
+				{int32(0), "fifth"},
+				{int32(1), "fifth"},
+				{int32(2), "fifth"},
+				{int32(3), "fifth"},
+			},
+		},
+	}
+}
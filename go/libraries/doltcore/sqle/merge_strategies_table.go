@@ -0,0 +1,152 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// DoltMergeStrategiesGetTable returns the `dolt_merge_strategies` table from the given db, creating it if it does not
+// already exist.
+func DoltMergeStrategiesGetTable(ctx *sql.Context, db Database) (*WritableDoltTable, error) {
+	root, err := db.GetRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tbl, found, err := db.getTable(ctx, root, doltdb.MergeStrategiesTableName, false)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		return tbl.(*WritableDoltTable), nil
+	}
+
+	err = db.createDoltTable(ctx, doltdb.MergeStrategiesTableName, root, merge.MergeStrategiesTableSchema())
+	if err != nil {
+		return nil, err
+	}
+	root, err = db.GetRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tbl, found, err = db.getTable(ctx, root, doltdb.MergeStrategiesTableName, false)
+	if err != nil {
+		return nil, err
+	}
+	// Verify it was created successfully
+	if !found {
+		return nil, sql.ErrTableNotFound.New(doltdb.MergeStrategiesTableName)
+	}
+	return tbl.(*WritableDoltTable), nil
+}
+
+var _ sql.InsertableTable = (*emptyMergeStrategiesTable)(nil)
+
+// emptyMergeStrategiesTable is the `dolt_merge_strategies` table of a root that doesn't have one yet. It has no rows,
+// and creates the table when rows are first inserted into it.
+type emptyMergeStrategiesTable struct {
+	db Database
+}
+
+// Name implements sql.Table
+func (t *emptyMergeStrategiesTable) Name() string {
+	return doltdb.MergeStrategiesTableName
+}
+
+// String implements sql.Table
+func (t *emptyMergeStrategiesTable) String() string {
+	return doltdb.MergeStrategiesTableName
+}
+
+// Schema implements sql.Table
+func (t *emptyMergeStrategiesTable) Schema() sql.Schema {
+	sqlSchema, err := sqlutil.FromDoltSchema(doltdb.MergeStrategiesTableName, merge.MergeStrategiesTableSchema())
+	if err != nil {
+		panic(err) // should never happen
+	}
+	return sqlSchema
+}
+
+// Partitions implements sql.Table
+func (t *emptyMergeStrategiesTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return sqlutil.NewSinglePartitionIter(types.Map{}), nil
+}
+
+// PartitionRows implements sql.Table
+func (t *emptyMergeStrategiesTable) PartitionRows(*sql.Context, sql.Partition) (sql.RowIter, error) {
+	return sql.RowsToRowIter(), nil
+}
+
+// Inserter implements sql.InsertableTable
+func (t *emptyMergeStrategiesTable) Inserter(*sql.Context) sql.RowInserter {
+	return &mergeStrategiesInserter{db: t.db}
+}
+
+// mergeStrategiesInserter creates the `dolt_merge_strategies` table when the first row is inserted, and then inserts
+// rows into it.
+type mergeStrategiesInserter struct {
+	db       Database
+	inserter sql.RowInserter
+}
+
+// StatementBegin implements sql.TableEditor
+func (i *mergeStrategiesInserter) StatementBegin(ctx *sql.Context) {
+	if i.inserter != nil {
+		i.inserter.StatementBegin(ctx)
+	}
+}
+
+// DiscardChanges implements sql.TableEditor
+func (i *mergeStrategiesInserter) DiscardChanges(ctx *sql.Context, errorEncountered error) error {
+	if i.inserter == nil {
+		return nil
+	}
+	return i.inserter.DiscardChanges(ctx, errorEncountered)
+}
+
+// StatementComplete implements sql.TableEditor
+func (i *mergeStrategiesInserter) StatementComplete(ctx *sql.Context) error {
+	if i.inserter == nil {
+		return nil
+	}
+	return i.inserter.StatementComplete(ctx)
+}
+
+// Insert implements sql.RowInserter
+func (i *mergeStrategiesInserter) Insert(ctx *sql.Context, row sql.Row) error {
+	if i.inserter == nil {
+		tbl, err := DoltMergeStrategiesGetTable(ctx, i.db)
+		if err != nil {
+			return err
+		}
+		i.inserter = tbl.Inserter(ctx)
+		i.inserter.StatementBegin(ctx)
+	}
+
+	return i.inserter.Insert(ctx, row)
+}
+
+// Close implements sql.RowInserter
+func (i *mergeStrategiesInserter) Close(ctx *sql.Context) error {
+	if i.inserter == nil {
+		return nil
+	}
+	return i.inserter.Close(ctx)
+}
@@ -0,0 +1,117 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package privileges
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"net"
+
+	"github.com/dolthub/go-mysql-server/auth"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/mysql"
+	querypb "github.com/dolthub/vitess/go/vt/proto/query"
+)
+
+var _ auth.Auth = (*Store)(nil)
+
+// Mysql implements auth.Auth. Clients authenticate with the mysql_native_password method against the users in the
+// store at the time they connect.
+func (s *Store) Mysql() mysql.AuthServer {
+	return &authServer{s}
+}
+
+// Allowed implements auth.Auth. It only denies queries to users that don't exist and writes to read only stores. The
+// privileges that users have on databases and tables are checked by the rule returned by NewAuthorizationRule.
+func (s *Store) Allowed(ctx *sql.Context, permission auth.Permission) error {
+	s.mu.RLock()
+	_, ok := s.users[ctx.Client().User]
+	s.mu.RUnlock()
+
+	if !ok || (s.readOnly && permission&auth.WritePerm != 0) {
+		return auth.ErrNotAuthorized.Wrap(auth.ErrNoPermission.New(permission))
+	}
+
+	return nil
+}
+
+type authServer struct {
+	store *Store
+}
+
+var _ mysql.AuthServer = (*authServer)(nil)
+
+// AuthMethod implements mysql.AuthServer
+func (as *authServer) AuthMethod(user string) (string, error) {
+	return mysql.MysqlNativePassword, nil
+}
+
+// Salt implements mysql.AuthServer
+func (as *authServer) Salt() ([]byte, error) {
+	return mysql.NewSalt()
+}
+
+// ValidateHash implements mysql.AuthServer
+func (as *authServer) ValidateHash(salt []byte, user string, authResponse []byte, remoteAddr net.Addr) (mysql.Getter, error) {
+	u, ok := as.store.GetUser(user)
+	if !ok || !isValidScramble(authResponse, salt, u.PasswordHash) {
+		return nil, mysql.NewSQLError(mysql.ERAccessDeniedError, mysql.SSAccessDeniedError, "Access denied for user '%v'", user)
+	}
+
+	return userData{user}, nil
+}
+
+// Negotiate implements mysql.AuthServer. It's never called, since every user authenticates with
+// mysql_native_password.
+func (as *authServer) Negotiate(c *mysql.Conn, user string, remoteAddr net.Addr) (mysql.Getter, error) {
+	return nil, mysql.NewSQLError(mysql.ERAccessDeniedError, mysql.SSAccessDeniedError, "Access denied for user '%v'", user)
+}
+
+// isValidScramble returns whether |reply| is the client's scramble of the password with the mysql_native_password
+// hash |passwordHash| and |salt|.
+func isValidScramble(reply, salt []byte, passwordHash string) bool {
+	if passwordHash == "" {
+		// clients send an empty reply for empty passwords
+		return len(reply) == 0
+	}
+
+	hash, err := hex.DecodeString(passwordHash[1:])
+	if err != nil || len(reply) != sha1.Size {
+		return false
+	}
+
+	// the reply is SHA1(password) XOR SHA1(salt + SHA1(SHA1(password)))
+	crypt := sha1.New()
+	crypt.Write(salt)
+	crypt.Write(hash)
+	stage1 := crypt.Sum(nil)
+	for i := range stage1 {
+		stage1[i] ^= reply[i]
+	}
+
+	crypt.Reset()
+	crypt.Write(stage1)
+	return bytes.Equal(crypt.Sum(nil), hash)
+}
+
+type userData struct {
+	name string
+}
+
+// Get implements mysql.Getter
+func (ud userData) Get() *querypb.VTGateCallerID {
+	return &querypb.VTGateCallerID{Username: ud.name}
+}
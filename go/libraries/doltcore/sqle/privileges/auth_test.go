@@ -0,0 +1,55 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package privileges
+
+import (
+	"testing"
+
+	"github.com/dolthub/vitess/go/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthServer(t *testing.T) {
+	store := NewStore()
+	require.NoError(t, store.AddUsers(
+		User{Name: "analyst", PasswordHash: HashPassword("pass")},
+		User{Name: "guest"},
+	))
+
+	as := store.Mysql()
+	method, err := as.AuthMethod("analyst")
+	require.NoError(t, err)
+	assert.Equal(t, mysql.MysqlNativePassword, method)
+
+	salt, err := as.Salt()
+	require.NoError(t, err)
+
+	getter, err := as.ValidateHash(salt, "analyst", mysql.ScramblePassword(salt, []byte("pass")), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "analyst", getter.Get().Username)
+
+	_, err = as.ValidateHash(salt, "analyst", mysql.ScramblePassword(salt, []byte("wrong")), nil)
+	assert.Error(t, err)
+	_, err = as.ValidateHash(salt, "analyst", nil, nil)
+	assert.Error(t, err)
+	_, err = as.ValidateHash(salt, "nobody", mysql.ScramblePassword(salt, []byte("pass")), nil)
+	assert.Error(t, err)
+
+	_, err = as.ValidateHash(salt, "guest", nil, nil)
+	assert.NoError(t, err)
+	_, err = as.ValidateHash(salt, "guest", mysql.ScramblePassword(salt, []byte("pass")), nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,194 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package privileges
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Privilege is a set of MySQL style privileges that a user can be granted on databases and tables.
+type Privilege uint16
+
+const (
+	Select Privilege = 1 << iota
+	Insert
+	Update
+	Delete
+	Create
+	Drop
+	Alter
+	GrantOption
+
+	// None is the empty set of privileges
+	None Privilege = 0
+	// DDL is the set of privileges needed to create, drop and alter tables, views, indexes and triggers
+	DDL = Create | Drop | Alter
+	// Write is the set of privileges needed to change the rows of a table
+	Write = Insert | Update | Delete
+	// All is the set of privileges granted by GRANT ALL PRIVILEGES. Like in MySQL, it doesn't include GRANT OPTION.
+	All = Select | Write | DDL
+)
+
+// Wildcard matches any database or table in a grant
+const Wildcard = "*"
+
+var privilegeNames = []struct {
+	priv Privilege
+	name string
+}{
+	{Select, "SELECT"},
+	{Insert, "INSERT"},
+	{Update, "UPDATE"},
+	{Delete, "DELETE"},
+	{Create, "CREATE"},
+	{Drop, "DROP"},
+	{Alter, "ALTER"},
+	{GrantOption, "GRANT OPTION"},
+}
+
+// ParsePrivileges parses a comma separated list of privilege names, such as "SELECT, INSERT". "ALL" and
+// "ALL PRIVILEGES" name every privilege but GRANT OPTION, and "DDL" names CREATE, DROP and ALTER.
+func ParsePrivileges(str string) (Privilege, error) {
+	var privs Privilege
+	for _, name := range strings.Split(str, ",") {
+		priv, err := parsePrivilege(name)
+		if err != nil {
+			return None, err
+		}
+
+		privs |= priv
+	}
+
+	return privs, nil
+}
+
+func parsePrivilege(name string) (Privilege, error) {
+	name = strings.Join(strings.Fields(strings.ToUpper(name)), " ")
+
+	switch name {
+	case "ALL", "ALL PRIVILEGES":
+		return All, nil
+	case "DDL":
+		return DDL, nil
+	}
+
+	for _, pn := range privilegeNames {
+		if pn.name == name {
+			return pn.priv, nil
+		}
+	}
+
+	return None, fmt.Errorf("unknown privilege '%s'", name)
+}
+
+// Has returns whether every privilege in |privs| is in this set
+func (p Privilege) Has(privs Privilege) bool {
+	return p&privs == privs
+}
+
+// First returns the first privilege of this set in the order they're listed by String.
+func (p Privilege) First() Privilege {
+	for _, pn := range privilegeNames {
+		if p.Has(pn.priv) {
+			return pn.priv
+		}
+	}
+
+	return None
+}
+
+// String returns the names of the privileges in this set, separated by commas.
+func (p Privilege) String() string {
+	if p == None {
+		return "USAGE"
+	}
+
+	var names []string
+	if p.Has(All) {
+		names = append(names, "ALL PRIVILEGES")
+		p &^= All
+	}
+
+	for _, pn := range privilegeNames {
+		if p.Has(pn.priv) {
+			names = append(names, pn.name)
+		}
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// Grant is a set of privileges on a database and table, either of which can be the Wildcard
+type Grant struct {
+	Database   string
+	Table      string
+	Privileges Privilege
+}
+
+// ParseGrant parses a grant of the privileges in |privs| on the object |on|, which is a database and table name
+// separated by a period, as in GRANT statements. "*.*" grants the privileges on every table of every database, and
+// "mydb.*" grants them on every table of mydb.
+func ParseGrant(privs, on string) (Grant, error) {
+	p, err := ParsePrivileges(privs)
+	if err != nil {
+		return Grant{}, err
+	}
+
+	db, table, err := parseGrantObject(on)
+	if err != nil {
+		return Grant{}, err
+	}
+
+	return Grant{Database: db, Table: table, Privileges: p}, nil
+}
+
+func parseGrantObject(on string) (db string, table string, err error) {
+	parts := strings.Split(on, ".")
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("invalid grant object '%s', expected a database and table such as 'mydb.*'", on)
+	}
+
+	db = strings.Trim(strings.TrimSpace(parts[0]), "`")
+	table = strings.Trim(strings.TrimSpace(parts[1]), "`")
+	if db == "" || table == "" {
+		return "", "", fmt.Errorf("invalid grant object '%s', expected a database and table such as 'mydb.*'", on)
+	}
+
+	if db == Wildcard && table != Wildcard {
+		return "", "", fmt.Errorf("invalid grant object '%s', tables can't be granted on every database", on)
+	}
+
+	return db, table, nil
+}
+
+// On returns the object of this grant as it's written in GRANT statements, such as "mydb.*"
+func (g Grant) On() string {
+	return g.Database + "." + g.Table
+}
+
+// String returns this grant as it's written in GRANT statements, without the user.
+func (g Grant) String() string {
+	return fmt.Sprintf("%s ON %s", g.Privileges.String(), g.On())
+}
+
+func (g Grant) appliesTo(db, table string) bool {
+	if g.Database != Wildcard && !strings.EqualFold(g.Database, db) {
+		return false
+	}
+
+	// privileges on a database as a whole can only come from grants on all of its tables
+	return g.Table == Wildcard || (table != "" && strings.EqualFold(g.Table, table))
+}
@@ -0,0 +1,79 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package privileges
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePrivileges(t *testing.T) {
+	tests := []struct {
+		str      string
+		expected Privilege
+	}{
+		{"SELECT", Select},
+		{"select, insert", Select | Insert},
+		{" UPDATE ,DELETE ", Update | Delete},
+		{"all", All},
+		{"ALL PRIVILEGES", All},
+		{"DDL", Create | Drop | Alter},
+		{"SELECT, GRANT OPTION", Select | GrantOption},
+	}
+
+	for _, test := range tests {
+		t.Run(test.str, func(t *testing.T) {
+			privs, err := ParsePrivileges(test.str)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, privs)
+		})
+	}
+
+	_, err := ParsePrivileges("SELECT, FLY")
+	assert.Error(t, err)
+	_, err = ParsePrivileges("")
+	assert.Error(t, err)
+}
+
+func TestPrivilegeString(t *testing.T) {
+	assert.Equal(t, "USAGE", None.String())
+	assert.Equal(t, "SELECT, INSERT", (Select | Insert).String())
+	assert.Equal(t, "ALL PRIVILEGES, GRANT OPTION", (All | GrantOption).String())
+	assert.Equal(t, Insert, (Delete | Insert).First())
+
+	for _, privs := range []Privilege{Select, Select | Delete | Alter, All, All | GrantOption} {
+		parsed, err := ParsePrivileges(privs.String())
+		require.NoError(t, err)
+		assert.Equal(t, privs, parsed)
+	}
+}
+
+func TestParseGrant(t *testing.T) {
+	grant, err := ParseGrant("SELECT", "*.*")
+	require.NoError(t, err)
+	assert.Equal(t, Grant{Database: "*", Table: "*", Privileges: Select}, grant)
+
+	grant, err = ParseGrant("INSERT", "`mydb`.`mytable`")
+	require.NoError(t, err)
+	assert.Equal(t, Grant{Database: "mydb", Table: "mytable", Privileges: Insert}, grant)
+	assert.Equal(t, "INSERT ON mydb.mytable", grant.String())
+
+	for _, on := range []string{"mydb", "*", "*.mytable", "mydb.", "a.b.c"} {
+		_, err = ParseGrant("SELECT", on)
+		assert.Error(t, err, on)
+	}
+}
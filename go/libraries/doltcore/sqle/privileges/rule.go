@@ -0,0 +1,289 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package privileges
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/analyzer"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+	"gopkg.in/src-d/go-errors.v1"
+)
+
+// AuthorizationRuleName is the name of the analyzer rule returned by NewAuthorizationRule
+const AuthorizationRuleName = "dolt_authorization"
+
+var ErrTableAccessDenied = errors.NewKind("%s command denied to user '%s' for table '%s'")
+var ErrDatabaseAccessDenied = errors.NewKind("%s command denied to user '%s' for database '%s'")
+var ErrGlobalAccessDenied = errors.NewKind("%s command denied to user '%s', it requires the privilege on *.*")
+
+// informationSchemaName is the name of the database that every user can read, like in MySQL
+const informationSchemaName = "information_schema"
+
+// dualTableName is the name of the table that SELECT statements without a FROM clause read
+const dualTableName = "dual"
+
+// revisionDelimiter separates a database name from the revision it names, e.g. "mydb/mybranch". Privileges on a
+// database apply to all of its revisions.
+const revisionDelimiter = "/"
+
+// NewAuthorizationRule returns an analyzer rule that denies queries needing privileges that the user running them
+// hasn't been granted. It must run before tables are resolved. Reading a table needs SELECT, and INSERT, UPDATE and
+// DELETE need the privileges of the same names on the tables they write to. Statements that create, drop and alter
+// schema elements need CREATE, DROP and ALTER. Calling any of the functions named in |writeFuncNames| needs INSERT,
+// UPDATE and DELETE on the whole current database, and so does setting a session variable named after a database
+// and one of |writeVarSuffixes|, such as @@mydb_working, on that database.
+func NewAuthorizationRule(store *Store, writeFuncNames []string, writeVarSuffixes []string) analyzer.RuleFunc {
+	writeFuncs := make(map[string]bool, len(writeFuncNames))
+	for _, name := range writeFuncNames {
+		writeFuncs[strings.ToLower(name)] = true
+	}
+
+	return func(ctx *sql.Context, a *analyzer.Analyzer, n sql.Node, scope *analyzer.Scope) (sql.Node, error) {
+		c := checker{ctx: ctx, store: store, user: ctx.Client().User, writeFuncs: writeFuncs, writeVarSuffixes: writeVarSuffixes}
+		return n, c.checkNode(n, Select)
+	}
+}
+
+type checker struct {
+	ctx              *sql.Context
+	store            *Store
+	user             string
+	writeFuncs       map[string]bool
+	writeVarSuffixes []string
+}
+
+// checkNode checks that the user has the privileges needed to run |n|. |privs| are the privileges needed on the tables
+// read or written by |n| and its children, unless they're tables which |n| itself needs other privileges on.
+func (c checker) checkNode(n sql.Node, privs Privilege) error {
+	switch n := n.(type) {
+	case *plan.UnresolvedTable:
+		if n.Database == "" && strings.EqualFold(n.Name(), dualTableName) {
+			return nil
+		}
+		return c.checkTable(n.Database, n.Name(), privs)
+	case *plan.InsertInto:
+		destPrivs := Insert
+		if n.IsReplace {
+			destPrivs |= Delete
+		}
+		if len(n.OnDupExprs) > 0 {
+			destPrivs |= Update
+		}
+
+		if err := c.checkNode(n.Destination, destPrivs); err != nil {
+			return err
+		}
+		if err := c.checkExpressions(n); err != nil {
+			return err
+		}
+		return c.checkNode(n.Source, Select)
+	case *plan.Update:
+		return c.checkChildren(n, Update)
+	case *plan.DeleteFrom:
+		return c.checkChildren(n, Delete)
+	case *plan.CreateTable:
+		if err := c.checkTable(databaseName(n), n.Name(), Create); err != nil {
+			return err
+		}
+		if n.Like() != nil {
+			if err := c.checkNode(n.Like(), Select); err != nil {
+				return err
+			}
+		}
+		if n.Select() != nil {
+			if err := c.checkNode(n.Select(), Select); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *plan.DropTable:
+		for _, name := range n.TableNames() {
+			if err := c.checkTable(databaseName(n), name, Drop); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *plan.AddColumn:
+		return c.checkTable(databaseName(n), n.TableName(), Alter)
+	case *plan.ModifyColumn:
+		return c.checkTable(databaseName(n), n.TableName(), Alter)
+	case *plan.CreateDB, *plan.DropDB:
+		return c.checkGlobal(ddlPrivilege(n))
+	case *plan.CreateView:
+		if err := c.checkDatabase(databaseName(n), Create); err != nil {
+			return err
+		}
+		// like in MySQL, creating a view needs SELECT on the tables it reads
+		return c.checkChildren(n, Select)
+	}
+
+	if plan.IsDDLNode(n) {
+		return c.checkDDL(n, ddlPrivilege(n))
+	}
+
+	if err := c.checkExpressions(n); err != nil {
+		return err
+	}
+
+	return c.checkChildren(n, privs)
+}
+
+func (c checker) checkChildren(n sql.Node, privs Privilege) error {
+	for _, child := range n.Children() {
+		if err := c.checkNode(child, privs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkDDL checks the privileges needed by DDL statements without a case of their own in checkNode. They need
+// |privs| on the tables they name directly, or on their whole database if they don't name any.
+func (c checker) checkDDL(n sql.Node, privs Privilege) error {
+	checkedTable := false
+	for _, child := range n.Children() {
+		if t, ok := child.(*plan.UnresolvedTable); ok {
+			if err := c.checkTable(t.Database, t.Name(), privs); err != nil {
+				return err
+			}
+			checkedTable = true
+		}
+	}
+
+	if checkedTable {
+		return nil
+	}
+
+	return c.checkDatabase(databaseName(n), privs)
+}
+
+// checkExpressions checks the privileges needed by the subqueries and functions in the expressions of |n|
+func (c checker) checkExpressions(n sql.Node) error {
+	exprs, ok := n.(sql.Expressioner)
+	if !ok {
+		return nil
+	}
+
+	var err error
+	for _, expr := range exprs.Expressions() {
+		sql.Inspect(expr, func(e sql.Expression) bool {
+			switch e := e.(type) {
+			case *plan.Subquery:
+				err = c.checkNode(e.Query, Select)
+			case *expression.UnresolvedFunction:
+				if c.writeFuncs[strings.ToLower(e.Name())] {
+					err = c.checkDatabase("", Write)
+				}
+			case *expression.SetField:
+				if sysVar, ok := e.Left.(*expression.SystemVar); ok {
+					err = c.checkSystemVar(sysVar.Name)
+				}
+			}
+			return err == nil
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkSystemVar checks the privileges needed to set the system variable named
+func (c checker) checkSystemVar(name string) error {
+	name = strings.ToLower(name)
+	for _, suffix := range c.writeVarSuffixes {
+		if strings.HasSuffix(name, suffix) && len(name) > len(suffix) {
+			return c.checkDatabase(name[:len(name)-len(suffix)], Write)
+		}
+	}
+
+	return nil
+}
+
+func (c checker) checkTable(db, table string, privs Privilege) error {
+	db = c.resolveDatabase(db)
+	if db == "" || strings.EqualFold(db, informationSchemaName) {
+		return nil
+	}
+
+	missing := c.store.MissingPrivileges(c.user, db, table, privs)
+	if missing != None {
+		return ErrTableAccessDenied.New(missing.First().String(), c.user, table)
+	}
+
+	return nil
+}
+
+func (c checker) checkDatabase(db string, privs Privilege) error {
+	db = c.resolveDatabase(db)
+	if db == "" || strings.EqualFold(db, informationSchemaName) {
+		return nil
+	}
+
+	missing := c.store.MissingPrivileges(c.user, db, "", privs)
+	if missing != None {
+		return ErrDatabaseAccessDenied.New(missing.First().String(), c.user, db)
+	}
+
+	return nil
+}
+
+func (c checker) checkGlobal(privs Privilege) error {
+	missing := c.store.MissingPrivileges(c.user, Wildcard, "", privs)
+	if missing != None {
+		return ErrGlobalAccessDenied.New(missing.First().String(), c.user)
+	}
+
+	return nil
+}
+
+// resolveDatabase returns the name of the database that privileges on |db| are granted on. Tables without a database
+// are in the current database, and revision databases share the privileges of their database.
+func (c checker) resolveDatabase(db string) string {
+	if db == "" {
+		db = c.ctx.GetCurrentDatabase()
+	}
+
+	return strings.SplitN(db, revisionDelimiter, 2)[0]
+}
+
+// databaseName returns the name of the database of |n|, or an empty string for the current database
+func databaseName(n sql.Node) string {
+	if databaser, ok := n.(sql.Databaser); ok && databaser.Database() != nil {
+		return databaser.Database().Name()
+	}
+
+	return ""
+}
+
+// ddlPrivilege returns the privilege needed to run the DDL statement |n|
+func ddlPrivilege(n sql.Node) Privilege {
+	switch n.(type) {
+	case *plan.CreateTable, *plan.CreateDB, *plan.CreateView, *plan.CreateIndex, *plan.CreateTrigger,
+		*plan.CreateProcedure, *plan.CreateForeignKey, *plan.CreateCheck:
+		return Create
+	case *plan.DropTable, *plan.DropDB, *plan.DropView, *plan.DropIndex, *plan.DropTrigger, *plan.DropProcedure,
+		*plan.DropForeignKey, *plan.DropCheck, *plan.Truncate:
+		return Drop
+	default:
+		return Alter
+	}
+}
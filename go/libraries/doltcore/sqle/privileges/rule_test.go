@@ -0,0 +1,134 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package privileges
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/parse"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dfunctions"
+)
+
+func TestAuthorizationRule(t *testing.T) {
+	store := NewStore()
+	require.NoError(t, store.AddUsers(
+		User{Name: "root", Grants: []Grant{{Database: "*", Table: "*", Privileges: All | GrantOption}}},
+		User{Name: "analyst", Grants: []Grant{{Database: "mydb", Table: "*", Privileges: Select}}},
+		User{Name: "etl", Grants: []Grant{
+			{Database: "mydb", Table: "*", Privileges: Select},
+			{Database: "mydb", Table: "events", Privileges: Insert | Update},
+		}},
+		User{Name: "dba", Grants: []Grant{{Database: "mydb", Table: "*", Privileges: All}}},
+	))
+
+	rule := NewAuthorizationRule(store, []string{"dolt_commit"}, []string{"_working"})
+
+	tests := []struct {
+		query   string
+		allowed []string
+	}{
+		{"SELECT 1", []string{"root", "analyst", "etl", "dba", "nobody"}},
+		{"SELECT * FROM events", []string{"root", "analyst", "etl", "dba"}},
+		{"SELECT * FROM `mydb/feature`.events", []string{"root", "analyst", "etl", "dba"}},
+		{"SELECT * FROM otherdb.events", []string{"root"}},
+		{"SELECT * FROM information_schema.tables", []string{"root", "analyst", "etl", "dba", "nobody"}},
+		{"SELECT * FROM events WHERE id IN (SELECT id FROM otherdb.users)", []string{"root"}},
+		{"INSERT INTO events VALUES (1)", []string{"root", "etl", "dba"}},
+		{"INSERT INTO events SELECT * FROM otherdb.events", []string{"root"}},
+		{"INSERT INTO users VALUES (1)", []string{"root", "dba"}},
+		{"INSERT INTO events VALUES (1) ON DUPLICATE KEY UPDATE id = 2", []string{"root", "etl", "dba"}},
+		{"REPLACE INTO events VALUES (1)", []string{"root", "dba"}},
+		{"UPDATE events SET id = 2", []string{"root", "etl", "dba"}},
+		{"DELETE FROM events WHERE id = 1", []string{"root", "dba"}},
+		{"CREATE TABLE t (id int primary key)", []string{"root", "dba"}},
+		{"CREATE TABLE otherdb.t (id int primary key)", []string{"root"}},
+		{"DROP TABLE events", []string{"root", "dba"}},
+		{"ALTER TABLE events ADD COLUMN c int", []string{"root", "dba"}},
+		{"CREATE INDEX idx ON events (id)", []string{"root", "dba"}},
+		{"CREATE VIEW v AS SELECT * FROM otherdb.events", []string{"root"}},
+		{"CREATE DATABASE newdb", []string{"root"}},
+		{"SELECT DOLT_COMMIT('-m', 'message')", []string{"root", "dba"}},
+		{"SET @@mydb_working = 'abc'", []string{"root", "dba"}},
+		{"SET @@autocommit = 1", []string{"root", "analyst", "etl", "dba", "nobody"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			for _, user := range []string{"root", "analyst", "etl", "dba", "nobody"} {
+				ctx := sql.NewContext(context.Background(), sql.WithSession(sql.NewSession("localhost", "127.0.0.1", user, 1)))
+				ctx.SetCurrentDatabase("mydb")
+
+				parsed, err := parse.Parse(ctx, test.query)
+				require.NoError(t, err)
+
+				_, err = rule(ctx, nil, parsed, nil)
+				if contains(test.allowed, user) {
+					assert.NoError(t, err, "user %s", user)
+				} else {
+					assert.Error(t, err, "user %s", user)
+				}
+			}
+		})
+	}
+}
+
+func TestAuthorizationRuleDoltWriteFunctions(t *testing.T) {
+	store := NewStore()
+	require.NoError(t, store.AddUsers(
+		User{Name: "root", Grants: []Grant{{Database: "*", Table: "*", Privileges: All}}},
+		User{Name: "analyst", Grants: []Grant{{Database: "mydb", Table: "*", Privileges: Select}}},
+	))
+
+	rule := NewAuthorizationRule(store, dfunctions.DoltWriteFunctionNames, nil)
+
+	queries := []string{
+		"SELECT DOLT_MERGE('feature')",
+		"SELECT MERGE('feature')",
+		"SELECT DOLT_RESET('--hard')",
+		"SELECT RESET('hard')",
+		"SELECT SQUASH('feature')",
+	}
+
+	for _, query := range queries {
+		t.Run(query, func(t *testing.T) {
+			ctx := sql.NewContext(context.Background(), sql.WithSession(sql.NewSession("localhost", "127.0.0.1", "analyst", 1)))
+			ctx.SetCurrentDatabase("mydb")
+			parsed, err := parse.Parse(ctx, query)
+			require.NoError(t, err)
+
+			_, err = rule(ctx, nil, parsed, nil)
+			assert.Error(t, err)
+
+			ctx = sql.NewContext(context.Background(), sql.WithSession(sql.NewSession("localhost", "127.0.0.1", "root", 1)))
+			ctx.SetCurrentDatabase("mydb")
+			_, err = rule(ctx, nil, parsed, nil)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func contains(strs []string, str string) bool {
+	for _, s := range strs {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
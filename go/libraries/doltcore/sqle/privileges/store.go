@@ -0,0 +1,151 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package privileges
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/auth"
+	"gopkg.in/src-d/go-errors.v1"
+)
+
+var ErrInvalidPasswordHash = errors.NewKind("invalid password hash for user '%s', expected a mysql_native_password hash")
+
+// User is an account that clients can connect to the server as
+type User struct {
+	Name string
+	// PasswordHash is the hash of the user's password in the mysql_native_password format, as returned by
+	// HashPassword. It's empty for users without a password.
+	PasswordHash string
+	Grants       []Grant
+}
+
+// HashPassword returns the mysql_native_password hash of |password|, which is "*" followed by the upper case hex of
+// SHA1(SHA1(password)), or an empty string for an empty password.
+func HashPassword(password string) string {
+	return auth.NativePassword(password)
+}
+
+// ValidatePasswordHash returns an error if |hash| isn't empty or a valid mysql_native_password hash
+func ValidatePasswordHash(hash string) error {
+	if hash == "" {
+		return nil
+	}
+
+	if len(hash) != 41 || hash[0] != '*' {
+		return fmt.Errorf("expected '*' followed by 40 hex digits")
+	}
+
+	_, err := hex.DecodeString(hash[1:])
+	return err
+}
+
+// Privileges returns the privileges this user has on the table named in the database named. If |table| is empty it
+// returns the privileges the user has on the database as a whole.
+func (u *User) Privileges(db, table string) Privilege {
+	var privs Privilege
+	for _, g := range u.Grants {
+		if g.appliesTo(db, table) {
+			privs |= g.Privileges
+		}
+	}
+
+	return privs
+}
+
+func (u *User) copy() *User {
+	cp := *u
+	cp.Grants = append([]Grant(nil), u.Grants...)
+	return &cp
+}
+
+// Store holds the users of a server and the privileges granted to them. Users are defined in the server's config, and
+// can't be changed once it has started.
+type Store struct {
+	mu       sync.RWMutex
+	users    map[string]*User
+	readOnly bool
+}
+
+// NewStore returns a Store without any users
+func NewStore() *Store {
+	return &Store{users: make(map[string]*User)}
+}
+
+// SetReadOnly sets whether every user is denied writes, regardless of their privileges. This is only safe to do
+// during initialization.
+func (s *Store) SetReadOnly(readOnly bool) {
+	s.readOnly = readOnly
+}
+
+// AddUsers adds users defined in the server's config to the store. It's an error to define a user more than once.
+func (s *Store) AddUsers(users ...User) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, u := range users {
+		if _, ok := s.users[u.Name]; ok {
+			return fmt.Errorf("user '%s' is defined more than once", u.Name)
+		}
+
+		if err := ValidatePasswordHash(u.PasswordHash); err != nil {
+			return ErrInvalidPasswordHash.New(u.Name)
+		}
+
+		s.users[u.Name] = u.copy()
+	}
+
+	return nil
+}
+
+// GetUser returns a copy of the user named, if it exists
+func (s *Store) GetUser(name string) (User, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	u, ok := s.users[name]
+	if !ok {
+		return User{}, false
+	}
+
+	return *u.copy(), true
+}
+
+// HasPrivileges returns whether the user named has every privilege in |privs| on the table named in the database
+// named. If |table| is empty the user must have them on the database as a whole, and if |db| is the Wildcard they
+// must have them on every database.
+func (s *Store) HasPrivileges(user, db, table string, privs Privilege) bool {
+	return s.MissingPrivileges(user, db, table, privs) == None
+}
+
+// MissingPrivileges returns the privileges in |privs| which the user named doesn't have on the table named in the
+// database named. See HasPrivileges.
+func (s *Store) MissingPrivileges(user, db, table string, privs Privilege) Privilege {
+	if s.readOnly && privs&^Select != None {
+		return privs &^ Select
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	u, ok := s.users[user]
+	if !ok {
+		return privs
+	}
+
+	return privs &^ u.Privileges(db, table)
+}
@@ -0,0 +1,74 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package privileges
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorePrivileges(t *testing.T) {
+	store := NewStore()
+	require.NoError(t, store.AddUsers(
+		User{Name: "analyst", PasswordHash: HashPassword("pass"), Grants: []Grant{{Database: "*", Table: "*", Privileges: Select}}},
+		User{Name: "etl", Grants: []Grant{
+			{Database: "mydb", Table: "*", Privileges: Select | Insert},
+			{Database: "mydb", Table: "events", Privileges: Delete},
+		}},
+	))
+
+	assert.True(t, store.HasPrivileges("analyst", "mydb", "events", Select))
+	assert.True(t, store.HasPrivileges("analyst", "otherdb", "", Select))
+	assert.False(t, store.HasPrivileges("analyst", "mydb", "events", Insert))
+
+	assert.True(t, store.HasPrivileges("etl", "MyDB", "events", Select|Insert|Delete))
+	assert.False(t, store.HasPrivileges("etl", "mydb", "users", Delete))
+	assert.False(t, store.HasPrivileges("etl", "mydb", "", Delete))
+	assert.False(t, store.HasPrivileges("etl", "otherdb", "events", Select))
+	assert.False(t, store.HasPrivileges("etl", Wildcard, "", Select))
+	assert.Equal(t, Update|Delete, store.MissingPrivileges("etl", "mydb", "users", Write))
+
+	assert.False(t, store.HasPrivileges("nobody", "mydb", "events", Select))
+
+	user, ok := store.GetUser("analyst")
+	require.True(t, ok)
+	assert.Equal(t, HashPassword("pass"), user.PasswordHash)
+	_, ok = store.GetUser("nobody")
+	assert.False(t, ok)
+}
+
+func TestStoreReadOnly(t *testing.T) {
+	store := NewStore()
+	require.NoError(t, store.AddUsers(User{
+		Name:   "root",
+		Grants: []Grant{{Database: "*", Table: "*", Privileges: All}},
+	}))
+	store.SetReadOnly(true)
+
+	assert.True(t, store.HasPrivileges("root", "mydb", "events", Select))
+	assert.False(t, store.HasPrivileges("root", "mydb", "events", Insert))
+	assert.False(t, store.HasPrivileges("root", "mydb", "", Create))
+}
+
+func TestStoreAddUsers(t *testing.T) {
+	store := NewStore()
+	require.NoError(t, store.AddUsers(User{Name: "analyst"}))
+	assert.Error(t, store.AddUsers(User{Name: "analyst"}))
+
+	err := store.AddUsers(User{Name: "etl", PasswordHash: "pass"})
+	assert.True(t, ErrInvalidPasswordHash.Is(err))
+}
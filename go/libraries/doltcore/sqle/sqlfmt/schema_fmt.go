@@ -125,6 +125,37 @@ func FmtForeignKey(fk doltdb.ForeignKey, sch, parentSch schema.Schema) string {
 	return sb.String()
 }
 
+// CreateTableStmt returns a CREATE TABLE statement for a table named |tableName| with the schema |sch|, including its
+// primary key and secondary indexes.
+func CreateTableStmt(tableName string, sch schema.Schema) string {
+	var defs []string
+	_ = sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		defs = append(defs, FmtCol(2, 0, 0, col))
+		return false, nil
+	})
+
+	var pkNames []string
+	_ = sch.GetPKCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		pkNames = append(pkNames, QuoteIdentifier(col.Name))
+		return false, nil
+	})
+	if len(pkNames) > 0 {
+		defs = append(defs, "  PRIMARY KEY ("+strings.Join(pkNames, ",")+")")
+	}
+
+	for _, idx := range sch.Indexes().AllIndexes() {
+		defs = append(defs, "  "+FmtIndex(idx))
+	}
+
+	var b strings.Builder
+	b.WriteString("CREATE TABLE ")
+	b.WriteString(QuoteIdentifier(tableName))
+	b.WriteString(" (\n")
+	b.WriteString(strings.Join(defs, ",\n"))
+	b.WriteString("\n);")
+	return b.String()
+}
+
 func DropTableStmt(tableName string) string {
 	var b strings.Builder
 	b.WriteString("DROP TABLE ")
@@ -68,3 +68,22 @@ func TestFmtCol(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateTableStmt(t *testing.T) {
+	colColl := schema.NewColCollection(
+		schema.NewColumn("id", 0, types.IntKind, true, schema.NotNullConstraint{}),
+		schema.NewColumn("name", 1, types.StringKind, false),
+	)
+	sch, err := schema.SchemaFromCols(colColl)
+	assert.NoError(t, err)
+	_, err = sch.Indexes().AddIndexByColNames("idx_name", []string{"name"}, schema.IndexProperties{IsUnique: true})
+	assert.NoError(t, err)
+
+	expected := "CREATE TABLE `people` (\n" +
+		"  `id` BIGINT NOT NULL,\n" +
+		"  `name` LONGTEXT,\n" +
+		"  PRIMARY KEY (`id`),\n" +
+		"  UNIQUE INDEX `idx_name` (`name`)\n" +
+		");"
+	assert.Equal(t, expected, CreateTableStmt("people", sch))
+}
@@ -87,6 +87,7 @@ func newBlobStoreTests() []BlobstoreTest {
 	tests = append(tests, BlobstoreTest{"inmem", NewInMemoryBlobstore(), 10, 20})
 	tests = appendLocalTest(tests)
 	tests = appendGCSTest(tests)
+	tests = appendS3Tests(tests)
 
 	return tests
 }
@@ -0,0 +1,197 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+const (
+	s3NotFoundCode         = "NotFound"
+	s3IfMatchHeader        = "If-Match"
+	s3IfNoneMatchHeader    = "If-None-Match"
+	s3RangeHeaderPrefix    = "bytes"
+	s3UnknownActualVersion = "unknown (Not supported in S3 implementation)"
+)
+
+// S3Svc is the subset of the S3 API used by an S3Blobstore, which is implemented by the client of the aws-sdk-go s3
+// package.
+type S3Svc interface {
+	HeadObjectWithContext(ctx aws.Context, input *s3.HeadObjectInput, opts ...request.Option) (*s3.HeadObjectOutput, error)
+	GetObjectWithContext(ctx aws.Context, input *s3.GetObjectInput, opts ...request.Option) (*s3.GetObjectOutput, error)
+	PutObjectWithContext(ctx aws.Context, input *s3.PutObjectInput, opts ...request.Option) (*s3.PutObjectOutput, error)
+}
+
+// S3Blobstore provides an S3 implementation of the Blobstore interface. The version of a blob is the ETag of its
+// object, and CheckAndPut is implemented with conditional writes, so it works with S3-compatible servers, such as
+// MinIO, which support the If-Match and If-None-Match headers on PutObject.
+type S3Blobstore struct {
+	s3         S3Svc
+	bucketName string
+	prefix     string
+}
+
+// NewS3Blobstore creates a new instance of an S3Blobstore, which stores blobs in |bucketName| under |prefix|
+func NewS3Blobstore(s3 S3Svc, bucketName, prefix string) *S3Blobstore {
+	for len(prefix) > 0 && prefix[0] == '/' {
+		prefix = prefix[1:]
+	}
+
+	return &S3Blobstore{s3, bucketName, prefix}
+}
+
+func (bs *S3Blobstore) absKey(key string) string {
+	return path.Join(bs.prefix, key)
+}
+
+// Exists returns true if a blob exists for the given key, and false if it does not.
+func (bs *S3Blobstore) Exists(ctx context.Context, key string) (bool, error) {
+	_, err := bs.s3.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bs.bucketName),
+		Key:    aws.String(bs.absKey(key)),
+	})
+
+	if isS3NotFoundErr(err) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// Get retrieves an io.reader for the portion of a blob specified by br along with
+// its version
+func (bs *S3Blobstore) Get(ctx context.Context, key string, br BlobRange) (io.ReadCloser, string, error) {
+	absKey := bs.absKey(key)
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(bs.bucketName),
+		Key:    aws.String(absKey),
+	}
+
+	if !br.isAllRange() {
+		input.Range = aws.String(s3RangeHeader(br))
+	}
+
+	result, err := bs.s3.GetObjectWithContext(ctx, input)
+
+	if isS3NotFoundErr(err) {
+		return nil, "", NotFound{"s3://" + path.Join(bs.bucketName, absKey)}
+	} else if err != nil {
+		return nil, "", err
+	}
+
+	rc := result.Body
+	if br.offset < 0 && br.length != 0 {
+		// a suffix range returns everything from the offset to the end of the blob
+		rc = &s3LimitedReadCloser{io.LimitReader(rc, br.length), rc}
+	}
+
+	return rc, aws.StringValue(result.ETag), nil
+}
+
+// s3RangeHeader returns the value of the Range header which requests |br| of an object.
+func s3RangeHeader(br BlobRange) string {
+	if br.offset < 0 {
+		return fmt.Sprintf("%s=%d", s3RangeHeaderPrefix, br.offset)
+	} else if br.length == 0 {
+		return fmt.Sprintf("%s=%d-", s3RangeHeaderPrefix, br.offset)
+	}
+
+	return fmt.Sprintf("%s=%d-%d", s3RangeHeaderPrefix, br.offset, br.offset+br.length-1)
+}
+
+type s3LimitedReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// Put sets the blob and the version for a key
+func (bs *S3Blobstore) Put(ctx context.Context, key string, reader io.Reader) (string, error) {
+	return bs.put(ctx, key, reader)
+}
+
+// CheckAndPut will check the current version of a blob against an expectedVersion, and if the
+// versions match it will update the data and version associated with the key
+func (bs *S3Blobstore) CheckAndPut(ctx context.Context, expectedVersion, key string, reader io.Reader) (string, error) {
+	condition := func(r *request.Request) {
+		if expectedVersion != "" {
+			r.HTTPRequest.Header.Set(s3IfMatchHeader, expectedVersion)
+		} else {
+			r.HTTPRequest.Header.Set(s3IfNoneMatchHeader, "*")
+		}
+	}
+
+	ver, err := bs.put(ctx, key, reader, condition)
+
+	if err != nil {
+		// a conditional write of an object which doesn't exist fails as though it's missing
+		if isS3PreconditionErr(err) || (expectedVersion != "" && isS3NotFoundErr(err)) {
+			return "", CheckAndPutError{key, expectedVersion, s3UnknownActualVersion}
+		}
+
+		return "", err
+	}
+
+	return ver, nil
+}
+
+func (bs *S3Blobstore) put(ctx context.Context, key string, reader io.Reader, opts ...request.Option) (string, error) {
+	// the body of a PutObject must be seekable, so it can be signed and retried
+	data, err := ioutil.ReadAll(reader)
+
+	if err != nil {
+		return "", err
+	}
+
+	result, err := bs.s3.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(bs.bucketName),
+		Key:    aws.String(bs.absKey(key)),
+		Body:   bytes.NewReader(data),
+	}, opts...)
+
+	if err != nil {
+		return "", err
+	}
+
+	return aws.StringValue(result.ETag), nil
+}
+
+func isS3NotFoundErr(err error) bool {
+	if awsErr, ok := err.(awserr.Error); ok {
+		return awsErr.Code() == s3.ErrCodeNoSuchKey || awsErr.Code() == s3NotFoundCode
+	}
+
+	return false
+}
+
+// isS3PreconditionErr returns whether |err| is the failure of a conditional write, either because the condition didn't
+// hold, or because of a concurrent conditional write of the same object.
+func isS3PreconditionErr(err error) bool {
+	if reqErr, ok := err.(awserr.RequestFailure); ok {
+		return reqErr.StatusCode() == http.StatusPreconditionFailed || reqErr.StatusCode() == http.StatusConflict
+	}
+
+	return false
+}
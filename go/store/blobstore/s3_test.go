@@ -0,0 +1,170 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blobstore
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/google/uuid"
+)
+
+// appendS3Tests adds a test of an S3Blobstore backed by a fake S3, and if TEST_S3_BUCKET is set, one backed by that
+// bucket. TEST_S3_ENDPOINT can be set to the url of an S3-compatible server, such as a local MinIO.
+func appendS3Tests(tests []BlobstoreTest) []BlobstoreTest {
+	tests = append(tests, BlobstoreTest{"fakes3", NewS3Blobstore(newFakeS3(), "bucket", uuid.New().String()), 10, 20})
+
+	testS3Bucket := os.Getenv("TEST_S3_BUCKET")
+	if testS3Bucket == "" {
+		return tests
+	}
+
+	config := aws.NewConfig().WithRegion("us-east-1")
+	if endpoint := os.Getenv("TEST_S3_ENDPOINT"); endpoint != "" {
+		config = config.WithEndpoint(endpoint).WithS3ForcePathStyle(true)
+	}
+
+	sess, err := session.NewSession(config)
+
+	if err != nil {
+		panic("Could not create S3Blobstore")
+	}
+
+	s3Test := BlobstoreTest{"s3", NewS3Blobstore(s3.New(sess), testS3Bucket, uuid.New().String()+"/"), 4, 4}
+	return append(tests, s3Test)
+}
+
+type fakeS3Object struct {
+	data []byte
+	etag string
+}
+
+// fakeS3 is an in memory S3Svc which supports range reads and conditional writes.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string]fakeS3Object
+	etags   int
+}
+
+func newFakeS3() *fakeS3 {
+	return &fakeS3{objects: map[string]fakeS3Object{}}
+}
+
+func fakeS3Error(code string, statusCode int) error {
+	return awserr.NewRequestFailure(awserr.New(code, code, nil), statusCode, "")
+}
+
+func (m *fakeS3) HeadObjectWithContext(ctx aws.Context, input *s3.HeadObjectInput, opts ...request.Option) (*s3.HeadObjectOutput, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	obj, ok := m.objects[aws.StringValue(input.Key)]
+	if !ok {
+		return nil, fakeS3Error(s3NotFoundCode, http.StatusNotFound)
+	}
+
+	return &s3.HeadObjectOutput{ETag: aws.String(obj.etag), ContentLength: aws.Int64(int64(len(obj.data)))}, nil
+}
+
+func (m *fakeS3) GetObjectWithContext(ctx aws.Context, input *s3.GetObjectInput, opts ...request.Option) (*s3.GetObjectOutput, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	obj, ok := m.objects[aws.StringValue(input.Key)]
+	if !ok {
+		return nil, fakeS3Error(s3.ErrCodeNoSuchKey, http.StatusNotFound)
+	}
+
+	data := obj.data
+	if input.Range != nil {
+		start, end := parseFakeS3Range(aws.StringValue(input.Range), int64(len(data)))
+		data = data[start:end]
+	}
+
+	return &s3.GetObjectOutput{Body: ioutil.NopCloser(bytes.NewReader(data)), ETag: aws.String(obj.etag)}, nil
+}
+
+// parseFakeS3Range returns the start and end of the range of an object of |size| bytes requested by a Range header.
+func parseFakeS3Range(rangeHeader string, size int64) (int64, int64) {
+	spec := strings.TrimPrefix(rangeHeader, s3RangeHeaderPrefix+"=")
+	parts := strings.SplitN(spec, "-", 2)
+
+	if parts[0] == "" {
+		suffix, _ := strconv.ParseInt(parts[1], 10, 64)
+		if suffix > size {
+			suffix = size
+		}
+
+		return size - suffix, size
+	}
+
+	start, _ := strconv.ParseInt(parts[0], 10, 64)
+	end := size
+	if parts[1] != "" {
+		last, _ := strconv.ParseInt(parts[1], 10, 64)
+		if last+1 < end {
+			end = last + 1
+		}
+	}
+
+	return start, end
+}
+
+func (m *fakeS3) PutObjectWithContext(ctx aws.Context, input *s3.PutObjectInput, opts ...request.Option) (*s3.PutObjectOutput, error) {
+	data, err := ioutil.ReadAll(input.Body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	r := &request.Request{HTTPRequest: &http.Request{Header: http.Header{}}}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := aws.StringValue(input.Key)
+	obj, exists := m.objects[key]
+
+	if ifMatch := r.HTTPRequest.Header.Get(s3IfMatchHeader); ifMatch != "" {
+		if !exists {
+			return nil, fakeS3Error(s3.ErrCodeNoSuchKey, http.StatusNotFound)
+		} else if obj.etag != ifMatch {
+			return nil, fakeS3Error("PreconditionFailed", http.StatusPreconditionFailed)
+		}
+	}
+
+	if r.HTTPRequest.Header.Get(s3IfNoneMatchHeader) == "*" && exists {
+		return nil, fakeS3Error("PreconditionFailed", http.StatusPreconditionFailed)
+	}
+
+	m.etags++
+	etag := strconv.Quote(strconv.Itoa(m.etags))
+	m.objects[key] = fakeS3Object{data, etag}
+
+	return &s3.PutObjectOutput{ETag: aws.String(etag)}, nil
+}
@@ -29,6 +29,7 @@ import (
 
 	"github.com/golang/snappy"
 	flag "github.com/juju/gnuflag"
+	"github.com/klauspost/compress/zstd"
 
 	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/cmd/noms/util"
@@ -49,7 +50,8 @@ const (
 	totalUncmpSize = u64Size
 	magicSize      = u64Size
 
-	magicNumber uint64 = 0xffb5d8c22463ee50
+	magicNumber     uint64 = 0xffb5d8c22463ee50
+	zstdMagicNumber uint64 = 0x39eb4e6e411fd824
 )
 
 var (
@@ -81,6 +83,7 @@ type footer struct {
 	chunkCnt   uint32
 	uncompSize uint64
 	magicMatch bool
+	zstd       bool
 }
 
 type prefixIndex struct {
@@ -123,12 +126,17 @@ func runCat(ctx context.Context, args []string) int {
 	pos, suffixes := parseChunkSuffixes(fileBytes, pos, int(footer.chunkCnt))
 	pos, sizes := parseChunkSizes(fileBytes, pos, int(footer.chunkCnt))
 	pos, pi := parsePrefixIndices(fileBytes, pos, int(footer.chunkCnt))
-	pos, cd := parseChunks(fileBytes, pos, sizes)
+	pos, cd := parseChunks(fileBytes, pos, sizes, footer.zstd)
 
 	fmt.Println("Info for file", chunkFile+":")
 	fmt.Printf("    chunk count:                     %d\n", footer.chunkCnt)
 	fmt.Printf("    total uncompressed chunk size:   %d\n", footer.uncompSize)
 	fmt.Printf("    magic number matches:            %t\n", footer.magicMatch)
+	if footer.zstd {
+		fmt.Printf("    chunk codec:                     zstd\n")
+	} else {
+		fmt.Printf("    chunk codec:                     snappy\n")
+	}
 	fmt.Println()
 
 	fmt.Println("Prefix Indices:")
@@ -222,10 +230,12 @@ func parseFooter(bytes []byte, pos int) (int, footer) {
 	chunkCntBytes := bytes[pos-chunkCntSize : pos]
 	pos -= chunkCntSize
 
+	magic := binary.BigEndian.Uint64(magicBytes)
 	return pos, footer{
 		chunkCnt:   binary.BigEndian.Uint32(chunkCntBytes),
 		uncompSize: binary.BigEndian.Uint64(totalSizeBytes),
-		magicMatch: binary.BigEndian.Uint64(magicBytes) == magicNumber,
+		magicMatch: magic == magicNumber || magic == zstdMagicNumber,
+		zstd:       magic == zstdMagicNumber,
 	}
 }
 
@@ -280,7 +290,7 @@ func parseChunkSizes(bytes []byte, pos, numChunks int) (int, []int) {
 	return pos, sizes
 }
 
-func parseChunks(bytes []byte, pos int, sizes []int) (int, []chunkData) {
+func parseChunks(bytes []byte, pos int, sizes []int, isZstd bool) (int, []chunkData) {
 	var crcs []uint32
 	var offsets []uint64
 	var chunkBytes [][]byte
@@ -303,9 +313,23 @@ func parseChunks(bytes []byte, pos int, sizes []int) (int, []chunkData) {
 		offsets = append(offsets, offset)
 	}
 
+	decode := func(src []byte) ([]byte, error) {
+		return snappy.Decode(nil, src)
+	}
+
+	if isZstd {
+		dec, err := zstd.NewReader(nil)
+		d.PanicIfError(err)
+		defer dec.Close()
+
+		decode = func(src []byte) ([]byte, error) {
+			return dec.DecodeAll(src, nil)
+		}
+	}
+
 	var cd []chunkData
 	for i := len(sizes) - 1; i >= 0; i-- {
-		uncompressed, err := snappy.Decode(nil, chunkBytes[i])
+		uncompressed, err := decode(chunkBytes[i])
 		d.PanicIfError(err)
 
 		cd = append(cd, chunkData{
@@ -141,6 +141,11 @@ func parentsToQueue(ctx context.Context, refs types.RefSlice, q *types.RefByHeig
 		}
 		seen[r.TargetHash()] = true
 
+		if IsShallowCommit(vr, r.TargetHash()) {
+			// the parents of a shallow commit aren't in the database
+			continue
+		}
+
 		v, err := r.TargetValue(ctx, vr)
 		if err != nil {
 			return err
@@ -153,39 +158,55 @@ func parentsToQueue(ctx context.Context, refs types.RefSlice, q *types.RefByHeig
 		if !ok {
 			return fmt.Errorf("target ref is not struct: %v", v)
 		}
-		ps, ok, err := c.MaybeGet(ParentsListField)
+
+		parents, err := commitParents(ctx, c)
 		if err != nil {
 			return err
 		}
+
+		for _, p := range parents {
+			q.PushBack(p)
+		}
+	}
+
+	sort.Sort(q)
+	return nil
+}
+
+// commitParents returns the refs of the parents of the commit |c|, in order if it has a list of them.
+func commitParents(ctx context.Context, c types.Struct) ([]types.Ref, error) {
+	var parents []types.Ref
+	ps, ok, err := c.MaybeGet(ParentsListField)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		p := ps.(types.List)
+		err = p.Iter(ctx, func(v types.Value, _ uint64) (stop bool, err error) {
+			parents = append(parents, v.(types.Ref))
+			return
+		})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		ps, ok, err := c.MaybeGet(ParentsField)
+		if err != nil {
+			return nil, err
+		}
 		if ok {
-			p := ps.(types.List)
-			err = p.Iter(ctx, func(v types.Value, _ uint64) (stop bool, err error) {
-				q.PushBack(v.(types.Ref))
+			p := ps.(types.Set)
+			err = p.Iter(ctx, func(v types.Value) (stop bool, err error) {
+				parents = append(parents, v.(types.Ref))
 				return
 			})
 			if err != nil {
-				return err
-			}
-		} else {
-			ps, ok, err := c.MaybeGet(ParentsField)
-			if err != nil {
-				return err
-			}
-			if ok {
-				p := ps.(types.Set)
-				err = p.Iter(ctx, func(v types.Value) (stop bool, err error) {
-					q.PushBack(v.(types.Ref))
-					return
-				})
-				if err != nil {
-					return err
-				}
+				return nil, err
 			}
 		}
 	}
 
-	sort.Sort(q)
-	return nil
+	return parents, nil
 }
 
 func findCommonRef(a, b types.RefSlice) (types.Ref, bool) {
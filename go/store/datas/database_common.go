@@ -39,6 +39,9 @@ type database struct {
 	*types.ValueStore
 	rt rootTracker
 	mu sync.Mutex
+
+	shallowMu sync.RWMutex
+	shallow   hash.HashSet
 }
 
 var (
@@ -216,7 +219,7 @@ func (db *database) doSetHead(ctx context.Context, ds Dataset, newHeadRef types.
 		return err
 	}
 
-	refSt, err := db.WriteValue(ctx, newSt) // will be orphaned if the tryCommitChunks() below fails
+	refSt, err := writeHeadValue(ctx, db, newSt) // will be orphaned if the tryCommitChunks() below fails
 
 	if err != nil {
 		return err
@@ -339,7 +342,7 @@ func (db *database) doCommit(ctx context.Context, datasetID string, commit types
 			return err
 		}
 
-		commitRef, err := db.WriteValue(ctx, commit) // will be orphaned if the tryCommitChunks() below fails
+		commitRef, err := writeHeadValue(ctx, db, commit) // will be orphaned if the tryCommitChunks() below fails
 
 		if err != nil {
 			return err
@@ -684,7 +687,12 @@ func (db *database) doDelete(ctx context.Context, datasetIDstr string) error {
 
 // GC traverses the database starting at the Root and removes all unreferenced data from persistent storage.
 func (db *database) GC(ctx context.Context) error {
-	return db.ValueStore.GC(ctx)
+	missing, err := shallowParents(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	return db.ValueStore.GCIgnoringMissing(ctx, missing)
 }
 
 func (db *database) tryCommitChunks(ctx context.Context, currentDatasets types.Map, currentRootHash hash.Hash) error {
@@ -0,0 +1,252 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// fsckBatchSize is the number of chunks read from the chunk store at a time when checking a database.
+const fsckBatchSize = 4 * 1024
+
+// ErrChunkMissing is the problem with a chunk that's referenced, but isn't in the chunk store.
+var ErrChunkMissing = errors.New("chunk is missing")
+
+// FsckProblem is a chunk reachable from the root of a database which is missing or corrupt.
+type FsckProblem struct {
+	// Hash is the address of the chunk.
+	Hash hash.Hash
+	// Err describes the problem with the chunk.
+	Err error
+	// Datasets are the IDs of the datasets whose heads reach the chunk. It's empty if the chunk is part of the map of
+	// datasets at the root of the database, which every dataset is affected by.
+	Datasets []string
+}
+
+// FsckResults are the results of checking a database with Fsck.
+type FsckResults struct {
+	// Root is the hash of the root of the database which was checked.
+	Root hash.Hash
+	// ChunksChecked is the number of chunks reachable from the root.
+	ChunksChecked int
+	// Problems are the chunks which are missing or corrupt, ordered by address.
+	Problems []FsckProblem
+}
+
+// Fsck checks that every chunk reachable from the root of |db|, which includes the heads of all of its datasets, is
+// in its chunk store, and that the contents of each hash to its address. For each missing or corrupt chunk, it finds
+// the datasets whose heads reach it. The parents of the shallow commits of |db| aren't expected to be in it, so they
+// aren't problems.
+func Fsck(ctx context.Context, db Database) (FsckResults, error) {
+	cs := db.chunkStore()
+	root, err := cs.Root(ctx)
+	if err != nil {
+		return FsckResults{}, err
+	}
+
+	results := FsckResults{Root: root}
+	if root.IsEmpty() {
+		return results, nil
+	}
+
+	// the parents of shallow commits aren't expected to be in the database
+	shallowParents, err := shallowParents(ctx, db)
+	if err != nil {
+		return FsckResults{}, err
+	}
+
+	problems := make(map[hash.Hash]*FsckProblem)
+	datasetsMapOK := true
+	mapChunks := hash.NewHashSet(root)
+
+	err = walkChunks(ctx, cs, db.Format(), hash.NewHashSet(root), hash.NewHashSet(), func(h hash.Hash, problem error, refs []types.Ref) error {
+		if problem == ErrChunkMissing && shallowParents.Has(h) {
+			return nil
+		}
+
+		results.ChunksChecked++
+
+		if problem != nil {
+			problems[h] = &FsckProblem{Hash: h, Err: problem}
+			datasetsMapOK = datasetsMapOK && !mapChunks.Has(h)
+			return nil
+		}
+
+		if mapChunks.Has(h) {
+			// the map of datasets is made of the root chunk and the chunks of maps it references
+			for _, r := range refs {
+				t, err := r.TargetType()
+				if err != nil {
+					return err
+				}
+
+				if t.TargetKind() == types.MapKind {
+					mapChunks.Insert(r.TargetHash())
+				}
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return FsckResults{}, err
+	}
+
+	if len(problems) > 0 && datasetsMapOK {
+		err = findAffectedDatasets(ctx, db, problems)
+		if err != nil {
+			return FsckResults{}, err
+		}
+	}
+
+	for _, p := range problems {
+		results.Problems = append(results.Problems, *p)
+	}
+
+	sort.Slice(results.Problems, func(i, j int) bool {
+		return results.Problems[i].Hash.Less(results.Problems[j].Hash)
+	})
+
+	return results, nil
+}
+
+// findAffectedDatasets adds the IDs of the datasets of |db| whose heads reach each chunk of |problems| to the
+// problem. The map of datasets must be intact.
+func findAffectedDatasets(ctx context.Context, db Database, problems map[hash.Hash]*FsckProblem) error {
+	datasets, err := db.Datasets(ctx)
+	if err != nil {
+		return err
+	}
+
+	return datasets.IterAll(ctx, func(k, v types.Value) error {
+		id := string(k.(types.String))
+		head := v.(types.Ref).TargetHash()
+
+		return walkChunks(ctx, db.chunkStore(), db.Format(), hash.NewHashSet(head), hash.NewHashSet(), func(h hash.Hash, _ error, _ []types.Ref) error {
+			if p, ok := problems[h]; ok {
+				p.Datasets = append(p.Datasets, id)
+			}
+			return nil
+		})
+	})
+}
+
+// walkChunks visits each chunk reachable from |start| that isn't in |visited|, adding it to |visited|. |visit| is
+// called with the address of each chunk and the refs it contains, or the problem that kept the chunk from being read.
+// The refs of a chunk with a problem aren't followed.
+func walkChunks(ctx context.Context, cs chunks.ChunkStore, nbf *types.NomsBinFormat, start, visited hash.HashSet, visit func(h hash.Hash, problem error, refs []types.Ref) error) error {
+	next := start
+	for len(next) > 0 {
+		var batch []hash.Hash
+		for h := range next {
+			if !visited.Has(h) {
+				visited.Insert(h)
+				batch = append(batch, h)
+			}
+		}
+
+		next = hash.NewHashSet()
+		for len(batch) > 0 {
+			n := fsckBatchSize
+			if n > len(batch) {
+				n = len(batch)
+			}
+
+			found, readErrs, err := fsckGetChunks(ctx, cs, batch[:n])
+			if err != nil {
+				return err
+			}
+
+			for _, h := range batch[:n] {
+				var refs []types.Ref
+				problem := readErrs[h]
+
+				if c, ok := found[h]; problem == nil && !ok {
+					problem = ErrChunkMissing
+				} else if problem == nil && hash.Of(c.Data()) != h {
+					problem = fmt.Errorf("chunk data hashes to %s", hash.Of(c.Data()).String())
+				} else if problem == nil {
+					err = types.WalkRefs(c, nbf, func(r types.Ref) error {
+						refs = append(refs, r)
+						return nil
+					})
+
+					if err != nil {
+						problem = fmt.Errorf("chunk can't be decoded: %w", err)
+						refs = nil
+					}
+				}
+
+				for _, r := range refs {
+					if !visited.Has(r.TargetHash()) {
+						next.Insert(r.TargetHash())
+					}
+				}
+
+				err = visit(h, problem, refs)
+				if err != nil {
+					return err
+				}
+			}
+
+			batch = batch[n:]
+		}
+	}
+
+	return nil
+}
+
+// fsckGetChunks reads the chunks with addresses |hashes| from |cs|. A chunk which can't be read because its data is
+// invalid is returned in the map of errors rather than failing the read of the others.
+func fsckGetChunks(ctx context.Context, cs chunks.ChunkStore, hashes []hash.Hash) (map[hash.Hash]chunks.Chunk, map[hash.Hash]error, error) {
+	var mu sync.Mutex
+	found := make(map[hash.Hash]chunks.Chunk, len(hashes))
+	err := cs.GetMany(ctx, hash.NewHashSet(hashes...), func(c *chunks.Chunk) {
+		mu.Lock()
+		defer mu.Unlock()
+		found[c.Hash()] = *c
+	})
+
+	if err == nil {
+		return found, nil, nil
+	}
+
+	// read the chunks one at a time to find the ones that couldn't be read
+	found = make(map[hash.Hash]chunks.Chunk, len(hashes))
+	readErrs := make(map[hash.Hash]error)
+	for _, h := range hashes {
+		c, err := cs.Get(ctx, h)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, nil, ctx.Err()
+			}
+
+			readErrs[h] = fmt.Errorf("chunk can't be read: %w", err)
+		} else if !c.IsEmpty() {
+			found[h] = c
+		}
+	}
+
+	return found, readErrs, nil
+}
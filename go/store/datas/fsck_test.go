@@ -0,0 +1,89 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datas
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// missingChunkStore is a ChunkStore which doesn't have the chunks in |missing|.
+type missingChunkStore struct {
+	chunks.ChunkStore
+	missing hash.HashSet
+}
+
+func (cs missingChunkStore) Get(ctx context.Context, h hash.Hash) (chunks.Chunk, error) {
+	if cs.missing.Has(h) {
+		return chunks.EmptyChunk, nil
+	}
+	return cs.ChunkStore.Get(ctx, h)
+}
+
+func (cs missingChunkStore) GetMany(ctx context.Context, hashes hash.HashSet, found func(*chunks.Chunk)) error {
+	return cs.ChunkStore.GetMany(ctx, hashes, func(c *chunks.Chunk) {
+		if !cs.missing.Has(c.Hash()) {
+			found(c)
+		}
+	})
+}
+
+func TestFsck(t *testing.T) {
+	ctx := context.Background()
+	stg := &chunks.MemoryStorage{}
+	db := NewDatabase(stg.NewView())
+	defer db.Close()
+
+	commitRef := func(id string, v types.Value) hash.Hash {
+		ref, err := db.WriteValue(ctx, v)
+		require.NoError(t, err)
+		ds, err := db.GetDataset(ctx, id)
+		require.NoError(t, err)
+		_, err = db.CommitValue(ctx, ds, ref)
+		require.NoError(t, err)
+		return ref.TargetHash()
+	}
+
+	a := commitRef("ds1", types.String("a"))
+	commitRef("ds2", types.String("b"))
+
+	results, err := Fsck(ctx, db)
+	require.NoError(t, err)
+	assert.False(t, results.Root.IsEmpty())
+	assert.Empty(t, results.Problems)
+	assert.Greater(t, results.ChunksChecked, 3)
+
+	missingA := NewDatabase(missingChunkStore{stg.NewView(), hash.NewHashSet(a)})
+	results, err = Fsck(ctx, missingA)
+	require.NoError(t, err)
+	require.Len(t, results.Problems, 1)
+	assert.Equal(t, a, results.Problems[0].Hash)
+	assert.Equal(t, ErrChunkMissing, results.Problems[0].Err)
+	assert.Equal(t, []string{"ds1"}, results.Problems[0].Datasets)
+
+	missingRoot := NewDatabase(missingChunkStore{stg.NewView(), hash.NewHashSet(results.Root)})
+	results, err = Fsck(ctx, missingRoot)
+	require.NoError(t, err)
+	require.Len(t, results.Problems, 1)
+	assert.Equal(t, results.Root, results.Problems[0].Hash)
+	assert.Empty(t, results.Problems[0].Datasets)
+}
@@ -73,8 +73,10 @@ type Puller struct {
 	sinkDBCS      chunks.ChunkStore
 	rootChunkHash hash.Hash
 	downloaded    hash.HashSet
+	skipped       hash.HashSet
 
 	wr            *nbs.CmpChunkTableWriter
+	codec         nbs.ChunkCodec
 	tablefileSema *semaphore.Weighted
 	tempDir       string
 	chunksPerTF   int
@@ -163,7 +165,13 @@ func NewPuller(ctx context.Context, tempDir string, chunksPerTF int, srcDB, sink
 		return nil, ErrIncompatibleSourceChunkStore
 	}
 
-	wr, err := nbs.NewCmpChunkTableWriter(tempDir)
+	// table files are written with the default codec if the sink supports it
+	codec := nbs.SnappyCodec
+	if tfs, ok := sinkDBCS.(nbs.TableFileStore); ok {
+		codec = nbs.ChunkCodecIn(nbs.DefaultChunkCodec, tfs.SupportedOperations().ChunkCodecs)
+	}
+
+	wr, err := nbs.NewCmpChunkTableWriter(tempDir, codec)
 
 	if err != nil {
 		return nil, err
@@ -186,9 +194,11 @@ func NewPuller(ctx context.Context, tempDir string, chunksPerTF int, srcDB, sink
 		sinkDBCS:      sinkDBCS,
 		rootChunkHash: rootChunkHash,
 		downloaded:    hash.HashSet{},
+		skipped:       hash.HashSet{},
 		tablefileSema: semaphore.NewWeighted(outstandingTableFiles),
 		tempDir:       tempDir,
 		wr:            wr,
+		codec:         codec,
 		chunksPerTF:   chunksPerTF,
 		eventCh:       eventCh,
 		pushLog:       pushLogger,
@@ -304,6 +314,14 @@ func (p *Puller) processCompletedTables(ctx context.Context, ae *atomicerr.Atomi
 	ae.SetIfError(err)
 }
 
+// SkipChunks keeps the chunks of |hashes| from being pulled, along with the chunks only reachable through them. The
+// sink won't have them after the pull.
+func (p *Puller) SkipChunks(hashes hash.HashSet) {
+	for h := range hashes {
+		p.skipped.Insert(h)
+	}
+}
+
 // Pull executes the sync operation
 func (p *Puller) Pull(ctx context.Context) error {
 	twDetails := &TreeWalkEventDetails{TreeLevel: -1}
@@ -325,6 +343,7 @@ func (p *Puller) Pull(ctx context.Context) error {
 	p.tablefileSema.Acquire(ctx, 1)
 	for len(absent) > 0 {
 		limitToNewChunks(absent, p.downloaded)
+		limitToNewChunks(absent, p.skipped)
 
 		chunksInLevel := len(absent)
 		twDetails.ChunksInLevel = chunksInLevel
@@ -456,7 +475,7 @@ func (p *Puller) getCmp(ctx context.Context, twDetails *TreeWalkEventDetails, le
 			p.wr = nil
 
 			p.tablefileSema.Acquire(ctx, 1)
-			p.wr, err = nbs.NewCmpChunkTableWriter(p.tempDir)
+			p.wr, err = nbs.NewCmpChunkTableWriter(p.tempDir, p.codec)
 
 			if ae.SetIfError(err) {
 				continue
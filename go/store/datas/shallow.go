@@ -0,0 +1,287 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// ErrShallowPullUnsupported is returned by PullShallow when either database can't be pulled with a Puller.
+var ErrShallowPullUnsupported = errors.New("shallow pulls are only supported between table file stores")
+
+// SetShallowCommits sets the shallow commits of |db|. A shallow commit is treated as though it has no parents, because
+// its parents aren't in the database. They're the commits at the boundary of the history pulled by a shallow clone or
+// fetch.
+func SetShallowCommits(db Database, commits hash.HashSet) {
+	d, ok := db.(*database)
+	if !ok {
+		return
+	}
+
+	shallow := make(hash.HashSet, len(commits))
+	for h := range commits {
+		shallow.Insert(h)
+	}
+
+	d.shallowMu.Lock()
+	defer d.shallowMu.Unlock()
+	d.shallow = shallow
+}
+
+// ShallowCommits returns the shallow commits of |vr|, which are empty unless it's a Database with shallow commits.
+func ShallowCommits(vr types.ValueReader) hash.HashSet {
+	shallow := hash.NewHashSet()
+	d, ok := vr.(*database)
+	if !ok {
+		return shallow
+	}
+
+	d.shallowMu.RLock()
+	defer d.shallowMu.RUnlock()
+	for h := range d.shallow {
+		shallow.Insert(h)
+	}
+
+	return shallow
+}
+
+// IsShallowCommit returns whether the commit |h| is a shallow commit of |vr|.
+func IsShallowCommit(vr types.ValueReader, h hash.Hash) bool {
+	d, ok := vr.(*database)
+	if !ok {
+		return false
+	}
+
+	d.shallowMu.RLock()
+	defer d.shallowMu.RUnlock()
+	return d.shallow.Has(h)
+}
+
+// HasShallowCommits returns whether |vr| is a Database with any shallow commits.
+func HasShallowCommits(vr types.ValueReader) bool {
+	d, ok := vr.(*database)
+	if !ok {
+		return false
+	}
+
+	d.shallowMu.RLock()
+	defer d.shallowMu.RUnlock()
+	return len(d.shallow) > 0
+}
+
+// shallowParents returns the parents of the shallow commits of |db|, which aren't expected to be in it.
+func shallowParents(ctx context.Context, db Database) (hash.HashSet, error) {
+	parents := hash.NewHashSet()
+	for h := range ShallowCommits(db) {
+		v, err := db.ReadValue(ctx, h)
+		if err != nil {
+			return nil, err
+		}
+
+		c, ok := v.(types.Struct)
+		if !ok {
+			return nil, fmt.Errorf("shallow commit %s is not in the database", h.String())
+		}
+
+		refs, err := commitParents(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range refs {
+			parents.Insert(r.TargetHash())
+		}
+	}
+
+	return parents, nil
+}
+
+// ShallowHistory is the part of the history of a database within some depth of a set of commits.
+type ShallowHistory struct {
+	// Commits are the commits within the depth.
+	Commits hash.HashSet
+	// Boundary are the commits within the depth with a parent that isn't.
+	Boundary hash.HashSet
+	// Excluded are the parents of the boundary commits that aren't within the depth.
+	Excluded hash.HashSet
+}
+
+// FindShallowHistory returns the commits of |vr| within |depth| commits of |heads|, which are at depth 1. A shallow
+// commit of |vr| is on the boundary of the history, since its parents can't be walked.
+func FindShallowHistory(ctx context.Context, vr types.ValueReader, heads []hash.Hash, depth int) (ShallowHistory, error) {
+	if depth < 1 {
+		return ShallowHistory{}, fmt.Errorf("invalid depth %d", depth)
+	}
+
+	history := ShallowHistory{Commits: hash.NewHashSet(), Boundary: hash.NewHashSet(), Excluded: hash.NewHashSet()}
+	parents := make(map[hash.Hash][]hash.Hash)
+
+	level := hash.NewHashSet(heads...)
+	for d := 1; d <= depth && len(level) > 0; d++ {
+		next := hash.NewHashSet()
+		for h := range level {
+			history.Commits.Insert(h)
+
+			v, err := vr.ReadValue(ctx, h)
+			if err != nil {
+				return ShallowHistory{}, err
+			}
+
+			c, ok := v.(types.Struct)
+			if !ok {
+				return ShallowHistory{}, fmt.Errorf("commit %s not found", h.String())
+			}
+
+			refs, err := commitParents(ctx, c)
+			if err != nil {
+				return ShallowHistory{}, err
+			}
+
+			for _, r := range refs {
+				parents[h] = append(parents[h], r.TargetHash())
+			}
+
+			if IsShallowCommit(vr, h) {
+				continue
+			}
+
+			for _, r := range refs {
+				if !history.Commits.Has(r.TargetHash()) {
+					next.Insert(r.TargetHash())
+				}
+			}
+		}
+
+		level = next
+	}
+
+	for h := range history.Commits {
+		for _, p := range parents[h] {
+			if !history.Commits.Has(p) {
+				history.Boundary.Insert(h)
+				history.Excluded.Insert(p)
+			}
+		}
+	}
+
+	return history, nil
+}
+
+// PullShallow pulls the commits of |srcDB| within |depth| commits of |heads| into |sinkDB|, along with all the data
+// they reference, without the rest of their history. The shallow commits of |sinkDB| are updated to the pulled
+// commits, and the commits it already had, whose parents it doesn't have.
+//
+// Pulling into a database which already has shallow commits deepens its history, if the new history reaches past them.
+func PullShallow(ctx context.Context, tempDir string, chunksPerTF int, srcDB, sinkDB Database, heads []hash.Hash, depth int, eventCh chan PullerEvent) error {
+	if !CanUsePuller(srcDB) || !CanUsePuller(sinkDB) {
+		return ErrShallowPullUnsupported
+	}
+
+	history, err := FindShallowHistory(ctx, srcDB, heads, depth)
+	if err != nil {
+		return err
+	}
+
+	// the history within the depth is reachable from the heads, and from the parents of the shallow commits of the
+	// sink, which the puller won't walk through because the sink already has them
+	sinkParents, err := shallowParents(ctx, sinkDB)
+	if err != nil {
+		return err
+	}
+
+	roots := hash.NewHashSet(heads...)
+	for h := range sinkParents {
+		if history.Commits.Has(h) {
+			roots.Insert(h)
+		}
+	}
+
+	for h := range roots {
+		puller, err := NewPuller(ctx, tempDir, chunksPerTF, srcDB, sinkDB, h, eventCh)
+		if err == ErrDBUpToDate {
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		puller.SkipChunks(history.Excluded)
+
+		err = puller.Pull(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	// a commit is shallow if the sink doesn't have all of its parents
+	candidates := ShallowCommits(sinkDB)
+	for h := range history.Boundary {
+		candidates.Insert(h)
+	}
+
+	shallow := hash.NewHashSet()
+	for h := range candidates {
+		v, err := sinkDB.ReadValue(ctx, h)
+		if err != nil {
+			return err
+		}
+
+		c, ok := v.(types.Struct)
+		if !ok {
+			continue
+		}
+
+		refs, err := commitParents(ctx, c)
+		if err != nil {
+			return err
+		}
+
+		parents := hash.NewHashSet()
+		for _, r := range refs {
+			parents.Insert(r.TargetHash())
+		}
+
+		absent, err := sinkDB.chunkStore().HasMany(ctx, parents)
+		if err != nil {
+			return err
+		}
+
+		if len(absent) > 0 {
+			shallow.Insert(h)
+		}
+	}
+
+	SetShallowCommits(sinkDB, shallow)
+	return nil
+}
+
+// writeHeadValue writes |v|, the new value of a dataset head, to |db| and returns a ref to it. A shallow commit isn't
+// written again, since it's already in the database and writing it would require its missing parents to be present.
+func writeHeadValue(ctx context.Context, db *database, v types.Value) (types.Ref, error) {
+	h, err := v.Hash(db.Format())
+	if err != nil {
+		return types.Ref{}, err
+	}
+
+	if IsShallowCommit(db, h) {
+		return types.NewRef(v, db.Format())
+	}
+
+	return db.WriteValue(ctx, v)
+}
@@ -0,0 +1,148 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datas
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// linearHistory commits |n| values to a dataset of |db| and returns the refs of the commits, oldest first.
+func linearHistory(t *testing.T, ctx context.Context, db Database, n int) []types.Ref {
+	ds, err := db.GetDataset(ctx, "ds")
+	require.NoError(t, err)
+
+	var refs []types.Ref
+	for i := 0; i < n; i++ {
+		ds, err = db.CommitValue(ctx, ds, types.String(string(rune('a'+i))))
+		require.NoError(t, err)
+
+		r, ok, err := ds.MaybeHeadRef()
+		require.NoError(t, err)
+		require.True(t, ok)
+		refs = append(refs, r)
+	}
+
+	return refs
+}
+
+func pullShallow(t *testing.T, ctx context.Context, srcDB, sinkDB Database, head types.Ref, depth int) {
+	tmpDir, err := ioutil.TempDir("", "shallow")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	eventCh := make(chan PullerEvent, 128)
+	go func() {
+		for range eventCh {
+		}
+	}()
+	defer close(eventCh)
+
+	err = PullShallow(ctx, tmpDir, 128, srcDB, sinkDB, []hash.Hash{head.TargetHash()}, depth, eventCh)
+	require.NoError(t, err)
+
+	ds, err := sinkDB.GetDataset(ctx, "ds")
+	require.NoError(t, err)
+	_, err = sinkDB.SetHead(ctx, ds, head)
+	require.NoError(t, err)
+}
+
+func TestFindShallowHistory(t *testing.T) {
+	ctx := context.Background()
+	db, err := tempDirDB(ctx)
+	require.NoError(t, err)
+	refs := linearHistory(t, ctx, db, 5)
+
+	history, err := FindShallowHistory(ctx, db, []hash.Hash{refs[4].TargetHash()}, 2)
+	require.NoError(t, err)
+	assert.Equal(t, hash.NewHashSet(refs[4].TargetHash(), refs[3].TargetHash()), history.Commits)
+	assert.Equal(t, hash.NewHashSet(refs[3].TargetHash()), history.Boundary)
+	assert.Equal(t, hash.NewHashSet(refs[2].TargetHash()), history.Excluded)
+
+	history, err = FindShallowHistory(ctx, db, []hash.Hash{refs[4].TargetHash()}, 10)
+	require.NoError(t, err)
+	assert.Len(t, history.Commits, 5)
+	assert.Empty(t, history.Boundary)
+	assert.Empty(t, history.Excluded)
+
+	_, err = FindShallowHistory(ctx, db, []hash.Hash{refs[4].TargetHash()}, 0)
+	assert.Error(t, err)
+}
+
+func TestPullShallow(t *testing.T) {
+	ctx := context.Background()
+	srcDB, err := tempDirDB(ctx)
+	require.NoError(t, err)
+	refs := linearHistory(t, ctx, srcDB, 5)
+
+	sinkDB, err := tempDirDB(ctx)
+	require.NoError(t, err)
+
+	// a head which is itself shallow can be set
+	pullShallow(t, ctx, srcDB, sinkDB, refs[4], 1)
+	assert.Equal(t, hash.NewHashSet(refs[4].TargetHash()), ShallowCommits(sinkDB))
+
+	// deepening the history moves the boundary
+	pullShallow(t, ctx, srcDB, sinkDB, refs[4], 3)
+	assert.Equal(t, hash.NewHashSet(refs[2].TargetHash()), ShallowCommits(sinkDB))
+	assert.True(t, IsShallowCommit(sinkDB, refs[2].TargetHash()))
+
+	absent, err := sinkDB.chunkStore().HasMany(ctx, hash.NewHashSet(refs[0].TargetHash(), refs[1].TargetHash(), refs[2].TargetHash()))
+	require.NoError(t, err)
+	assert.Equal(t, hash.NewHashSet(refs[0].TargetHash(), refs[1].TargetHash()), absent)
+
+	// history stops at the shallow commit
+	ds, err := sinkDB.GetDataset(ctx, "ds")
+	require.NoError(t, err)
+	ds, err = sinkDB.CommitValue(ctx, ds, types.String("f"))
+	require.NoError(t, err)
+	head, ok, err := ds.MaybeHeadRef()
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	n := 0
+	for h := head.TargetHash(); ; n++ {
+		if IsShallowCommit(sinkDB, h) {
+			n++
+			break
+		}
+
+		v, err := sinkDB.ReadValue(ctx, h)
+		require.NoError(t, err)
+		parents, err := commitParents(ctx, v.(types.Struct))
+		require.NoError(t, err)
+		require.Len(t, parents, 1)
+		h = parents[0].TargetHash()
+	}
+	assert.Equal(t, 4, n)
+
+	// the missing parents of the shallow commit don't break gc or fsck
+	require.NoError(t, sinkDB.(GarbageCollector).GC(ctx))
+	results, err := Fsck(ctx, sinkDB)
+	require.NoError(t, err)
+	assert.Empty(t, results.Problems)
+
+	// pulling the whole history leaves no shallow commits
+	pullShallow(t, ctx, srcDB, sinkDB, refs[4], 10)
+	assert.False(t, HasShallowCommits(sinkDB))
+}
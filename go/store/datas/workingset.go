@@ -33,6 +33,8 @@ const (
 	MergeStateName                 = "MergeState"
 	MergeStateCommitField          = "commit"
 	MergeStateWorkingPreMergeField = "workingPreMerge"
+	MergeStateOtherCommitsField    = "otherCommits"
+	MergeStateSchemaConflictsField = "schemaConflicts"
 )
 
 const (
@@ -98,6 +100,42 @@ func NewMergeState(_ context.Context, preMergeWorking types.Ref, commit types.St
 	return mergeStateTemplate.NewStruct(preMergeWorking.Format(), []types.Value{commit, preMergeWorking})
 }
 
+// NewOctopusMergeState creates a merge state for a merge of more than one commit. |commit| is the first commit merged
+// and |otherCommits| are the rest, in the order they were merged.
+func NewOctopusMergeState(ctx context.Context, vrw types.ValueReadWriter, preMergeWorking types.Ref, commit types.Struct, otherCommits []types.Struct) (types.Struct, error) {
+	vals := make([]types.Value, len(otherCommits))
+	for i, cm := range otherCommits {
+		vals[i] = cm
+	}
+
+	l, err := types.NewList(ctx, vrw, vals...)
+	if err != nil {
+		return types.EmptyStruct(vrw.Format()), err
+	}
+
+	fields := make(types.StructData)
+	fields[MergeStateCommitField] = commit
+	fields[MergeStateWorkingPreMergeField] = preMergeWorking
+	fields[MergeStateOtherCommitsField] = l
+
+	return types.NewStruct(vrw.Format(), MergeStateName, fields)
+}
+
+// WithMergeStateSchemaConflicts returns |mergeState| recording |tables| as the tables whose schemas could not be merged.
+func WithMergeStateSchemaConflicts(ctx context.Context, vrw types.ValueReadWriter, mergeState types.Struct, tables []string) (types.Struct, error) {
+	vals := make([]types.Value, len(tables))
+	for i, tblName := range tables {
+		vals[i] = types.String(tblName)
+	}
+
+	l, err := types.NewList(ctx, vrw, vals...)
+	if err != nil {
+		return types.EmptyStruct(vrw.Format()), err
+	}
+
+	return mergeState.Set(MergeStateSchemaConflictsField, l)
+}
+
 func NewWorkingSetMeta(format *types.NomsBinFormat, name, email string, timestamp uint64, description string) (types.Struct, error) {
 	fields := make(types.StructData)
 	fields[WorkingSetMetaNameField] = types.String(name)
@@ -43,7 +43,7 @@ var parseIndexF = func(bs []byte) (tableIndex, error) {
 
 func TestAWSTablePersisterPersist(t *testing.T) {
 	calcPartSize := func(rdr chunkReader, maxPartNum uint64) uint64 {
-		return maxTableSize(uint64(mustUint32(rdr.count())), mustUint64(rdr.uncompressedLen())) / maxPartNum
+		return maxTableSize(uint64(mustUint32(rdr.count())), mustUint64(rdr.uncompressedLen()), SnappyCodec) / maxPartNum
 	}
 
 	mt := newMemTable(testMemTableSize)
@@ -537,9 +537,9 @@ func bytesToChunkSource(t *testing.T, bs ...[]byte) chunkSource {
 	for _, b := range bs {
 		sum += len(b)
 	}
-	maxSize := maxTableSize(uint64(len(bs)), uint64(sum))
+	maxSize := maxTableSize(uint64(len(bs)), uint64(sum), SnappyCodec)
 	buff := make([]byte, maxSize)
-	tw := newTableWriter(buff, nil)
+	tw := newTableWriter(buff, SnappyCodec, nil)
 	for _, b := range bs {
 		tw.addChunk(computeAddr(b), b)
 	}
@@ -22,8 +22,6 @@ import (
 	"io"
 	"sort"
 
-	"github.com/golang/snappy"
-
 	nomshash "github.com/dolthub/dolt/go/store/hash"
 )
 
@@ -46,17 +44,24 @@ type CmpChunkTableWriter struct {
 	prefixes              prefixIndexSlice // TODO: This is in danger of exploding memory
 	blockAddr             *addr
 	chunkHashes           nomshash.HashSet
+	codec                 ChunkCodec
 }
 
-// NewCmpChunkTableWriter creates a new CmpChunkTableWriter instance with a default ByteSink
-func NewCmpChunkTableWriter(tempDir string) (*CmpChunkTableWriter, error) {
+// NewCmpChunkTableWriter creates a new CmpChunkTableWriter instance with a default ByteSink, which writes a table file
+// compressed with |codec|
+func NewCmpChunkTableWriter(tempDir string, codec ChunkCodec) (*CmpChunkTableWriter, error) {
 	s, err := NewBufferedFileByteSink(tempDir, defaultTableSinkBlockSize, defaultChBufferSize)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return &CmpChunkTableWriter{NewHashingByteSink(s), 0, 0, nil, nil, nomshash.NewHashSet()}, nil
+	return &CmpChunkTableWriter{NewHashingByteSink(s), 0, 0, nil, nil, nomshash.NewHashSet(), codec}, nil
+}
+
+// Codec returns the codec that the chunks of the table file are compressed with
+func (tw *CmpChunkTableWriter) Codec() ChunkCodec {
+	return tw.codec
 }
 
 // Size returns the number of compressed chunks that have been added
@@ -78,7 +83,7 @@ func (tw *CmpChunkTableWriter) GetMD5() []byte {
 	return tw.sink.GetMD5()
 }
 
-// AddCmpChunk adds a compressed chunk
+// AddCmpChunk adds a compressed chunk. A chunk compressed with a different codec than the table file is recompressed.
 func (tw *CmpChunkTableWriter) AddCmpChunk(c CompressedChunk) error {
 	if len(c.CompressedData) == 0 {
 		panic("NBS blocks cannot be zero length")
@@ -89,7 +94,18 @@ func (tw *CmpChunkTableWriter) AddCmpChunk(c CompressedChunk) error {
 	}
 
 	tw.chunkHashes.Insert(c.H)
-	uncmpLen, err := snappy.DecodedLen(c.CompressedData)
+	return tw.addCmpChunk(c)
+}
+
+// addCmpChunk adds a compressed chunk, even if a chunk with the same hash has already been added.
+func (tw *CmpChunkTableWriter) addCmpChunk(c CompressedChunk) error {
+	c, err := c.Recompress(tw.codec)
+
+	if err != nil {
+		return err
+	}
+
+	uncmpLen, err := tw.codec.decodedLen(c.CompressedData)
 
 	if err != nil {
 		return err
@@ -235,8 +251,8 @@ func (tw *CmpChunkTableWriter) writeFooter() error {
 		return err
 	}
 
-	// magic number
-	_, err = tw.sink.Write([]byte(magicNumber))
+	// magic number, which identifies the codec
+	_, err = tw.sink.Write([]byte(tw.codec.magicNumber()))
 
 	if err != nil {
 		return err
@@ -53,7 +53,7 @@ func TestCmpChunkTableWriter(t *testing.T) {
 	require.NoError(t, eg.Wait())
 
 	// for all the chunks we find, write them using the compressed writer
-	tw, err := NewCmpChunkTableWriter("")
+	tw, err := NewCmpChunkTableWriter("", SnappyCodec)
 	require.NoError(t, err)
 	for _, cmpChnk := range found {
 		err = tw.AddCmpChunk(cmpChnk)
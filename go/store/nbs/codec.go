@@ -0,0 +1,246 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// ChunkCodec is the compression used for the chunk records of a table file. Every chunk of a table file is compressed
+// with the same codec, which is recorded in the magic number of its footer.
+type ChunkCodec uint8
+
+const (
+	// SnappyCodec is the codec of every table file written before codecs were recorded, and the zero value.
+	SnappyCodec ChunkCodec = iota
+	// ZstdCodec compresses each chunk as a single zstd frame, without a dictionary or a frame checksum.
+	ZstdCodec
+)
+
+// zstdMagicNumber is the magic number of table files written with ZstdCodec, the first 8 bytes of the SHA256 hash of
+// "https://github.com/dolthub/dolt/nbs/zstd". Table files written with SnappyCodec end in magicNumber.
+const zstdMagicNumber = "\x39\xeb\x4e\x6e\x41\x1f\xd8\x24"
+
+// ChunkCodecs are all the codecs that table files can be written with.
+var ChunkCodecs = []ChunkCodec{SnappyCodec, ZstdCodec}
+
+// DefaultChunkCodec is the codec used for new table files. It can be set with the DOLT_DEFAULT_CHUNK_CODEC environment
+// variable.
+var DefaultChunkCodec = SnappyCodec
+
+var zstdEncoder, zstdDecoder = newZstdCoders()
+
+func init() {
+	DefaultChunkCodec = defaultChunkCodecFromEnv(os.Getenv("DOLT_DEFAULT_CHUNK_CODEC"))
+}
+
+// defaultChunkCodecFromEnv returns the codec named by the DOLT_DEFAULT_CHUNK_CODEC environment variable |codecStr|, or
+// SnappyCodec if it's empty. An unknown codec is warned about and ignored, rather than keeping every command from
+// running.
+func defaultChunkCodecFromEnv(codecStr string) ChunkCodec {
+	if codecStr == "" {
+		return SnappyCodec
+	}
+
+	codec, err := ParseChunkCodec(codecStr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: ignoring DOLT_DEFAULT_CHUNK_CODEC: %s, using %s\n", err.Error(), SnappyCodec.String())
+		return SnappyCodec
+	}
+
+	return codec
+}
+
+func newZstdCoders() (*zstd.Encoder, *zstd.Decoder) {
+	enc, err := zstd.NewWriter(nil, zstd.WithEncoderCRC(false))
+	if err != nil {
+		panic(err)
+	}
+
+	dec, err := zstd.NewReader(nil)
+	if err != nil {
+		panic(err)
+	}
+
+	return enc, dec
+}
+
+// ParseChunkCodec returns the codec named |str|.
+func ParseChunkCodec(str string) (ChunkCodec, error) {
+	for _, codec := range ChunkCodecs {
+		if strings.EqualFold(str, codec.String()) {
+			return codec, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown chunk codec '%s'", str)
+}
+
+func (c ChunkCodec) String() string {
+	switch c {
+	case SnappyCodec:
+		return "snappy"
+	case ZstdCodec:
+		return "zstd"
+	default:
+		return fmt.Sprintf("ChunkCodec(%d)", uint8(c))
+	}
+}
+
+// ChunkCodecIn returns |codec| if it's one of |codecs|, and SnappyCodec, which every store can read, otherwise.
+func ChunkCodecIn(codec ChunkCodec, codecs []ChunkCodec) ChunkCodec {
+	for _, c := range codecs {
+		if c == codec {
+			return codec
+		}
+	}
+
+	return SnappyCodec
+}
+
+func (c ChunkCodec) magicNumber() string {
+	switch c {
+	case SnappyCodec:
+		return magicNumber
+	case ZstdCodec:
+		return zstdMagicNumber
+	default:
+		panic("unknown chunk codec " + c.String())
+	}
+}
+
+// codecForMagicNumber returns the codec of the table file whose footer ends in |magic|, and false if it isn't the
+// magic number of a table file.
+func codecForMagicNumber(magic string) (ChunkCodec, bool) {
+	switch magic {
+	case magicNumber:
+		return SnappyCodec, true
+	case zstdMagicNumber:
+		return ZstdCodec, true
+	default:
+		return 0, false
+	}
+}
+
+// maxEncodedLen returns the most bytes that |n| bytes can be encoded to. It's affine in |n|, so the bound for the sum
+// of many lengths can be computed from their average.
+func (c ChunkCodec) maxEncodedLen(n int) int {
+	switch c {
+	case ZstdCodec:
+		// incompressible data is stored in raw blocks of up to 128KB, each with a 3 byte header, after a frame header
+		// of up to 18 bytes
+		return n + n/128 + 64
+	default:
+		return snappy.MaxEncodedLen(n)
+	}
+}
+
+// encode compresses |src| into |dst| if it's large enough, and returns the compressed bytes.
+func (c ChunkCodec) encode(dst, src []byte) []byte {
+	switch c {
+	case ZstdCodec:
+		return zstdEncoder.EncodeAll(src, dst[:0])
+	default:
+		return snappy.Encode(dst, src)
+	}
+}
+
+func (c ChunkCodec) decode(src []byte) ([]byte, error) {
+	switch c {
+	case ZstdCodec:
+		return zstdDecoder.DecodeAll(src, nil)
+	default:
+		return snappy.Decode(nil, src)
+	}
+}
+
+// decodedLen returns the length of |src| once it's decompressed.
+func (c ChunkCodec) decodedLen(src []byte) (int, error) {
+	switch c {
+	case ZstdCodec:
+		if n, ok := zstdFrameContentSize(src); ok {
+			return int(n), nil
+		}
+
+		data, err := c.decode(src)
+		if err != nil {
+			return 0, err
+		}
+		return len(data), nil
+	default:
+		return snappy.DecodedLen(src)
+	}
+}
+
+// zstdFrameContentSize returns the content size in the header of the zstd frame |src|, and false if the header
+// doesn't have one.
+func zstdFrameContentSize(src []byte) (uint64, bool) {
+	if len(src) < 5 || binary.LittleEndian.Uint32(src) != 0xfd2fb528 {
+		return 0, false
+	}
+
+	fhd := src[4]
+	singleSegment := fhd&0x20 != 0
+	pos := 5
+	if !singleSegment {
+		// window descriptor
+		pos++
+	}
+	pos += []int{0, 1, 2, 4}[fhd&0x3]
+
+	var fcsSize int
+	switch fhd >> 6 {
+	case 0:
+		if singleSegment {
+			fcsSize = 1
+		}
+	case 1:
+		fcsSize = 2
+	case 2:
+		fcsSize = 4
+	case 3:
+		fcsSize = 8
+	}
+
+	if fcsSize == 0 || len(src) < pos+fcsSize {
+		return 0, false
+	}
+
+	switch fcsSize {
+	case 1:
+		return uint64(src[pos]), true
+	case 2:
+		return uint64(binary.LittleEndian.Uint16(src[pos:])) + 256, true
+	case 4:
+		return uint64(binary.LittleEndian.Uint32(src[pos:])), true
+	default:
+		return binary.LittleEndian.Uint64(src[pos:]), true
+	}
+}
+
+// codecEncoder is the chunkEncoder of a ChunkCodec.
+type codecEncoder struct {
+	codec ChunkCodec
+}
+
+func (e codecEncoder) Encode(dst, src []byte) []byte {
+	return e.codec.encode(dst, src)
+}
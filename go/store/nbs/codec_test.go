@@ -0,0 +1,185 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+func TestChunkCodecRoundTrip(t *testing.T) {
+	inputs := [][]byte{
+		{},
+		[]byte("hello"),
+		[]byte(strings.Repeat("compressible ", 1000)),
+	}
+
+	for _, codec := range ChunkCodecs {
+		t.Run(codec.String(), func(t *testing.T) {
+			parsed, err := ParseChunkCodec(strings.ToUpper(codec.String()))
+			require.NoError(t, err)
+			assert.Equal(t, codec, parsed)
+
+			parsed, ok := codecForMagicNumber(codec.magicNumber())
+			require.True(t, ok)
+			assert.Equal(t, codec, parsed)
+
+			for _, in := range inputs {
+				dst := make([]byte, codec.maxEncodedLen(len(in)))
+				enc := codec.encode(dst, in)
+				require.LessOrEqual(t, len(enc), len(dst))
+
+				n, err := codec.decodedLen(enc)
+				require.NoError(t, err)
+				assert.Equal(t, len(in), n)
+
+				dec, err := codec.decode(enc)
+				require.NoError(t, err)
+				assert.Equal(t, in, append([]byte{}, dec...))
+			}
+		})
+	}
+
+	_, err := ParseChunkCodec("lz4")
+	assert.Error(t, err)
+	assert.Equal(t, SnappyCodec, defaultChunkCodecFromEnv(""))
+	assert.Equal(t, ZstdCodec, defaultChunkCodecFromEnv("ZSTD"))
+	assert.Equal(t, SnappyCodec, defaultChunkCodecFromEnv("lz4"))
+	assert.Equal(t, SnappyCodec, ChunkCodecIn(ZstdCodec, []ChunkCodec{SnappyCodec}))
+	assert.Equal(t, ZstdCodec, ChunkCodecIn(ZstdCodec, ChunkCodecs))
+}
+
+func TestZstdTable(t *testing.T) {
+	ctx := context.Background()
+	chunks := [][]byte{
+		[]byte("hello2"),
+		[]byte("goodbye2"),
+		[]byte(strings.Repeat("badbye2", 100)),
+	}
+
+	buff := make([]byte, maxTableSize(uint64(len(chunks)), 6+8+700, ZstdCodec))
+	tw := newTableWriter(buff, ZstdCodec, nil)
+	for _, c := range chunks {
+		tw.addChunk(computeAddr(c), c)
+	}
+	length, _, err := tw.finish()
+	require.NoError(t, err)
+	buff = buff[:length]
+
+	ti, err := parseTableIndex(buff)
+	require.NoError(t, err)
+	assert.Equal(t, ZstdCodec, ti.Codec())
+
+	tr := newTableReader(ti, tableReaderAtFromBytes(buff), fileBlockSize)
+	for _, c := range chunks {
+		data, err := tr.get(ctx, computeAddr(c), &Stats{})
+		require.NoError(t, err)
+		assert.Equal(t, c, data)
+	}
+
+	codec, err := ReadTableFileCodec(bytes.NewReader(buff))
+	require.NoError(t, err)
+	assert.Equal(t, ZstdCodec, codec)
+}
+
+func TestCmpChunkTableWriterRecompresses(t *testing.T) {
+	ctx := context.Background()
+
+	_, buff, err := WriteChunks(testMDChunks)
+	require.NoError(t, err)
+	ti, err := parseTableIndex(buff)
+	require.NoError(t, err)
+	tr := newTableReader(ti, tableReaderAtFromBytes(buff), fileBlockSize)
+
+	tw, err := NewCmpChunkTableWriter("", ZstdCodec)
+	require.NoError(t, err)
+
+	hashes := make(hash.HashSet)
+	for _, c := range testMDChunks {
+		hashes.Insert(c.Hash())
+		require.NoError(t, tw.AddCmpChunk(ChunkToCompressedChunk(c)))
+	}
+
+	_, err = tw.Finish()
+	require.NoError(t, err)
+
+	output := bytes.NewBuffer(nil)
+	require.NoError(t, tw.Flush(output))
+
+	outputTI, err := parseTableIndex(output.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, ZstdCodec, outputTI.Codec())
+	outputTR := newTableReader(outputTI, tableReaderAtFromBytes(output.Bytes()), fileBlockSize)
+
+	compareContentsOfTables(t, ctx, hashes, tr, outputTR)
+}
+
+func TestTranscodeTableFile(t *testing.T) {
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "transcode")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	name, buff, err := WriteChunks(testMDChunks)
+	require.NoError(t, err)
+	ti, err := parseTableIndex(buff)
+	require.NoError(t, err)
+	tr := newTableReader(ti, tableReaderAtFromBytes(buff), fileBlockSize)
+
+	hashes := make(hash.HashSet)
+	for _, c := range testMDChunks {
+		hashes.Insert(c.Hash())
+	}
+
+	zstdPath := filepath.Join(dir, name+".zstd")
+	require.NoError(t, TranscodeTableFile(bytes.NewReader(buff), ZstdCodec, zstdPath))
+	zstdBuff, err := ioutil.ReadFile(zstdPath)
+	require.NoError(t, err)
+
+	snappyPath := filepath.Join(dir, name)
+	require.NoError(t, TranscodeTableFile(bytes.NewReader(zstdBuff), SnappyCodec, snappyPath))
+	snappyBuff, err := ioutil.ReadFile(snappyPath)
+	require.NoError(t, err)
+
+	// transcoding back to snappy reproduces the original table file
+	assert.Equal(t, buff, snappyBuff)
+
+	zstdTI, err := parseTableIndex(zstdBuff)
+	require.NoError(t, err)
+	assert.Equal(t, ZstdCodec, zstdTI.Codec())
+	zstdTR := newTableReader(zstdTI, tableReaderAtFromBytes(zstdBuff), fileBlockSize)
+	compareContentsOfTables(t, ctx, hashes, tr, zstdTR)
+
+	ranges, err := ChunkRanges(bytes.NewReader(zstdBuff), hashes)
+	require.NoError(t, err)
+	assert.Len(t, ranges, len(hashes))
+	for h, r := range ranges {
+		cmp, err := NewCompressedChunk(h, zstdBuff[r.Offset:r.Offset+uint64(r.Length)], ZstdCodec)
+		require.NoError(t, err)
+		c, err := cmp.ToChunk()
+		require.NoError(t, err)
+		assert.Equal(t, h, c.Hash())
+	}
+}
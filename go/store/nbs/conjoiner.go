@@ -237,6 +237,7 @@ func conjoinTables(ctx context.Context, p tablePersister, upstream []tableSpec,
 }
 
 // Current approach is to choose the smallest N tables which, when removed and replaced with the conjoinment, will leave the conjoinment as the smallest table.
+// Only tables compressed with the same codec can be conjoined, so they're chosen among the tables with the codec of the smallest table whose codec is shared by another.
 func chooseConjoinees(upstream chunkSources) (toConjoin, toKeep chunkSources, err error) {
 	sortedUpstream := make(chunkSources, len(upstream))
 	copy(sortedUpstream, upstream)
@@ -248,17 +249,68 @@ func chooseConjoinees(upstream chunkSources) (toConjoin, toKeep chunkSources, er
 		return nil, nil, csbac.err
 	}
 
+	codecs := make([]ChunkCodec, len(sortedUpstream))
+	codecCounts := make(map[ChunkCodec]int)
+	for i, src := range sortedUpstream {
+		index, err := src.index()
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		codecs[i] = index.Codec()
+		codecCounts[codecs[i]]++
+	}
+
+	var candidates chunkSources
+	var candidateIdxs []int
+	for i, src := range sortedUpstream {
+		if codecCounts[codecs[i]] < 2 || (len(candidateIdxs) > 0 && codecs[i] != codecs[candidateIdxs[0]]) {
+			continue
+		}
+
+		candidates = append(candidates, src)
+		candidateIdxs = append(candidateIdxs, i)
+	}
+
+	if len(candidates) < 2 {
+		return nil, nil, errors.New("no tables compressed with the same codec to conjoin")
+	}
+
+	partition, err := conjoineePartition(candidates)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	chosen := make(map[int]bool, partition)
+	for _, i := range candidateIdxs[:partition] {
+		chosen[i] = true
+	}
+
+	for i, src := range sortedUpstream {
+		if !chosen[i] {
+			toKeep = append(toKeep, src)
+		}
+	}
+
+	return candidates[:partition], toKeep, nil
+}
+
+// conjoineePartition returns the number N of the smallest tables of |sortedUpstream|, which is sorted by ascending
+// chunk count, that leaves the conjoinment as the smallest table.
+func conjoineePartition(sortedUpstream chunkSources) (int, error) {
 	partition := 2
 	upZero, err := sortedUpstream[0].count()
 
 	if err != nil {
-		return nil, nil, err
+		return 0, err
 	}
 
 	upOne, err := sortedUpstream[1].count()
 
 	if err != nil {
-		return nil, nil, err
+		return 0, err
 	}
 
 	sum := upZero + upOne
@@ -266,7 +318,7 @@ func chooseConjoinees(upstream chunkSources) (toConjoin, toKeep chunkSources, er
 		partCnt, err := sortedUpstream[partition].count()
 
 		if err != nil {
-			return nil, nil, err
+			return 0, err
 		}
 
 		if sum <= partCnt {
@@ -277,7 +329,7 @@ func chooseConjoinees(upstream chunkSources) (toConjoin, toKeep chunkSources, er
 		partition++
 	}
 
-	return sortedUpstream[:partition], sortedUpstream[partition:], nil
+	return partition, nil
 }
 
 func toSpecs(srcs chunkSources) ([]tableSpec, error) {
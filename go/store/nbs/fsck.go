@@ -0,0 +1,202 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// StoreProblem is a problem with the manifest or a table file of a store, found by VerifyFileStore.
+type StoreProblem struct {
+	// File is the name of the manifest or table file with the problem.
+	File string
+	// Chunk is the address of the chunk with the problem, if it's a problem with a single chunk of a table file.
+	Chunk hash.Hash
+	// Err describes the problem.
+	Err error
+}
+
+func (p StoreProblem) String() string {
+	if p.Chunk.IsEmpty() {
+		return fmt.Sprintf("%s: %v", p.File, p.Err)
+	}
+
+	return fmt.Sprintf("%s: chunk %s: %v", p.File, p.Chunk.String(), p.Err)
+}
+
+// VerifyFileStore checks the manifest of the store on disk in |dir|, and each table file it lists. Each table file must
+// exist and have a valid footer and index, with as many chunks as the manifest says it has, and each of its chunks must
+// pass its checksum and hash to its address. It returns the problems found, and an error if the store couldn't be
+// checked. A directory without a manifest is an empty store.
+func VerifyFileStore(ctx context.Context, dir string) ([]StoreProblem, error) {
+	var parseErr error
+	exists, contents, err := parseIfExistsWithParser(ctx, dir, func(r io.Reader) (manifestContents, error) {
+		mc, err := parseAnyManifest(r)
+		parseErr = err
+		return mc, nil
+	}, nil)
+
+	if err != nil {
+		return nil, err
+	} else if parseErr != nil {
+		return []StoreProblem{{File: manifestFileName, Err: parseErr}}, nil
+	} else if !exists {
+		return nil, nil
+	}
+
+	var problems []StoreProblem
+	seen := make(map[addr]bool)
+	for _, spec := range contents.specs {
+		name := spec.name.String()
+		if seen[spec.name] {
+			problems = append(problems, StoreProblem{File: manifestFileName, Err: fmt.Errorf("table file %s is listed more than once", name)})
+			continue
+		}
+		seen[spec.name] = true
+
+		f, err := os.Open(filepath.Join(dir, name))
+		if os.IsNotExist(err) {
+			problems = append(problems, StoreProblem{File: name, Err: errors.New("table file listed in the manifest doesn't exist")})
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		count, err := func() (uint32, error) {
+			defer f.Close()
+			return VerifyTableFile(f, func(h hash.Hash, err error) {
+				problems = append(problems, StoreProblem{File: name, Chunk: h, Err: err})
+			})
+		}()
+
+		if err != nil {
+			problems = append(problems, StoreProblem{File: name, Err: err})
+		} else if count != spec.chunkCount {
+			problems = append(problems, StoreProblem{File: name, Err: fmt.Errorf("table file has %d chunks, but the manifest lists %d", count, spec.chunkCount)})
+		}
+	}
+
+	return problems, nil
+}
+
+// parseAnyManifest parses a manifest of any version that's stored on disk.
+func parseAnyManifest(r io.Reader) (manifestContents, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return manifestContents{}, err
+	}
+
+	fields := strings.Split(string(data), ":")
+	if len(fields) < 4 {
+		return manifestContents{}, ErrCorruptManifest
+	} else if _, ok := hash.MaybeParse(fields[3]); !ok {
+		return manifestContents{}, fmt.Errorf("%w: invalid root hash %q", ErrCorruptManifest, fields[3])
+	}
+
+	switch fields[0] {
+	case StorageVersion:
+		return fileManifestV5{}.parseManifest(bytes.NewReader(data))
+	case storageVersion4:
+		return fileManifestV4{}.parseManifest(bytes.NewReader(data))
+	default:
+		return manifestContents{}, fmt.Errorf("unknown storage version %q", fields[0])
+	}
+}
+
+// VerifyTableFile checks the table file read from |rd|: its footer and index must be valid, and describe every byte of
+// the file. It returns the number of chunks in the file, or an error if its footer or index are invalid. |corrupt| is
+// called with the address of each chunk which fails its checksum, can't be decompressed, or doesn't hash to its
+// address.
+func VerifyTableFile(rd io.ReadSeeker, corrupt func(h hash.Hash, err error)) (uint32, error) {
+	size, err := rd.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, err
+	} else if size < int64(footerSize) {
+		return 0, fmt.Errorf("%w: file is smaller than a footer", ErrInvalidTableFile)
+	}
+
+	idx, err := ReadTableIndex(rd)
+	if err != nil {
+		return 0, err
+	}
+
+	count := idx.ChunkCount()
+	if count == 0 {
+		if size != int64(footerSize) {
+			return 0, fmt.Errorf("%w: file has no chunks, but is %d bytes", ErrInvalidTableFile, size)
+		}
+		return 0, nil
+	}
+
+	if idx.TableFileSize() != uint64(size) {
+		return 0, fmt.Errorf("%w: index describes a file of %d bytes, but it's %d bytes", ErrInvalidTableFile, idx.TableFileSize(), size)
+	}
+
+	seen := make([]bool, count)
+	for i, ord := range idx.ordinals {
+		if ord >= count || seen[ord] {
+			return 0, fmt.Errorf("%w: invalid ordinal %d in index", ErrInvalidTableFile, ord)
+		}
+		seen[ord] = true
+
+		if i > 0 && idx.prefixes[i-1] > idx.prefixes[i] {
+			return 0, fmt.Errorf("%w: index prefixes are out of order", ErrInvalidTableFile)
+		}
+	}
+
+	for i := uint32(0); i < count; i++ {
+		var a addr
+		ie := idx.IndexEntry(i, &a)
+		h := hash.Hash(a)
+
+		if ie.Length() < checksumSize {
+			corrupt(h, errors.New("chunk is too short to have a checksum"))
+			continue
+		}
+
+		buff, err := readNFrom(rd, ie.Offset(), ie.Length())
+		if err != nil {
+			return 0, err
+		}
+
+		cmp, err := NewCompressedChunk(h, buff, idx.Codec())
+		if err != nil {
+			corrupt(h, err)
+			continue
+		}
+
+		data, err := idx.Codec().decode(cmp.CompressedData)
+		if err != nil {
+			corrupt(h, fmt.Errorf("chunk can't be decompressed: %w", err))
+			continue
+		}
+
+		if computeAddr(data) != a {
+			corrupt(h, fmt.Errorf("chunk data hashes to %s", computeAddr(data).String()))
+		}
+	}
+
+	return count, nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/utils/file"
+	"github.com/dolthub/dolt/go/store/constants"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+var fsckTestChunks = [][]byte{
+	[]byte("hello2"),
+	[]byte("goodbye2"),
+	[]byte("badbye2"),
+}
+
+func TestVerifyTableFile(t *testing.T) {
+	tableData, _, err := buildTable(fsckTestChunks)
+	require.NoError(t, err)
+
+	verify := func(data []byte) (uint32, []hash.Hash, error) {
+		var corrupt []hash.Hash
+		count, err := VerifyTableFile(bytes.NewReader(data), func(h hash.Hash, err error) {
+			corrupt = append(corrupt, h)
+		})
+		return count, corrupt, err
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		count, corrupt, err := verify(tableData)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(len(fsckTestChunks)), count)
+		assert.Empty(t, corrupt)
+	})
+
+	t.Run("corrupt chunk", func(t *testing.T) {
+		data := append([]byte(nil), tableData...)
+		data[0] ^= 0xff
+
+		_, corrupt, err := verify(data)
+		require.NoError(t, err)
+		assert.Len(t, corrupt, 1)
+	})
+
+	t.Run("corrupt footer", func(t *testing.T) {
+		data := append([]byte(nil), tableData...)
+		data[len(data)-1] ^= 0xff
+
+		_, _, err := verify(data)
+		assert.ErrorIs(t, err, ErrInvalidTableFile)
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		_, _, err := verify(tableData[1:])
+		assert.ErrorIs(t, err, ErrInvalidTableFile)
+	})
+}
+
+func TestVerifyFileStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer file.RemoveAll(dir)
+
+	problems, err := VerifyFileStore(context.Background(), dir)
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+
+	tableData, name, err := buildTable(fsckTestChunks)
+	require.NoError(t, err)
+	err = ioutil.WriteFile(filepath.Join(dir, name.String()), tableData, 0666)
+	require.NoError(t, err)
+
+	writeManifest := func(chunkCount int, tables ...string) {
+		m := []string{StorageVersion, constants.NomsVersion, addr{}.String(), hash.Hash{}.String(), addr{}.String()}
+		for _, table := range tables {
+			m = append(m, table, strconv.Itoa(chunkCount))
+		}
+		require.NoError(t, clobberManifest(dir, strings.Join(m, ":")))
+	}
+
+	writeManifest(len(fsckTestChunks), name.String())
+	problems, err = VerifyFileStore(context.Background(), dir)
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+
+	writeManifest(len(fsckTestChunks)+1, name.String())
+	problems, err = VerifyFileStore(context.Background(), dir)
+	require.NoError(t, err)
+	require.Len(t, problems, 1)
+	assert.Equal(t, name.String(), problems[0].File)
+
+	missing := computeAddr([]byte("missing"))
+	writeManifest(len(fsckTestChunks), name.String(), missing.String())
+	problems, err = VerifyFileStore(context.Background(), dir)
+	require.NoError(t, err)
+	require.Len(t, problems, 1)
+	assert.Equal(t, missing.String(), problems[0].File)
+
+	require.NoError(t, clobberManifest(dir, "not a manifest"))
+	problems, err = VerifyFileStore(context.Background(), dir)
+	require.NoError(t, err)
+	require.Len(t, problems, 1)
+	assert.Equal(t, manifestFileName, problems[0].File)
+}
@@ -46,8 +46,8 @@ type gcCopier struct {
 	writer *CmpChunkTableWriter
 }
 
-func newGarbageCollectionCopier() (*gcCopier, error) {
-	writer, err := NewCmpChunkTableWriter("")
+func newGarbageCollectionCopier(codec ChunkCodec) (*gcCopier, error) {
+	writer, err := NewCmpChunkTableWriter("", codec)
 	if err != nil {
 		return nil, err
 	}
@@ -70,11 +70,17 @@ type memTable struct {
 	order              []hasRecord // Must maintain the invariant that these are sorted by rec.order
 	maxData, totalData uint64
 
-	snapper snappyEncoder
+	codec   ChunkCodec
+	encoder chunkEncoder
 }
 
 func newMemTable(memTableSize uint64) *memTable {
-	return &memTable{chunks: map[addr][]byte{}, maxData: memTableSize}
+	return newMemTableWithCodec(memTableSize, SnappyCodec)
+}
+
+// newMemTableWithCodec returns a memTable which writes table files compressed with |codec|.
+func newMemTableWithCodec(memTableSize uint64, codec ChunkCodec) *memTable {
+	return &memTable{chunks: map[addr][]byte{}, maxData: memTableSize, codec: codec}
 }
 
 func (mt *memTable) addChunk(h addr, data []byte) bool {
@@ -158,7 +164,7 @@ func (mt *memTable) getManyCompressed(ctx context.Context, eg *errgroup.Group, r
 		data := mt.chunks[*r.a]
 		if data != nil {
 			c := chunks.NewChunkWithHash(hash.Hash(*r.a), data)
-			found(ChunkToCompressedChunk(c))
+			found(ChunkToCompressedChunkWithCodec(c, mt.codec))
 		} else {
 			remaining = true
 		}
@@ -180,9 +186,9 @@ func (mt *memTable) write(haver chunkReader, stats *Stats) (name addr, data []by
 	if numChunks == 0 {
 		return addr{}, nil, 0, fmt.Errorf("mem table cannot write with zero chunks")
 	}
-	maxSize := maxTableSize(uint64(len(mt.order)), mt.totalData)
+	maxSize := maxTableSize(uint64(len(mt.order)), mt.totalData, mt.codec)
 	buff := make([]byte, maxSize)
-	tw := newTableWriter(buff, mt.snapper)
+	tw := newTableWriter(buff, mt.codec, mt.encoder)
 
 	if haver != nil {
 		sort.Sort(hasRecordByPrefix(mt.order)) // hasMany() requires addresses to be sorted.
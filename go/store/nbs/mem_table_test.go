@@ -200,7 +200,7 @@ func TestMemTableSnappyWriteOutOfLine(t *testing.T) {
 	for _, c := range chunks {
 		assert.True(mt.addChunk(computeAddr(c), c))
 	}
-	mt.snapper = &outOfLineSnappy{[]bool{false, true, false}} // chunks[1] should trigger a panic
+	mt.encoder = &outOfLineSnappy{[]bool{false, true, false}} // chunks[1] should trigger a panic
 
 	assert.Panics(func() { mt.write(nil, &Stats{}) })
 }
@@ -492,9 +492,9 @@ func compactSourcesToBuffer(sources chunkSources) (name addr, data []byte, chunk
 		return
 	}
 
-	maxSize := maxTableSize(uint64(chunkCount), totalData)
+	maxSize := maxTableSize(uint64(chunkCount), totalData, SnappyCodec)
 	buff := make([]byte, maxSize) // This can blow up RAM
-	tw := newTableWriter(buff, nil)
+	tw := newTableWriter(buff, SnappyCodec, nil)
 	errString := ""
 
 	for _, src := range sources {
@@ -94,6 +94,14 @@ type NomsBlockStore struct {
 	mtSize   uint64
 	putCount uint64
 
+	// codec is the codec of the table files written by the store
+	codec ChunkCodec
+
+	// gcInProgress is set while MarkAndSweepChunks runs, and gcNovelStart is the number of novel tables when it
+	// started. Tables added since then hold chunks written during the collection.
+	gcInProgress bool
+	gcNovelStart int
+
 	stats *Stats
 }
 
@@ -487,6 +495,7 @@ func newNomsBlockStore(ctx context.Context, nbfVerStr string, mm manifestManager
 		tables:   newTableSet(p),
 		upstream: manifestContents{vers: nbfVerStr},
 		mtSize:   memTableSize,
+		codec:    DefaultChunkCodec,
 		stats:    NewStats(),
 	}
 
@@ -533,6 +542,7 @@ func (nbs *NomsBlockStore) WithoutConjoiner() *NomsBlockStore {
 		upstream: nbs.upstream,
 		mtSize:   nbs.mtSize,
 		putCount: nbs.putCount,
+		codec:    nbs.codec,
 		stats:    nbs.stats,
 	}
 }
@@ -557,16 +567,26 @@ func (nbs *NomsBlockStore) addChunk(ctx context.Context, h addr, data []byte) bo
 	nbs.mu.Lock()
 	defer nbs.mu.Unlock()
 	if nbs.mt == nil {
-		nbs.mt = newMemTable(nbs.mtSize)
+		nbs.mt = newMemTableWithCodec(nbs.mtSize, nbs.codec)
 	}
 	if !nbs.mt.addChunk(h, data) {
-		nbs.tables = nbs.tables.Prepend(ctx, nbs.mt, nbs.stats)
-		nbs.mt = newMemTable(nbs.mtSize)
+		nbs.tables = nbs.prependMemTable(ctx, nbs.mt)
+		nbs.mt = newMemTableWithCodec(nbs.mtSize, nbs.codec)
 		return nbs.mt.addChunk(h, data)
 	}
 	return true
 }
 
+// prependMemTable returns nbs.tables with |mt| added as a novel table. Chunks that are already in nbs.tables are
+// usually left out of the new table, but not while a garbage collection is running, as the tables that have them may
+// be about to be removed. Callers must hold nbs.mu.
+func (nbs *NomsBlockStore) prependMemTable(ctx context.Context, mt *memTable) tableSet {
+	if nbs.gcInProgress {
+		return nbs.tables.PrependAll(ctx, mt, nbs.stats)
+	}
+	return nbs.tables.Prepend(ctx, mt, nbs.stats)
+}
+
 func (nbs *NomsBlockStore) Get(ctx context.Context, h hash.Hash) (chunks.Chunk, error) {
 	span, ctx := tracing.StartSpan(ctx, "nbs.Get")
 	defer func() {
@@ -925,7 +945,7 @@ func (nbs *NomsBlockStore) Commit(ctx context.Context, current, last hash.Hash)
 			}
 
 			if cnt > preflushChunkCount {
-				nbs.tables = nbs.tables.Prepend(ctx, nbs.mt, nbs.stats)
+				nbs.tables = nbs.prependMemTable(ctx, nbs.mt)
 				nbs.mt = nil
 			}
 		}
@@ -1008,7 +1028,7 @@ func (nbs *NomsBlockStore) updateManifest(ctx context.Context, current, last has
 		}
 
 		if cnt > 0 {
-			nbs.tables = nbs.tables.Prepend(ctx, nbs.mt, nbs.stats)
+			nbs.tables = nbs.prependMemTable(ctx, nbs.mt)
 			nbs.mt = nil
 		}
 	}
@@ -1263,10 +1283,11 @@ func (nbs *NomsBlockStore) chunkSourcesByAddr() (map[addr]chunkSource, error) {
 func (nbs *NomsBlockStore) SupportedOperations() TableFileStoreOps {
 	_, ok := nbs.p.(*fsTablePersister)
 	return TableFileStoreOps{
-		CanRead:  true,
-		CanWrite: ok,
-		CanPrune: ok,
-		CanGC:    ok,
+		CanRead:     true,
+		CanWrite:    ok,
+		CanPrune:    ok,
+		CanGC:       ok,
+		ChunkCodecs: ChunkCodecs,
 	}
 }
 
@@ -1384,16 +1405,55 @@ func (nbs *NomsBlockStore) PruneTableFiles(ctx context.Context) (err error) {
 	return nbs.p.PruneTableFiles(ctx, contents)
 }
 
+// MarkAndSweepChunks replaces the tables of the store with new tables holding only the chunks sent on |keepChunks|,
+// which must include every chunk reachable from the root |last|. Chunks may be written to the store while it runs:
+// they're kept in the memtable or in novel tables, which are written in full rather than de-duplicated against tables
+// that may be removed. It fails with errLastRootMismatch if the root changes before the tables are replaced.
 func (nbs *NomsBlockStore) MarkAndSweepChunks(ctx context.Context, last hash.Hash, keepChunks <-chan []hash.Hash) error {
 	ops := nbs.SupportedOperations()
 	if !ops.CanGC || !ops.CanPrune {
 		return chunks.ErrUnsupportedOperation
 	}
 
-	if nbs.upstream.root != last {
-		return errLastRootMismatch
+	err := func() error {
+		nbs.mu.Lock()
+		defer nbs.mu.Unlock()
+		if nbs.upstream.root != last {
+			return errLastRootMismatch
+		}
+
+		err := nbs.migrateManifestForGC(ctx)
+		if err != nil {
+			return err
+		}
+
+		// chunks written before the collection started are only kept if they're marked
+		if nbs.mt != nil {
+			cnt, err := nbs.mt.count()
+			if err != nil {
+				return err
+			}
+
+			if cnt > 0 {
+				nbs.tables = nbs.tables.Prepend(ctx, nbs.mt, nbs.stats)
+				nbs.mt = nil
+			}
+		}
+
+		nbs.gcInProgress = true
+		nbs.gcNovelStart = nbs.tables.Novel()
+		return nil
+	}()
+	if err != nil {
+		return err
 	}
 
+	defer func() {
+		nbs.mu.Lock()
+		defer nbs.mu.Unlock()
+		nbs.gcInProgress = false
+	}()
+
 	specs, err := nbs.copyMarkedChunks(ctx, keepChunks)
 	if err != nil {
 		return err
@@ -1402,6 +1462,14 @@ func (nbs *NomsBlockStore) MarkAndSweepChunks(ctx context.Context, last hash.Has
 		return ctx.Err()
 	}
 
+	// writes are blocked from here on, so that no table is created between swapping the tables and pruning files
+	nbs.mu.Lock()
+	defer nbs.mu.Unlock()
+
+	if nbs.upstream.root != last {
+		return errLastRootMismatch
+	}
+
 	err = nbs.swapTables(ctx, specs)
 	if err != nil {
 		return err
@@ -1421,11 +1489,36 @@ func (nbs *NomsBlockStore) MarkAndSweepChunks(ctx context.Context, last hash.Has
 		return ctx.Err()
 	}
 
+	// the novel tables aren't in the manifest yet, but their files must be kept
+	tableSpecs, err := nbs.tables.ToSpecs()
+	if err != nil {
+		return err
+	}
+	contents.specs = append(contents.specs, tableSpecs...)
+
 	return nbs.p.PruneTableFiles(ctx, contents)
 }
 
+// migrateManifestForGC migrates a version 4 file manifest, which can't record a garbage collection generation, to
+// version 5, as `dolt gc` does before it collects a database. Callers must hold nbs.mu.
+func (nbs *NomsBlockStore) migrateManifestForGC(ctx context.Context) error {
+	fm4, ok := nbs.mm.m.(fileManifestV4)
+	if !ok {
+		return nil
+	}
+
+	_, err := MaybeMigrateFileManifest(ctx, fm4.dir)
+	if err != nil {
+		return err
+	}
+
+	nbs.mm.m = fileManifestV5{fm4.dir}
+	nbs.upstream.gcGen = nbs.upstream.lock
+	return nil
+}
+
 func (nbs *NomsBlockStore) copyMarkedChunks(ctx context.Context, keepChunks <-chan []hash.Hash) ([]tableSpec, error) {
-	gcc, err := newGarbageCollectionCopier()
+	gcc, err := newGarbageCollectionCopier(nbs.codec)
 	if err != nil {
 		return nil, err
 	}
@@ -1506,19 +1599,10 @@ func (nbs *NomsBlockStore) swapTables(ctx context.Context, specs []tableSpec) er
 		return err
 	}
 
-	// clear memTable
-	nbs.mt = newMemTable(nbs.mtSize)
-
-	// clear nbs.tables.novel
-	nbs.tables, err = nbs.tables.Flatten()
-
-	if err != nil {
-		return nil
-	}
-
-	// replace nbs.tables.upstream with gc compacted tables
+	// replace nbs.tables.upstream with gc compacted tables. The memtable and the novel tables added since the
+	// collection started hold chunks written during it, so they're kept.
 	nbs.upstream = upstream
-	nbs.tables, err = nbs.tables.Rebase(ctx, specs, nbs.stats)
+	nbs.tables, err = nbs.tables.DropOldestNovel(nbs.gcNovelStart).Rebase(ctx, specs, nbs.stats)
 
 	if err != nil {
 		return err
@@ -96,7 +96,9 @@ import (
    +----------------------+----------------------------------------+------------------+
 
      -Total Uncompressed Chunk Data is the sum of the uncompressed byte lengths of all contained chunk byte slices.
-     -Magic Number is the first 8 bytes of the SHA256 hash of "https://github.com/attic-labs/nbs".
+     -Magic Number identifies the codec which the Chunk Data of every Chunk Record is compressed with. For snappy, it's
+      the first 8 bytes of the SHA256 hash of "https://github.com/attic-labs/nbs", and for zstd, the first 8 bytes of
+      the SHA256 hash of "https://github.com/dolthub/dolt/nbs/zstd".
 
     NOTE: Unsigned integer quanities, hashes and hash suffix are all encoded big-endian
 
@@ -296,6 +298,9 @@ type TableFileStoreOps struct {
 	CanPrune bool
 	// True is the TableFileStore supports garbage collecting chunks.
 	CanGC bool
+	// ChunkCodecs are the codecs of the table files which can be written to the TableFileStore. If it's empty, only
+	// snappy table files can be written.
+	ChunkCodecs []ChunkCodec
 }
 
 // TableFileStore is an interface for interacting with table files directly
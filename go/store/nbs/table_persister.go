@@ -205,6 +205,7 @@ type compactionPlan struct {
 	mergedIndex         []byte
 	chunkCount          uint32
 	totalCompressedData uint64
+	codec               ChunkCodec
 }
 
 func (cp compactionPlan) suffixes() []byte {
@@ -212,9 +213,11 @@ func (cp compactionPlan) suffixes() []byte {
 	return cp.mergedIndex[suffixesStart : suffixesStart+uint64(cp.chunkCount)*addrSuffixSize]
 }
 
+// planConjoin plans the table file made of all the chunks of |sources|, which must all be compressed with the same
+// codec, since their chunk records are copied as they are.
 func planConjoin(sources chunkSources, stats *Stats) (plan compactionPlan, err error) {
 	var totalUncompressedData uint64
-	for _, src := range sources {
+	for i, src := range sources {
 		var uncmp uint64
 		uncmp, err = src.uncompressedLen()
 
@@ -229,6 +232,12 @@ func planConjoin(sources chunkSources, stats *Stats) (plan compactionPlan, err e
 			return compactionPlan{}, err
 		}
 
+		if i == 0 {
+			plan.codec = index.Codec()
+		} else if index.Codec() != plan.codec {
+			return compactionPlan{}, fmt.Errorf("cannot conjoin tables compressed with %s and %s", plan.codec, index.Codec())
+		}
+
 		plan.chunkCount += index.ChunkCount()
 
 		// Calculate the amount of chunk data in |src|
@@ -315,7 +324,7 @@ func planConjoin(sources chunkSources, stats *Stats) (plan compactionPlan, err e
 		pfxPos += ordinalSize
 	}
 
-	writeFooter(plan.mergedIndex[uint64(len(plan.mergedIndex))-footerSize:], plan.chunkCount, totalUncompressedData)
+	writeFooter(plan.mergedIndex[uint64(len(plan.mergedIndex))-footerSize:], plan.chunkCount, totalUncompressedData, plan.codec)
 
 	stats.BytesPerConjoin.Sample(uint64(plan.totalCompressedData) + uint64(len(plan.mergedIndex)))
 	return plan, nil
@@ -32,7 +32,6 @@ import (
 	"sync/atomic"
 
 	"github.com/dolthub/mmap-go"
-	"github.com/golang/snappy"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
@@ -40,7 +39,7 @@ import (
 	"github.com/dolthub/dolt/go/store/hash"
 )
 
-// CompressedChunk represents a chunk of data in a table file which is still compressed via snappy.
+// CompressedChunk represents a chunk of data in a table file which is still compressed with the codec of the table file.
 type CompressedChunk struct {
 	// H is the hash of the chunk
 	H hash.Hash
@@ -48,12 +47,15 @@ type CompressedChunk struct {
 	// FullCompressedChunk is the entirety of the compressed chunk data including the crc
 	FullCompressedChunk []byte
 
-	// CompressedData is just the snappy encoded byte buffer that stores the chunk data
+	// CompressedData is just the encoded byte buffer that stores the chunk data
 	CompressedData []byte
+
+	// Codec is the codec that CompressedData is encoded with
+	Codec ChunkCodec
 }
 
-// NewCompressedChunk creates a CompressedChunk
-func NewCompressedChunk(h hash.Hash, buff []byte) (CompressedChunk, error) {
+// NewCompressedChunk creates a CompressedChunk from a chunk record encoded with |codec|
+func NewCompressedChunk(h hash.Hash, buff []byte, codec ChunkCodec) (CompressedChunk, error) {
 	dataLen := uint64(len(buff)) - checksumSize
 
 	chksum := binary.BigEndian.Uint32(buff[dataLen:])
@@ -63,12 +65,12 @@ func NewCompressedChunk(h hash.Hash, buff []byte) (CompressedChunk, error) {
 		return CompressedChunk{}, errors.New("checksum error")
 	}
 
-	return CompressedChunk{H: h, FullCompressedChunk: buff, CompressedData: compressedData}, nil
+	return CompressedChunk{H: h, FullCompressedChunk: buff, CompressedData: compressedData, Codec: codec}, nil
 }
 
-// ToChunk snappy decodes the compressed data and returns a chunks.Chunk
+// ToChunk decodes the compressed data and returns a chunks.Chunk
 func (cmp CompressedChunk) ToChunk() (chunks.Chunk, error) {
-	data, err := snappy.Decode(nil, cmp.CompressedData)
+	data, err := cmp.Codec.decode(cmp.CompressedData)
 
 	if err != nil {
 		return chunks.Chunk{}, err
@@ -77,12 +79,32 @@ func (cmp CompressedChunk) ToChunk() (chunks.Chunk, error) {
 	return chunks.NewChunkWithHash(cmp.H, data), nil
 }
 
+// ChunkToCompressedChunk compresses |chunk| with snappy.
 func ChunkToCompressedChunk(chunk chunks.Chunk) CompressedChunk {
-	compressed := snappy.Encode(nil, chunk.Data())
+	return ChunkToCompressedChunkWithCodec(chunk, SnappyCodec)
+}
+
+// ChunkToCompressedChunkWithCodec compresses |chunk| with |codec|.
+func ChunkToCompressedChunkWithCodec(chunk chunks.Chunk, codec ChunkCodec) CompressedChunk {
+	compressed := codec.encode(nil, chunk.Data())
 	length := len(compressed)
 	compressed = append(compressed, []byte{0, 0, 0, 0}...)
 	binary.BigEndian.PutUint32(compressed[length:], crc(compressed[:length]))
-	return CompressedChunk{H: chunk.Hash(), FullCompressedChunk: compressed, CompressedData: compressed[:length]}
+	return CompressedChunk{H: chunk.Hash(), FullCompressedChunk: compressed, CompressedData: compressed[:length], Codec: codec}
+}
+
+// Recompress returns |cmp| compressed with |codec|, decoding and encoding it again if it isn't already.
+func (cmp CompressedChunk) Recompress(codec ChunkCodec) (CompressedChunk, error) {
+	if cmp.Codec == codec {
+		return cmp, nil
+	}
+
+	chunk, err := cmp.ToChunk()
+	if err != nil {
+		return CompressedChunk{}, err
+	}
+
+	return ChunkToCompressedChunkWithCodec(chunk, codec), nil
 }
 
 // Hash returns the hash of the data
@@ -92,7 +114,12 @@ func (cmp CompressedChunk) Hash() hash.Hash {
 
 // IsEmpty returns true if the chunk contains no data.
 func (cmp CompressedChunk) IsEmpty() bool {
-	return len(cmp.CompressedData) == 0 || (len(cmp.CompressedData) == 1 && cmp.CompressedData[0] == 0)
+	if cmp.Codec == SnappyCodec {
+		return len(cmp.CompressedData) == 0 || (len(cmp.CompressedData) == 1 && cmp.CompressedData[0] == 0)
+	}
+
+	n, err := cmp.Codec.decodedLen(cmp.CompressedData)
+	return len(cmp.CompressedData) == 0 || (err == nil && n == 0)
 }
 
 var EmptyCompressedChunk CompressedChunk
@@ -110,6 +137,7 @@ type onHeapTableIndex struct {
 	prefixes, offsets     []uint64
 	lengths, ordinals     []uint32
 	suffixes              []byte
+	codec                 ChunkCodec
 }
 
 type indexEntry interface {
@@ -162,6 +190,7 @@ type mmapTableIndex struct {
 	prefixes              []uint64
 	data                  mmap.MMap
 	refCnt                *int32
+	codec                 ChunkCodec
 }
 
 func (i mmapTableIndex) Prefixes() []uint64 {
@@ -185,6 +214,10 @@ func (i mmapTableIndex) TotalUncompressedData() uint64 {
 	return i.totalUncompressedData
 }
 
+func (i mmapTableIndex) Codec() ChunkCodec {
+	return i.codec
+}
+
 func (i mmapTableIndex) Close() error {
 	cnt := atomic.AddInt32(i.refCnt, -1)
 	if cnt == 0 {
@@ -302,6 +335,7 @@ func newMmapTableIndex(ti onHeapTableIndex, f *os.File) (mmapTableIndex, error)
 		ti.Prefixes(),
 		arr,
 		refCnt,
+		ti.codec,
 	}, nil
 }
 
@@ -350,6 +384,9 @@ type tableIndex interface {
 	// TotalUncompressedData returns the total uncompressed data size of
 	// the table file. Used for informational statistics only.
 	TotalUncompressedData() uint64
+	// Codec returns the codec that the chunks of the table file are
+	// compressed with.
+	Codec() ChunkCodec
 
 	// Close releases any resources used by this tableIndex.
 	Close() error
@@ -382,7 +419,8 @@ func ReadTableIndex(rd io.ReadSeeker) (onHeapTableIndex, error) {
 		return onHeapTableIndex{}, err
 	}
 
-	if string(footer[uint32Size+uint64Size:]) != magicNumber {
+	codec, ok := codecForMagicNumber(string(footer[uint32Size+uint64Size:]))
+	if !ok {
 		return onHeapTableIndex{}, ErrInvalidTableFile
 	}
 
@@ -414,6 +452,7 @@ func ReadTableIndex(rd io.ReadSeeker) (onHeapTableIndex, error) {
 		prefixes, offsets,
 		lengths, ordinals,
 		suffixes,
+		codec,
 	}, nil
 }
 
@@ -421,6 +460,10 @@ func computeOffsets(count uint32, buff []byte) (lengths []uint32, offsets []uint
 	lengths = make([]uint32, count)
 	offsets = make([]uint64, count)
 
+	if count == 0 {
+		return
+	}
+
 	lengths[0] = binary.BigEndian.Uint32(buff)
 
 	for i := uint64(1); i < uint64(count); i++ {
@@ -531,6 +574,10 @@ func (i onHeapTableIndex) TotalUncompressedData() uint64 {
 	return i.totalUncompressedData
 }
 
+func (i onHeapTableIndex) Codec() ChunkCodec {
+	return i.codec
+}
+
 func (i onHeapTableIndex) Close() error {
 	return nil
 }
@@ -635,7 +682,7 @@ func (tr tableReader) get(ctx context.Context, h addr, stats *Stats) ([]byte, er
 		return nil, errors.New("failed to read all data")
 	}
 
-	cmp, err := NewCompressedChunk(hash.Hash(h), buff)
+	cmp, err := NewCompressedChunk(hash.Hash(h), buff, tr.Codec())
 
 	if err != nil {
 		return nil, err
@@ -718,7 +765,7 @@ func (tr tableReader) readAtOffsetsWithCB(
 	}
 
 	for i := range rb {
-		cmp, err := rb.ExtractChunkFromRead(buff, i)
+		cmp, err := rb.ExtractChunkFromRead(buff, i, tr.Codec())
 		if err != nil {
 			return err
 		}
@@ -790,10 +837,10 @@ func (r readBatch) End() uint64 {
 	return last.offset + uint64(last.length)
 }
 
-func (s readBatch) ExtractChunkFromRead(buff []byte, idx int) (CompressedChunk, error) {
+func (s readBatch) ExtractChunkFromRead(buff []byte, idx int, codec ChunkCodec) (CompressedChunk, error) {
 	rec := s[idx]
 	chunkStart := rec.offset - s.Start()
-	return NewCompressedChunk(hash.Hash(*rec.a), buff[chunkStart:chunkStart+uint64(rec.length)])
+	return NewCompressedChunk(hash.Hash(*rec.a), buff[chunkStart:chunkStart+uint64(rec.length)], codec)
 }
 
 func toReadBatches(offsets offsetRecSlice, blockSize uint64) []readBatch {
@@ -971,7 +1018,7 @@ func (tr tableReader) extract(ctx context.Context, chunks chan<- extractRecord)
 		if uint32(n) != or.length {
 			return errors.New("did not read all data")
 		}
-		cmp, err := NewCompressedChunk(hash.Hash(*or.a), buff)
+		cmp, err := NewCompressedChunk(hash.Hash(*or.a), buff, tr.Codec())
 
 		if err != nil {
 			return err
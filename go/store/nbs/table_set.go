@@ -352,13 +352,22 @@ func (ts tableSet) Upstream() int {
 // Prepend adds a memTable to an existing tableSet, compacting |mt| and
 // returning a new tableSet with newly compacted table added.
 func (ts tableSet) Prepend(ctx context.Context, mt *memTable, stats *Stats) tableSet {
+	return ts.prepend(ctx, mt, ts, stats)
+}
+
+// PrependAll is like Prepend, but every chunk in |mt| is written to the new table, including those already in |ts|.
+func (ts tableSet) PrependAll(ctx context.Context, mt *memTable, stats *Stats) tableSet {
+	return ts.prepend(ctx, mt, nil, stats)
+}
+
+func (ts tableSet) prepend(ctx context.Context, mt *memTable, haver chunkReader, stats *Stats) tableSet {
 	newTs := tableSet{
 		novel:    make(chunkSources, len(ts.novel)+1),
 		upstream: make(chunkSources, len(ts.upstream)),
 		p:        ts.p,
 		rl:       ts.rl,
 	}
-	newTs.novel[0] = newPersistingChunkSource(ctx, mt, ts, ts.p, ts.rl, stats)
+	newTs.novel[0] = newPersistingChunkSource(ctx, mt, haver, ts.p, ts.rl, stats)
 	copy(newTs.novel[1:], ts.novel)
 	copy(newTs.upstream, ts.upstream)
 	return newTs
@@ -409,6 +418,20 @@ func (ts tableSet) Flatten() (tableSet, error) {
 	return flattened, nil
 }
 
+// DropOldestNovel returns a new tableSet without the |n| novel tables that were added to |ts| first.
+func (ts tableSet) DropOldestNovel(n int) tableSet {
+	if n > len(ts.novel) {
+		n = len(ts.novel)
+	}
+
+	return tableSet{
+		novel:    ts.novel[:len(ts.novel)-n],
+		upstream: ts.upstream,
+		p:        ts.p,
+		rl:       ts.rl,
+	}
+}
+
 // Rebase returns a new tableSet holding the novel tables managed by |ts| and
 // those specified by |specs|.
 func (ts tableSet) Rebase(ctx context.Context, specs []tableSpec, stats *Stats) (tableSet, error) {
@@ -41,11 +41,11 @@ func buildTable(chunks [][]byte) ([]byte, addr, error) {
 	for _, chunk := range chunks {
 		totalData += uint64(len(chunk))
 	}
-	capacity := maxTableSize(uint64(len(chunks)), totalData)
+	capacity := maxTableSize(uint64(len(chunks)), totalData, SnappyCodec)
 
 	buff := make([]byte, capacity)
 
-	tw := newTableWriter(buff, nil)
+	tw := newTableWriter(buff, SnappyCodec, nil)
 
 	for _, chunk := range chunks {
 		tw.addChunk(computeAddr(chunk), chunk)
@@ -161,9 +161,9 @@ func TestHasManySequentialPrefix(t *testing.T) {
 	bogusData := []byte("bogus") // doesn't matter what this is. hasMany() won't check chunkRecords
 	totalData := uint64(len(bogusData) * len(addrs))
 
-	capacity := maxTableSize(uint64(len(addrs)), totalData)
+	capacity := maxTableSize(uint64(len(addrs)), totalData, SnappyCodec)
 	buff := make([]byte, capacity)
-	tw := newTableWriter(buff, nil)
+	tw := newTableWriter(buff, SnappyCodec, nil)
 
 	for _, a := range addrs {
 		tw.addChunk(a, bogusData)
@@ -399,7 +399,7 @@ func TestEmpty(t *testing.T) {
 	assert := assert.New(t)
 
 	buff := make([]byte, footerSize)
-	tw := newTableWriter(buff, nil)
+	tw := newTableWriter(buff, SnappyCodec, nil)
 	length, _, err := tw.finish()
 	require.NoError(t, err)
 	assert.True(length == footerSize)
@@ -29,8 +29,6 @@ import (
 	"hash"
 	"sort"
 
-	"github.com/golang/snappy"
-
 	"github.com/dolthub/dolt/go/store/d"
 )
 
@@ -43,25 +41,20 @@ type tableWriter struct {
 	prefixes              prefixIndexSlice // TODO: This is in danger of exploding memory
 	blockHash             hash.Hash
 
-	snapper snappyEncoder
+	codec   ChunkCodec
+	encoder chunkEncoder
 }
 
-type snappyEncoder interface {
+type chunkEncoder interface {
 	Encode(dst, src []byte) []byte
 }
 
-type realSnappyEncoder struct{}
-
-func (r realSnappyEncoder) Encode(dst, src []byte) []byte {
-	return snappy.Encode(dst, src)
-}
-
-func maxTableSize(numChunks, totalData uint64) uint64 {
+func maxTableSize(numChunks, totalData uint64, codec ChunkCodec) uint64 {
 	avgChunkSize := totalData / numChunks
 	d.Chk.True(avgChunkSize < maxChunkSize)
-	maxSnappySize := snappy.MaxEncodedLen(int(avgChunkSize))
-	d.Chk.True(maxSnappySize > 0)
-	return numChunks*(prefixTupleSize+lengthSize+addrSuffixSize+checksumSize+uint64(maxSnappySize)) + footerSize
+	maxEncodedSize := codec.maxEncodedLen(int(avgChunkSize))
+	d.Chk.True(maxEncodedSize > 0)
+	return numChunks*(prefixTupleSize+lengthSize+addrSuffixSize+checksumSize+uint64(maxEncodedSize)) + footerSize
 }
 
 func indexSize(numChunks uint32) uint64 {
@@ -76,15 +69,17 @@ func suffixesOffset(numChunks uint32) uint64 {
 	return uint64(numChunks) * (prefixTupleSize + lengthSize)
 }
 
-// len(buff) must be >= maxTableSize(numChunks, totalData)
-func newTableWriter(buff []byte, snapper snappyEncoder) *tableWriter {
-	if snapper == nil {
-		snapper = realSnappyEncoder{}
+// len(buff) must be >= maxTableSize(numChunks, totalData, codec). Chunks are compressed with |codec|, using |encoder|
+// if it isn't nil.
+func newTableWriter(buff []byte, codec ChunkCodec, encoder chunkEncoder) *tableWriter {
+	if encoder == nil {
+		encoder = codecEncoder{codec}
 	}
 	return &tableWriter{
 		buff:      buff,
 		blockHash: sha512.New(),
-		snapper:   snapper,
+		codec:     codec,
+		encoder:   encoder,
 	}
 }
 
@@ -94,16 +89,15 @@ func (tw *tableWriter) addChunk(h addr, data []byte) bool {
 	}
 
 	// Compress data straight into tw.buff
-	compressed := tw.snapper.Encode(tw.buff[tw.pos:], data)
+	compressed := tw.encoder.Encode(tw.buff[tw.pos:], data)
 	dataLength := uint64(len(compressed))
 	tw.totalCompressedData += dataLength
 
 	// BUG 3156 indicated that, sometimes, snappy decided that there's not enough space in tw.buff[tw.pos:] to encode into.
-	// This _should never happen anymore be_, because we iterate over all chunks to be added and sum the max amount of space that snappy says it might need.
-	// Since we know that |data| can't be 0-length, we also know that the compressed version of |data| has length greater than zero. The first element in a snappy-encoded blob is a Uvarint indicating how much data is present. Therefore, if there's a Uvarint-encoded 0 at tw.buff[tw.pos:], we know that snappy did not write anything there and we have a problem.
-	if v, n := binary.Uvarint(tw.buff[tw.pos:]); v == 0 {
-		d.Chk.True(n != 0)
-		panic(fmt.Errorf("bug 3156: unbuffered chunk %s: uncompressed %d, compressed %d, snappy max %d, tw.buff %d", h.String(), len(data), dataLength, snappy.MaxEncodedLen(len(data)), len(tw.buff[tw.pos:])))
+	// This _should never happen anymore be_, because we iterate over all chunks to be added and sum the max amount of space that the codec says it might need.
+	// Since we know that |data| can't be 0-length, we also know that the compressed version of |data| has length greater than zero. If it doesn't start at tw.buff[tw.pos], the encoder did not write it there and we have a problem.
+	if dataLength == 0 || &compressed[0] != &tw.buff[tw.pos] {
+		panic(fmt.Errorf("bug 3156: unbuffered chunk %s: uncompressed %d, compressed %d, %s max %d, tw.buff %d", h.String(), len(data), dataLength, tw.codec, tw.codec.maxEncodedLen(len(data)), len(tw.buff[tw.pos:])))
 	}
 
 	tw.pos += dataLength
@@ -195,10 +189,10 @@ func (tw *tableWriter) writeIndex() error {
 }
 
 func (tw *tableWriter) writeFooter() {
-	tw.pos += writeFooter(tw.buff[tw.pos:], uint32(len(tw.prefixes)), tw.totalUncompressedData)
+	tw.pos += writeFooter(tw.buff[tw.pos:], uint32(len(tw.prefixes)), tw.totalUncompressedData, tw.codec)
 }
 
-func writeFooter(dst []byte, chunkCount uint32, uncData uint64) (consumed uint64) {
+func writeFooter(dst []byte, chunkCount uint32, uncData uint64, codec ChunkCodec) (consumed uint64) {
 	// chunk count
 	binary.BigEndian.PutUint32(dst[consumed:], chunkCount)
 	consumed += uint32Size
@@ -207,8 +201,8 @@ func writeFooter(dst []byte, chunkCount uint32, uncData uint64) (consumed uint64
 	binary.BigEndian.PutUint64(dst[consumed:], uncData)
 	consumed += uint64Size
 
-	// magic number
-	copy(dst[consumed:], magicNumber)
+	// magic number, which identifies the codec
+	copy(dst[consumed:], codec.magicNumber())
 	consumed += magicNumberSize
 	return
 }
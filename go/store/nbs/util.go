@@ -16,6 +16,7 @@ package nbs
 
 import (
 	"io"
+	"path/filepath"
 
 	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
 
@@ -42,7 +43,7 @@ func IterChunks(rd io.ReadSeeker, cb func(chunk chunks.Chunk) (stop bool, err er
 				return err
 			}
 
-			cmpChnk, err := NewCompressedChunk(hash.Hash(a), chunkBytes)
+			cmpChnk, err := NewCompressedChunk(hash.Hash(a), chunkBytes, idx.Codec())
 			if err != nil {
 				return err
 			}
@@ -73,3 +74,92 @@ func readNFrom(rd io.ReadSeeker, offset uint64, length uint32) ([]byte, error) {
 
 	return iohelp.ReadNBytes(rd, int(length))
 }
+
+// ReadTableFileCodec returns the codec that the chunks of the table file read from |rd| are compressed with.
+func ReadTableFileCodec(rd io.ReadSeeker) (ChunkCodec, error) {
+	_, err := rd.Seek(-magicNumberSize, io.SeekEnd)
+	if err != nil {
+		return 0, err
+	}
+
+	magic, err := iohelp.ReadNBytes(rd, magicNumberSize)
+	if err != nil {
+		return 0, err
+	}
+
+	codec, ok := codecForMagicNumber(string(magic))
+	if !ok {
+		return 0, ErrInvalidTableFile
+	}
+
+	return codec, nil
+}
+
+// TranscodeTableFile writes the table file read from |rd| to |path| with its chunks compressed with |codec|. The chunks
+// are written in the same order, so the new table file has the same name.
+func TranscodeTableFile(rd io.ReadSeeker, codec ChunkCodec, path string) error {
+	idx, err := ReadTableIndex(rd)
+	if err != nil {
+		return err
+	}
+
+	defer idx.Close()
+
+	// the index entry of each chunk, in the order of the chunks in the file
+	entries := make([]uint32, idx.ChunkCount())
+	for i, ord := range idx.Ordinals() {
+		entries[ord] = uint32(i)
+	}
+
+	tw, err := NewCmpChunkTableWriter(filepath.Dir(path), codec)
+	if err != nil {
+		return err
+	}
+
+	for _, i := range entries {
+		var a addr
+		ie := idx.IndexEntry(i, &a)
+		buff, err := readNFrom(rd, ie.Offset(), ie.Length())
+		if err != nil {
+			return err
+		}
+
+		cmp, err := NewCompressedChunk(hash.Hash(a), buff, idx.Codec())
+		if err != nil {
+			return err
+		}
+
+		err = tw.addCmpChunk(cmp)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tw.Finish()
+	if err != nil {
+		return err
+	}
+
+	return tw.FlushToFile(path)
+}
+
+// ChunkRanges returns the range of the chunk record of each chunk of |hashes| in the table file read from |rd|. Chunks
+// which aren't in the table file are left out.
+func ChunkRanges(rd io.ReadSeeker, hashes hash.HashSet) (map[hash.Hash]Range, error) {
+	idx, err := ReadTableIndex(rd)
+	if err != nil {
+		return nil, err
+	}
+
+	defer idx.Close()
+
+	ranges := make(map[hash.Hash]Range)
+	for h := range hashes {
+		a := addr(h)
+		if ie, ok := idx.Lookup(&a); ok {
+			ranges[h] = Range{Offset: ie.Offset(), Length: ie.Length()}
+		}
+	}
+
+	return ranges, nil
+}
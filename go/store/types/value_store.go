@@ -553,6 +553,12 @@ func (lvs *ValueStore) Commit(ctx context.Context, current, last hash.Hash) (boo
 
 // GC traverses the ValueStore from the root and removes unreferenced chunks from the ChunkStore
 func (lvs *ValueStore) GC(ctx context.Context) error {
+	return lvs.GCIgnoringMissing(ctx, nil)
+}
+
+// GCIgnoringMissing is GC for a ValueStore which is allowed to be missing the chunks of |missing|, such as the parents
+// of the commits at the boundary of a shallow clone. They're skipped by the traversal, rather than failing it.
+func (lvs *ValueStore) GCIgnoringMissing(ctx context.Context, missing hash.HashSet) error {
 	collector, ok := lvs.cs.(chunks.ChunkStoreGarbageCollector)
 
 	if !ok {
@@ -576,6 +582,13 @@ func (lvs *ValueStore) GC(ctx context.Context) error {
 		return nil
 	}
 
+	// values which are buffered but haven't been committed aren't reachable from the root, so they're discarded
+	lvs.bufferMu.Lock()
+	lvs.bufferedChunks = make(map[hash.Hash]chunks.Chunk, lvs.bufferedChunkSize)
+	lvs.bufferedChunkSize = 0
+	lvs.withBufferedChildren = map[hash.Hash]uint64{}
+	lvs.bufferMu.Unlock()
+
 	keepChunks := make(chan []hash.Hash, gcBuffSize)
 
 	eg, ctx := errgroup.WithContext(ctx)
@@ -621,9 +634,6 @@ func (lvs *ValueStore) GC(ctx context.Context) error {
 			toVisit = make([][]hash.Hash, len(batches))
 			toVisitCount = 0
 			for i, batch := range batches {
-				if err := keepHashes(batch); err != nil {
-					return err
-				}
 				vals, err := lvs.ReadManyValues(ctx, batch)
 				if err != nil {
 					return err
@@ -631,6 +641,13 @@ func (lvs *ValueStore) GC(ctx context.Context) error {
 				if len(vals) != len(batch) {
 					return errors.New("dangling reference found in chunk store")
 				}
+				batch, vals, err = removeMissingValues(batch, vals, missing)
+				if err != nil {
+					return err
+				}
+				if err := keepHashes(batch); err != nil {
+					return err
+				}
 				hashes, err := walker.GetRefs(visited, vals)
 				if err != nil {
 					return err
@@ -649,15 +666,30 @@ func (lvs *ValueStore) GC(ctx context.Context) error {
 		return err
 	}
 
-	// purge the cache
-	lvs.decodedChunks = sizecache.New(lvs.decodedChunks.Size())
-	lvs.bufferedChunks = make(map[hash.Hash]chunks.Chunk, lvs.bufferedChunkSize)
-	lvs.bufferedChunkSize = 0
-	lvs.withBufferedChildren = map[hash.Hash]uint64{}
+	// purge the cache. Chunks buffered since the collection started are values written while it ran, so they're kept.
+	lvs.decodedChunks.Purge()
 
 	return nil
 }
 
+// removeMissingValues removes the values of |hashes| which weren't found, which must be in |missing|.
+func removeMissingValues(hashes hash.HashSlice, vals ValueSlice, missing hash.HashSet) (hash.HashSlice, ValueSlice, error) {
+	found := 0
+	for i, v := range vals {
+		if v == nil {
+			if !missing.Has(hashes[i]) {
+				return nil, nil, errors.New("dangling reference found in chunk store")
+			}
+			continue
+		}
+
+		hashes[found], vals[found] = hashes[i], v
+		found++
+	}
+
+	return hashes[:found], vals[:found], nil
+}
+
 // Close closes the underlying ChunkStore
 func (lvs *ValueStore) Close() error {
 	return lvs.cs.Close()
@@ -140,6 +140,16 @@ func (c *SizeCache) Drop(key interface{}) {
 	}
 }
 
+// Purge removes every element from the cache. The expire callback isn't called for them.
+func (c *SizeCache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.totalSize = 0
+	c.lru.Init()
+	c.cache = map[interface{}]sizeCacheEntry{}
+}
+
 func (c *SizeCache) Size() uint64 {
 	return c.maxSize
 }
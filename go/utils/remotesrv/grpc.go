@@ -107,24 +107,9 @@ func (rs *RemoteChunkStore) GetDownloadLocations(ctx context.Context, req *remot
 		return nil, err
 	}
 
-	var locs []*remotesapi.DownloadLoc
-	for loc, hashToRange := range locations {
-		var ranges []*remotesapi.RangeChunk
-		for h, r := range hashToRange {
-			hCpy := h
-			ranges = append(ranges, &remotesapi.RangeChunk{Hash: hCpy[:], Offset: r.Offset, Length: r.Length})
-		}
-
-		url, err := rs.getDownloadUrl(logger, org, repoName, loc.String())
-		if err != nil {
-			log.Println("Failed to sign request", err)
-			return nil, err
-		}
-
-		logger("The URL is " + url)
-
-		getRange := &remotesapi.HttpGetRange{Url: url, Ranges: ranges}
-		locs = append(locs, &remotesapi.DownloadLoc{Location: &remotesapi.DownloadLoc_HttpGetRange{HttpGetRange: getRange}})
+	locs, err := rs.getDownloadLocs(logger, org, repoName, locations, remotestorage.ChunkCodecsFromProto(req.AcceptedCodecs))
+	if err != nil {
+		return nil, err
 	}
 
 	return &remotesapi.GetDownloadLocsResponse{Locs: locs}, nil
@@ -162,30 +147,59 @@ func (rs *RemoteChunkStore) StreamDownloadLocations(stream remotesapi.ChunkStore
 			return err
 		}
 
-		var locs []*remotesapi.DownloadLoc
-		for loc, hashToRange := range locations {
-			var ranges []*remotesapi.RangeChunk
-			for h, r := range hashToRange {
-				hCpy := h
-				ranges = append(ranges, &remotesapi.RangeChunk{Hash: hCpy[:], Offset: r.Offset, Length: r.Length})
+		locs, err := rs.getDownloadLocs(logger, org, repoName, locations, remotestorage.ChunkCodecsFromProto(req.AcceptedCodecs))
+		if err != nil {
+			return err
+		}
+
+		if err := stream.Send(&remotesapi.GetDownloadLocsResponse{Locs: locs}); err != nil {
+			return err
+		}
+	}
+}
+
+// getDownloadLocs returns the download locations of the chunks in |locations|, which maps table files to the ranges of
+// the chunks in them. Table files compressed with a codec the client doesn't accept are served from a transcoded copy.
+func (rs *RemoteChunkStore) getDownloadLocs(logger func(string), org, repoName string, locations map[hash.Hash]map[hash.Hash]nbs.Range, accepted []nbs.ChunkCodec) ([]*remotesapi.DownloadLoc, error) {
+	var locs []*remotesapi.DownloadLoc
+	for loc, hashToRange := range locations {
+		name, codec, err := servedTableFile(org, repoName, loc.String(), accepted)
+		if err != nil {
+			logger(fmt.Sprintf("failed to serve table file %s: %v", loc.String(), err))
+			return nil, status.Error(codes.Internal, "failed to serve table file "+loc.String())
+		}
+
+		if name != loc.String() {
+			hashes := make(hash.HashSet, len(hashToRange))
+			for h := range hashToRange {
+				hashes.Insert(h)
 			}
 
-			url, err := rs.getDownloadUrl(logger, org, repoName, loc.String())
+			hashToRange, err = servedChunkRanges(org, repoName, name, hashes)
 			if err != nil {
-				log.Println("Failed to sign request", err)
-				return err
+				return nil, err
 			}
+		}
 
-			logger("The URL is " + url)
-
-			getRange := &remotesapi.HttpGetRange{Url: url, Ranges: ranges}
-			locs = append(locs, &remotesapi.DownloadLoc{Location: &remotesapi.DownloadLoc_HttpGetRange{HttpGetRange: getRange}})
+		var ranges []*remotesapi.RangeChunk
+		for h, r := range hashToRange {
+			hCpy := h
+			ranges = append(ranges, &remotesapi.RangeChunk{Hash: hCpy[:], Offset: r.Offset, Length: r.Length})
 		}
 
-		if err := stream.Send(&remotesapi.GetDownloadLocsResponse{Locs: locs}); err != nil {
-			return err
+		url, err := rs.getDownloadUrl(logger, org, repoName, name)
+		if err != nil {
+			log.Println("Failed to sign request", err)
+			return nil, err
 		}
+
+		logger("The URL is " + url)
+
+		getRange := &remotesapi.HttpGetRange{Url: url, Ranges: ranges, Codec: remotestorage.ChunkCodecToProto(codec)}
+		locs = append(locs, &remotesapi.DownloadLoc{Location: &remotesapi.DownloadLoc_HttpGetRange{HttpGetRange: getRange}})
 	}
+
+	return locs, nil
 }
 
 func (rs *RemoteChunkStore) getDownloadUrl(logger func(string), org, repoName, fileId string) (string, error) {
@@ -361,9 +375,10 @@ func (rs *RemoteChunkStore) GetRepoMetadata(ctx context.Context, req *remotesapi
 	}
 
 	return &remotesapi.GetRepoMetadataResponse{
-		NbfVersion:  cs.Version(),
-		NbsVersion:  req.ClientRepoFormat.NbsVersion,
-		StorageSize: size,
+		NbfVersion:      cs.Version(),
+		NbsVersion:      req.ClientRepoFormat.NbsVersion,
+		StorageSize:     size,
+		SupportedCodecs: remotestorage.ChunkCodecsToProto(nbs.ChunkCodecs),
 	}, nil
 }
 
@@ -405,9 +420,16 @@ func (rs *RemoteChunkStore) ListTableFiles(ctx context.Context, req *remotesapi.
 }
 
 func getTableFileInfo(rs *RemoteChunkStore, logger func(string), tableList []nbs.TableFile, req *remotesapi.ListTableFilesRequest) ([]*remotesapi.TableFileInfo, error) {
+	accepted := remotestorage.ChunkCodecsFromProto(req.AcceptedCodecs)
 	appendixTableFileInfo := make([]*remotesapi.TableFileInfo, 0)
 	for _, t := range tableList {
-		url, err := rs.getDownloadUrl(logger, req.RepoId.Org, req.RepoId.RepoName, t.FileID())
+		name, codec, err := servedTableFile(req.RepoId.Org, req.RepoId.RepoName, t.FileID(), accepted)
+		if err != nil {
+			logger(fmt.Sprintf("failed to serve table file %s: %v", t.FileID(), err))
+			return nil, status.Error(codes.Internal, "failed to serve table file "+t.FileID())
+		}
+
+		url, err := rs.getDownloadUrl(logger, req.RepoId.Org, req.RepoId.RepoName, name)
 		if err != nil {
 			return nil, status.Error(codes.Internal, "failed to get download url for "+t.FileID())
 		}
@@ -416,6 +438,7 @@ func getTableFileInfo(rs *RemoteChunkStore, logger func(string), tableList []nbs
 			FileId:    t.FileID(),
 			NumChunks: uint32(t.NumChunks()),
 			Url:       url,
+			Codec:     remotestorage.ChunkCodecToProto(codec),
 		})
 	}
 	return appendixTableFileInfo, nil
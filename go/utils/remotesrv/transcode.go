@@ -0,0 +1,87 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/nbs"
+)
+
+// transcodeMu serializes the transcoding of table files, so each is only transcoded once.
+var transcodeMu = &sync.Mutex{}
+
+// tableFileCodec returns the codec of the table file |fileId| of a repo.
+func tableFileCodec(org, repo, fileId string) (nbs.ChunkCodec, error) {
+	f, err := os.Open(filepath.Join(org, repo, fileId))
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	return nbs.ReadTableFileCodec(f)
+}
+
+// servedTableFile returns the name of the file which serves the table file |fileId| of a repo to a client which accepts
+// |accepted| codecs, and the codec it's compressed with. If the client doesn't accept the codec of the table file, it's
+// served from a copy compressed with snappy, which all clients accept. Copies are named for the table file and the
+// codec, so they're never mistaken for table files of the store.
+func servedTableFile(org, repo, fileId string, accepted []nbs.ChunkCodec) (string, nbs.ChunkCodec, error) {
+	codec, err := tableFileCodec(org, repo, fileId)
+	if err != nil {
+		return "", 0, err
+	}
+
+	if nbs.ChunkCodecIn(codec, accepted) == codec {
+		return fileId, codec, nil
+	}
+
+	name := fileId + "." + nbs.SnappyCodec.String()
+	path := filepath.Join(org, repo, name)
+
+	transcodeMu.Lock()
+	defer transcodeMu.Unlock()
+
+	if _, err := os.Stat(path); err == nil {
+		return name, nbs.SnappyCodec, nil
+	}
+
+	f, err := os.Open(filepath.Join(org, repo, fileId))
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+
+	err = nbs.TranscodeTableFile(f, nbs.SnappyCodec, path)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return name, nbs.SnappyCodec, nil
+}
+
+// servedChunkRanges returns the ranges of the chunks of |hashes| in the file |name|, which serves a table file.
+func servedChunkRanges(org, repo, name string, hashes hash.HashSet) (map[hash.Hash]nbs.Range, error) {
+	f, err := os.Open(filepath.Join(org, repo, name))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return nbs.ChunkRanges(f, hashes)
+}
@@ -0,0 +1,107 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    dolt sql <<SQL
+CREATE TABLE test (
+  pk int NOT NULL,
+  c1 varchar(20),
+  c2 int,
+  PRIMARY KEY (pk)
+);
+INSERT INTO test VALUES (1, 'a', 1), (2, 'b', 2), (3, NULL, 3);
+CREATE TABLE to_drop (id int PRIMARY KEY);
+SQL
+    dolt add .
+    dolt commit -m "initial tables"
+    dolt branch other
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "apply: apply a patch to another branch" {
+    dolt sql -q "alter table test add column c3 float"
+    dolt sql -q "update test set c1 = 'z' where pk = 1"
+    dolt sql -q "delete from test where pk = 2"
+    dolt sql -q "insert into test values (4, 'd', 4, 1.5)"
+    dolt sql -q "drop table to_drop"
+    dolt sql -q "create table added (x int primary key, y text)"
+    dolt sql -q "insert into added values (1, 'one')"
+    dolt diff -r patch > changes.patch
+    dolt add .
+    dolt commit -m "changes"
+
+    dolt checkout other
+    run dolt apply changes.patch
+    [ "$status" -eq 0 ]
+
+    run dolt diff master -r sql
+    [ "$status" -eq 0 ]
+    [ "$output" = "" ]
+
+    run dolt sql -q "select * from test order by pk" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "1,z,1," ]
+    [ "${lines[2]}" = "3,,3," ]
+    [ "${lines[3]}" = "4,d,4,1.5" ]
+}
+
+@test "apply: removed rows are matched with the schema from before the patch's schema changes" {
+    dolt sql -q "alter table test drop column c2"
+    dolt sql -q "update test set c1 = 'z' where pk = 1"
+    dolt sql -q "delete from test where pk = 2"
+    dolt diff -r patch > changes.patch
+    dolt add .
+    dolt commit -m "changes"
+
+    dolt checkout other
+    run dolt apply changes.patch
+    [ "$status" -eq 0 ]
+
+    run dolt sql -q "select * from test order by pk" -r csv
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 3 ]
+    [ "${lines[0]}" = "pk,c1" ]
+    [ "${lines[1]}" = "1,z" ]
+    [ "${lines[2]}" = "3," ]
+}
+
+@test "apply: reads the patch from stdin" {
+    dolt sql -q "insert into test values (4, 'd', 4)"
+    dolt diff -r patch > changes.patch
+    dolt checkout test
+
+    dolt apply < changes.patch
+    run dolt sql -q "select * from test where pk = 4" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "4,d,4" ]
+}
+
+@test "apply: patch does not apply to changed rows" {
+    dolt sql -q "update test set c1 = 'z' where pk = 1"
+    dolt sql -q "insert into test values (4, 'd', 4)"
+    dolt diff -r patch > changes.patch
+    dolt checkout test
+
+    dolt sql -q "update test set c2 = 10 where pk = 1"
+    run dolt apply changes.patch
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "patch does not apply" ]] || false
+
+    # nothing was applied
+    run dolt sql -q "select count(*) from test where pk = 4" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "0" ]
+}
+
+@test "apply: invalid patch" {
+    echo "not a patch" > bad.patch
+    run dolt apply bad.patch
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "invalid patch" ]] || false
+}
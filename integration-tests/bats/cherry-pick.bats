@@ -0,0 +1,99 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    dolt sql -q "CREATE TABLE test (pk int primary key, c int);"
+    dolt add -A && dolt commit -m "created table"
+
+    dolt checkout -b feature
+    dolt sql -q "INSERT INTO test VALUES (1, 1);"
+    dolt commit -am "inserted 1"
+    dolt sql -q "INSERT INTO test VALUES (2, 2);"
+    dolt commit -am "inserted 2"
+    dolt checkout master
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "cherry-pick: applies the changes of a single commit" {
+    run dolt cherry-pick feature
+    [ "$status" -eq 0 ]
+
+    run dolt sql -q "SELECT * FROM test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2,2" ]] || false
+    [[ ! "$output" =~ "1,1" ]] || false
+
+    run dolt log -n 1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "inserted 2" ]] || false
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+}
+
+@test "cherry-pick: requires a clean working set" {
+    dolt sql -q "INSERT INTO test VALUES (3, 3);"
+
+    run dolt cherry-pick feature
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "local changes" ]] || false
+}
+
+@test "cherry-pick: conflicts are recorded in the working set" {
+    dolt sql -q "INSERT INTO test VALUES (2, 20);"
+    dolt commit -am "inserted a different 2"
+
+    run dolt cherry-pick feature
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "CONFLICT" ]] || false
+
+    run dolt sql -q "SELECT * FROM dolt_conflicts" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "test,1" ]] || false
+
+    run dolt log -n 1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "inserted a different 2" ]] || false
+}
+
+@test "cherry-pick: merge commits are not supported" {
+    dolt sql -q "INSERT INTO test VALUES (3, 3);"
+    dolt commit -am "inserted 3"
+    dolt merge feature
+    dolt commit -m "merged feature"
+
+    dolt checkout -b other HEAD~1
+    run dolt cherry-pick master
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "merge commit" ]] || false
+}
+
+@test "cherry-pick: DOLT_CHERRY_PICK applies the changes of a single commit" {
+    run dolt sql -q "SELECT DOLT_CHERRY_PICK('feature~1');"
+    [ "$status" -eq 0 ]
+
+    run dolt sql -q "SELECT * FROM test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,1" ]] || false
+    [[ ! "$output" =~ "2,2" ]] || false
+
+    run dolt log -n 1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "inserted 1" ]] || false
+}
+
+@test "cherry-pick: DOLT_CHERRY_PICK requires a clean working set" {
+    run dolt sql <<SQL
+INSERT INTO test VALUES (3, 3);
+SELECT DOLT_CHERRY_PICK('feature');
+SQL
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "uncommitted changes" ]] || false
+}
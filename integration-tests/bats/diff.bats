@@ -489,3 +489,135 @@ SQL
     [[ "$output" =~ "cv1" ]] || false
     [ $status -eq 0 ]
 }
+
+@test "diff: json output" {
+    dolt add .
+    dolt commit -m table
+    dolt sql -q 'insert into test values (0,0,0,0,0,0), (1,1,1,1,1,1)'
+    dolt add .
+    dolt commit -m rows
+    dolt sql -q 'alter table test add column c6 bigint'
+    dolt sql -q 'update test set c1 = 10, c6 = 6 where pk = 0'
+    dolt sql -q 'delete from test where pk = 1'
+
+    run dolt diff -r json
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '"name":"test","from_name":"test","to_name":"test","change_type":"modified"' ]] || false
+    [[ "$output" =~ '{"change_type":"added","from":null,"to":{"name":"c6","type":"BIGINT","primary_key":false,"nullable":true' ]] || false
+    [[ "$output" =~ '{"change_type":"modified","from":{"c1":0,"c2":0,"c3":0,"c4":0,"c5":0,"pk":0},"to":{"c1":10,"c2":0,"c3":0,"c4":0,"c5":0,"c6":6,"pk":0}}' ]] || false
+    [[ "$output" =~ '{"change_type":"removed","from":{"c1":1,"c2":1,"c3":1,"c4":1,"c5":1,"pk":1},"to":null}' ]] || false
+
+    run dolt diff -r json --schema
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '"schema_diff"' ]] || false
+    ! [[ "$output" =~ '"data_diff"' ]] || false
+
+    run dolt diff -r json --summary
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "--summary cannot be combined" ]] || false
+}
+
+@test "diff: json output of added and dropped tables" {
+    dolt add .
+    dolt commit -m table
+    dolt sql -q 'create table new_table (id int primary key, v text)'
+    dolt sql -q "insert into new_table values (1, 'one')"
+    dolt sql -q 'drop table test'
+
+    run dolt diff -r json
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '"name":"new_table","from_name":"","to_name":"new_table","change_type":"added"' ]] || false
+    [[ "$output" =~ '"data_diff":[{"change_type":"added","from":null,"to":{"id":1,"v":"one"}}]' ]] || false
+    [[ "$output" =~ '"name":"test","from_name":"test","to_name":"","change_type":"removed"' ]] || false
+}
+
+@test "diff: patch output" {
+    dolt add .
+    dolt commit -m table
+    dolt sql -q 'insert into test values (0,0,0,0,0,0)'
+    dolt sql -q 'alter table test add column c6 bigint'
+
+    run dolt diff -r patch
+    [ "$status" -eq 0 ]
+    [ "${lines[0]}" = "diff --dolt a/test b/test" ]
+    [ "${lines[1]}" = "--- a/test" ]
+    [ "${lines[2]}" = "+++ b/test" ]
+    [ "${lines[3]}" = '!ALTER TABLE `test` ADD `c6` BIGINT;' ]
+    [ "${lines[4]}" = '+{"c1":"0","c2":"0","c3":"0","c4":"0","c5":"0","c6":null,"pk":"0"}' ]
+}
+
+@test "diff: three dot diff from the merge base" {
+    dolt add .
+    dolt commit -m table
+    dolt sql -q 'insert into test values (0,0,0,0,0,0), (1,1,1,1,1,1)'
+    dolt commit -am "base rows"
+    dolt checkout -b feature
+    dolt sql -q 'insert into test values (2,2,2,2,2,2)'
+    dolt sql -q 'update test set c1 = 10 where pk = 0'
+    dolt commit -am "feature changes"
+    dolt checkout master
+    dolt sql -q 'delete from test where pk = 1'
+    dolt commit -am "master changes"
+
+    run dolt diff master...feature
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "|  +  | 2  | 2  | 2  | 2  | 2  | 2  |" ]] || false
+    [[ "$output" =~ "|  >  | 0  | 10 | 0  | 0  | 0  | 0  |" ]] || false
+    # the row deleted on master isn't part of the diff
+    ! [[ "$output" =~ "|  +  | 1  |" ]] || false
+    ! [[ "$output" =~ "|  -  | 1  |" ]] || false
+
+    run dolt diff master feature
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "|  +  | 1  | 1  | 1  | 1  | 1  | 1  |" ]] || false
+
+    # an omitted commit defaults to HEAD
+    run dolt diff ...feature --stat
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "test | 1 row added, 1 row modified, 0 rows deleted" ]] || false
+
+    run dolt diff feature... --stat
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "test | 0 rows added, 0 rows modified, 1 row deleted" ]] || false
+
+    run dolt diff master...doesnotexist
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "unable to resolve commit doesnotexist" ]] || false
+}
+
+@test "diff: --name-only and --stat" {
+    dolt add .
+    dolt commit -m table
+    dolt sql -q 'insert into test values (0,0,0,0,0,0), (1,1,1,1,1,1)'
+    dolt sql -q 'create table other (id int primary key)'
+    dolt commit -am "rows"
+    dolt sql -q 'insert into test values (2,2,2,2,2,2)'
+    dolt sql -q 'update test set c1 = 10 where pk = 0'
+    dolt sql -q 'delete from test where pk = 1'
+    dolt sql -q 'create table added (id int primary key)'
+    dolt sql -q 'insert into added values (1), (2)'
+
+    run dolt diff --name-only
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [ "${lines[0]}" = "added" ]
+    [ "${lines[1]}" = "test" ]
+
+    run dolt diff --stat
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 3 ]
+    [[ "${lines[0]}" =~ "added | 2 rows added, 0 rows modified, 0 rows deleted" ]] || false
+    [[ "${lines[1]}" =~ "test  | 1 row added, 1 row modified, 1 row deleted" ]] || false
+    [ "${lines[2]}" = "2 tables changed, 3 rows added, 1 row modified, 1 row deleted" ]
+
+    run dolt diff --stat test
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+
+    run dolt diff --name-only --stat
+    [ "$status" -ne 0 ]
+    run dolt diff --stat --summary
+    [ "$status" -ne 0 ]
+    run dolt diff --name-only -r sql
+    [ "$status" -ne 0 ]
+}
@@ -0,0 +1,59 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    dolt sql -q "CREATE TABLE test (pk int primary key, c1 varchar(20));"
+    dolt sql -q "INSERT INTO test VALUES (0, 'zero'), (1, 'one');"
+    dolt add -A && dolt commit -m "commit A"
+    dolt branch other
+    dolt sql -q "INSERT INTO test VALUES (2, 'two');"
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "fsck: intact repository" {
+    run dolt fsck
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "No problems found" ]] || false
+}
+
+@test "fsck: corrupt table file" {
+    for f in .dolt/noms/*; do
+        name=$(basename "$f")
+        if [ "$name" != "manifest" ] && [ "$name" != "LOCK" ]; then
+            printf '\x00\x00\x00\x00' | dd of="$f" bs=1 seek=2 conv=notrunc
+        fi
+    done
+
+    run dolt fsck
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "checksum error" ]] || false
+    [[ "$output" =~ "affects" ]] || false
+    [[ "$output" =~ "refs/heads/master" ]] || false
+}
+
+@test "fsck: missing table file" {
+    for f in .dolt/noms/*; do
+        name=$(basename "$f")
+        if [ "$name" != "manifest" ] && [ "$name" != "LOCK" ]; then
+            rm "$f"
+            break
+        fi
+    done
+
+    run dolt fsck
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "table file listed in the manifest doesn't exist" ]] || false
+}
+
+@test "fsck: not a repository" {
+    mkdir not_a_repo
+    cd not_a_repo
+    run dolt fsck
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "not a valid dolt repository" ]] || false
+}
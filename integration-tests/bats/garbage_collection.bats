@@ -67,6 +67,25 @@ SQL
     [ "$status" -eq "0" ]
 }
 
+@test "garbage_collection: dolt_gc() in sql" {
+    dolt sql <<SQL
+CREATE TABLE test (pk int PRIMARY KEY);
+INSERT INTO test VALUES (1),(2),(3);
+SELECT DOLT_GC();
+INSERT INTO test VALUES (4),(5);
+SQL
+
+    run dolt sql -q 'select dolt_gc()' -r csv
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "0" ]] || false
+
+    run dolt sql -q 'select count(*) from test' -r csv
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "5" ]] || false
+    run dolt status
+    [ "$status" -eq "0" ]
+}
+
 @test "garbage_collection: clone a remote" {
     dolt sql <<SQL
 CREATE TABLE test (pk int PRIMARY KEY);
@@ -29,10 +29,13 @@ else:
 
 from pytest import DoltConnection, csv_to_row_maps
 
+user = os.environ.get('SQL_USER', 'dolt')
+password = os.environ.get('SQL_PASSWORD', '')
+
 if not database:
-    dc = DoltConnection(port=int(port_str), database=None, user='dolt', auto_commit=auto_commit)
+    dc = DoltConnection(port=int(port_str), database=None, user=user, password=password, auto_commit=auto_commit)
 else:
-    dc = DoltConnection(port=int(port_str), database=database, user='dolt', auto_commit=auto_commit)
+    dc = DoltConnection(port=int(port_str), database=database, user=user, password=password, auto_commit=auto_commit)
 
 dc.connect()
 
@@ -308,11 +308,15 @@ SQL
 
     run dolt merge master
     [ $status -eq 0 ]
+    [[ ! "$output" =~ "CONFLICT" ]] || false
     run dolt sql -q "SELECT * FROM keyless WHERE c0 > 6 ORDER BY c0;" -r csv
     [ $status -eq 0 ]
     [[ "${lines[1]}" = "7,7" ]] || false
-    [[ "${lines[2]}" = "8,8" ]] || false
-    [[ "${lines[3]}" = "9,9" ]] || false
+    [[ "${lines[2]}" = "7,7" ]] || false
+    [[ "${lines[3]}" = "8,8" ]] || false
+    [[ "${lines[4]}" = "8,8" ]] || false
+    [[ "${lines[5]}" = "9,9" ]] || false
+    [[ "${lines[6]}" = "9,9" ]] || false
 }
 
 @test "keyless: diff deletes from two branches" {
@@ -412,14 +416,12 @@ SQL
 
     run dolt merge right
     [ $status -eq 0 ]
-    [[ "$output" =~ "CONFLICT" ]] || false
+    [[ ! "$output" =~ "CONFLICT" ]] || false
 
-    run dolt conflicts resolve --ours dupe
-    [ $status -eq 0 ]
-    dolt commit -am "resolved"
+    dolt commit -am "merged"
     run dolt sql -q "select sum(c0), sum(c1) from dupe" -r csv
     [ $status -eq 0 ]
-    [[ "${lines[1]}" = "6,6" ]] || false
+    [[ "${lines[1]}" = "4,4" ]] || false
 }
 
 @test "keyless: diff duplicate updates" {
@@ -459,14 +461,12 @@ SQL
 
     run dolt merge right
     [ $status -eq 0 ]
-    [[ "$output" =~ "CONFLICT" ]] || false
+    [[ ! "$output" =~ "CONFLICT" ]] || false
 
-    run dolt conflicts resolve --theirs dupe
-    [ $status -eq 0 ]
-    dolt commit -am "resolved"
+    dolt commit -am "merged"
     run dolt sql -q "select sum(c0), sum(c1) from dupe" -r csv
     [ $status -eq 0 ]
-    [[ "${lines[1]}" = "10,12" ]] || false
+    [[ "${lines[1]}" = "10,16" ]] || false
 }
 
 @test "keyless: sql diff" {
@@ -617,26 +617,22 @@ CSV
     dolt sql -q "UPDATE keyless SET c1 = c1+20 WHERE c0 > 6"
     dolt commit -am "updated on other"
 
+    # updates become delete+add, so both sides delete
+    # the original rows and we get both sets of adds
     run dolt merge master
     [ $status -eq 0 ]
-    [[ "$output" =~ "CONFLICT" ]] || false
-
-    dolt conflicts resolve --ours keyless
-    run dolt conflicts resolve --ours keyless
-    [ $status -eq 0 ]
-    dolt commit -am "resolved"
+    [[ ! "$output" =~ "CONFLICT" ]] || false
 
-    skip "incorrect resolve"
-    # updates become delete+add
-    # conflict is generated for delete
-    # the corresponding add does not conflict
-    # on resolve, we get both sets of adds
-
-    run dolt sql -q "select * from keyless where c0 > 6 order by c0" -r csv
+    dolt commit -am "merged"
+    run dolt sql -q "select * from keyless where c0 > 6 order by c0, c1" -r csv
     [ $status -eq 0 ]
     [[ "${lines[1]}" = "7,17" ]] || false
-    [[ "${lines[1]}" = "8,18" ]] || false
-    [[ "${lines[1]}" = "9,19" ]] || false
+    [[ "${lines[2]}" = "7,27" ]] || false
+    [[ "${lines[3]}" = "8,18" ]] || false
+    [[ "${lines[4]}" = "8,28" ]] || false
+    [[ "${lines[5]}" = "9,19" ]] || false
+    [[ "${lines[6]}" = "9,29" ]] || false
+    [ "${#lines[@]}" -eq 7 ]
 }
 
 @test "keyless: diff branches with reordered mutation history" {
@@ -666,14 +662,15 @@ CSV
 
     run dolt merge master
     [ $status -eq 0 ]
-     run dolt sql -q "SELECT count(*) FROM keyless WHERE c0 > 6;" -r csv
+    [[ ! "$output" =~ "CONFLICT" ]] || false
+    run dolt sql -q "SELECT count(*) FROM keyless WHERE c0 > 6;" -r csv
     [ $status -eq 0 ]
-    [[ "${lines[1]}" = "3" ]] || false
+    [[ "${lines[1]}" = "6" ]] || false
     run dolt sql -q "SELECT * FROM keyless WHERE c0 > 6 ORDER BY c0;" -r csv
     [ $status -eq 0 ]
     [[ "${lines[1]}" = "7,7" ]] || false
-    [[ "${lines[2]}" = "8,8" ]] || false
-    [[ "${lines[3]}" = "9,9" ]] || false
+    [[ "${lines[3]}" = "8,8" ]] || false
+    [[ "${lines[5]}" = "9,9" ]] || false
 }
 
 @test "keyless: diff branches with convergent mutation history" {
@@ -712,16 +709,17 @@ SQL
 
     run dolt merge master
     [ $status -eq 0 ]
-    [[ "$output" =~ "CONFLICT" ]] || false
+    [[ ! "$output" =~ "CONFLICT" ]] || false
 
-    run dolt conflicts resolve --theirs keyless
-    [ $status -eq 0 ]
-    dolt commit -am "resolved"
+    dolt commit -am "merged"
     run dolt sql -q "select * from keyless where c0 > 6 order by c0" -r csv
     [ $status -eq 0 ]
     [[ "${lines[1]}" = "7,7" ]] || false
-    [[ "${lines[2]}" = "8,8" ]] || false
-    [[ "${lines[3]}" = "9,9" ]] || false
+    [[ "${lines[2]}" = "7,7" ]] || false
+    [[ "${lines[3]}" = "8,8" ]] || false
+    [[ "${lines[4]}" = "8,8" ]] || false
+    [[ "${lines[5]}" = "9,9" ]] || false
+    [[ "${lines[6]}" = "9,9" ]] || false
 }
 
 @test "keyless: diff branches with offset mutation history" {
@@ -752,17 +750,14 @@ SQL
 
     run dolt merge master
     [ $status -eq 0 ]
-    [[ "$output" =~ "CONFLICT" ]] || false
+    [[ ! "$output" =~ "CONFLICT" ]] || false
 
-    run dolt conflicts resolve --ours keyless
-    [ $status -eq 0 ]
-    dolt commit -am "resolved"
-    run dolt sql -q "select * from keyless where c0 > 6 order by c0" -r csv
+    dolt commit -am "merged"
+    run dolt sql -q "select c0, count(*) from keyless where c0 > 6 group by c0 order by c0" -r csv
     [ $status -eq 0 ]
-    [[ "${lines[1]}" = "7,7" ]] || false
-    [[ "${lines[2]}" = "7,7" ]] || false
-    [[ "${lines[3]}" = "8,8" ]] || false
-    [[ "${lines[4]}" = "9,9" ]] || false
+    [[ "${lines[1]}" = "7,3" ]] || false
+    [[ "${lines[2]}" = "8,2" ]] || false
+    [[ "${lines[3]}" = "9,2" ]] || false
 }
 
 @test "keyless: diff delete+add against working" {
@@ -808,15 +803,39 @@ SQL
 
     run dolt merge right
     [ $status -eq 0 ]
-    [[ "$output" =~ "CONFLICT" ]] || false
+    [[ ! "$output" =~ "CONFLICT" ]] || false
 
-    run dolt conflicts resolve --theirs keyless
-    [ $status -eq 0 ]
-    dolt commit -am "resolved"
+    dolt commit -am "merged"
     run dolt sql -q "select * from keyless order by c0" -r csv
     [ $status -eq 0 ]
     [[ "${lines[1]}" = "0,0" ]] || false
     [[ "${lines[2]}" = "1,1" ]] || false
     [[ "${lines[3]}" = "1,1" ]] || false
-    [ "${#lines[@]}" -eq 4 ]
+    [[ "${lines[4]}" = "2,2" ]] || false
+    [ "${#lines[@]}" -eq 5 ]
+}
+
+@test "keyless: merge deletes of more rows than the ancestor had" {
+    make_dupe_table
+
+    dolt branch left
+    dolt checkout -b right
+
+    dolt sql -q "DELETE FROM dupe LIMIT 4;"
+    dolt commit -am "deleted four rows on right"
+
+    dolt checkout left
+    dolt sql -q "DELETE FROM dupe LIMIT 8;"
+    dolt commit -am "deleted eight rows on left"
+
+    run dolt merge right
+    [ $status -eq 0 ]
+    [[ "$output" =~ "CONFLICT" ]] || false
+
+    run dolt conflicts resolve --ours dupe
+    [ $status -eq 0 ]
+    dolt commit -am "resolved"
+    run dolt sql -q "select count(*) from dupe" -r csv
+    [ $status -eq 0 ]
+    [[ "${lines[1]}" = "2" ]] || false
 }
@@ -89,3 +89,24 @@ teardown() {
     [ "$status" -eq 0 ]
     [ "${lines[1]}" = "true" ]
 }
+
+@test "merge-base: --all lists every best common ancestor" {
+    run dolt merge-base --all master two
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 1 ]
+    [ "$output" = "$(dolt merge-base master two)" ]
+
+    # merge master and two into each other, so that both are best common ancestors of the results
+    dolt checkout -b merged_two master
+    dolt merge two
+    dolt commit -m "merged two into master"
+    dolt checkout -b merged_master two
+    dolt merge master
+    dolt commit -m "merged master into two"
+
+    run dolt merge-base --all merged_two merged_master
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [[ "$output" =~ "$(dolt merge-base master master)" ]] || false
+    [[ "$output" =~ "$(dolt merge-base two two)" ]] || false
+}
@@ -407,3 +407,232 @@ SQL
     skip "merge fails on unique index violation, should log conflict"
     dolt merge other
 }
+
+@test "merge: conflicts resolved by merge strategies" {
+    dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY, c0 int);"
+    dolt sql -q "INSERT INTO test VALUES (1,1);"
+    dolt sql -q "INSERT INTO dolt_merge_strategies VALUES ('test','c0','max');"
+    dolt add -A && dolt commit -am "setup"
+
+    dolt checkout -b other
+    dolt sql -q "UPDATE test SET c0 = 11 WHERE pk = 1;"
+    dolt commit -am "changed row"
+
+    dolt checkout master
+    dolt sql -q "UPDATE test SET c0 = 2 WHERE pk = 1;"
+    dolt commit -am "changed row"
+
+    run dolt merge other
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "CONFLICT" ]] || false
+
+    run dolt sql -q "SELECT * FROM test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,11" ]] || false
+}
+
+@test "merge: octopus merge records every head as a parent" {
+    dolt branch other
+    dolt branch another
+    dolt sql -q "INSERT INTO test1 VALUES (0,0,0);"
+    dolt commit -am "added row on master"
+
+    dolt checkout other
+    dolt sql -q "INSERT INTO test1 VALUES (1,1,1);"
+    dolt commit -am "added row on other"
+
+    dolt checkout another
+    dolt sql -q "INSERT INTO test2 VALUES (2,2,2);"
+    dolt commit -am "added row on another"
+
+    dolt checkout master
+    run dolt merge other another
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "CONFLICT" ]] || false
+    dolt commit -m "octopus merge"
+
+    run dolt sql -q "SELECT count(*) FROM dolt_commit_ancestors WHERE commit_hash = hashof('HEAD')" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "3" ]] || false
+    run dolt sql -q "SELECT count(*) FROM test1" -r csv
+    [[ "$output" =~ "2" ]] || false
+}
+
+@test "merge: octopus merge fails on conflicts" {
+    dolt branch other
+    dolt branch another
+
+    dolt checkout other
+    dolt sql -q "INSERT INTO test1 VALUES (1,1,1);"
+    dolt commit -am "added row on other"
+
+    dolt checkout another
+    dolt sql -q "INSERT INTO test1 VALUES (1,2,2);"
+    dolt commit -am "added row on another"
+
+    dolt checkout master
+    dolt sql -q "INSERT INTO test2 VALUES (0,0,0);"
+    dolt commit -am "added row on master"
+
+    run dolt merge other another
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "octopus" ]] || false
+
+    run dolt status
+    [[ "$output" =~ "nothing to commit" ]] || false
+}
+
+@test "merge: column changes on both branches are merged" {
+    dolt sql -q "INSERT INTO test1 VALUES (1,1,1),(2,2,2);"
+    dolt commit -am "added rows"
+    dolt branch other
+
+    dolt sql -q "ALTER TABLE test1 MODIFY COLUMN c1 bigint COMMENT 'widened';"
+    dolt sql -q "UPDATE test1 SET c1 = 10 WHERE pk = 1;"
+    dolt commit -am "widened column on master"
+
+    dolt checkout other
+    dolt sql -q "ALTER TABLE test1 RENAME COLUMN c1 TO c11;"
+    dolt sql -q "ALTER TABLE test1 MODIFY COLUMN c11 int DEFAULT 5;"
+    dolt sql -q "UPDATE test1 SET c11 = 20 WHERE pk = 2;"
+    dolt commit -am "renamed column on other"
+
+    dolt checkout master
+    run dolt merge other
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "CONFLICT" ]] || false
+
+    run dolt schema show test1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "\`c11\` bigint DEFAULT 5 COMMENT 'widened'" ]] || false
+
+    run dolt sql -q "SELECT pk, c11 FROM test1 ORDER BY pk" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,10" ]] || false
+    [[ "$output" =~ "2,20" ]] || false
+}
+
+@test "merge: conflicting column changes are schema conflicts" {
+    dolt branch other
+    dolt sql -q "ALTER TABLE test1 MODIFY COLUMN c1 int DEFAULT 1;"
+    dolt commit -am "changed default on master"
+
+    dolt checkout other
+    dolt sql -q "ALTER TABLE test1 MODIFY COLUMN c1 int DEFAULT 2;"
+    dolt commit -am "changed default on other"
+
+    dolt checkout master
+    run dolt merge other
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "CONFLICT (schema): Merge conflict in test1" ]] || false
+
+    run dolt sql -q "SELECT table_name, description FROM dolt_schema_conflicts" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "test1,different column definitions for our column c1 and their column c1" ]] || false
+
+    run dolt status
+    [[ "$output" =~ "schema conflict: test1" ]] || false
+
+    run dolt commit -am "merged"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "unresolved schema conflicts" ]] || false
+
+    dolt conflicts resolve --schema --theirs test1
+    run dolt sql -q "SELECT COUNT(*) FROM dolt_schema_conflicts" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "0" ]] || false
+
+    run dolt sql -q "SHOW CREATE TABLE test1"
+    [[ "$output" =~ "DEFAULT 2" ]] || false
+    dolt commit -am "merged"
+}
+
+@test "merge: schema conflicts are resolved by deleting from dolt_schema_conflicts" {
+    dolt branch other
+    dolt sql -q "ALTER TABLE test1 MODIFY COLUMN c1 int DEFAULT 1;"
+    dolt commit -am "changed default on master"
+
+    dolt checkout other
+    dolt sql -q "ALTER TABLE test1 MODIFY COLUMN c1 int DEFAULT 2;"
+    dolt commit -am "changed default on other"
+
+    dolt checkout master
+    run dolt sql -q "SELECT DOLT_MERGE('other')"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "merge has unresolved schema conflicts" ]] || false
+
+    dolt sql -q "DELETE FROM dolt_schema_conflicts WHERE table_name = 'test1'"
+    run dolt sql -q "SHOW CREATE TABLE test1"
+    [[ "$output" =~ "DEFAULT 1" ]] || false
+    dolt commit -am "merged"
+}
+
+@test "merge: squash merge with schema conflicts fails" {
+    dolt branch other
+    dolt sql -q "ALTER TABLE test1 MODIFY COLUMN c1 int DEFAULT 1;"
+    dolt commit -am "changed default on master"
+
+    dolt checkout other
+    dolt sql -q "ALTER TABLE test1 MODIFY COLUMN c1 int DEFAULT 2;"
+    dolt commit -am "changed default on other"
+
+    dolt checkout master
+    run dolt merge --squash other
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "cannot squash a merge with schema conflicts in tables: test1" ]] || false
+
+    run dolt status
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+    [[ ! "$output" =~ "schema conflict" ]] || false
+
+    run dolt sql -q "SELECT DOLT_MERGE('--squash', 'other')"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "cannot squash a merge with schema conflicts in tables: test1" ]] || false
+
+    run dolt status
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+
+    dolt merge other
+    run dolt sql -q "SELECT table_name FROM dolt_schema_conflicts" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "test1" ]] || false
+}
+
+@test "merge: criss-cross merge bases with schema conflicts fall back to a single merge base" {
+    dolt sql -q "INSERT INTO test1 VALUES (1,1,1),(2,2,2);"
+    dolt commit -am "added rows"
+    dolt branch other
+    dolt sql -q "ALTER TABLE test1 MODIFY COLUMN c1 int DEFAULT 1;"
+    dolt commit -am "changed default on master"
+
+    dolt checkout other
+    dolt sql -q "ALTER TABLE test1 MODIFY COLUMN c1 int DEFAULT 2;"
+    dolt commit -am "changed default on other"
+
+    # merge each branch into the other, so that both are best common ancestors of the results
+    dolt checkout -b merged_master
+    dolt merge master
+    dolt conflicts resolve --schema --theirs test1
+    dolt commit -am "merged master into other"
+    dolt sql -q "UPDATE test1 SET c2 = 20 WHERE pk = 2;"
+    dolt commit -am "changed row on merged_master"
+
+    dolt checkout master
+    dolt merge other
+    dolt sql -q "DELETE FROM dolt_schema_conflicts WHERE table_name = 'test1'"
+    dolt commit -am "merged other into master"
+    dolt sql -q "UPDATE test1 SET c2 = 10 WHERE pk = 1;"
+    dolt commit -am "changed row on master"
+
+    run dolt merge-base --all master merged_master
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+
+    run dolt merge merged_master
+    [ "$status" -eq 0 ]
+
+    run dolt sql -q "SELECT * FROM test1 ORDER BY pk" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "1,1,10" ]
+    [ "${lines[2]}" = "2,2,20" ]
+}
@@ -0,0 +1,224 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    dolt sql -q "CREATE TABLE test (pk int primary key, c int);"
+    dolt add -A && dolt commit -m "created table"
+
+    dolt checkout -b feature
+    dolt sql -q "INSERT INTO test VALUES (1, 1);"
+    dolt commit -am "inserted 1"
+    dolt sql -q "INSERT INTO test VALUES (2, 2);"
+    dolt commit -am "inserted 2"
+    dolt sql -q "INSERT INTO test VALUES (3, 3);"
+    dolt commit -am "inserted 3"
+
+    dolt checkout master
+    dolt sql -q "INSERT INTO test VALUES (10, 10);"
+    dolt commit -am "inserted 10"
+    dolt checkout feature
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "rebase: replays the branch's commits onto upstream" {
+    run dolt rebase master
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Successfully rebased" ]] || false
+
+    run dolt sql -q "SELECT COUNT(*) FROM test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "4" ]] || false
+
+    run dolt log
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "inserted 3" ]] || false
+    [[ "$output" =~ "inserted 10" ]] || false
+    [[ ! "$output" =~ "Merge" ]] || false
+
+    run dolt log -n 1 HEAD~3
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "inserted 10" ]] || false
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+}
+
+@test "rebase: up to date branches are left alone" {
+    dolt rebase master
+    run dolt rebase master
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "up to date" ]] || false
+}
+
+@test "rebase: requires a clean working set" {
+    dolt sql -q "INSERT INTO test VALUES (4, 4);"
+
+    run dolt rebase master
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "local changes" ]] || false
+}
+
+@test "rebase: stops on conflicts and continues" {
+    dolt checkout master
+    dolt sql -q "INSERT INTO test VALUES (2, 20);"
+    dolt commit -am "inserted a different 2"
+    dolt checkout feature
+
+    run dolt rebase master
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "CONFLICT" ]] || false
+    [[ "$output" =~ "dolt rebase --continue" ]] || false
+
+    run dolt rebase master
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "already in progress" ]] || false
+
+    run dolt rebase --continue
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "unresolved conflicts" ]] || false
+
+    dolt conflicts resolve --theirs test
+    dolt add test
+    run dolt rebase --continue
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Successfully rebased" ]] || false
+
+    run dolt sql -q "SELECT * FROM test WHERE pk = 2" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2,2" ]] || false
+
+    run dolt log -n 1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "inserted 3" ]] || false
+}
+
+@test "rebase: abort restores the branch" {
+    dolt checkout master
+    dolt sql -q "INSERT INTO test VALUES (2, 20);"
+    dolt commit -am "inserted a different 2"
+    dolt checkout feature
+    head=$(dolt log -n 1 | head -n 1)
+
+    run dolt rebase master
+    [ "$status" -eq 1 ]
+
+    run dolt rebase --abort
+    [ "$status" -eq 0 ]
+
+    run dolt log -n 1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "$head" ]] || false
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+
+    run dolt rebase --abort
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "no rebase in progress" ]] || false
+}
+
+@test "rebase: todo list can pick, squash, drop and reword" {
+    cat > todo.txt <<TODO
+pick HEAD~2
+squash HEAD~1
+drop HEAD
+TODO
+    run dolt rebase --todo todo.txt master
+    [ "$status" -eq 0 ]
+
+    run dolt sql -q "SELECT pk FROM test ORDER BY pk" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+    [[ "$output" =~ "2" ]] || false
+    [[ ! "$output" =~ "3" ]] || false
+
+    run dolt log -n 1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "inserted 1" ]] || false
+    [[ "$output" =~ "inserted 2" ]] || false
+
+    run dolt log -n 1 HEAD~1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "inserted 10" ]] || false
+
+    cat > todo.txt <<TODO
+reword HEAD a better message
+TODO
+    run dolt rebase --todo todo.txt master
+    [ "$status" -eq 0 ]
+
+    run dolt log -n 1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "a better message" ]] || false
+}
+
+@test "rebase: squashing into a pick that applies nothing keeps both messages" {
+    dolt checkout master
+    dolt sql -q "INSERT INTO test VALUES (1, 1);"
+    dolt commit -am "upstream 1"
+    dolt checkout feature
+
+    cat > todo.txt <<TODO
+pick HEAD~2
+squash HEAD~1
+drop HEAD
+TODO
+    run dolt rebase --todo todo.txt master
+    [ "$status" -eq 0 ]
+
+    run dolt sql -q "SELECT pk FROM test ORDER BY pk" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+    [[ ! "$output" =~ "3" ]] || false
+
+    run dolt log -n 1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "inserted 1" ]] || false
+    [[ "$output" =~ "inserted 2" ]] || false
+
+    run dolt log -n 1 HEAD~1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "upstream 1" ]] || false
+}
+
+@test "rebase: squashes that apply nothing keep their messages" {
+    dolt checkout master
+    dolt sql -q "INSERT INTO test VALUES (2, 2);"
+    dolt commit -am "upstream 2"
+    dolt checkout feature
+
+    cat > todo.txt <<TODO
+reword HEAD~2 a better message
+squash HEAD~1
+drop HEAD
+TODO
+    run dolt rebase --todo todo.txt master
+    [ "$status" -eq 0 ]
+
+    run dolt log -n 1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "a better message" ]] || false
+    [[ "$output" =~ "inserted 2" ]] || false
+    [[ ! "$output" =~ "inserted 1" ]] || false
+
+    run dolt log -n 1 HEAD~1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "upstream 2" ]] || false
+}
+
+@test "rebase: todo list can't start with squash" {
+    cat > todo.txt <<TODO
+squash HEAD
+TODO
+    run dolt rebase --todo todo.txt master
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "without a previous commit" ]] || false
+}
@@ -0,0 +1,106 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    dolt sql -q "CREATE TABLE test (pk int primary key, c int);"
+    dolt add -A && dolt commit -m "created table"
+    dolt sql -q "INSERT INTO test VALUES (1, 1);"
+    dolt commit -am "inserted 1"
+    dolt sql -q "INSERT INTO test VALUES (2, 2);"
+    dolt commit -am "inserted 2"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "revert: reverts the changes of a single commit" {
+    run dolt revert HEAD~1
+    [ "$status" -eq 0 ]
+
+    run dolt sql -q "SELECT * FROM test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2,2" ]] || false
+    [[ ! "$output" =~ "1,1" ]] || false
+
+    run dolt log -n 1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ 'Revert "inserted 1"' ]] || false
+    [[ "$output" =~ "This reverts commit" ]] || false
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+}
+
+@test "revert: reverts multiple commits in a single commit" {
+    run dolt revert HEAD HEAD~1
+    [ "$status" -eq 0 ]
+
+    run dolt sql -q "SELECT COUNT(*) FROM test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "0" ]] || false
+
+    run dolt log -n 1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ 'Revert "inserted 2"' ]] || false
+    [[ "$output" =~ 'Revert "inserted 1"' ]] || false
+    [[ "$output" =~ "This reverts commits" ]] || false
+}
+
+@test "revert: requires a clean working set" {
+    dolt sql -q "INSERT INTO test VALUES (3, 3);"
+
+    run dolt revert HEAD
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "local changes" ]] || false
+}
+
+@test "revert: conflicts are recorded in the working set" {
+    dolt sql -q "UPDATE test SET c = 20 WHERE pk = 2;"
+    dolt commit -am "updated 2"
+
+    run dolt revert HEAD~1
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "CONFLICT" ]] || false
+
+    run dolt sql -q "SELECT * FROM dolt_conflicts" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "test,1" ]] || false
+
+    run dolt log -n 1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "updated 2" ]] || false
+}
+
+@test "revert: reverting a table creation after later changes fails" {
+    run dolt revert HEAD~2
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "deleted and modified" ]] || false
+}
+
+@test "revert: DOLT_REVERT reverts the changes of the commits named" {
+    run dolt sql -q "SELECT DOLT_REVERT('HEAD');"
+    [ "$status" -eq 0 ]
+
+    run dolt sql -q "SELECT * FROM test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,1" ]] || false
+    [[ ! "$output" =~ "2,2" ]] || false
+
+    run dolt log -n 1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ 'Revert "inserted 2"' ]] || false
+}
+
+@test "revert: DOLT_REVERT requires a clean working set" {
+    run dolt sql <<SQL
+INSERT INTO test VALUES (3, 3);
+SELECT DOLT_REVERT('HEAD');
+SQL
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "uncommitted changes" ]] || false
+}
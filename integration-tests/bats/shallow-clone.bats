@@ -0,0 +1,124 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    cd $BATS_TMPDIR
+    cd dolt-repo-$$
+
+    dolt sql -q "CREATE TABLE test (pk BIGINT NOT NULL PRIMARY KEY, c1 BIGINT)"
+    dolt add test
+    dolt commit -m "commit 0"
+    for i in 1 2 3 4; do
+        dolt sql -q "INSERT INTO test VALUES ($i, $i)"
+        dolt add test
+        dolt commit -m "commit $i"
+    done
+    dolt tag v1 HEAD~3
+    dolt checkout -b other
+    dolt sql -q "INSERT INTO test VALUES (10, 10)"
+    dolt add test
+    dolt commit -m "other commit"
+    dolt checkout master
+
+    mkdir remote
+    dolt remote add origin file://remote
+    dolt push origin master
+    dolt push origin other
+    dolt push origin v1
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "shallow-clone: clone with a depth" {
+    dolt clone --depth 2 file://remote clone
+    cd clone
+
+    run dolt log
+    [ $status -eq 0 ]
+    [[ "$output" =~ "commit 4" ]] || false
+    [[ "$output" =~ "commit 3" ]] || false
+    [[ ! "$output" =~ "commit 2" ]] || false
+    [ -f .dolt/shallow ]
+
+    run dolt branch -a
+    [[ "$output" =~ "remotes/origin/other" ]] || false
+
+    # tags of commits outside of the depth aren't cloned
+    run dolt tag
+    [[ ! "$output" =~ "v1" ]] || false
+
+    run dolt sql -q "SELECT COUNT(*) FROM test" -r csv
+    [[ "$output" =~ "4" ]] || false
+
+    run dolt fsck
+    [ $status -eq 0 ]
+    dolt gc
+}
+
+@test "shallow-clone: commit, merge and push from a shallow clone" {
+    dolt clone --depth 2 file://remote clone
+    cd clone
+
+    dolt sql -q "INSERT INTO test VALUES (5, 5)"
+    dolt add test
+    dolt commit -m "commit 5"
+    dolt merge origin/other
+    dolt commit -m "merge other"
+    dolt push origin master
+
+    cd ..
+    dolt clone file://remote full
+    cd full
+    run dolt log
+    [ $status -eq 0 ]
+    [[ "$output" =~ "merge other" ]] || false
+    [[ "$output" =~ "commit 0" ]] || false
+    run dolt fsck
+    [ $status -eq 0 ]
+}
+
+@test "shallow-clone: deepen a shallow clone with fetch" {
+    dolt clone --depth 1 file://remote clone
+    cd clone
+
+    dolt fetch --depth 3
+    run dolt log origin/master
+    [[ "$output" =~ "commit 2" ]] || false
+    [[ ! "$output" =~ "commit 1" ]] || false
+    [ -f .dolt/shallow ]
+
+    dolt fetch --depth 100
+    run dolt log origin/master
+    [[ "$output" =~ "commit 0" ]] || false
+    [ ! -f .dolt/shallow ]
+    run dolt tag
+    [[ "$output" =~ "v1" ]] || false
+}
+
+@test "shallow-clone: clone a single branch" {
+    dolt clone --single-branch -b other --depth 1 file://remote clone
+    cd clone
+
+    run dolt branch -a
+    [[ "$output" =~ "* other" ]] || false
+    [[ "$output" =~ "remotes/origin/other" ]] || false
+    [[ ! "$output" =~ "master" ]] || false
+
+    dolt fetch
+    run dolt branch -a
+    [[ ! "$output" =~ "master" ]] || false
+}
+
+@test "shallow-clone: invalid depths and branches" {
+    run dolt clone --depth 0 file://remote clone
+    [ $status -ne 0 ]
+    [[ "$output" =~ "depth must be a positive number of commits" ]] || false
+
+    run dolt clone --single-branch -b missing file://remote clone
+    [ $status -ne 0 ]
+    [[ "$output" =~ "remote branch 'missing' not found" ]] || false
+}
@@ -0,0 +1,150 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    cd $BATS_TMPDIR
+    cd dolt-repo-$$
+    mkdir remotedir
+    mkdir dolt-repo-clones
+
+    dolt sql <<SQL
+CREATE TABLE test (
+    pk int primary key
+);
+
+INSERT INTO test VALUES (0),(1),(2);
+SQL
+    dolt add test
+    dolt commit -m "test commit"
+    dolt remote add origin file://remotedir
+    dolt push origin master
+
+    cd dolt-repo-clones
+    dolt clone file://../remotedir test-repo
+    cd ..
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "sql-remotes: DOLT_PUSH pushes a branch to a remote" {
+    dolt sql <<SQL
+INSERT INTO test VALUES (3);
+SELECT DOLT_COMMIT('-a', '-m', 'Step 1');
+SELECT DOLT_PUSH('origin', 'master');
+SQL
+    run dolt branch --list master -v
+    master_state=$output
+
+    cd dolt-repo-clones/test-repo
+    dolt pull
+    run dolt branch --list master -v
+    [[ "$output" = "$master_state" ]] || false
+}
+
+@test "sql-remotes: DOLT_PUSH reports when everything is up to date" {
+    run dolt sql <<SQL
+SELECT DOLT_PUSH('origin', 'master');
+SHOW WARNINGS;
+SQL
+    [ $status -eq 0 ]
+    [[ "$output" =~ "Everything up-to-date" ]] || false
+}
+
+@test "sql-remotes: DOLT_PUSH rejects non fast forward pushes without --force" {
+    cd dolt-repo-clones/test-repo
+    dolt sql -q "INSERT INTO test VALUES (4)"
+    dolt commit -am "clone commit"
+    dolt push origin master
+
+    cd ../..
+    dolt sql -q "INSERT INTO test VALUES (3)"
+    dolt commit -am "local commit"
+    run dolt sql -q "SELECT DOLT_PUSH('origin', 'master')"
+    [ $status -eq 1 ]
+    [[ "$output" =~ "updates were rejected" ]] || false
+
+    run dolt sql -q "SELECT DOLT_PUSH('origin', 'master', '--force')"
+    [ $status -eq 0 ]
+}
+
+@test "sql-remotes: DOLT_PUSH pushes and deletes branches and tags" {
+    dolt branch feature
+    dolt tag v1
+    dolt sql -q "SELECT DOLT_PUSH('origin', 'feature')"
+    dolt sql -q "SELECT DOLT_PUSH('origin', 'v1')"
+
+    cd dolt-repo-clones/test-repo
+    dolt fetch
+    run dolt branch -a
+    [[ "$output" =~ "remotes/origin/feature" ]] || false
+    run dolt tag
+    [[ "$output" =~ "v1" ]] || false
+
+    cd ../..
+    dolt sql -q "SELECT DOLT_PUSH('origin', ':feature')"
+    run dolt branch -a
+    [[ ! "$output" =~ "remotes/origin/feature" ]] || false
+}
+
+@test "sql-remotes: DOLT_PUSH with an unknown remote throws an error" {
+    run dolt sql -q "SELECT DOLT_PUSH('unknown', 'master')"
+    [ $status -eq 1 ]
+    [[ "$output" =~ "unknown remote 'unknown'" ]] || false
+
+    run dolt sql -q "SELECT DOLT_PUSH('origin')"
+    [ $status -eq 1 ]
+}
+
+@test "sql-remotes: DOLT_FETCH updates remote tracking branches" {
+    cd dolt-repo-clones/test-repo
+    dolt sql -q "INSERT INTO test VALUES (3)"
+    dolt commit -am "clone commit"
+    dolt push origin master
+
+    cd ../..
+    run dolt sql -q "SELECT DOLT_FETCH('origin')" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "0" ]] || false
+
+    run dolt sql -q "SELECT count(*) FROM test" -r csv
+    [[ "$output" =~ "3" ]] || false
+    run dolt sql -q "SELECT count(*) FROM test AS OF 'origin/master'" -r csv
+    [[ "$output" =~ "4" ]] || false
+}
+
+@test "sql-remotes: DOLT_PULL fetches and merges the current branch" {
+    cd dolt-repo-clones/test-repo
+    dolt sql -q "INSERT INTO test VALUES (3)"
+    dolt commit -am "clone commit"
+    dolt push origin master
+    run dolt log -n 1
+    head_commit=$(echo "$output" | head -1 | awk '{print $2}')
+
+    cd ../..
+    run dolt sql -q "SELECT DOLT_PULL('origin')"
+    [ $status -eq 0 ]
+    [[ "$output" =~ "$head_commit" ]] || false
+
+    run dolt sql -q "SELECT count(*) FROM test" -r csv
+    [[ "$output" =~ "4" ]] || false
+}
+
+@test "sql-remotes: DOLT_PULL merges diverged branches" {
+    cd dolt-repo-clones/test-repo
+    dolt sql -q "INSERT INTO test VALUES (3)"
+    dolt commit -am "clone commit"
+    dolt push origin master
+
+    cd ../..
+    dolt sql -q "INSERT INTO test VALUES (4)"
+    dolt commit -am "local commit"
+    dolt sql -q "SELECT DOLT_PULL()"
+
+    run dolt sql -q "SELECT pk FROM test ORDER BY pk" -r csv
+    [[ "$output" =~ "3" ]] || false
+    [[ "$output" =~ "4" ]] || false
+}
@@ -788,3 +788,279 @@ SQL
     )" ""
     server_query 1 "SHOW tables" "" # validate that it does have show tables
 }
+
+@test "sql-server: branch, tag and commit revisions are available as databases" {
+    skiponwindows "Has dependencies that are missing on the Jenkins Windows installation."
+
+    cd repo1
+    dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY)"
+    dolt add .
+    dolt commit -m "created table"
+    dolt branch feature
+    dolt tag v1
+    dolt sql -q "INSERT INTO test VALUES (1)"
+    dolt add .
+    dolt commit -m "inserted a row on master"
+    start_sql_server repo1
+
+    server_query 1 "SELECT * FROM test" "pk\n1"
+    server_query 1 "SELECT COUNT(*) FROM \`repo1/feature\`.test" "COUNT(*)\n0"
+    server_query 1 "SELECT COUNT(*) FROM \`repo1/v1\`.test" "COUNT(*)\n0"
+
+    insert_query 1 "USE \`repo1/feature\`; INSERT INTO test VALUES (2)"
+    server_query 1 "USE \`repo1/feature\`; SELECT * FROM test" ";pk\n2"
+    server_query 1 "SELECT * FROM test" "pk\n1"
+
+    run server_query 1 "INSERT INTO \`repo1/v1\`.test VALUES (3)" ""
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "read-only" ]] || false
+
+    run server_query 1 "SELECT * FROM \`repo1/missing\`.test" ""
+    [ "$status" -ne 0 ]
+}
+
+@test "sql-server: commits are pushed to the replication remote" {
+    skiponwindows "Has dependencies that are missing on the Jenkins Windows installation."
+
+    mkdir remote
+    cd repo1
+    dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY)"
+    dolt add .
+    dolt commit -m "created table"
+    dolt remote add origin file://../remote
+    dolt push origin master
+
+    let PORT="$$ % (65536-1024) + 1024"
+    echo "
+user:
+  name: dolt
+
+listener:
+  host: 0.0.0.0
+  port: $PORT
+
+replication:
+  remote: origin
+" > replication.yaml
+    DEFAULT_DB=repo1
+    dolt sql-server --config replication.yaml &
+    SERVER_PID=$!
+    wait_for_connection $PORT 5000
+
+    insert_query 1 "INSERT INTO test VALUES (1)"
+    server_query 1 "SELECT DOLT_COMMIT('-a', '-m', 'inserted a row')" ""
+
+    cd ..
+    dolt clone file://./remote clone
+    cd clone
+    run dolt sql -q "SELECT * FROM test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+    run dolt log -n 1
+    [[ "$output" =~ "inserted a row" ]] || false
+}
+
+@test "sql-server: commits aren't pushed over a diverged replication remote" {
+    skiponwindows "Has dependencies that are missing on the Jenkins Windows installation."
+
+    mkdir remote
+    cd repo1
+    dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY)"
+    dolt add .
+    dolt commit -m "created table"
+    dolt remote add origin file://../remote
+    dolt push origin master
+
+    cd ..
+    dolt clone file://./remote clone
+    cd clone
+    dolt sql -q "INSERT INTO test VALUES (2)"
+    dolt commit -am "diverged on the remote"
+    dolt push origin master
+
+    cd ../repo1
+    let PORT="$$ % (65536-1024) + 1024"
+    echo "
+user:
+  name: dolt
+
+listener:
+  host: 0.0.0.0
+  port: $PORT
+
+replication:
+  remote: origin
+" > replication.yaml
+    DEFAULT_DB=repo1
+    dolt sql-server --config replication.yaml &
+    SERVER_PID=$!
+    wait_for_connection $PORT 5000
+
+    # the commit succeeds locally even though it can't be replicated
+    insert_query 1 "INSERT INTO test VALUES (1)"
+    server_query 1 "SELECT DOLT_COMMIT('-a', '-m', 'inserted a row')" ""
+    server_query 1 "SELECT * FROM test" "pk\n1"
+
+    cd ../clone
+    dolt pull origin
+    run dolt log -n 1
+    [[ "$output" =~ "diverged on the remote" ]] || false
+    run dolt sql -q "SELECT * FROM test" -r csv
+    [[ "$output" =~ "2" ]] || false
+    [[ ! "$output" =~ "1" ]] || false
+}
+
+@test "sql-server: read replicas pull from the replication remote before each transaction" {
+    skiponwindows "Has dependencies that are missing on the Jenkins Windows installation."
+
+    mkdir remote
+    cd repo2
+    dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY)"
+    dolt add .
+    dolt commit -m "created table"
+    dolt remote add origin file://../remote
+    dolt push origin master
+    cd ..
+    rm -rf repo1
+    dolt clone file://./remote repo1
+
+    cd repo1
+    let PORT="$$ % (65536-1024) + 1024"
+    echo "
+user:
+  name: dolt
+
+listener:
+  host: 0.0.0.0
+  port: $PORT
+
+replication:
+  remote: origin
+  read_replica: true
+" > replication.yaml
+    DEFAULT_DB=repo1
+    dolt sql-server --config replication.yaml &
+    SERVER_PID=$!
+    wait_for_connection $PORT 5000
+
+    server_query 1 "SELECT COUNT(*) FROM test" "COUNT(*)\n0"
+
+    cd ../repo2
+    dolt sql -q "INSERT INTO test VALUES (1)"
+    dolt commit -am "inserted a row"
+    dolt push origin master
+
+    server_query 1 "SELECT * FROM test" "pk\n1"
+}
+
+@test "sql-server: replication requires the remote to exist" {
+    cd repo1
+    let PORT="$$ % (65536-1024) + 1024"
+    echo "
+listener:
+  port: $PORT
+
+replication:
+  remote: origin
+" > replication.yaml
+    run dolt sql-server --config replication.yaml
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "unknown remote 'origin'" ]] || false
+}
+
+@test "sql-server: users only have the privileges granted to them" {
+    skiponwindows "Has dependencies that are missing on the Jenkins Windows installation."
+
+    cd repo1
+    dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY)"
+    dolt sql -q "INSERT INTO test VALUES (1)"
+
+    let PORT="$$ % (65536-1024) + 1024"
+    echo "
+user:
+  name: dolt
+
+users:
+  - name: analyst
+    password: analystpass
+    grants:
+      - privileges: SELECT
+        on: \"*.*\"
+
+listener:
+  host: 0.0.0.0
+  port: $PORT
+  max_connections: 10
+" > users.yaml
+    DEFAULT_DB=repo1
+    dolt sql-server --config users.yaml &
+    SERVER_PID=$!
+    wait_for_connection $PORT 5000
+
+    SQL_USER=analyst SQL_PASSWORD=analystpass server_query 1 "SELECT * FROM test" "pk\n1"
+
+    SQL_USER=analyst SQL_PASSWORD=analystpass run insert_query 1 "INSERT INTO test VALUES (2)"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "INSERT command denied to user 'analyst' for table 'test'" ]] || false
+
+    SQL_USER=analyst SQL_PASSWORD=analystpass run server_query 1 "SELECT DOLT_COMMIT('-am', 'message')" ""
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "command denied to user 'analyst'" ]] || false
+
+    SQL_USER=analyst SQL_PASSWORD=analystpass run server_query 1 "CREATE TABLE test2 (pk int PRIMARY KEY)" ""
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "CREATE command denied" ]] || false
+
+    SQL_USER=analyst SQL_PASSWORD=wrong run server_query 1 "SELECT * FROM test" ""
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "Access denied for user 'analyst'" ]] || false
+
+    insert_query 1 "INSERT INTO test VALUES (2)"
+    server_query 1 "SELECT * FROM test ORDER BY pk" "pk\n1\n2"
+}
+
+@test "sql-server: serves connections over TLS with a certificate and key" {
+    skiponwindows "Has dependencies that are missing on the Jenkins Windows installation."
+
+    cd repo1
+    dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY)"
+    dolt sql -q "INSERT INTO test VALUES (1)"
+    openssl req -x509 -newkey rsa:2048 -nodes -days 1 -subj "/CN=localhost" -keyout key.pem -out cert.pem
+
+    let PORT="$$ % (65536-1024) + 1024"
+    echo "
+user:
+  name: dolt
+
+listener:
+  host: 0.0.0.0
+  port: $PORT
+  max_connections: 10
+  tls_key: key.pem
+  tls_cert: cert.pem
+  require_secure_transport: true
+" > tls.yaml
+    DEFAULT_DB=repo1
+    dolt sql-server --config tls.yaml &
+    SERVER_PID=$!
+    wait_for_connection $PORT 5000
+
+    server_query 1 "SELECT * FROM test" "pk\n1"
+}
+
+@test "sql-server: require secure transport needs a TLS certificate and key" {
+    cd repo1
+    let PORT="$$ % (65536-1024) + 1024"
+
+    run dolt sql-server --port=$PORT --require-secure-transport
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "require_secure_transport can only be enabled when tls_key and tls_cert are set" ]] || false
+
+    run dolt sql-server --port=$PORT --tls-key=key.pem
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "tls_key and tls_cert must both be set" ]] || false
+
+    run dolt sql-server --port=$PORT --tls-key=missing-key.pem --tls-cert=missing-cert.pem
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "failed to load tls_cert" ]] || false
+}
@@ -0,0 +1,144 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    dolt sql -q "CREATE TABLE test (pk int primary key, c int);"
+    dolt sql -q "INSERT INTO test VALUES (1, 1);"
+    dolt add -A && dolt commit -m "created table"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "stash: push saves local changes and resets the working set" {
+    dolt sql -q "INSERT INTO test VALUES (2, 2);"
+    dolt add test
+    dolt sql -q "INSERT INTO test VALUES (3, 3);"
+
+    run dolt stash
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Saved working directory and index state WIP on master" ]] || false
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+
+    run dolt stash list
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "stash@{0}: WIP on master" ]] || false
+}
+
+@test "stash: push with no local changes" {
+    run dolt stash push
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "No local changes to save" ]] || false
+
+    run dolt stash list
+    [ "$status" -eq 0 ]
+    [ "$output" = "" ]
+}
+
+@test "stash: pop restores staged and working changes on another branch" {
+    dolt sql -q "INSERT INTO test VALUES (2, 2);"
+    dolt add test
+    dolt sql -q "INSERT INTO test VALUES (3, 3);"
+    dolt stash
+
+    dolt checkout -b other
+    run dolt stash pop
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Dropped stash@{0}" ]] || false
+
+    run dolt sql -q "SELECT COUNT(*) FROM test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "3" ]] || false
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Changes to be committed" ]] || false
+    [[ "$output" =~ "Changes not staged for commit" ]] || false
+
+    run dolt stash list
+    [ "$status" -eq 0 ]
+    [ "$output" = "" ]
+}
+
+@test "stash: list, apply and drop named stashes" {
+    dolt sql -q "INSERT INTO test VALUES (2, 2);"
+    dolt stash -m "first"
+    dolt sql -q "INSERT INTO test VALUES (3, 3);"
+    dolt stash -m "second"
+
+    run dolt stash list
+    [ "$status" -eq 0 ]
+    [ "${lines[0]}" = "stash@{0}: On master: second" ]
+    [ "${lines[1]}" = "stash@{1}: On master: first" ]
+
+    run dolt stash apply stash@{1}
+    [ "$status" -eq 0 ]
+
+    run dolt sql -q "SELECT pk FROM test WHERE pk > 1" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+    [[ ! "$output" =~ "3" ]] || false
+
+    run dolt stash list
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+
+    run dolt stash drop 1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Dropped stash@{1}" ]] || false
+
+    run dolt stash list
+    [ "$status" -eq 0 ]
+    [ "${lines[0]}" = "stash@{0}: On master: second" ]
+    [ "${#lines[@]}" -eq 1 ]
+
+    run dolt stash drop stash@{5}
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "not a valid reference" ]] || false
+}
+
+@test "stash: pop surfaces conflicts and keeps the stash" {
+    dolt sql -q "UPDATE test SET c = 10 WHERE pk = 1;"
+    dolt stash
+    dolt sql -q "UPDATE test SET c = 20 WHERE pk = 1;"
+    dolt commit -am "conflicting change"
+
+    run dolt stash pop
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "CONFLICT" ]] || false
+    [[ "$output" =~ "The stash entry is kept" ]] || false
+
+    run dolt sql -q "SELECT * FROM dolt_conflicts" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "test,1" ]] || false
+
+    run dolt stash list
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "stash@{0}" ]] || false
+
+    run dolt stash apply
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "unresolved conflicts" ]] || false
+}
+
+@test "stash: pop with no stashes" {
+    run dolt stash pop
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "no stash entries found" ]] || false
+}
+
+@test "stash: stashes are not listed as branches" {
+    dolt sql -q "INSERT INTO test VALUES (2, 2);"
+    dolt stash
+
+    run dolt branch -a
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "stash" ]] || false
+}
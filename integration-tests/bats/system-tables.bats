@@ -43,6 +43,8 @@ teardown() {
     [[ "$output" =~ "dolt_branches" ]] || false
     [[ "$output" =~ "dolt_query_catalog" ]] || false
     [[ "$output" =~ "dolt_status" ]] || false
+    [[ "$output" =~ "dolt_tags" ]] || false
+    [[ "$output" =~ "dolt_remotes" ]] || false
     [[ "$output" =~ "test" ]] || false
     dolt add test
     dolt commit -m "Added test table"
@@ -269,6 +271,33 @@ teardown() {
     [ "${#lines[@]}" -eq 6 ]
 }
 
+@test "system-tables: query dolt_blame_ system table" {
+    dolt sql -q "create table test (pk int, c1 int, primary key(pk))"
+    dolt sql -q "insert into test values (0,0), (1,1)"
+    dolt add test
+    dolt commit -m "Added (0,0) and (1,1) rows"
+    dolt sql -q "insert into test values (2,2)"
+    dolt sql -q "update test set c1 = 10 where pk = 0"
+    dolt add test
+    dolt commit -m "Added (2,2) row and updated (0,0)"
+    run dolt sql -r csv -q "select pk, message from dolt_blame_test"
+    [ $status -eq 0 ]
+    [[ "$output" =~ "pk,message" ]] || false
+    [[ "$output" =~ '0,"Added (2,2) row and updated (0,0)"' ]] || false
+    [[ "$output" =~ '1,"Added (0,0) and (1,1) rows"' ]] || false
+    [[ "$output" =~ '2,"Added (2,2) row and updated (0,0)"' ]] || false
+    run dolt sql -r csv -q "select t.pk, t.c1, b.committer from test t join dolt_blame_test b on t.pk = b.pk where t.pk = 1"
+    [ $status -eq 0 ]
+    [[ "$output" =~ "1,1,Bats Tests" ]] || false
+
+    dolt sql -q "create table keyless (c1 int)"
+    dolt add keyless
+    dolt commit -m "Added keyless table"
+    run dolt sql -q "select * from dolt_blame_keyless"
+    [ $status -ne 0 ]
+    [[ "$output" =~ "not supported for tables without a primary key" ]] || false
+}
+
 @test "system-tables: query dolt_commits" {
     run dolt sql -q "SELECT count(*) FROM dolt_commits;" -r csv
     [ "$status" -eq 0 ]
@@ -410,3 +439,95 @@ SQL
     run dolt sql -q "DELETE FROM dolt_branches"
     [ "$status" -ne 0 ]
 }
+
+@test "system-tables: query dolt_tags system table" {
+    dolt sql -q "create table test (pk int, c1 int, primary key(pk))"
+    dolt add test
+    dolt commit -m "Added test table"
+    dolt tag v1 -m "first release"
+    run dolt sql -q "select name, message from dolt_tags" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "v1,first release" ]] || false
+
+    run dolt sql -q "select count(*) from dolt_tags t join dolt_log l on t.hash = l.commit_hash where l.message = 'Added test table'" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+}
+
+@test "system-tables: insert into and delete from dolt_tags" {
+    dolt sql -q "create table test (pk int, c1 int, primary key(pk))"
+    dolt add test
+    dolt commit -m "Added test table"
+
+    dolt sql -q "insert into dolt_tags (name, hash, message) values ('v1', 'HEAD', 'made in sql')"
+    run dolt tag -v
+    [ $status -eq 0 ]
+    [[ "$output" =~ "v1" ]] || false
+    [[ "$output" =~ "made in sql" ]] || false
+
+    run dolt sql -q "insert into dolt_tags (name, hash) values ('v1', 'master')"
+    [ $status -ne 0 ]
+    run dolt sql -q "insert into dolt_tags (name, hash) values ('v2', 'not-a-branch')"
+    [ $status -ne 0 ]
+
+    dolt sql -q "replace into dolt_tags (name, hash, message) values ('v1', 'HEAD', 'replaced in sql')"
+    run dolt tag -v
+    [ $status -eq 0 ]
+    [[ "$output" =~ "replaced in sql" ]] || false
+    [[ ! "$output" =~ "made in sql" ]] || false
+
+    dolt sql -q "delete from dolt_tags where name = 'v1'"
+    run dolt tag
+    [ $status -eq 0 ]
+    [[ ! "$output" =~ "v1" ]] || false
+}
+
+@test "system-tables: query and modify dolt_remotes system table" {
+    dolt remote add origin http://localhost:50051/test-org/test-repo
+    run dolt sql -q "select name, url from dolt_remotes" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "origin,http://localhost:50051/test-org/test-repo" ]] || false
+
+    dolt sql -q "insert into dolt_remotes (name, url) values ('backup', 'file://$BATS_TMPDIR/remotes-$$/empty')"
+    run dolt remote -v
+    [ $status -eq 0 ]
+    [[ "$output" =~ "backup" ]] || false
+    run dolt sql -q "select fetch_specs from dolt_remotes where name = 'backup'" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "refs/heads/*:refs/remotes/backup/*" ]] || false
+
+    run dolt sql -q "insert into dolt_remotes (name, url) values ('origin', 'http://localhost:50051/test-org/other')"
+    [ $status -ne 0 ]
+    run dolt sql -q "insert into dolt_remotes (name, url) values ('bad.name', 'http://localhost:50051/test-org/other')"
+    [ $status -ne 0 ]
+
+    # urls are normalized the same way as by dolt remote add
+    dolt sql -q "insert into dolt_remotes (name, url) values ('short', 'test-org/test-repo')"
+    run dolt sql -q "select url from dolt_remotes where name = 'short'" -r csv
+    [[ "$output" =~ "https://doltremoteapi.dolthub.com/test-org/test-repo" ]] || false
+    mkdir relative-remote
+    dolt sql -q "insert into dolt_remotes (name, url) values ('relative', 'file://./relative-remote')"
+    run dolt sql -q "select url from dolt_remotes where name = 'relative'" -r csv
+    [[ "$output" =~ "file://$(pwd)/relative-remote" ]] || false
+    run dolt sql -q "insert into dolt_remotes (name, url) values ('missing', 'file://./missing-remote')"
+    [ $status -ne 0 ]
+
+    dolt push backup master
+    run dolt branch -a
+    [[ "$output" =~ "remotes/backup/master" ]] || false
+
+    # replacing a remote keeps its remote tracking branches
+    mkdir other-remote
+    dolt sql -q "replace into dolt_remotes (name, url) values ('backup', 'file://./other-remote')"
+    run dolt sql -q "select url from dolt_remotes where name = 'backup'" -r csv
+    [[ "$output" =~ "file://$(pwd)/other-remote" ]] || false
+    run dolt branch -a
+    [[ "$output" =~ "remotes/backup/master" ]] || false
+
+    dolt sql -q "delete from dolt_remotes where name = 'backup'"
+    run dolt remote -v
+    [ $status -eq 0 ]
+    [[ ! "$output" =~ "backup" ]] || false
+    run dolt branch -a
+    [[ ! "$output" =~ "remotes/backup/master" ]] || false
+}